@@ -0,0 +1,25 @@
+-- Add bot templates. A template points at a hidden copy of a bot that no
+-- user owns.
+
+begin;
+
+create table order_bot_mgmt.bot_template
+(
+    id            text not null
+        primary key,
+    user_id       text not null
+        references order_bot_mgmt.users,
+    bot_id        text not null
+        references order_bot_mgmt.bot,
+    template_name text not null,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.bot_template
+    owner to melkey;
+
+create index idx_bot_template_user_id
+    on order_bot_mgmt.bot_template (user_id);
+
+commit;
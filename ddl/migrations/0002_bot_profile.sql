@@ -0,0 +1,37 @@
+-- Add the bot profile and its published copy for the order bot. Existing
+-- bots get an empty profile and nothing is published until it is saved.
+
+begin;
+
+alter table order_bot_mgmt.bot
+    add column display_name          text not null default '',
+    add column description           text not null default '',
+    add column logo_url              text not null default '',
+    add column theme_primary_color   text not null default '',
+    add column theme_secondary_color text not null default '',
+    add column greeting_message      text not null default '',
+    add column contact_phone         text not null default '',
+    add column contact_email         text not null default '',
+    add column contact_address       text not null default '';
+
+create table order_bot.published_bot_profile
+(
+    bot_id                text not null
+        primary key,
+    display_name          text not null default '',
+    description           text not null default '',
+    logo_url              text not null default '',
+    theme_primary_color   text not null default '',
+    theme_secondary_color text not null default '',
+    greeting_message      text not null default '',
+    contact_phone         text not null default '',
+    contact_email         text not null default '',
+    contact_address       text not null default '',
+    created_at            timestamp,
+    updated_at            timestamp
+);
+
+alter table order_bot.published_bot_profile
+    owner to melkey;
+
+commit;
@@ -0,0 +1,14 @@
+-- Add the bot's currency and price scale, and record them on published
+-- menus. Existing bots and published menus are USD with two decimals.
+
+begin;
+
+alter table order_bot_mgmt.bot
+    add column currency    text    not null default 'USD',
+    add column price_scale integer not null default 2;
+
+alter table order_bot.published_menu
+    add column currency    text    not null default 'USD',
+    add column price_scale integer not null default 2;
+
+commit;
@@ -0,0 +1,62 @@
+-- Migrate menu prices from double precision to scaled integers.
+-- Values are scaled by the owning bot's price_scale (or the published menu's
+-- price_scale for the order_bot schema). Prices are converted exactly: the
+-- migration fails, listing the rows, if any price has more decimals than
+-- its scale allows. Fix those prices or the scale and run it again.
+
+begin;
+
+do
+$$
+    declare
+        inexact text;
+    begin
+        select string_agg(mi.id || ' (' || mi.price || ')', ', ' order by mi.id)
+        into inexact
+        from order_bot_mgmt.menu_item mi
+                 join order_bot_mgmt.menu m on m.id = mi.menu_id
+                 join order_bot_mgmt.bot b on b.id = m.bot_id
+        where coalesce(mi.price, 0)::numeric * power(10::numeric, b.price_scale)
+                  <> trunc(coalesce(mi.price, 0)::numeric * power(10::numeric, b.price_scale));
+        if inexact is not null then
+            raise exception 'order_bot_mgmt.menu_item prices with more decimals than the bot''s price_scale: %', inexact;
+        end if;
+
+        select string_agg(pmi.id || ' (' || pmi.price || ')', ', ' order by pmi.id)
+        into inexact
+        from order_bot.published_menu_item pmi
+                 join order_bot.published_menu pm on pm.id = pmi.menu_id
+        where coalesce(pmi.price, 0)::numeric * power(10::numeric, pm.price_scale)
+                  <> trunc(coalesce(pmi.price, 0)::numeric * power(10::numeric, pm.price_scale));
+        if inexact is not null then
+            raise exception 'order_bot.published_menu_item prices with more decimals than the menu''s price_scale: %', inexact;
+        end if;
+    end
+$$;
+
+alter table order_bot_mgmt.menu_item
+    add column price_scaled bigint;
+
+update order_bot_mgmt.menu_item mi
+set price_scaled = (coalesce(mi.price, 0)::numeric * power(10::numeric, b.price_scale))::bigint
+from order_bot_mgmt.menu m
+         join order_bot_mgmt.bot b on b.id = m.bot_id
+where mi.menu_id = m.id;
+
+alter table order_bot_mgmt.menu_item
+    alter column price_scaled set not null,
+    drop column price;
+
+alter table order_bot.published_menu_item
+    add column price_scaled bigint;
+
+update order_bot.published_menu_item pmi
+set price_scaled = (coalesce(pmi.price, 0)::numeric * power(10::numeric, pm.price_scale))::bigint
+from order_bot.published_menu pm
+where pmi.menu_id = pm.id;
+
+alter table order_bot.published_menu_item
+    alter column price_scaled set not null,
+    drop column price;
+
+commit;
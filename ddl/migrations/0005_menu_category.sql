@@ -0,0 +1,50 @@
+-- Add menu categories and display ordering. Existing items stay
+-- uncategorized at sort position 0 until the menu is saved again.
+
+begin;
+
+create table order_bot_mgmt.menu_category
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot_mgmt.menu,
+    category_name text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_category
+    owner to melkey;
+
+create index idx_menu_category_menu_id
+    on order_bot_mgmt.menu_category (menu_id);
+
+alter table order_bot_mgmt.menu_item
+    add column category_id   text references order_bot_mgmt.menu_category,
+    add column sort_position integer not null default 0;
+
+create table order_bot.published_menu_category
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    category_name text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_category
+    owner to melkey;
+
+create index idx_published_menu_category_menu_id
+    on order_bot.published_menu_category (menu_id);
+
+alter table order_bot.published_menu_item
+    add column category_id   text references order_bot.published_menu_category,
+    add column sort_position integer not null default 0;
+
+commit;
@@ -0,0 +1,117 @@
+-- Add option groups, options and their links to menu items.
+
+begin;
+
+create table order_bot_mgmt.menu_option_group
+(
+    id             text    not null
+        primary key,
+    menu_id        text    not null
+        references order_bot_mgmt.menu,
+    group_name     text    not null,
+    selection_type text    not null default 'single',
+    min_choices    integer not null default 0,
+    max_choices    integer not null default 1,
+    required       boolean not null default false,
+    sort_position  integer not null default 0,
+    created_at     timestamp,
+    updated_at     timestamp
+);
+
+alter table order_bot_mgmt.menu_option_group
+    owner to melkey;
+
+create index idx_menu_option_group_menu_id
+    on order_bot_mgmt.menu_option_group (menu_id);
+
+create table order_bot_mgmt.menu_option
+(
+    id                 text    not null
+        primary key,
+    group_id           text    not null
+        references order_bot_mgmt.menu_option_group,
+    option_name        text    not null,
+    price_delta_scaled bigint  not null default 0,
+    sort_position      integer not null default 0,
+    created_at         timestamp,
+    updated_at         timestamp
+);
+
+alter table order_bot_mgmt.menu_option
+    owner to melkey;
+
+create index idx_menu_option_group_id
+    on order_bot_mgmt.menu_option (group_id);
+
+create table order_bot_mgmt.menu_item_option_group
+(
+    menu_item_id  text    not null
+        references order_bot_mgmt.menu_item,
+    group_id      text    not null
+        references order_bot_mgmt.menu_option_group,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, group_id)
+);
+
+alter table order_bot_mgmt.menu_item_option_group
+    owner to melkey;
+
+create table order_bot.published_menu_option_group
+(
+    id             text    not null
+        primary key,
+    menu_id        text    not null
+        references order_bot.published_menu,
+    group_name     text    not null,
+    selection_type text    not null default 'single',
+    min_choices    integer not null default 0,
+    max_choices    integer not null default 1,
+    required       boolean not null default false,
+    sort_position  integer not null default 0,
+    created_at     timestamp,
+    updated_at     timestamp
+);
+
+alter table order_bot.published_menu_option_group
+    owner to melkey;
+
+create index idx_published_menu_option_group_menu_id
+    on order_bot.published_menu_option_group (menu_id);
+
+create table order_bot.published_menu_option
+(
+    id                 text    not null
+        primary key,
+    group_id           text    not null
+        references order_bot.published_menu_option_group,
+    option_name        text    not null,
+    price_delta_scaled bigint  not null default 0,
+    sort_position      integer not null default 0,
+    created_at         timestamp,
+    updated_at         timestamp
+);
+
+alter table order_bot.published_menu_option
+    owner to melkey;
+
+create index idx_published_menu_option_group_id
+    on order_bot.published_menu_option (group_id);
+
+create table order_bot.published_menu_item_option_group
+(
+    menu_item_id  text    not null
+        references order_bot.published_menu_item,
+    group_id      text    not null
+        references order_bot.published_menu_option_group,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, group_id)
+);
+
+alter table order_bot.published_menu_item_option_group
+    owner to melkey;
+
+commit;
@@ -0,0 +1,17 @@
+-- Add sold-out ("86") flags with an optional automatic restore time.
+
+begin;
+
+alter table order_bot_mgmt.menu_item
+    add column sold_out   boolean not null default false,
+    add column restore_at timestamp;
+
+create index idx_menu_item_restore_at
+    on order_bot_mgmt.menu_item (restore_at)
+    where sold_out;
+
+alter table order_bot.published_menu_item
+    add column sold_out   boolean not null default false,
+    add column restore_at timestamp;
+
+commit;
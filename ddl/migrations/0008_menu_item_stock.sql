@@ -0,0 +1,47 @@
+-- Add optional per-item stock counts, a ledger of stock movements, and a
+-- cursor table for background jobs. Ledger rows keep no foreign keys so the
+-- audit trail outlives edited or deleted menu items.
+
+begin;
+
+alter table order_bot_mgmt.menu_item
+    add column track_stock boolean not null default false,
+    add column stock       integer not null default 0;
+
+create table order_bot_mgmt.stock_movement
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null,
+    menu_item_id  text    not null,
+    delta         integer not null,
+    balance       integer not null,
+    reason        text    not null,
+    order_item_id text
+        unique,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.stock_movement
+    owner to melkey;
+
+create index idx_stock_movement_menu_id_created_at
+    on order_bot_mgmt.stock_movement (menu_id, created_at);
+
+create table order_bot_mgmt.job_cursor
+(
+    name       text      not null
+        primary key,
+    position   timestamp not null,
+    created_at timestamp,
+    updated_at timestamp
+);
+
+alter table order_bot_mgmt.job_cursor
+    owner to melkey;
+
+create index ix_order_item_created_at
+    on order_bot.order_item (created_at);
+
+commit;
@@ -0,0 +1,27 @@
+-- Keep every saved draft menu as an immutable version. Existing menus get
+-- their first version on the next save.
+
+begin;
+
+create table order_bot_mgmt.menu_version
+(
+    id          text    not null
+        primary key,
+    menu_id     text    not null
+        references order_bot_mgmt.menu,
+    version     integer not null,
+    author_id   text
+        references order_bot_mgmt.users,
+    note        text    not null default '',
+    price_scale integer not null,
+    item_count  integer not null,
+    snapshot    jsonb   not null,
+    created_at  timestamp,
+    updated_at  timestamp,
+    unique (menu_id, version)
+);
+
+alter table order_bot_mgmt.menu_version
+    owner to melkey;
+
+commit;
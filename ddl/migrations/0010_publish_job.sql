@@ -0,0 +1,39 @@
+-- Publish a bot's draft menu at a scheduled time. Pending jobs are claimed
+-- by the publish worker in run_at order and marked running; running jobs
+-- whose worker died are claimed again after a while, up to a few attempts.
+
+begin;
+
+create table order_bot_mgmt.publish_job
+(
+    id          text      not null
+        primary key,
+    bot_id      text      not null
+        references order_bot_mgmt.bot,
+    run_at      timestamp not null,
+    status      text      not null,
+    created_by  text
+        references order_bot_mgmt.users,
+    attempts    integer   not null default 0,
+    started_at  timestamp,
+    error       text      not null default '',
+    finished_at timestamp,
+    created_at  timestamp,
+    updated_at  timestamp
+);
+
+alter table order_bot_mgmt.publish_job
+    owner to melkey;
+
+create index idx_publish_job_bot_id_run_at
+    on order_bot_mgmt.publish_job (bot_id, run_at);
+
+create index idx_publish_job_pending_run_at
+    on order_bot_mgmt.publish_job (run_at)
+    where status = 'pending';
+
+create index idx_publish_job_running_started_at
+    on order_bot_mgmt.publish_job (started_at)
+    where status = 'running';
+
+commit;
@@ -0,0 +1,28 @@
+-- Allow several named menus per bot, each served during a time window in the
+-- bot's timezone. Windows are minutes from local midnight; equal bounds mean
+-- the menu is served all day. Published menus carry the window and timezone so
+-- order-bot-svc can pick the active menu without reading the bot row.
+
+begin;
+
+alter table order_bot_mgmt.bot
+    add column timezone text not null default 'UTC';
+
+alter table order_bot_mgmt.menu
+    add column menu_name       text    not null default '',
+    add column available_from  integer not null default 0,
+    add column available_until integer not null default 0;
+
+create unique index uq_menu_bot_id_menu_name
+    on order_bot_mgmt.menu (bot_id, lower(menu_name));
+
+alter table order_bot.published_menu
+    add column menu_name       text    not null default '',
+    add column timezone        text    not null default 'UTC',
+    add column available_from  integer not null default 0,
+    add column available_until integer not null default 0;
+
+create index idx_published_menu_bot_id
+    on order_bot.published_menu (bot_id);
+
+commit;
@@ -0,0 +1,21 @@
+-- Add item images and logo thumbnails. URLs point at the blob store; an empty
+-- string means no image. Published rows carry them so order-bot-svc can show
+-- pictures without reading the draft menu.
+
+begin;
+
+alter table order_bot_mgmt.bot
+    add column logo_thumbnail_url text not null default '';
+
+alter table order_bot_mgmt.menu_item
+    add column image_url     text not null default '',
+    add column thumbnail_url text not null default '';
+
+alter table order_bot.published_menu_item
+    add column image_url     text not null default '',
+    add column thumbnail_url text not null default '';
+
+alter table order_bot.published_bot_profile
+    add column logo_thumbnail_url text not null default '';
+
+commit;
@@ -0,0 +1,25 @@
+-- Add item descriptions, allergens, dietary tags, spicy level and nutrition
+-- facts. Allergens and tags are jsonb arrays of the values the service
+-- accepts; nutrition is a jsonb object or null when unknown. The published
+-- copy lets order-bot-svc filter items and answer dietary questions.
+
+begin;
+
+alter table order_bot_mgmt.menu_item
+    add column description  text    not null default '',
+    add column allergens    jsonb   not null default '[]',
+    add column dietary_tags jsonb   not null default '[]',
+    add column spicy_level  integer not null default 0,
+    add column nutrition    jsonb;
+
+alter table order_bot.published_menu_item
+    add column description  text    not null default '',
+    add column allergens    jsonb   not null default '[]',
+    add column dietary_tags jsonb   not null default '[]',
+    add column spicy_level  integer not null default 0,
+    add column nutrition    jsonb;
+
+create index idx_published_menu_item_allergens
+    on order_bot.published_menu_item using gin (allergens);
+
+commit;
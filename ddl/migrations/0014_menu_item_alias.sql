@@ -0,0 +1,41 @@
+-- Add item aliases, other names such as "coke" that the order bot matches an
+-- item by. Aliases are unique ignoring case across the item names and aliases
+-- of a menu; order-bot-mgmt-svc checks that on save, and the published table
+-- enforces it per menu.
+
+begin;
+
+create table order_bot_mgmt.menu_item_alias
+(
+    menu_item_id  text    not null
+        references order_bot_mgmt.menu_item,
+    alias         text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, alias)
+);
+
+alter table order_bot_mgmt.menu_item_alias
+    owner to melkey;
+
+create table order_bot.published_menu_item_alias
+(
+    menu_item_id  text    not null
+        references order_bot.published_menu_item,
+    alias         text    not null,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, alias)
+);
+
+alter table order_bot.published_menu_item_alias
+    owner to melkey;
+
+create unique index uq_published_menu_item_alias_menu_id_alias
+    on order_bot.published_menu_item_alias (menu_id, lower(alias));
+
+commit;
@@ -0,0 +1,28 @@
+-- Add menu translations. A bot writes its menus in its default locale and
+-- lists the locales it answers in; item and category translations are jsonb
+-- objects keyed by locale, such as {"zh-TW": {"name": "拿鐵"}}. The published
+-- copy lets order-bot-svc answer in the customer's language.
+
+begin;
+
+alter table order_bot_mgmt.bot
+    add column default_locale text  not null default 'en',
+    add column locales        jsonb not null default '["en"]';
+
+alter table order_bot_mgmt.menu_category
+    add column translations jsonb not null default '{}';
+
+alter table order_bot_mgmt.menu_item
+    add column translations jsonb not null default '{}';
+
+alter table order_bot.published_menu
+    add column default_locale text  not null default 'en',
+    add column locales        jsonb not null default '["en"]';
+
+alter table order_bot.published_menu_category
+    add column translations jsonb not null default '{}';
+
+alter table order_bot.published_menu_item
+    add column translations jsonb not null default '{}';
+
+commit;
@@ -0,0 +1,10 @@
+-- Add a version counter to menus. Every change to the draft bumps it; the
+-- API returns it as an ETag and only applies updates whose If-Match names
+-- the current version, so concurrent editors cannot overwrite each other.
+
+begin;
+
+alter table order_bot_mgmt.menu
+    add column version integer not null default 1;
+
+commit;
@@ -0,0 +1,122 @@
+-- Add bundles, combos such as "burger + fries + drink" sold at a fixed price.
+-- Each slot of a bundle is filled with one of its choices, items of the same
+-- menu that may add an upcharge. The order bot adds a bundle as one cart line
+-- and keeps the picks in components, which orders copy.
+
+begin;
+
+create table order_bot_mgmt.menu_bundle
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot_mgmt.menu,
+    bundle_name   text    not null,
+    price_scaled  bigint  not null,
+    description   text    not null default '',
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_bundle
+    owner to melkey;
+
+create index idx_menu_bundle_menu_id
+    on order_bot_mgmt.menu_bundle (menu_id);
+
+create table order_bot_mgmt.menu_bundle_slot
+(
+    id            text    not null
+        primary key,
+    bundle_id     text    not null
+        references order_bot_mgmt.menu_bundle,
+    slot_name     text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_bundle_slot
+    owner to melkey;
+
+create index idx_menu_bundle_slot_bundle_id
+    on order_bot_mgmt.menu_bundle_slot (bundle_id);
+
+create table order_bot_mgmt.menu_bundle_choice
+(
+    slot_id         text    not null
+        references order_bot_mgmt.menu_bundle_slot,
+    menu_item_id    text    not null
+        references order_bot_mgmt.menu_item,
+    upcharge_scaled bigint  not null default 0,
+    sort_position   integer not null default 0,
+    created_at      timestamp,
+    updated_at      timestamp,
+    primary key (slot_id, menu_item_id)
+);
+
+alter table order_bot_mgmt.menu_bundle_choice
+    owner to melkey;
+
+create table order_bot.published_menu_bundle
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    bundle_name   text    not null,
+    price_scaled  bigint  not null,
+    description   text    not null default '',
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_bundle
+    owner to melkey;
+
+create index idx_published_menu_bundle_menu_id
+    on order_bot.published_menu_bundle (menu_id);
+
+create table order_bot.published_menu_bundle_slot
+(
+    id            text    not null
+        primary key,
+    bundle_id     text    not null
+        references order_bot.published_menu_bundle,
+    slot_name     text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_bundle_slot
+    owner to melkey;
+
+create index idx_published_menu_bundle_slot_bundle_id
+    on order_bot.published_menu_bundle_slot (bundle_id);
+
+create table order_bot.published_menu_bundle_choice
+(
+    slot_id         text    not null
+        references order_bot.published_menu_bundle_slot,
+    menu_item_id    text    not null
+        references order_bot.published_menu_item,
+    upcharge_scaled bigint  not null default 0,
+    sort_position   integer not null default 0,
+    created_at      timestamp,
+    updated_at      timestamp,
+    primary key (slot_id, menu_item_id)
+);
+
+alter table order_bot.published_menu_bundle_choice
+    owner to melkey;
+
+alter table order_bot.cart_item
+    add column components jsonb not null default '[]';
+
+alter table order_bot.order_item
+    add column components jsonb not null default '[]';
+
+commit;
@@ -0,0 +1,77 @@
+-- Add promotions: percent or fixed amounts off, buy-X-get-Y deals and order
+-- thresholds, limited to items or categories and to a schedule. Priority
+-- orders the promotions of a cart and stackable ones combine. Enabled
+-- promotions that have not ended are published for the order bot, with the
+-- bot's price scale and timezone. A currency change rescales the draft
+-- promotions; the published rows keep the old price_scale until the
+-- promotions are saved again.
+
+begin;
+
+create table order_bot_mgmt.promotion
+(
+    id                  text    not null
+        primary key,
+    bot_id              text    not null
+        references order_bot_mgmt.bot,
+    promotion_name      text    not null,
+    promotion_type      text    not null,
+    percent_off         bigint  not null default 0,
+    amount_off_scaled   bigint  not null default 0,
+    buy_quantity        integer not null default 0,
+    get_quantity        integer not null default 0,
+    min_subtotal_scaled bigint  not null default 0,
+    item_ids            jsonb   not null default '[]',
+    category_ids        jsonb   not null default '[]',
+    starts_at           timestamp,
+    ends_at             timestamp,
+    weekdays            jsonb   not null default '[]',
+    available_from      integer not null default 0,
+    available_until     integer not null default 0,
+    priority            integer not null default 0,
+    stackable           boolean not null default false,
+    enabled             boolean not null default true,
+    created_at          timestamp,
+    updated_at          timestamp
+);
+
+alter table order_bot_mgmt.promotion
+    owner to melkey;
+
+create index idx_promotion_bot_id
+    on order_bot_mgmt.promotion (bot_id);
+
+create table order_bot.published_promotion
+(
+    id                  text    not null
+        primary key,
+    bot_id              text    not null,
+    promotion_name      text    not null,
+    promotion_type      text    not null,
+    percent_off         bigint  not null default 0,
+    amount_off_scaled   bigint  not null default 0,
+    buy_quantity        integer not null default 0,
+    get_quantity        integer not null default 0,
+    min_subtotal_scaled bigint  not null default 0,
+    price_scale         integer not null,
+    timezone            text    not null,
+    item_ids            jsonb   not null default '[]',
+    category_ids        jsonb   not null default '[]',
+    starts_at           timestamp,
+    ends_at             timestamp,
+    weekdays            jsonb   not null default '[]',
+    available_from      integer not null default 0,
+    available_until     integer not null default 0,
+    priority            integer not null default 0,
+    stackable           boolean not null default false,
+    created_at          timestamp,
+    updated_at          timestamp
+);
+
+alter table order_bot.published_promotion
+    owner to melkey;
+
+create index idx_published_promotion_bot_id
+    on order_bot.published_promotion (bot_id);
+
+commit;
@@ -0,0 +1,41 @@
+-- Add per-bot tax config: named rates in millionths, a default rate,
+-- per-category and per-item overrides by rate code, and exclusive or
+-- inclusive pricing. Saving the config publishes it for the order bot, which
+-- taxes orders with it when they are placed.
+
+begin;
+
+create table order_bot_mgmt.tax_config
+(
+    bot_id            text  not null
+        primary key
+        references order_bot_mgmt.bot,
+    tax_mode          text  not null default 'exclusive',
+    default_rate_code text  not null default '',
+    rates             jsonb not null default '[]',
+    category_rates    jsonb not null default '{}',
+    item_rates        jsonb not null default '{}',
+    created_at        timestamp,
+    updated_at        timestamp
+);
+
+alter table order_bot_mgmt.tax_config
+    owner to melkey;
+
+create table order_bot.published_tax_config
+(
+    bot_id            text  not null
+        primary key,
+    tax_mode          text  not null default 'exclusive',
+    default_rate_code text  not null default '',
+    rates             jsonb not null default '[]',
+    category_rates    jsonb not null default '{}',
+    item_rates        jsonb not null default '{}',
+    created_at        timestamp,
+    updated_at        timestamp
+);
+
+alter table order_bot.published_tax_config
+    owner to melkey;
+
+commit;
@@ -0,0 +1,25 @@
+-- Store the tax breakdown of each order when it is placed, so later changes
+-- to the bot's tax config or menu leave past orders as they were. tax_rates
+-- has the net amount and tax of each rate the order used, with the rate's
+-- code, name and millionths at the time; items keep their rate code and
+-- share of the tax. Orders placed before have no tax: their net and gross
+-- amounts are their total.
+
+begin;
+
+alter table order_bot.orders
+    add column tax_mode     text   not null default 'exclusive',
+    add column net_scaled   bigint not null default 0,
+    add column tax_scaled   bigint not null default 0,
+    add column gross_scaled bigint not null default 0,
+    add column tax_rates    jsonb  not null default '[]';
+
+update order_bot.orders
+set net_scaled   = total_scaled,
+    gross_scaled = total_scaled;
+
+alter table order_bot.order_item
+    add column tax_rate_code text   not null default '',
+    add column tax_scaled    bigint not null default 0;
+
+commit;
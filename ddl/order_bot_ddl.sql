@@ -2,24 +2,67 @@
 
 create table order_bot.published_menu
 (
-    id         text not null
+    id              text    not null
         primary key,
-    bot_id     text not null,
-    created_at timestamp,
-    updated_at timestamp
+    bot_id          text    not null,
+    menu_name       text    not null default '',
+    currency        text    not null default 'USD',
+    price_scale     integer not null default 2,
+    timezone        text    not null default 'UTC',
+    default_locale  text    not null default 'en',
+    locales         jsonb   not null default '["en"]',
+    available_from  integer not null default 0,
+    available_until integer not null default 0,
+    created_at      timestamp,
+    updated_at      timestamp
 );
 
 alter table order_bot.published_menu
     owner to melkey;
 
+create index idx_published_menu_bot_id
+    on order_bot.published_menu (bot_id);
+
+create table order_bot.published_menu_category
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    category_name text    not null,
+    sort_position integer not null default 0,
+    translations  jsonb   not null default '{}',
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_category
+    owner to melkey;
+
+create index idx_published_menu_category_menu_id
+    on order_bot.published_menu_category (menu_id);
+
 create table order_bot.published_menu_item
 (
-    id             text not null
+    id             text    not null
         primary key,
-    menu_id        text not null
+    menu_id        text    not null
         references order_bot.published_menu,
-    menu_item_name text not null,
-    price          double precision,
+    menu_item_name text    not null,
+    price_scaled   bigint  not null,
+    category_id    text
+        references order_bot.published_menu_category,
+    sort_position  integer not null default 0,
+    sold_out       boolean not null default false,
+    restore_at     timestamp,
+    image_url      text    not null default '',
+    thumbnail_url  text    not null default '',
+    description    text    not null default '',
+    allergens      jsonb   not null default '[]',
+    dietary_tags   jsonb   not null default '[]',
+    spicy_level    integer not null default 0,
+    nutrition      jsonb,
+    translations   jsonb   not null default '{}',
     created_at     timestamp,
     updated_at     timestamp
 );
@@ -30,6 +73,205 @@ alter table order_bot.published_menu_item
 create index idx_menu_item_menu_id
     on order_bot.published_menu_item (menu_id);
 
+create table order_bot.published_menu_option_group
+(
+    id             text    not null
+        primary key,
+    menu_id        text    not null
+        references order_bot.published_menu,
+    group_name     text    not null,
+    selection_type text    not null default 'single',
+    min_choices    integer not null default 0,
+    max_choices    integer not null default 1,
+    required       boolean not null default false,
+    sort_position  integer not null default 0,
+    created_at     timestamp,
+    updated_at     timestamp
+);
+
+alter table order_bot.published_menu_option_group
+    owner to melkey;
+
+create index idx_published_menu_option_group_menu_id
+    on order_bot.published_menu_option_group (menu_id);
+
+create table order_bot.published_menu_option
+(
+    id                 text    not null
+        primary key,
+    group_id           text    not null
+        references order_bot.published_menu_option_group,
+    option_name        text    not null,
+    price_delta_scaled bigint  not null default 0,
+    sort_position      integer not null default 0,
+    created_at         timestamp,
+    updated_at         timestamp
+);
+
+alter table order_bot.published_menu_option
+    owner to melkey;
+
+create index idx_published_menu_option_group_id
+    on order_bot.published_menu_option (group_id);
+
+create table order_bot.published_menu_item_option_group
+(
+    menu_item_id  text    not null
+        references order_bot.published_menu_item,
+    group_id      text    not null
+        references order_bot.published_menu_option_group,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, group_id)
+);
+
+alter table order_bot.published_menu_item_option_group
+    owner to melkey;
+
+create table order_bot.published_menu_item_alias
+(
+    menu_item_id  text    not null
+        references order_bot.published_menu_item,
+    alias         text    not null,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, alias)
+);
+
+alter table order_bot.published_menu_item_alias
+    owner to melkey;
+
+create unique index uq_published_menu_item_alias_menu_id_alias
+    on order_bot.published_menu_item_alias (menu_id, lower(alias));
+
+create table order_bot.published_menu_bundle
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot.published_menu,
+    bundle_name   text    not null,
+    price_scaled  bigint  not null,
+    description   text    not null default '',
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_bundle
+    owner to melkey;
+
+create index idx_published_menu_bundle_menu_id
+    on order_bot.published_menu_bundle (menu_id);
+
+create table order_bot.published_menu_bundle_slot
+(
+    id            text    not null
+        primary key,
+    bundle_id     text    not null
+        references order_bot.published_menu_bundle,
+    slot_name     text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot.published_menu_bundle_slot
+    owner to melkey;
+
+create index idx_published_menu_bundle_slot_bundle_id
+    on order_bot.published_menu_bundle_slot (bundle_id);
+
+create table order_bot.published_menu_bundle_choice
+(
+    slot_id         text    not null
+        references order_bot.published_menu_bundle_slot,
+    menu_item_id    text    not null
+        references order_bot.published_menu_item,
+    upcharge_scaled bigint  not null default 0,
+    sort_position   integer not null default 0,
+    created_at      timestamp,
+    updated_at      timestamp,
+    primary key (slot_id, menu_item_id)
+);
+
+alter table order_bot.published_menu_bundle_choice
+    owner to melkey;
+
+create table order_bot.published_bot_profile
+(
+    bot_id                text not null
+        primary key,
+    display_name          text not null default '',
+    description           text not null default '',
+    logo_url              text not null default '',
+    logo_thumbnail_url    text not null default '',
+    theme_primary_color   text not null default '',
+    theme_secondary_color text not null default '',
+    greeting_message      text not null default '',
+    contact_phone         text not null default '',
+    contact_email         text not null default '',
+    contact_address       text not null default '',
+    created_at            timestamp,
+    updated_at            timestamp
+);
+
+alter table order_bot.published_bot_profile
+    owner to melkey;
+
+create table order_bot.published_promotion
+(
+    id                  text    not null
+        primary key,
+    bot_id              text    not null,
+    promotion_name      text    not null,
+    promotion_type      text    not null,
+    percent_off         bigint  not null default 0,
+    amount_off_scaled   bigint  not null default 0,
+    buy_quantity        integer not null default 0,
+    get_quantity        integer not null default 0,
+    min_subtotal_scaled bigint  not null default 0,
+    price_scale         integer not null,
+    timezone            text    not null,
+    item_ids            jsonb   not null default '[]',
+    category_ids        jsonb   not null default '[]',
+    starts_at           timestamp,
+    ends_at             timestamp,
+    weekdays            jsonb   not null default '[]',
+    available_from      integer not null default 0,
+    available_until     integer not null default 0,
+    priority            integer not null default 0,
+    stackable           boolean not null default false,
+    created_at          timestamp,
+    updated_at          timestamp
+);
+
+alter table order_bot.published_promotion
+    owner to melkey;
+
+create index idx_published_promotion_bot_id
+    on order_bot.published_promotion (bot_id);
+
+create table order_bot.published_tax_config
+(
+    bot_id            text  not null
+        primary key,
+    tax_mode          text  not null default 'exclusive',
+    default_rate_code text  not null default '',
+    rates             jsonb not null default '[]',
+    category_rates    jsonb not null default '{}',
+    item_rates        jsonb not null default '{}',
+    created_at        timestamp,
+    updated_at        timestamp
+);
+
+alter table order_bot.published_tax_config
+    owner to melkey;
+
 create table order_bot.cart
 (
     id           varchar(36) not null
@@ -59,6 +301,7 @@ create table order_bot.cart_item
     quantity           integer      not null,
     unit_price_scaled  integer      not null,
     total_price_scaled integer      not null,
+    components         jsonb        not null default '[]',
     created_at         timestamp,
     updated_at         timestamp,
     constraint menu_item_id
@@ -80,6 +323,11 @@ create table order_bot.orders
     session_id   varchar(36) not null,
     total_scaled integer     not null,
     bot_id       varchar(36) not null,
+    tax_mode     text        not null default 'exclusive',
+    net_scaled   bigint      not null default 0,
+    tax_scaled   bigint      not null default 0,
+    gross_scaled bigint      not null default 0,
+    tax_rates    jsonb       not null default '[]',
     created_at   timestamp,
     updated_at   timestamp
 );
@@ -104,6 +352,9 @@ create table order_bot.order_item
     quantity           integer      not null,
     unit_price_scaled  integer      not null,
     total_price_scaled integer      not null,
+    components         jsonb        not null default '[]',
+    tax_rate_code      text         not null default '',
+    tax_scaled         bigint       not null default 0,
     created_at         timestamp,
     updated_at         timestamp
 );
@@ -114,3 +365,6 @@ alter table order_bot.order_item
 create index ix_order_item_order_id
     on order_bot.order_item (order_id);
 
+create index ix_order_item_created_at
+    on order_bot.order_item (created_at);
+
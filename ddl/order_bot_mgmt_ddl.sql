@@ -2,11 +2,26 @@
 
 create table order_bot_mgmt.bot
 (
-    id         text not null
+    id                    text    not null
         primary key,
-    bot_name   text not null,
-    created_at timestamp,
-    updated_at timestamp
+    bot_name              text    not null,
+    display_name          text    not null default '',
+    description           text    not null default '',
+    logo_url              text    not null default '',
+    logo_thumbnail_url    text    not null default '',
+    theme_primary_color   text    not null default '',
+    theme_secondary_color text    not null default '',
+    greeting_message      text    not null default '',
+    contact_phone         text    not null default '',
+    contact_email         text    not null default '',
+    contact_address       text    not null default '',
+    currency              text    not null default 'USD',
+    price_scale           integer not null default 2,
+    timezone              text    not null default 'UTC',
+    default_locale        text    not null default 'en',
+    locales               jsonb   not null default '["en"]',
+    created_at            timestamp,
+    updated_at            timestamp
 );
 
 alter table order_bot_mgmt.bot
@@ -14,12 +29,16 @@ alter table order_bot_mgmt.bot
 
 create table order_bot_mgmt.menu
 (
-    id         text not null
+    id              text    not null
         primary key,
-    bot_id     text not null
+    bot_id          text    not null
         references order_bot_mgmt.bot,
-    created_at timestamp,
-    updated_at timestamp
+    menu_name       text    not null default '',
+    available_from  integer not null default 0,
+    available_until integer not null default 0,
+    version         integer not null default 1,
+    created_at      timestamp,
+    updated_at      timestamp
 );
 
 alter table order_bot_mgmt.menu
@@ -28,14 +47,51 @@ alter table order_bot_mgmt.menu
 create index idx_menu_bot_id
     on order_bot_mgmt.menu (bot_id);
 
+create unique index uq_menu_bot_id_menu_name
+    on order_bot_mgmt.menu (bot_id, lower(menu_name));
+
+create table order_bot_mgmt.menu_category
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot_mgmt.menu,
+    category_name text    not null,
+    sort_position integer not null default 0,
+    translations  jsonb   not null default '{}',
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_category
+    owner to melkey;
+
+create index idx_menu_category_menu_id
+    on order_bot_mgmt.menu_category (menu_id);
+
 create table order_bot_mgmt.menu_item
 (
-    id             text not null
+    id             text    not null
         primary key,
-    menu_id        text not null
+    menu_id        text    not null
         references order_bot_mgmt.menu,
-    menu_item_name text not null,
-    price          double precision,
+    menu_item_name text    not null,
+    price_scaled   bigint  not null,
+    category_id    text
+        references order_bot_mgmt.menu_category,
+    sort_position  integer not null default 0,
+    sold_out       boolean not null default false,
+    restore_at     timestamp,
+    track_stock    boolean not null default false,
+    stock          integer not null default 0,
+    image_url      text    not null default '',
+    thumbnail_url  text    not null default '',
+    description    text    not null default '',
+    allergens      jsonb   not null default '[]',
+    dietary_tags   jsonb   not null default '[]',
+    spicy_level    integer not null default 0,
+    nutrition      jsonb,
+    translations   jsonb   not null default '{}',
     created_at     timestamp,
     updated_at     timestamp
 );
@@ -46,6 +102,134 @@ alter table order_bot_mgmt.menu_item
 create index idx_menu_item_menu_id
     on order_bot_mgmt.menu_item (menu_id);
 
+create index idx_menu_item_restore_at
+    on order_bot_mgmt.menu_item (restore_at)
+    where sold_out;
+
+create table order_bot_mgmt.menu_option_group
+(
+    id             text    not null
+        primary key,
+    menu_id        text    not null
+        references order_bot_mgmt.menu,
+    group_name     text    not null,
+    selection_type text    not null default 'single',
+    min_choices    integer not null default 0,
+    max_choices    integer not null default 1,
+    required       boolean not null default false,
+    sort_position  integer not null default 0,
+    created_at     timestamp,
+    updated_at     timestamp
+);
+
+alter table order_bot_mgmt.menu_option_group
+    owner to melkey;
+
+create index idx_menu_option_group_menu_id
+    on order_bot_mgmt.menu_option_group (menu_id);
+
+create table order_bot_mgmt.menu_option
+(
+    id                 text    not null
+        primary key,
+    group_id           text    not null
+        references order_bot_mgmt.menu_option_group,
+    option_name        text    not null,
+    price_delta_scaled bigint  not null default 0,
+    sort_position      integer not null default 0,
+    created_at         timestamp,
+    updated_at         timestamp
+);
+
+alter table order_bot_mgmt.menu_option
+    owner to melkey;
+
+create index idx_menu_option_group_id
+    on order_bot_mgmt.menu_option (group_id);
+
+create table order_bot_mgmt.menu_item_option_group
+(
+    menu_item_id  text    not null
+        references order_bot_mgmt.menu_item,
+    group_id      text    not null
+        references order_bot_mgmt.menu_option_group,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, group_id)
+);
+
+alter table order_bot_mgmt.menu_item_option_group
+    owner to melkey;
+
+create table order_bot_mgmt.menu_item_alias
+(
+    menu_item_id  text    not null
+        references order_bot_mgmt.menu_item,
+    alias         text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp,
+    primary key (menu_item_id, alias)
+);
+
+alter table order_bot_mgmt.menu_item_alias
+    owner to melkey;
+
+create table order_bot_mgmt.menu_bundle
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null
+        references order_bot_mgmt.menu,
+    bundle_name   text    not null,
+    price_scaled  bigint  not null,
+    description   text    not null default '',
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_bundle
+    owner to melkey;
+
+create index idx_menu_bundle_menu_id
+    on order_bot_mgmt.menu_bundle (menu_id);
+
+create table order_bot_mgmt.menu_bundle_slot
+(
+    id            text    not null
+        primary key,
+    bundle_id     text    not null
+        references order_bot_mgmt.menu_bundle,
+    slot_name     text    not null,
+    sort_position integer not null default 0,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.menu_bundle_slot
+    owner to melkey;
+
+create index idx_menu_bundle_slot_bundle_id
+    on order_bot_mgmt.menu_bundle_slot (bundle_id);
+
+create table order_bot_mgmt.menu_bundle_choice
+(
+    slot_id         text    not null
+        references order_bot_mgmt.menu_bundle_slot,
+    menu_item_id    text    not null
+        references order_bot_mgmt.menu_item,
+    upcharge_scaled bigint  not null default 0,
+    sort_position   integer not null default 0,
+    created_at      timestamp,
+    updated_at      timestamp,
+    primary key (slot_id, menu_item_id)
+);
+
+alter table order_bot_mgmt.menu_bundle_choice
+    owner to melkey;
+
 create table order_bot_mgmt.users
 (
     id            text not null
@@ -83,3 +267,157 @@ create index idx_user_bot_user_id
 create index idx_user_bot_bot_id
     on order_bot_mgmt.user_bot (bot_id);
 
+create table order_bot_mgmt.bot_template
+(
+    id            text not null
+        primary key,
+    user_id       text not null
+        references order_bot_mgmt.users,
+    bot_id        text not null
+        references order_bot_mgmt.bot,
+    template_name text not null,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.bot_template
+    owner to melkey;
+
+create index idx_bot_template_user_id
+    on order_bot_mgmt.bot_template (user_id);
+
+create table order_bot_mgmt.menu_version
+(
+    id          text    not null
+        primary key,
+    menu_id     text    not null
+        references order_bot_mgmt.menu,
+    version     integer not null,
+    author_id   text
+        references order_bot_mgmt.users,
+    note        text    not null default '',
+    price_scale integer not null,
+    item_count  integer not null,
+    snapshot    jsonb   not null,
+    created_at  timestamp,
+    updated_at  timestamp,
+    unique (menu_id, version)
+);
+
+alter table order_bot_mgmt.menu_version
+    owner to melkey;
+
+create table order_bot_mgmt.stock_movement
+(
+    id            text    not null
+        primary key,
+    menu_id       text    not null,
+    menu_item_id  text    not null,
+    delta         integer not null,
+    balance       integer not null,
+    reason        text    not null,
+    order_item_id text
+        unique,
+    created_at    timestamp,
+    updated_at    timestamp
+);
+
+alter table order_bot_mgmt.stock_movement
+    owner to melkey;
+
+create index idx_stock_movement_menu_id_created_at
+    on order_bot_mgmt.stock_movement (menu_id, created_at);
+
+create table order_bot_mgmt.job_cursor
+(
+    name       text      not null
+        primary key,
+    position   timestamp not null,
+    created_at timestamp,
+    updated_at timestamp
+);
+
+alter table order_bot_mgmt.job_cursor
+    owner to melkey;
+
+create table order_bot_mgmt.publish_job
+(
+    id          text      not null
+        primary key,
+    bot_id      text      not null
+        references order_bot_mgmt.bot,
+    run_at      timestamp not null,
+    status      text      not null,
+    created_by  text
+        references order_bot_mgmt.users,
+    attempts    integer   not null default 0,
+    started_at  timestamp,
+    error       text      not null default '',
+    finished_at timestamp,
+    created_at  timestamp,
+    updated_at  timestamp
+);
+
+alter table order_bot_mgmt.publish_job
+    owner to melkey;
+
+create index idx_publish_job_bot_id_run_at
+    on order_bot_mgmt.publish_job (bot_id, run_at);
+
+create index idx_publish_job_pending_run_at
+    on order_bot_mgmt.publish_job (run_at)
+    where status = 'pending';
+
+create index idx_publish_job_running_started_at
+    on order_bot_mgmt.publish_job (started_at)
+    where status = 'running';
+
+create table order_bot_mgmt.promotion
+(
+    id                  text    not null
+        primary key,
+    bot_id              text    not null
+        references order_bot_mgmt.bot,
+    promotion_name      text    not null,
+    promotion_type      text    not null,
+    percent_off         bigint  not null default 0,
+    amount_off_scaled   bigint  not null default 0,
+    buy_quantity        integer not null default 0,
+    get_quantity        integer not null default 0,
+    min_subtotal_scaled bigint  not null default 0,
+    item_ids            jsonb   not null default '[]',
+    category_ids        jsonb   not null default '[]',
+    starts_at           timestamp,
+    ends_at             timestamp,
+    weekdays            jsonb   not null default '[]',
+    available_from      integer not null default 0,
+    available_until     integer not null default 0,
+    priority            integer not null default 0,
+    stackable           boolean not null default false,
+    enabled             boolean not null default true,
+    created_at          timestamp,
+    updated_at          timestamp
+);
+
+alter table order_bot_mgmt.promotion
+    owner to melkey;
+
+create index idx_promotion_bot_id
+    on order_bot_mgmt.promotion (bot_id);
+
+create table order_bot_mgmt.tax_config
+(
+    bot_id            text  not null
+        primary key
+        references order_bot_mgmt.bot,
+    tax_mode          text  not null default 'exclusive',
+    default_rate_code text  not null default '',
+    rates             jsonb not null default '[]',
+    category_rates    jsonb not null default '{}',
+    item_rates        jsonb not null default '{}',
+    created_at        timestamp,
+    updated_at        timestamp
+);
+
+alter table order_bot_mgmt.tax_config
+    owner to melkey;
@@ -18,6 +18,8 @@ type FetchApiOptions<T> = {
   jwt?: string
   headers?: Record<string, string>
   wrapReq?: boolean
+  // passStatuses are returned to the caller instead of failing the request.
+  passStatuses?: number[]
   errMsg: string
 }
 
@@ -39,7 +41,7 @@ export const isControlledErr = (x: unknown): x is Err => {
 }
 
 export const fetchApi = async <T>(basePath: string, path: string, options: FetchApiOptions<T>) => {
-  const { method = 'PUT', req, jwt, headers, wrapReq = true, errMsg } = options
+  const { method = 'PUT', req, jwt, headers, wrapReq = true, passStatuses = [], errMsg } = options
 
   if (!isBusinessOpenUtc8()) {
     redirectToClosed()
@@ -63,7 +65,7 @@ export const fetchApi = async <T>(basePath: string, path: string, options: Fetch
     router.push('/b/login')
     return response
   }
-  if (!response.ok) {
+  if (!response.ok && !passStatuses.includes(response.status)) {
     throw new Error(errMsg)
   }
 
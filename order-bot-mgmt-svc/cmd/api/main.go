@@ -7,20 +7,27 @@ import (
 	"log/slog"
 	"net/http"
 	"order-bot-mgmt-svc/internal/config"
+	"order-bot-mgmt-svc/internal/infra/blobstore"
 	"order-bot-mgmt-svc/internal/infra/httphdlr/httpserver"
 	"order-bot-mgmt-svc/internal/infra/sqldb"
 	"order-bot-mgmt-svc/internal/infra/sqldb/orderbotmgmtsqldb"
 	"order-bot-mgmt-svc/internal/services/authsvc"
 	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/services/ordersvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
+	"order-bot-mgmt-svc/internal/services/stocksvc"
+	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util"
 	"order-bot-mgmt-svc/internal/util/errutil"
+	"order-bot-mgmt-svc/internal/worker"
 	"os/signal"
 	"syscall"
 	"time"
 
 	_ "github.com/joho/godotenv/autoload"
+	_ "time/tzdata"
 
 	"order-bot-mgmt-svc/internal/services"
 )
@@ -57,24 +64,95 @@ func newServices(db *sqldb.DB, orderBotDb *sqldb.DB, cfg config.Config) *service
 			return authsvc.NewSvc(db, ctxFunc, cfg, sqldb.NewUserStore(db))
 		},
 		func() *menusvc.Svc {
+			botStore := sqldb.NewBotStore(db)
 			menuStore := sqldb.NewMenuStore(db)
 			menuItemStore := sqldb.NewMenuItemStore(db)
+			menuCategoryStore := sqldb.NewMenuCategoryStore(db)
+			menuOptionGroupStore := sqldb.NewMenuOptionGroupStore(db)
+			menuBundleStore := sqldb.NewMenuBundleStore(db)
+			menuVersionStore := sqldb.NewMenuVersionStore(db)
+			publishJobStore := sqldb.NewPublishJobStore(db)
 			publishedMenuStore := orderbotmgmtsqldb.NewPublishedMenuStore(orderBotDb)
-			return menusvc.NewSvc(db, orderBotDb, ctxFunc, menuStore, menuItemStore, publishedMenuStore)
+			return menusvc.NewSvc(
+				db, orderBotDb, ctxFunc,
+				botStore, menuStore, menuItemStore, menuCategoryStore, menuOptionGroupStore, menuBundleStore, menuVersionStore,
+				publishJobStore, publishedMenuStore,
+			)
 		},
 		func() *botsvc.Svc {
 			botStore := sqldb.NewBotStore(db)
 			userBotStore := sqldb.NewUserBotStore(db)
-			return botsvc.NewSvc(db, ctxFunc, cfg, botStore, userBotStore)
+			botTemplateStore := sqldb.NewBotTemplateStore(db)
+			menuStore := sqldb.NewMenuStore(db)
+			menuItemStore := sqldb.NewMenuItemStore(db)
+			menuCategoryStore := sqldb.NewMenuCategoryStore(db)
+			menuOptionGroupStore := sqldb.NewMenuOptionGroupStore(db)
+			menuBundleStore := sqldb.NewMenuBundleStore(db)
+			promotionStore := sqldb.NewPromotionStore(db)
+			taxConfigStore := sqldb.NewTaxConfigStore(db)
+			publishedBotProfileStore := orderbotmgmtsqldb.NewPublishedBotProfileStore(orderBotDb)
+			publishedTaxConfigStore := orderbotmgmtsqldb.NewPublishedTaxConfigStore(orderBotDb)
+			publishedPromotionStore := orderbotmgmtsqldb.NewPublishedPromotionStore(orderBotDb)
+			return botsvc.NewSvc(
+				db, orderBotDb, ctxFunc, cfg,
+				botStore, userBotStore, botTemplateStore, menuStore, menuItemStore, menuCategoryStore, menuOptionGroupStore,
+				menuBundleStore, promotionStore, taxConfigStore, publishedBotProfileStore, publishedTaxConfigStore,
+				publishedPromotionStore,
+			)
 		},
 		func() *ordersvc.Svc {
 			orderStore := sqldb.NewOrderStore(orderBotDb)
 			orderItemStore := sqldb.NewOrderItemStore(orderBotDb)
 			return ordersvc.NewSvc(ctxFunc, orderStore, orderItemStore)
 		},
+		func() *filesvc.Svc {
+			var blobStore store.Blob = blobstore.NewLocalStore(cfg.Blob.LocalDir)
+			if cfg.Blob.Backend == "s3" {
+				blobStore = blobstore.NewS3Store(cfg.Blob.S3)
+			}
+			return filesvc.NewSvc(ctxFunc, cfg, blobStore)
+		},
+		func() *stocksvc.Svc {
+			return stocksvc.NewSvc(
+				db, ctxFunc,
+				sqldb.NewMenuStore(db), sqldb.NewMenuItemStore(db), sqldb.NewStockMovementStore(db), sqldb.NewJobCursorStore(db),
+				sqldb.NewOrderItemStore(orderBotDb), orderbotmgmtsqldb.NewPublishedMenuStore(orderBotDb),
+			)
+		},
+		func() *promotionsvc.Svc {
+			return promotionsvc.NewSvc(
+				db, orderBotDb, ctxFunc,
+				sqldb.NewBotStore(db), sqldb.NewMenuStore(db), sqldb.NewMenuItemStore(db), sqldb.NewMenuCategoryStore(db),
+				sqldb.NewPromotionStore(db), orderbotmgmtsqldb.NewPublishedPromotionStore(orderBotDb),
+			)
+		},
 	)
 }
 
+func startWorkers(ctx context.Context, s *services.Services, cfg config.Config) {
+	go worker.Run(ctx, "menu availability restore", cfg.Worker.AvailabilityRestoreInterval, func(ctx context.Context) error {
+		restored, err := s.Menu.Get().RestoreDueItems(ctx)
+		if restored > 0 {
+			slog.Info("main.startWorkers(), menu items restored", "count", restored)
+		}
+		return err
+	})
+	go worker.Run(ctx, "scheduled menu publish", cfg.Worker.PublishJobInterval, func(ctx context.Context) error {
+		ran, err := s.Menu.Get().RunDuePublishJobs(ctx)
+		if ran > 0 {
+			slog.Info("main.startWorkers(), scheduled publish jobs ran", "count", ran)
+		}
+		return err
+	})
+	go worker.Run(ctx, "stock order reconcile", cfg.Worker.StockReconcileInterval, func(ctx context.Context) error {
+		applied, err := s.Stock.Get().ReconcileOrders(ctx)
+		if applied > 0 {
+			slog.Info("main.startWorkers(), order items applied to stock", "count", applied)
+		}
+		return err
+	})
+}
+
 func main() {
 
 	// Set up logger level
@@ -99,6 +177,7 @@ func main() {
 		}
 	}()
 	serviceContainer := newServices(db, orderBotDb, cfg)
+	startWorkers(context.Background(), serviceContainer, cfg)
 
 	server := httpserver.NewServer(
 		port,
@@ -28,6 +28,32 @@ type Auth struct {
 	RefreshTokenTTL time.Duration
 }
 
+// Blob picks where uploads are stored. Backend is "local" or "s3".
+type Blob struct {
+	Backend       string
+	LocalDir      string
+	S3            BlobS3
+	PublicBaseURL string
+	MaxImageBytes int64
+}
+
+// BlobS3 configures an S3-compatible bucket. PathStyle puts the bucket in the
+// path instead of the host name, as MinIO expects.
+type BlobS3 struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool
+}
+
+type Worker struct {
+	AvailabilityRestoreInterval time.Duration
+	StockReconcileInterval      time.Duration
+	PublishJobInterval          time.Duration
+}
+
 type Others struct {
 	QryCtxTimeout time.Duration
 }
@@ -37,6 +63,8 @@ type Config struct {
 	Db         Db
 	OrderBotDb Db
 	Auth       Auth
+	Blob       Blob
+	Worker     Worker
 	Others     Others
 }
 
@@ -69,6 +97,25 @@ func Load() Config {
 			AccessTokenTTL:  parseDurationEnv("JWT_ACCESS_TTL", 30*time.Minute),
 			RefreshTokenTTL: parseDurationEnv("JWT_REFRESH_TTL", 7*24*time.Hour),
 		},
+		Blob: Blob{
+			Backend:  envOrDefault("BLOB_BACKEND", "local"),
+			LocalDir: envOrDefault("BLOB_LOCAL_DIR", "./data/blobs"),
+			S3: BlobS3{
+				Endpoint:        os.Getenv("BLOB_S3_ENDPOINT"),
+				Region:          envOrDefault("BLOB_S3_REGION", "us-east-1"),
+				Bucket:          os.Getenv("BLOB_S3_BUCKET"),
+				AccessKeyID:     os.Getenv("BLOB_S3_ACCESS_KEY_ID"),
+				SecretAccessKey: os.Getenv("BLOB_S3_SECRET_ACCESS_KEY"),
+				PathStyle:       parseBoolEnv("BLOB_S3_PATH_STYLE", false),
+			},
+			PublicBaseURL: envOrDefault("BLOB_PUBLIC_BASE_URL", "/orderbotmgmt/files"),
+			MaxImageBytes: parseInt64Env("BLOB_MAX_IMAGE_BYTES", 2<<20),
+		},
+		Worker: Worker{
+			AvailabilityRestoreInterval: parseDurationEnv("WORKER_AVAILABILITY_RESTORE_INTERVAL", 30*time.Second),
+			StockReconcileInterval:      parseDurationEnv("WORKER_STOCK_RECONCILE_INTERVAL", 10*time.Second),
+			PublishJobInterval:          parseDurationEnv("WORKER_PUBLISH_JOB_INTERVAL", 30*time.Second),
+		},
 		Others: Others{
 			QryCtxTimeout: parseDurationEnv("QRY_CTX_TIMEOUT", 15*time.Second),
 		},
@@ -95,6 +142,30 @@ func parseDurationEnv(key string, fallback time.Duration) time.Duration {
 	return parsed
 }
 
+func parseInt64Env(key string, fallback int64) int64 {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
+func parseBoolEnv(key string, fallback bool) bool {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return fallback
+	}
+	return parsed
+}
+
 func getEnv(key string) string {
 	val := os.Getenv(key)
 	if val == "" {
@@ -0,0 +1,87 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"order-bot-mgmt-svc/internal/store"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// LocalStore keeps blobs as plain files below a root directory.
+type LocalStore struct{ root string }
+
+func NewLocalStore(root string) *LocalStore {
+	if root == "" {
+		panic("blobstore.NewLocalStore(), the root dir is empty")
+	}
+	return &LocalStore{root: root}
+}
+
+func (s *LocalStore) Put(_ context.Context, key string, _ string, data []byte) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("blobstore.LocalStore.Put: %w", err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Get(_ context.Context, key string) (store.BlobObject, error) {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return store.BlobObject{}, fmt.Errorf("blobstore.LocalStore.Get: %w", err)
+	}
+	f, err := os.Open(filePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return store.BlobObject{}, fmt.Errorf("blobstore.LocalStore.Get: %w", store.ErrBlobNotFound)
+		}
+		return store.BlobObject{}, fmt.Errorf("blobstore.LocalStore.Get: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return store.BlobObject{}, fmt.Errorf("blobstore.LocalStore.Get: %w", err)
+	}
+	return store.BlobObject{
+		Body:        f,
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+		Size:        info.Size(),
+	}, nil
+}
+
+func (s *LocalStore) Delete(_ context.Context, key string) error {
+	filePath, err := s.filePath(key)
+	if err != nil {
+		return fmt.Errorf("blobstore.LocalStore.Delete: %w", err)
+	}
+	if err := os.Remove(filePath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("blobstore.LocalStore.Delete: %w", err)
+	}
+	return nil
+}
+
+// filePath maps a key to a path inside the root and rejects keys that would
+// escape it.
+func (s *LocalStore) filePath(key string) (string, error) {
+	cleaned := path.Clean("/" + key)
+	if cleaned == "/" || strings.Contains(key, "..") {
+		return "", fmt.Errorf("blobstore.LocalStore.filePath(), invalid key %q: %w", key, store.ErrBlobNotFound)
+	}
+	return filepath.Join(s.root, filepath.FromSlash(strings.TrimPrefix(cleaned, "/"))), nil
+}
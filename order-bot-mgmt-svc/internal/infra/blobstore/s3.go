@@ -0,0 +1,233 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"order-bot-mgmt-svc/internal/config"
+	"order-bot-mgmt-svc/internal/store"
+	"sort"
+	"strings"
+	"time"
+)
+
+// S3Store keeps blobs in a bucket of an S3-compatible service such as AWS S3
+// or MinIO. Requests are signed with AWS Signature Version 4.
+type S3Store struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+	now       func() time.Time
+}
+
+func NewS3Store(cfg config.BlobS3) *S3Store {
+	endpoint, err := url.Parse(cfg.Endpoint)
+	if err != nil || endpoint.Scheme == "" || endpoint.Host == "" || cfg.Bucket == "" {
+		panic("blobstore.NewS3Store(), the endpoint or bucket is invalid")
+	}
+	return &S3Store{
+		endpoint:  endpoint,
+		region:    cfg.Region,
+		bucket:    cfg.Bucket,
+		accessKey: cfg.AccessKeyID,
+		secretKey: cfg.SecretAccessKey,
+		pathStyle: cfg.PathStyle,
+		client:    &http.Client{Timeout: 30 * time.Second},
+		now:       time.Now,
+	}
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, contentType string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return fmt.Errorf("blobstore.S3Store.Put: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("blobstore.S3Store.Put: %w", s3Error(res))
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) (store.BlobObject, error) {
+	res, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return store.BlobObject{}, fmt.Errorf("blobstore.S3Store.Get: %w", err)
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return store.BlobObject{Body: res.Body, ContentType: res.Header.Get("Content-Type"), Size: res.ContentLength}, nil
+	case http.StatusNotFound:
+		_ = res.Body.Close()
+		return store.BlobObject{}, fmt.Errorf("blobstore.S3Store.Get: %w", store.ErrBlobNotFound)
+	default:
+		defer res.Body.Close()
+		return store.BlobObject{}, fmt.Errorf("blobstore.S3Store.Get: %w", s3Error(res))
+	}
+}
+
+// Delete succeeds for missing keys, as S3 itself does.
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return fmt.Errorf("blobstore.S3Store.Delete: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusNoContent && res.StatusCode != http.StatusOK && res.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("blobstore.S3Store.Delete: %w", s3Error(res))
+	}
+	return nil
+}
+
+// createBucket is used by tests to prepare a fresh MinIO.
+func (s *S3Store) createBucket(ctx context.Context) error {
+	res, err := s.do(ctx, http.MethodPut, "", "", nil)
+	if err != nil {
+		return fmt.Errorf("blobstore.S3Store.createBucket: %w", err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("blobstore.S3Store.createBucket: %w", s3Error(res))
+	}
+	return nil
+}
+
+func (s *S3Store) do(ctx context.Context, method string, key string, contentType string, body []byte) (*http.Response, error) {
+	if strings.Contains(key, "..") {
+		return nil, fmt.Errorf("blobstore.S3Store.do(), invalid key %q: %w", key, store.ErrBlobNotFound)
+	}
+	u := *s.endpoint
+	objectPath := "/" + strings.TrimPrefix(key, "/")
+	if s.pathStyle {
+		objectPath = "/" + s.bucket + strings.TrimSuffix(objectPath, "/")
+	} else {
+		u.Host = s.bucket + "." + u.Host
+	}
+	u.Path = objectPath
+	u.RawPath = escapeS3Path(objectPath)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("blobstore.S3Store.do: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	s.sign(req, body, s.now().UTC())
+	res, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("blobstore.S3Store.do: %w", err)
+	}
+	return res, nil
+}
+
+// sign adds a Signature Version 4 Authorization header covering the host and
+// every header already set on req.
+func (s *S3Store) sign(req *http.Request, body []byte, t time.Time) {
+	amzDate := t.Format("20060102T150405Z")
+	date := amzDate[:8]
+	payloadHash := sha256Hex(body)
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + sha256Hex([]byte(canonicalRequest))
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	for _, part := range []string{s.region, "s3", "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature,
+	))
+}
+
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	var parts []string
+	for _, key := range keys {
+		vals := append([]string(nil), values[key]...)
+		sort.Strings(vals)
+		for _, val := range vals {
+			parts = append(parts, escapeS3(key)+"="+escapeS3(val))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapeS3Path URI-encodes each segment of a path the way SigV4 expects.
+func escapeS3Path(p string) string {
+	segments := strings.Split(p, "/")
+	for idx, segment := range segments {
+		segments[idx] = escapeS3(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escapeS3 percent-encodes everything except the unreserved characters of
+// RFC 3986.
+func escapeS3(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if 'A' <= c && c <= 'Z' || 'a' <= c && c <= 'z' || '0' <= c && c <= '9' || c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func s3Error(res *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("s3 responded %s: %s", res.Status, strings.TrimSpace(string(msg)))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -3,13 +3,17 @@ package httphdlr
 import (
 	"context"
 	"errors"
+	"io"
 	"log/slog"
 	"net/http"
 	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util/errutil"
 	"order-bot-mgmt-svc/internal/util/jwtutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,6 +21,8 @@ import (
 type BotServer interface {
 	BotService() *botsvc.Svc
 	MenuService() *menusvc.Svc
+	FileService() *filesvc.Svc
+	PromotionService() *promotionsvc.Svc
 	GetWithTx(ctx context.Context, fn func(ctx context.Context, tx store.Tx) (any, error)) (any, error)
 }
 
@@ -24,6 +30,22 @@ const BotPrefix = "/bot"
 
 func RegisterBotRoutes(r gin.IRoutes, s BotServer) {
 	r.GET("/", getBotHdlrFunc(s))
+	r.POST("/:botId/clone", cloneBotHdlrFunc(s))
+	r.POST("/:botId/templates", saveBotTemplateHdlrFunc(s))
+	r.GET("/templates", listBotTemplatesHdlrFunc(s))
+	r.POST("/templates/:templateId/bots", createBotFromTemplateHdlrFunc(s))
+	r.GET("/:botId/profile", getBotProfileHdlrFunc(s))
+	r.PUT("/:botId/profile", updateBotProfileHdlrFunc(s))
+	r.DELETE("/:botId/profile", deleteBotProfileHdlrFunc(s))
+	r.POST("/:botId/profile/logo", uploadBotLogoHdlrFunc(s))
+	r.GET("/:botId/currency", getBotCurrencyHdlrFunc(s))
+	r.PUT("/:botId/currency", updateBotCurrencyHdlrFunc(s))
+	r.GET("/:botId/timezone", getBotTimezoneHdlrFunc(s))
+	r.PUT("/:botId/timezone", updateBotTimezoneHdlrFunc(s))
+	r.GET("/:botId/locales", getBotLocalesHdlrFunc(s))
+	r.PUT("/:botId/locales", updateBotLocalesHdlrFunc(s))
+	r.GET("/:botId/tax", getBotTaxConfigHdlrFunc(s))
+	r.PUT("/:botId/tax", updateBotTaxConfigHdlrFunc(s))
 }
 
 func getBotHdlrFunc(s BotServer) gin.HandlerFunc {
@@ -48,12 +70,8 @@ func getBotHdlrFunc(s BotServer) gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bot"})
 			return
 		}
-		menu, err := s.MenuService().GetMenu(nil, botID)
+		menus, err := s.MenuService().ListMenus(nil, botID)
 		if err != nil {
-			if errors.Is(err, store.ErrMenuNotFound) {
-				c.JSON(http.StatusOK, gin.H{"bot_id": botID, "menu_id": nil})
-				return
-			}
 			slog.Error(errutil.FormatErrChain(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bot"})
 			return
@@ -63,6 +81,439 @@ func getBotHdlrFunc(s BotServer) gin.HandlerFunc {
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load bot"})
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"bot_id": botID, "menu_id": menu.ID})
+		// menu_id is the bot's first menu, kept for clients that predate
+		// several menus per bot.
+		menuIDs := make([]string, 0, len(menus))
+		for _, menu := range menus {
+			menuIDs = append(menuIDs, menu.ID)
+		}
+		if len(menuIDs) == 0 {
+			c.JSON(http.StatusOK, gin.H{"bot_id": botID, "menu_id": nil, "menu_ids": menuIDs})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{"bot_id": botID, "menu_id": menuIDs[0], "menu_ids": menuIDs})
+	}
+}
+
+func cloneBotHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req cloneBotReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		bot, err := s.BotService().CloneBot(c.Request.Context(), token, c.Param("botId"), req.BotName)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		if err := s.PromotionService().PublishPromotions(c.Request.Context(), bot.ID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, botResFromModel(bot))
+	}
+}
+
+func saveBotTemplateHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req botTemplateReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		template, err := s.BotService().SaveAsTemplate(c.Request.Context(), token, c.Param("botId"), req.TemplateName)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, botTemplateResFromModel(template))
+	}
+}
+
+func listBotTemplatesHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		templates, err := s.BotService().ListTemplates(c.Request.Context(), token)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		res := make([]botTemplateRes, 0, len(templates))
+		for _, template := range templates {
+			res = append(res, botTemplateResFromModel(template))
+		}
+		c.JSON(http.StatusOK, gin.H{"templates": res})
+	}
+}
+
+func createBotFromTemplateHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req cloneBotReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		bot, err := s.BotService().CreateBotFromTemplate(c.Request.Context(), token, c.Param("templateId"), req.BotName)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		if err := s.PromotionService().PublishPromotions(c.Request.Context(), bot.ID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, botResFromModel(bot))
+	}
+}
+
+func getBotProfileHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		profile, err := s.BotService().GetProfile(c.Request.Context(), token, c.Param("botId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botProfileResFromModel(profile))
+	}
+}
+
+func updateBotProfileHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req botProfileReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		profile, err := s.BotService().UpdateProfile(c.Request.Context(), token, c.Param("botId"), modelFromBotProfileReq(req))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botProfileResFromModel(profile))
+	}
+}
+
+func deleteBotProfileHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		if err := s.BotService().DeleteProfile(c.Request.Context(), token, c.Param("botId")); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func uploadBotLogoHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		botID := c.Param("botId")
+		if err := s.BotService().AuthorizeBot(c.Request.Context(), token, botID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		data, ok := readUploadedFile(c, s.FileService().MaxImageBytes())
+		if !ok {
+			return
+		}
+		logo, err := s.FileService().SaveImage(c.Request.Context(), "bots/"+botID+"/logo", data)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeFileError(c, err)
+			return
+		}
+		profile, err := s.BotService().SetLogo(c.Request.Context(), token, botID, logo)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botProfileResFromModel(profile))
+	}
+}
+
+func getBotCurrencyHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		botID := c.Param("botId")
+		if err := s.BotService().AuthorizeBot(c.Request.Context(), token, botID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botCurrencyResFromModel(bot))
+	}
+}
+
+func updateBotCurrencyHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req botCurrencyReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		bot, err := s.BotService().UpdateCurrency(c.Request.Context(), token, c.Param("botId"), req.Currency, req.PriceScale)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botCurrencyResFromModel(bot))
+	}
+}
+
+func getBotTimezoneHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		botID := c.Param("botId")
+		if err := s.BotService().AuthorizeBot(c.Request.Context(), token, botID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botTimezoneRes{Timezone: bot.Timezone})
+	}
+}
+
+func updateBotTimezoneHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req botTimezoneReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		bot, err := s.BotService().UpdateTimezone(c.Request.Context(), token, c.Param("botId"), req.Timezone)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botTimezoneRes{Timezone: bot.Timezone})
+	}
+}
+
+func getBotLocalesHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		botID := c.Param("botId")
+		if err := s.BotService().AuthorizeBot(c.Request.Context(), token, botID); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botLocalesResFromModel(bot))
+	}
+}
+
+func updateBotLocalesHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req botLocalesReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		bot, err := s.BotService().UpdateLocales(c.Request.Context(), token, c.Param("botId"), req.DefaultLocale, req.Locales)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, botLocalesResFromModel(bot))
+	}
+}
+
+func getBotTaxConfigHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		config, err := s.BotService().GetTaxConfig(c.Request.Context(), token, c.Param("botId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, taxConfigResFromModel(config))
+	}
+}
+
+func updateBotTaxConfigHdlrFunc(s BotServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, ok := jwtutil.GetTokenGin(c)
+		if !ok {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+			return
+		}
+		var req taxConfigReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		config, err := modelFromTaxConfigReq(req)
+		if err != nil {
+			writeBotError(c, err)
+			return
+		}
+		config, err = s.BotService().UpdateTaxConfig(c.Request.Context(), token, c.Param("botId"), config)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeBotError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, taxConfigResFromModel(config))
+	}
+}
+
+// readUploadedFile reads the multipart "file" field and writes the error
+// response itself when it returns false.
+func readUploadedFile(c *gin.Context, maxBytes int64) ([]byte, bool) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+		return nil, false
+	}
+	if fileHeader.Size > maxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": filesvc.ErrFileTooLarge.Error()})
+		return nil, false
+	}
+	f, err := fileHeader.Open()
+	if err != nil {
+		slog.Error(errutil.FormatErrChain(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+		return nil, false
+	}
+	defer f.Close()
+	data, err := io.ReadAll(io.LimitReader(f, maxBytes+1))
+	if err != nil {
+		slog.Error(errutil.FormatErrChain(err))
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+		return nil, false
+	}
+	return data, true
+}
+
+func writeBotError(c *gin.Context, err error) {
+	if fields := validatorutil.Fields(err); len(fields) > 0 {
+		msg := botsvc.ErrInvalidTaxConfig.Error()
+		if !errors.Is(err, botsvc.ErrInvalidTaxConfig) {
+			msg = ErrMsgInvalidRequestBody.Error()
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "fields": fields})
+		return
+	}
+	switch {
+	case errors.Is(err, botsvc.ErrInvalidBot):
+		c.JSON(http.StatusBadRequest, gin.H{"error": botsvc.ErrInvalidBot.Error()})
+	case errors.Is(err, botsvc.ErrInvalidCurrency):
+		c.JSON(http.StatusBadRequest, gin.H{"error": botsvc.ErrInvalidCurrency.Error()})
+	case errors.Is(err, botsvc.ErrInvalidTimezone):
+		c.JSON(http.StatusBadRequest, gin.H{"error": botsvc.ErrInvalidTimezone.Error()})
+	case errors.Is(err, botsvc.ErrInvalidLocale):
+		c.JSON(http.StatusBadRequest, gin.H{"error": botsvc.ErrInvalidLocale.Error()})
+	case errors.Is(err, botsvc.ErrInvalidTaxConfig):
+		c.JSON(http.StatusBadRequest, gin.H{"error": botsvc.ErrInvalidTaxConfig.Error()})
+	case errors.Is(err, botsvc.ErrBotNotOwned):
+		c.JSON(http.StatusForbidden, gin.H{"error": botsvc.ErrBotNotOwned.Error()})
+	case errors.Is(err, store.ErrBotNotFound), errors.Is(err, store.ErrBotTemplateNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+	case errors.Is(err, jwtutil.ErrInvalidToken), errors.Is(err, jwtutil.ErrExpiredToken):
+		c.JSON(http.StatusUnauthorized, gin.H{"error": http.StatusText(http.StatusUnauthorized)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "bot request failed"})
 	}
 }
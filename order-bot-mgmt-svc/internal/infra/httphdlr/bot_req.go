@@ -0,0 +1,120 @@
+package httphdlr
+
+import "order-bot-mgmt-svc/internal/models/entities"
+
+type cloneBotReq struct {
+	BotName string `json:"bot_name" binding:"required"`
+}
+
+type botTemplateReq struct {
+	TemplateName string `json:"template_name" binding:"required"`
+}
+
+type botRes struct {
+	ID      string `json:"id"`
+	BotName string `json:"bot_name"`
+}
+
+type botTemplateRes struct {
+	ID           string `json:"id"`
+	TemplateName string `json:"template_name"`
+}
+
+func botResFromModel(bot entities.Bot) botRes {
+	return botRes{ID: bot.ID, BotName: bot.BotName}
+}
+
+func botTemplateResFromModel(template entities.BotTemplate) botTemplateRes {
+	return botTemplateRes{ID: template.ID, TemplateName: template.TemplateName}
+}
+
+type botProfileReq struct {
+	DisplayName         string `json:"display_name"`
+	Description         string `json:"description"`
+	ThemePrimaryColor   string `json:"theme_primary_color"`
+	ThemeSecondaryColor string `json:"theme_secondary_color"`
+	GreetingMessage     string `json:"greeting_message"`
+	ContactPhone        string `json:"contact_phone"`
+	ContactEmail        string `json:"contact_email"`
+	ContactAddress      string `json:"contact_address"`
+}
+
+type botProfileRes struct {
+	DisplayName         string `json:"display_name"`
+	Description         string `json:"description"`
+	LogoURL             string `json:"logo_url"`
+	LogoThumbnailURL    string `json:"logo_thumbnail_url"`
+	ThemePrimaryColor   string `json:"theme_primary_color"`
+	ThemeSecondaryColor string `json:"theme_secondary_color"`
+	GreetingMessage     string `json:"greeting_message"`
+	ContactPhone        string `json:"contact_phone"`
+	ContactEmail        string `json:"contact_email"`
+	ContactAddress      string `json:"contact_address"`
+}
+
+func modelFromBotProfileReq(req botProfileReq) entities.BotProfile {
+	return entities.BotProfile{
+		DisplayName:         req.DisplayName,
+		Description:         req.Description,
+		ThemePrimaryColor:   req.ThemePrimaryColor,
+		ThemeSecondaryColor: req.ThemeSecondaryColor,
+		GreetingMessage:     req.GreetingMessage,
+		ContactPhone:        req.ContactPhone,
+		ContactEmail:        req.ContactEmail,
+		ContactAddress:      req.ContactAddress,
+	}
+}
+
+func botProfileResFromModel(profile entities.BotProfile) botProfileRes {
+	return botProfileRes{
+		DisplayName:         profile.DisplayName,
+		Description:         profile.Description,
+		LogoURL:             profile.LogoURL,
+		LogoThumbnailURL:    profile.LogoThumbnailURL,
+		ThemePrimaryColor:   profile.ThemePrimaryColor,
+		ThemeSecondaryColor: profile.ThemeSecondaryColor,
+		GreetingMessage:     profile.GreetingMessage,
+		ContactPhone:        profile.ContactPhone,
+		ContactEmail:        profile.ContactEmail,
+		ContactAddress:      profile.ContactAddress,
+	}
+}
+
+type botCurrencyReq struct {
+	Currency   string `json:"currency" binding:"required"`
+	PriceScale *int   `json:"price_scale"`
+}
+
+type botCurrencyRes struct {
+	Currency   string `json:"currency"`
+	PriceScale int    `json:"price_scale"`
+}
+
+func botCurrencyResFromModel(bot entities.Bot) botCurrencyRes {
+	return botCurrencyRes{Currency: bot.Currency, PriceScale: bot.PriceScale}
+}
+
+// botTimezoneReq takes an IANA timezone name such as "Asia/Taipei".
+type botTimezoneReq struct {
+	Timezone string `json:"timezone" binding:"required"`
+}
+
+type botTimezoneRes struct {
+	Timezone string `json:"timezone"`
+}
+
+// botLocalesReq takes BCP 47 tags such as "zh-TW". The default locale is
+// supported even when locales leaves it out.
+type botLocalesReq struct {
+	DefaultLocale string   `json:"default_locale" binding:"required"`
+	Locales       []string `json:"locales"`
+}
+
+type botLocalesRes struct {
+	DefaultLocale string   `json:"default_locale"`
+	Locales       []string `json:"locales"`
+}
+
+func botLocalesResFromModel(bot entities.Bot) botLocalesRes {
+	return botLocalesRes{DefaultLocale: bot.DefaultLocale, Locales: bot.Locales}
+}
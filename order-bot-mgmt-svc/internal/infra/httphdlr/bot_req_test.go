@@ -0,0 +1,55 @@
+package httphdlr
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/services/botsvc"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestModelFromBotProfileReq(t *testing.T) {
+	var req botProfileReq
+	body := `{"display_name": "Diner", "theme_primary_color": "#123456", "contact_email": "hello@diner.example",
+		"logo_url": "/files/elsewhere.png"}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	want := entities.BotProfile{DisplayName: "Diner", ThemePrimaryColor: "#123456", ContactEmail: "hello@diner.example"}
+	if got := modelFromBotProfileReq(req); got != want {
+		t.Errorf("modelFromBotProfileReq() = %+v, want %+v, without the logo", got, want)
+	}
+}
+
+func TestBotProfileResFromModel(t *testing.T) {
+	res := botProfileResFromModel(entities.BotProfile{
+		DisplayName:      "Diner",
+		LogoURL:          "/files/bots/b/logo.png",
+		LogoThumbnailURL: "/files/bots/b/logo_thumb.png",
+	})
+	data, err := json.Marshal(res)
+	if err != nil {
+		t.Fatalf("Marshal() = %v", err)
+	}
+	var got map[string]string
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if got["logo_url"] != "/files/bots/b/logo.png" || got["logo_thumbnail_url"] != "/files/bots/b/logo_thumb.png" ||
+		got["display_name"] != "Diner" {
+		t.Errorf("botProfileResFromModel() = %s", data)
+	}
+}
+
+func TestWriteBotErrorInvalidProfile(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	writeBotError(c, fmt.Errorf("botsvc.UpdateProfile: %w", botsvc.ErrInvalidBot))
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,11 @@
+package httphdlr
+
+import "github.com/gin-gonic/gin"
+
+// CtxKeyUserID holds the ID of the authenticated user. The auth middleware
+// sets it on protected routes.
+const CtxKeyUserID = "userID"
+
+func userIDFromCtx(c *gin.Context) string {
+	return c.GetString(CtxKeyUserID)
+}
@@ -11,4 +11,12 @@ var (
 		Code: "ErrMsgInvalidRequestBody",
 		Msg:  "invalid request body",
 	}
+	ErrMsgIfMatchRequired = apperr.Err{
+		Code: "ErrMsgIfMatchRequired",
+		Msg:  "If-Match with the ETag of the menu is required",
+	}
+	ErrMsgInvalidIfMatch = apperr.Err{
+		Code: "ErrMsgInvalidIfMatch",
+		Msg:  "If-Match must be a single ETag of the menu or *",
+	}
 )
@@ -0,0 +1,56 @@
+package httphdlr
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"order-bot-mgmt-svc/internal/services/filesvc"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/errutil"
+	"order-bot-mgmt-svc/internal/util/imageutil"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FileServer interface {
+	FileService() *filesvc.Svc
+}
+
+const FilePrefix = "/files"
+
+func RegisterFileRoutes(r gin.IRoutes, s FileServer) {
+	r.GET("/*key", getFileHdlrFunc(s))
+}
+
+func getFileHdlrFunc(s FileServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := strings.TrimPrefix(c.Param("key"), "/")
+		obj, err := s.FileService().Open(c.Request.Context(), key)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeFileError(c, err)
+			return
+		}
+		defer obj.Body.Close()
+		c.Header("Cache-Control", "public, max-age=86400")
+		c.DataFromReader(http.StatusOK, obj.Size, obj.ContentType, obj.Body, nil)
+	}
+}
+
+func writeFileError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, filesvc.ErrUnsupportedType):
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": filesvc.ErrUnsupportedType.Error()})
+	case errors.Is(err, filesvc.ErrFileTooLarge):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": filesvc.ErrFileTooLarge.Error()})
+	case errors.Is(err, imageutil.ErrTooManyPixels):
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": imageutil.ErrTooManyPixels.Error()})
+	case errors.Is(err, imageutil.ErrInvalidImage):
+		c.JSON(http.StatusBadRequest, gin.H{"error": imageutil.ErrInvalidImage.Error()})
+	case errors.Is(err, store.ErrBlobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": http.StatusText(http.StatusNotFound)})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "file request failed"})
+	}
+}
@@ -3,6 +3,7 @@ package httpserver
 import (
 	"log/slog"
 	"net/http"
+	"order-bot-mgmt-svc/internal/infra/httphdlr"
 	"order-bot-mgmt-svc/internal/util/errutil"
 	"strings"
 
@@ -13,7 +14,8 @@ func corsMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS, PATCH")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, X-CSRF-Token")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Accept, Accept-Language, Authorization, Content-Type, If-Match, X-CSRF-Token")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "ETag")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "false")
 		if c.Request.Method == http.MethodOptions {
 			c.Status(http.StatusNoContent)
@@ -36,11 +38,13 @@ func authMiddleware(s *Server) gin.HandlerFunc {
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
-		if err := authService.ValidateAccessToken(c.Request.Context(), accessToken); err != nil {
+		userID, err := authService.ValidateAccessToken(c.Request.Context(), accessToken)
+		if err != nil {
 			slog.Debug(errutil.FormatErrChain(err))
 			c.AbortWithStatus(http.StatusUnauthorized)
 			return
 		}
+		c.Set(httphdlr.CtxKeyUserID, userID)
 		c.Next()
 	}
 }
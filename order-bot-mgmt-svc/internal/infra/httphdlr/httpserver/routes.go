@@ -41,6 +41,13 @@ func Run(s *Server, ginMode string, addr string) {
 	httphdlr.RegisterBotRoutes(bot, s)
 	orders := protected.Group(httphdlr.OrderPrefix)
 	httphdlr.RegisterOrderRoutes(orders, s)
+	stock := protected.Group(httphdlr.StockPrefix)
+	httphdlr.RegisterStockRoutes(stock, s)
+	promotions := protected.Group(httphdlr.PromotionPrefix)
+	httphdlr.RegisterPromotionRoutes(promotions, s)
+	// Bot images are public: published menus link to them for customers.
+	files := public.Group(httphdlr.FilePrefix)
+	httphdlr.RegisterFileRoutes(files, s)
 
 	health := public.Group("/health")
 	health.GET("/chk", func(c *gin.Context) {
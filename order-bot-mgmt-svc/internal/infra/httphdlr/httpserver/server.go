@@ -6,8 +6,11 @@ import (
 	"order-bot-mgmt-svc/internal/services"
 	"order-bot-mgmt-svc/internal/services/authsvc"
 	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/services/ordersvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
+	"order-bot-mgmt-svc/internal/services/stocksvc"
 	"order-bot-mgmt-svc/internal/store"
 )
 
@@ -35,3 +38,8 @@ func (s *Server) BotService() *botsvc.Svc   { return s.services.Bot.Get() }
 func (s *Server) OrderService() *ordersvc.Svc {
 	return s.services.Order.Get()
 }
+func (s *Server) FileService() *filesvc.Svc   { return s.services.File.Get() }
+func (s *Server) StockService() *stocksvc.Svc { return s.services.Stock.Get() }
+func (s *Server) PromotionService() *promotionsvc.Svc {
+	return s.services.Promotion.Get()
+}
@@ -0,0 +1,144 @@
+package httphdlr
+
+import (
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+)
+
+// menuBundlesReq replaces every bundle of a draft menu, in display order.
+type menuBundlesReq struct {
+	Bundles []menuBundleReq `json:"bundles"`
+}
+
+// menuBundleReq keeps the bundle with ID, an id from a menu response, or
+// adds one when ID is empty.
+type menuBundleReq struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Price       moneyutil.Decimal `json:"price"`
+	Description string            `json:"description"`
+	Slots       []bundleSlotReq   `json:"slots"`
+}
+
+type bundleSlotReq struct {
+	Name    string            `json:"name"`
+	Choices []bundleChoiceReq `json:"choices"`
+}
+
+// bundleChoiceReq takes an item id from a menu response. An empty upcharge
+// is zero.
+type bundleChoiceReq struct {
+	ItemID   string            `json:"item_id"`
+	Upcharge moneyutil.Decimal `json:"upcharge"`
+}
+
+type menuBundleRes struct {
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Price        string          `json:"price"`
+	PriceScaled  int64           `json:"price_scaled"`
+	Description  string          `json:"description"`
+	SortPosition int             `json:"sort_position"`
+	Slots        []bundleSlotRes `json:"slots"`
+}
+
+type bundleSlotRes struct {
+	ID           string            `json:"id"`
+	Name         string            `json:"name"`
+	SortPosition int               `json:"sort_position"`
+	Choices      []bundleChoiceRes `json:"choices"`
+}
+
+type bundleChoiceRes struct {
+	ItemID         string `json:"item_id"`
+	Upcharge       string `json:"upcharge"`
+	UpchargeScaled int64  `json:"upcharge_scaled"`
+	SortPosition   int    `json:"sort_position"`
+}
+
+type menuBundlesRes struct {
+	Bundles []menuBundleRes `json:"bundles"`
+}
+
+// modelFromBundlesReq parses prices at the bot's scale. Field errors are
+// relative to the request, which matches the menu for bundles.
+func modelFromBundlesReq(req menuBundlesReq, priceScale int) ([]entities.MenuBundle, error) {
+	var errs validatorutil.FieldErrors
+	bundles := make([]entities.MenuBundle, 0, len(req.Bundles))
+	for idx, reqBundle := range req.Bundles {
+		prefix := fmt.Sprintf("bundles[%d]", idx)
+		price, err := moneyutil.ParseMoney(string(reqBundle.Price), priceScale)
+		if err != nil {
+			addPriceErr(&errs, err, prefix+".price", reqBundle.Price, priceScale)
+		}
+		bundle := entities.MenuBundle{
+			ID:          reqBundle.ID,
+			BundleName:  reqBundle.Name,
+			PriceScaled: int64(price),
+			Description: reqBundle.Description,
+			Slots:       make([]entities.MenuBundleSlot, 0, len(reqBundle.Slots)),
+		}
+		for slotIdx, reqSlot := range reqBundle.Slots {
+			slot := entities.MenuBundleSlot{
+				SlotName: reqSlot.Name,
+				Choices:  make([]entities.MenuBundleChoice, 0, len(reqSlot.Choices)),
+			}
+			for choiceIdx, reqChoice := range reqSlot.Choices {
+				var upcharge moneyutil.Money
+				if reqChoice.Upcharge != "" {
+					upcharge, err = moneyutil.ParseMoney(string(reqChoice.Upcharge), priceScale)
+					if err != nil {
+						field := fmt.Sprintf("%s.slots[%d].choices[%d].upcharge", prefix, slotIdx, choiceIdx)
+						addPriceErr(&errs, err, field, reqChoice.Upcharge, priceScale)
+					}
+				}
+				slot.Choices = append(slot.Choices, entities.MenuBundleChoice{
+					MenuItemID:     reqChoice.ItemID,
+					UpchargeScaled: int64(upcharge),
+				})
+			}
+			bundle.Slots = append(bundle.Slots, slot)
+		}
+		bundles = append(bundles, bundle)
+	}
+	if err := errs.Err(); err != nil {
+		return nil, fmt.Errorf("httphdlr.modelFromBundlesReq: %w", err)
+	}
+	return bundles, nil
+}
+
+func menuBundlesResFromModel(bot entities.Bot, bundles []entities.MenuBundle) []menuBundleRes {
+	resBundles := make([]menuBundleRes, 0, len(bundles))
+	for _, bundle := range bundles {
+		slots := make([]bundleSlotRes, 0, len(bundle.Slots))
+		for _, slot := range bundle.Slots {
+			choices := make([]bundleChoiceRes, 0, len(slot.Choices))
+			for _, choice := range slot.Choices {
+				choices = append(choices, bundleChoiceRes{
+					ItemID:         choice.MenuItemID,
+					Upcharge:       moneyutil.Money(choice.UpchargeScaled).Format(bot.PriceScale),
+					UpchargeScaled: choice.UpchargeScaled,
+					SortPosition:   choice.SortPosition,
+				})
+			}
+			slots = append(slots, bundleSlotRes{
+				ID:           slot.ID,
+				Name:         slot.SlotName,
+				SortPosition: slot.SortPosition,
+				Choices:      choices,
+			})
+		}
+		resBundles = append(resBundles, menuBundleRes{
+			ID:           bundle.ID,
+			Name:         bundle.BundleName,
+			Price:        moneyutil.Money(bundle.PriceScaled).Format(bot.PriceScale),
+			PriceScaled:  bundle.PriceScaled,
+			Description:  bundle.Description,
+			SortPosition: bundle.SortPosition,
+			Slots:        slots,
+		})
+	}
+	return resBundles
+}
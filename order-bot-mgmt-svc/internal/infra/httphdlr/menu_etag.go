@@ -0,0 +1,100 @@
+package httphdlr
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/errutil"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// menuETag is the entity tag of a draft menu: its version in quotes.
+func menuETag(menu entities.Menu) string {
+	return strconv.Quote(strconv.Itoa(menu.Version))
+}
+
+func setMenuETag(c *gin.Context, menu entities.Menu) {
+	if menu.Version > 0 {
+		c.Header("ETag", menuETag(menu))
+	}
+}
+
+// versionFromIfMatch reads the menu version an edit is based on from an
+// If-Match header. Weak tags are read like strong ones. "*" matches any
+// version and gives 0, which the service reads as no check.
+func versionFromIfMatch(header string) (int, bool) {
+	tag := strings.TrimSpace(header)
+	if tag == "*" {
+		return 0, true
+	}
+	tag = strings.TrimPrefix(tag, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+	version, err := strconv.Atoi(tag[1 : len(tag)-1])
+	if err != nil || version < 1 {
+		return 0, false
+	}
+	return version, true
+}
+
+// requireIfMatch reads the If-Match of an edit of a draft menu. It responds
+// 428 when the header is missing, 400 when it is malformed, and then reports
+// false.
+func requireIfMatch(c *gin.Context) (int, bool) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": ErrMsgIfMatchRequired.Error()})
+		return 0, false
+	}
+	version, ok := versionFromIfMatch(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidIfMatch.Error()})
+		return 0, false
+	}
+	return version, true
+}
+
+// optionalIfMatch reads the If-Match of edits that may leave it out, which
+// then apply to the draft as it is. It responds 400 and reports false when
+// the header is malformed.
+func optionalIfMatch(c *gin.Context) (int, bool) {
+	ifMatch := c.GetHeader("If-Match")
+	if ifMatch == "" {
+		return 0, true
+	}
+	version, ok := versionFromIfMatch(ifMatch)
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidIfMatch.Error()})
+		return 0, false
+	}
+	return version, true
+}
+
+// writeMenuEditError responds to a failed edit of a draft menu. An edit based
+// on an older version is expected and not logged as an error.
+func writeMenuEditError(c *gin.Context, err error) {
+	if errors.Is(err, store.ErrMenuChanged) {
+		slog.Info(errutil.FormatErrChain(err))
+	} else {
+		slog.Error(errutil.FormatErrChain(err))
+	}
+	writeMenuError(c, err)
+}
+
+// writeMenuChanged responds 412 with the current version of the menu, as the
+// ETag and in the body, so the client can reload and reapply its edit.
+func writeMenuChanged(c *gin.Context, err error) {
+	var changed store.MenuChangedErr
+	if !errors.As(err, &changed) {
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": store.ErrMenuChanged.Error()})
+		return
+	}
+	setMenuETag(c, entities.Menu{Version: changed.Version})
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": store.ErrMenuChanged.Error(), "version": changed.Version})
+}
@@ -1,28 +1,67 @@
 package httphdlr
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
 	"log/slog"
 	"net/http"
 	"order-bot-mgmt-svc/internal/apperr"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util/errutil"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/sheetutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type MenuServer interface {
 	MenuService() *menusvc.Svc
+	BotService() *botsvc.Svc
+	FileService() *filesvc.Svc
 }
 
 const MenuPrefix = "/menus"
 
+// RegisterMenuRoutes registers the menu routes. Routes under /:botId that
+// work on a single menu take it from the menu_id query parameter, which may
+// be left out while the bot has one menu.
 func RegisterMenuRoutes(r gin.IRoutes, s MenuServer) {
 	r.POST("/", createMenuHdlrFunc(s))
 	r.GET("/:botId", getMenuHdlrFunc(s))
+	r.GET("/:botId/menus", listMenusHdlrFunc(s))
+	r.DELETE("/:botId/menus/:menuId", deleteMenuHdlrFunc(s))
 	r.PUT("/", updateMenuHdlrFunc(s))
 	r.POST("/:botId/publish", publishMenuHdlrFunc(s))
+	r.GET("/:botId/compare", compareMenuHdlrFunc(s))
+	r.POST("/:botId/match", matchMenuItemsHdlrFunc(s))
+	r.POST("/:botId/publish/schedules", schedulePublishHdlrFunc(s))
+	r.GET("/:botId/publish/schedules", listPublishJobsHdlrFunc(s))
+	r.DELETE("/:botId/publish/schedules/:jobId", cancelPublishJobHdlrFunc(s))
+	r.PUT("/:botId/categories/order", reorderCategoriesHdlrFunc(s))
+	r.PUT("/:botId/categories/:categoryId/items/order", reorderItemsHdlrFunc(s))
+	r.PUT("/:botId/items/order", reorderItemsHdlrFunc(s))
+	r.PUT("/:botId/availability", setAvailabilityHdlrFunc(s))
+	r.POST("/:botId/items", createItemHdlrFunc(s))
+	r.PATCH("/:botId/items", patchItemsHdlrFunc(s))
+	r.GET("/:botId/items/:itemId", getItemHdlrFunc(s))
+	r.PATCH("/:botId/items/:itemId", patchItemHdlrFunc(s))
+	r.DELETE("/:botId/items/:itemId", deleteItemHdlrFunc(s))
+	r.PUT("/:botId/items/:itemId/image", uploadItemImageHdlrFunc(s))
+	r.DELETE("/:botId/items/:itemId/image", deleteItemImageHdlrFunc(s))
+	r.PUT("/:botId/items/:itemId/aliases", setItemAliasesHdlrFunc(s))
+	r.PUT("/:botId/bundles", setBundlesHdlrFunc(s))
+	r.GET("/:botId/versions", listMenuVersionsHdlrFunc(s))
+	r.GET("/:botId/versions/diff", diffMenuVersionsHdlrFunc(s))
+	r.POST("/:botId/versions/:version/rollback", rollbackMenuHdlrFunc(s))
+	r.GET("/:botId/export", exportMenuHdlrFunc(s))
+	r.POST("/:botId/import", importMenuHdlrFunc(s))
 	r.GET("/published/:menuId", isMenuPublishedHdlrFunc(s))
 }
 
@@ -33,59 +72,751 @@ func createMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
 			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
 			return
 		}
-		menu, items, err := s.MenuService().CreateMenu(c.Request.Context(), req.BotID, modelFromMenReq(req))
+		bot, err := s.BotService().GetBot(c.Request.Context(), req.BotID)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(c, err)
 			return
 		}
-		c.JSON(http.StatusCreated, menuResFromModel(menu, items))
+		reqDetail, reqFields, err := modelFromMenReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		detail, err := s.MenuService().CreateMenu(c.Request.Context(), req.BotID, userIDFromCtx(c), reqDetail)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, reqFields.requestErr(err))
+			return
+		}
+		setMenuETag(c, detail.Menu)
+		c.JSON(http.StatusCreated, menuResFromModel(bot, detail, menuLocale(c, bot)))
 	}
 }
 
 func getMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		writeMenu(c, s, c.Param("botId"), c.Query("menu_id"))
+	}
+}
+
+func listMenusHdlrFunc(s MenuServer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		botID := c.Param("botId")
-		menu, items, err := s.MenuService().GetMenuMenuItems(c.Request.Context(), botID)
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		menus, err := s.MenuService().ListMenus(c.Request.Context(), botID)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, menuResFromModel(menu, items))
+		c.JSON(http.StatusOK, menuSummariesResFromModel(bot, menus))
 	}
 }
 
+func deleteMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.MenuService().DeleteMenu(c.Request.Context(), c.Param("botId"), c.Param("menuId")); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// updateMenuHdlrFunc replaces a draft menu. If-Match must carry the ETag the
+// edit is based on, or "*" to overwrite whatever the draft is.
 func updateMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
 		var req menuReq
 		if err := c.ShouldBindJSON(&req); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
 			return
 		}
-		menu, items, err := s.MenuService().UpdateMenu(c.Request.Context(), req.BotID, modelFromMenReq(req))
+		bot, err := s.BotService().GetBot(c.Request.Context(), req.BotID)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, menuResFromModel(menu, items))
+		reqDetail, reqFields, err := modelFromMenReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		reqDetail.Menu.Version = version
+		detail, err := s.MenuService().UpdateMenu(c.Request.Context(), req.BotID, userIDFromCtx(c), reqDetail)
+		if err != nil {
+			writeMenuEditError(c, reqFields.requestErr(err))
+			return
+		}
+		setMenuETag(c, detail.Menu)
+		c.JSON(http.StatusOK, menuResFromModel(bot, detail, menuLocale(c, bot)))
 	}
 }
 
+// publishMenuHdlrFunc publishes all of the bot's menus and responds with
+// them, or with the pending changes per menu when called with ?dry_run=true.
 func publishMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
 	return func(c *gin.Context) {
+		var req menuPublishReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		details, comparisons, err := s.MenuService().PublishMenus(c.Request.Context(), botID, req.DryRun)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		if req.DryRun {
+			res := menuComparisonsRes{Menus: make([]menuComparisonRes, 0, len(comparisons))}
+			for _, comparison := range comparisons {
+				res.Menus = append(res.Menus, menuComparisonResFromModel(bot, comparison))
+			}
+			c.JSON(http.StatusOK, res)
+			return
+		}
+		res := menusRes{Menus: make([]menuRes, 0, len(details))}
+		locale := menuLocale(c, bot)
+		for _, detail := range details {
+			res.Menus = append(res.Menus, menuResFromModel(bot, detail, locale))
+		}
+		c.JSON(http.StatusOK, res)
+	}
+}
+
+func compareMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		comparison, err := s.MenuService().CompareWithPublished(c.Request.Context(), botID, c.Query("menu_id"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, menuComparisonResFromModel(bot, comparison))
+	}
+}
+
+// matchMenuItemsHdlrFunc matches free text against the published menu and
+// responds with the candidate items of each phrase.
+func matchMenuItemsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req menuMatchReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		if req.Limit == 0 {
+			req.Limit = defaultMatchLimit
+		}
+		match, err := s.MenuService().MatchItems(c.Request.Context(), c.Param("botId"), c.Query("menu_id"), req.Text, req.Limit)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, menuMatchResFromModel(match, time.Now()))
+	}
+}
+
+func schedulePublishHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req publishJobReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		job, err := s.MenuService().SchedulePublish(c.Request.Context(), c.Param("botId"), userIDFromCtx(c), req.RunAt)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, publishJobResFromModel(job))
+	}
+}
+
+func listPublishJobsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		jobs, err := s.MenuService().ListPublishJobs(c.Request.Context(), c.Param("botId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		resJobs := make([]publishJobRes, 0, len(jobs))
+		for _, job := range jobs {
+			resJobs = append(resJobs, publishJobResFromModel(job))
+		}
+		c.JSON(http.StatusOK, publishJobsRes{Jobs: resJobs})
+	}
+}
+
+func cancelPublishJobHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		job, err := s.MenuService().CancelPublishJob(c.Request.Context(), c.Param("botId"), c.Param("jobId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, publishJobResFromModel(job))
+	}
+}
+
+// reorderCategoriesHdlrFunc reorders the categories of a draft menu. Like
+// every edit of a draft it needs If-Match.
+func reorderCategoriesHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuReorderReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		if err := s.MenuService().ReorderCategories(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, req.IDs); err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		writeMenu(c, s, botID, menuID)
+	}
+}
+
+// reorderItemsHdlrFunc reorders the items of the :categoryId category, or the
+// uncategorized items when the route has no category.
+func reorderItemsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuReorderReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		err := s.MenuService().ReorderItems(
+			c.Request.Context(), botID, menuID, userIDFromCtx(c), version, c.Param("categoryId"), req.IDs,
+		)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		writeMenu(c, s, botID, menuID)
+	}
+}
+
+// setAvailabilityHdlrFunc marks draft items sold out or back and responds with
+// them and the ETag of the menu. If-Match is optional: the toggle is a quick
+// action for the kitchen and a full menu save never writes sold_out, so it
+// cannot undo one.
+func setAvailabilityHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := optionalIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuAvailabilityReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		var restoreAt time.Time
+		if req.RestoreAt != nil {
+			restoreAt = *req.RestoreAt
+		}
+		items, menu, err := s.MenuService().SetAvailability(
+			c.Request.Context(), c.Param("botId"), c.Query("menu_id"), version, req.ItemIDs, req.SoldOut, restoreAt,
+		)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.JSON(http.StatusOK, menuAvailabilityResFromModel(items))
+	}
+}
+
+func listMenuVersionsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		versions, err := s.MenuService().ListVersions(c.Request.Context(), c.Param("botId"), c.Query("menu_id"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, menuVersionsResFromModel(versions))
+	}
+}
+
+func diffMenuVersionsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req menuVersionDiffReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
 		botID := c.Param("botId")
-		menu, items, err := s.MenuService().PublishMenu(c.Request.Context(), botID)
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(c, err)
 			return
 		}
-		c.JSON(http.StatusOK, menuResFromModel(menu, items))
+		diff, err := s.MenuService().DiffVersions(c.Request.Context(), botID, c.Query("menu_id"), req.From, req.To)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		res := menuVersionDiffRes{From: req.From, To: req.To, menuDiffRes: menuDiffResFromModel(bot, diff)}
+		c.JSON(http.StatusOK, res)
 	}
 }
 
+// rollbackMenuHdlrFunc makes an earlier version the draft again. If-Match
+// names the draft version being replaced.
+func rollbackMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuVersionUri
+		if err := c.ShouldBindUri(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		detail, err := s.MenuService().RollbackMenu(c.Request.Context(), botID, c.Query("menu_id"), userIDFromCtx(c), version, req.Version)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, detail.Menu)
+		c.JSON(http.StatusOK, menuResFromModel(bot, detail, menuLocale(c, bot)))
+	}
+}
+
+// createItemHdlrFunc adds an item to a draft menu and responds with it. The
+// item handlers need If-Match like updateMenuHdlrFunc.
+func createItemHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuItemCreateReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		reqItem, err := modelFromItemCreateReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		item, menu, err := s.MenuService().CreateItem(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, reqItem)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.JSON(http.StatusCreated, menuItemResFromModel(bot, item, menuLocale(c, bot)))
+	}
+}
+
+func getItemHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		item, menu, err := s.MenuService().GetItem(c.Request.Context(), botID, c.Query("menu_id"), c.Param("itemId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.JSON(http.StatusOK, menuItemResFromModel(bot, item, menuLocale(c, bot)))
+	}
+}
+
+// patchItemHdlrFunc changes the fields of a draft item that the request
+// carries and responds with the item.
+func patchItemHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuItemPatchReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		patch, err := modelFromItemPatchReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		item, menu, err := s.MenuService().PatchItem(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, c.Param("itemId"), patch)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.JSON(http.StatusOK, menuItemResFromModel(bot, item, menuLocale(c, bot)))
+	}
+}
+
+func deleteItemHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		menu, err := s.MenuService().DeleteItem(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, c.Param("itemId"))
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// patchItemsHdlrFunc changes several draft items at once, such as raising
+// the prices of a category by a percentage, and responds with the changed
+// items.
+func patchItemsHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuItemsPatchReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		patch, err := modelFromItemsPatchReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		items, menu, err := s.MenuService().PatchItems(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, patch)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, menu)
+		c.JSON(http.StatusOK, menuItemsResFromModel(bot, items, menuLocale(c, bot)))
+	}
+}
+
+// uploadItemImageHdlrFunc stores the image in the multipart "file" field and
+// responds with the menu.
+func uploadItemImageHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		data, ok := readUploadedFile(c, s.FileService().MaxImageBytes())
+		if !ok {
+			return
+		}
+		botID, menuID, itemID := c.Param("botId"), c.Query("menu_id"), c.Param("itemId")
+		image, err := s.FileService().SaveImage(c.Request.Context(), "bots/"+botID+"/items/"+itemID, data)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeFileError(c, err)
+			return
+		}
+		if err := s.MenuService().SetItemImage(c.Request.Context(), botID, menuID, version, itemID, image); err != nil {
+			if errDel := s.FileService().DeleteImage(c.Request.Context(), image); errDel != nil {
+				slog.Error(errutil.FormatErrChain(errDel))
+			}
+			writeMenuEditError(c, err)
+			return
+		}
+		writeMenu(c, s, botID, menuID)
+	}
+}
+
+// setItemAliasesHdlrFunc replaces the aliases of a draft item and responds
+// with the menu.
+func setItemAliasesHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req itemAliasesReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		detail, err := s.MenuService().SetItemAliases(
+			c.Request.Context(), botID, c.Query("menu_id"), userIDFromCtx(c), version, c.Param("itemId"), req.Aliases,
+		)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, detail.Menu)
+		c.JSON(http.StatusOK, menuResFromModel(bot, detail, menuLocale(c, bot)))
+	}
+}
+
+// setBundlesHdlrFunc replaces the bundles of a draft menu and responds with
+// them. Choices take item ids from a menu response. It needs If-Match.
+func setBundlesHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		var req menuBundlesReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		bundles, err := modelFromBundlesReq(req, bot.PriceScale)
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		detail, err := s.MenuService().SetBundles(c.Request.Context(), botID, menuID, userIDFromCtx(c), version, bundles)
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		setMenuETag(c, detail.Menu)
+		c.JSON(http.StatusOK, menuBundlesRes{Bundles: menuBundlesResFromModel(bot, detail.Bundles)})
+	}
+}
+
+// deleteItemImageHdlrFunc clears the image of the draft item. The files stay,
+// since the published menu may still show them.
+func deleteItemImageHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		version, ok := requireIfMatch(c)
+		if !ok {
+			return
+		}
+		botID, menuID := c.Param("botId"), c.Query("menu_id")
+		if err := s.MenuService().SetItemImage(c.Request.Context(), botID, menuID, version, c.Param("itemId"), entities.Image{}); err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		writeMenu(c, s, botID, menuID)
+	}
+}
+
+func exportMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req menuExportReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		rows, err := s.MenuService().ExportMenuSheet(c.Request.Context(), botID, req.MenuID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		var buf bytes.Buffer
+		format := menuSheetFormat(req.Format, "")
+		contentType := menuSheetContentTypeCSV
+		if format == menuSheetFormatXLSX {
+			contentType = menuSheetContentTypeXLSX
+			err = sheetutil.WriteXLSX(&buf, "Menu", menuSheetTableFromRows(rows))
+		} else {
+			err = sheetutil.WriteCSV(&buf, menuSheetTableFromRows(rows))
+		}
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="menu-%s.%s"`, botID, format))
+		c.Data(http.StatusOK, contentType, buf.Bytes())
+	}
+}
+
+// importMenuHdlrFunc reads a CSV or XLSX upload from the multipart "file"
+// field. Row errors come back with the parsed rows, with 200 on a dry run and
+// 400 otherwise. Only a real import needs If-Match.
+func importMenuHdlrFunc(s MenuServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req menuImportReq
+		if err := c.ShouldBindQuery(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		var version int
+		if !req.DryRun {
+			var ok bool
+			if version, ok = requireIfMatch(c); !ok {
+				return
+			}
+		}
+		data, ok := readUploadedFile(c, maxMenuSheetBytes)
+		if !ok {
+			return
+		}
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeMenuError(c, err)
+			return
+		}
+		var table [][]string
+		if menuSheetFormat(req.Format, fileHeader.Filename) == menuSheetFormatXLSX {
+			table, err = sheetutil.ReadXLSX(data)
+		} else {
+			table, err = sheetutil.ReadCSV(data)
+		}
+		if err != nil {
+			writeMenuError(c, err)
+			return
+		}
+		mode := entities.MenuImportMerge
+		if req.Mode != "" {
+			mode = entities.MenuImportMode(req.Mode)
+		}
+		status := http.StatusBadRequest
+		if req.DryRun {
+			status = http.StatusOK
+		}
+		rows, rowErrs := menuSheetRowsFromTable(table)
+		if len(rowErrs) > 0 {
+			c.JSON(status, menuImportResFromModel(bot, rows, entities.MenuImport{Mode: mode, Errors: rowErrs}, req.DryRun, menuLocale(c, bot)))
+			return
+		}
+		result, err := s.MenuService().ImportMenuSheet(
+			c.Request.Context(), botID, req.MenuID, userIDFromCtx(c), version, rows, mode, req.DryRun,
+		)
+		if errors.Is(err, menusvc.ErrInvalidMenuImport) && len(result.Errors) > 0 {
+			c.JSON(status, menuImportResFromModel(bot, rows, result, req.DryRun, menuLocale(c, bot)))
+			return
+		}
+		if err != nil {
+			writeMenuEditError(c, err)
+			return
+		}
+		if !req.DryRun {
+			setMenuETag(c, result.Detail.Menu)
+		}
+		c.JSON(http.StatusOK, menuImportResFromModel(bot, rows, result, req.DryRun, menuLocale(c, bot)))
+	}
+}
+
+// writeMenu responds with one of the bot's current draft menus.
+func writeMenu(c *gin.Context, s MenuServer, botID string, menuID string) {
+	detail, err := s.MenuService().GetMenuMenuItems(c.Request.Context(), botID, menuID)
+	if err != nil {
+		slog.Error(errutil.FormatErrChain(err))
+		writeMenuError(c, err)
+		return
+	}
+	bot, err := s.BotService().GetBot(c.Request.Context(), detail.Menu.BotID)
+	if err != nil {
+		slog.Error(errutil.FormatErrChain(err))
+		writeMenuError(c, err)
+		return
+	}
+	setMenuETag(c, detail.Menu)
+	c.JSON(http.StatusOK, menuResFromModel(bot, detail, menuLocale(c, bot)))
+}
+
 func isMenuPublishedHdlrFunc(s MenuServer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		menuID := c.Param("menuId")
@@ -99,10 +830,77 @@ func isMenuPublishedHdlrFunc(s MenuServer) gin.HandlerFunc {
 	}
 }
 
+// writeMenuFieldErrors responds with every problem of a menu request under
+// the message writeMenuError would use. Only a menu whose sole problems are
+// taken aliases is a conflict.
+func writeMenuFieldErrors(c *gin.Context, err error, fields validatorutil.FieldErrors) {
+	status, msg := http.StatusBadRequest, menusvc.ErrInvalidMenu.Error()
+	switch {
+	case errors.Is(err, menusvc.ErrInvalidMenu):
+	case errors.Is(err, menusvc.ErrInvalidOptionGroup):
+		msg = menusvc.ErrInvalidOptionGroup.Error()
+	case errors.Is(err, menusvc.ErrInvalidBundle):
+		msg = menusvc.ErrInvalidBundle.Error()
+	case errors.Is(err, ErrMsgInvalidRequestBody):
+		msg = ErrMsgInvalidRequestBody.Error()
+	case errors.Is(err, moneyutil.ErrInvalidAmount):
+		msg = moneyutil.ErrInvalidAmount.Error()
+	case errors.Is(err, moneyutil.ErrTooPrecise):
+		msg = moneyutil.ErrTooPrecise.Error()
+	case errors.Is(err, menusvc.ErrDuplicateAlias):
+		status, msg = http.StatusConflict, menusvc.ErrDuplicateAlias.Error()
+	}
+	c.JSON(status, gin.H{"error": msg, "fields": fields})
+}
+
 func writeMenuError(c *gin.Context, err error) {
+	if fields := validatorutil.Fields(err); len(fields) > 0 {
+		writeMenuFieldErrors(c, err, fields)
+		return
+	}
 	switch {
 	case errors.Is(err, menusvc.ErrInvalidMenu):
 		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidMenu.Error()})
+	case errors.Is(err, menusvc.ErrInvalidOptionGroup):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidOptionGroup.Error()})
+	case errors.Is(err, menusvc.ErrInvalidBundle):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidBundle.Error()})
+	case errors.Is(err, ErrMsgInvalidRequestBody):
+		c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody.Error()})
+	case errors.Is(err, moneyutil.ErrInvalidAmount):
+		c.JSON(http.StatusBadRequest, gin.H{"error": moneyutil.ErrInvalidAmount.Error()})
+	case errors.Is(err, moneyutil.ErrTooPrecise):
+		c.JSON(http.StatusBadRequest, gin.H{"error": moneyutil.ErrTooPrecise.Error()})
+	case errors.Is(err, menusvc.ErrInvalidAvailability):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidAvailability.Error()})
+	case errors.Is(err, store.ErrNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrNotFound.Error()})
+	case errors.Is(err, store.ErrMenuAmbiguous):
+		c.JSON(http.StatusBadRequest, gin.H{"error": store.ErrMenuAmbiguous.Error()})
+	case errors.Is(err, store.ErrMenuChanged):
+		writeMenuChanged(c, err)
+	case errors.Is(err, menusvc.ErrInvalidPublishJob):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidPublishJob.Error()})
+	case errors.Is(err, menusvc.ErrPublishJobNotPending):
+		c.JSON(http.StatusConflict, gin.H{"error": menusvc.ErrPublishJobNotPending.Error()})
+	case errors.Is(err, store.ErrPublishJobNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrPublishJobNotFound.Error()})
+	case errors.Is(err, store.ErrMenuItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrMenuItemNotFound.Error()})
+	case errors.Is(err, store.ErrMenuVersionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrMenuVersionNotFound.Error()})
+	case errors.Is(err, menusvc.ErrInvalidMenuImport):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidMenuImport.Error()})
+	case errors.Is(err, sheetutil.ErrInvalidSheet):
+		c.JSON(http.StatusBadRequest, gin.H{"error": sheetutil.ErrInvalidSheet.Error()})
+	case errors.Is(err, menusvc.ErrDuplicateAlias):
+		c.JSON(http.StatusConflict, gin.H{"error": menusvc.ErrDuplicateAlias.Error()})
+	case errors.Is(err, menusvc.ErrInvalidMatch):
+		c.JSON(http.StatusBadRequest, gin.H{"error": menusvc.ErrInvalidMatch.Error()})
+	case errors.Is(err, menusvc.ErrCategoryNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": menusvc.ErrCategoryNotFound.Error()})
+	case errors.Is(err, store.ErrBotNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrBotNotFound.Error()})
 	case errors.Is(err, store.ErrMenuNotFound):
 		var appErr apperr.Err
 		if ok := errors.As(err, &appErr); !ok {
@@ -0,0 +1,183 @@
+package httphdlr
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+)
+
+// percentScale is the number of decimals a percentage may have. A percentage
+// at this scale is in basis points.
+const percentScale = 2
+
+// menuItemCreateReq adds an item to a draft menu. CategoryID and
+// OptionGroupIDs are ids from a menu response; an item without a category
+// goes with the uncategorized items.
+type menuItemCreateReq struct {
+	Name           string                     `json:"name"`
+	Price          moneyutil.Decimal          `json:"price"`
+	CategoryID     string                     `json:"category_id"`
+	OptionGroupIDs []string                   `json:"option_group_ids"`
+	Aliases        []string                   `json:"aliases"`
+	Translations   map[string]translationBody `json:"translations"`
+	menuItemInfo
+}
+
+// menuItemPatchReq changes the fields it carries and leaves the others. An
+// empty category_id moves the item out of its category and a null
+// nutrition clears it.
+type menuItemPatchReq struct {
+	Name           *string                     `json:"name"`
+	Price          *moneyutil.Decimal          `json:"price"`
+	CategoryID     *string                     `json:"category_id"`
+	OptionGroupIDs *[]string                   `json:"option_group_ids"`
+	Aliases        *[]string                   `json:"aliases"`
+	Translations   *map[string]translationBody `json:"translations"`
+	Description    *string                     `json:"description"`
+	Allergens      *[]string                   `json:"allergens"`
+	DietaryTags    *[]string                   `json:"dietary_tags"`
+	SpicyLevel     *int                        `json:"spicy_level"`
+	Nutrition      optionalNutrition           `json:"nutrition"`
+}
+
+// optionalNutrition tells a null nutrition apart from a missing one.
+type optionalNutrition struct {
+	Set bool
+	V   *nutritionInfo
+}
+
+func (n *optionalNutrition) UnmarshalJSON(b []byte) error {
+	n.Set = true
+	if bytes.Equal(bytes.TrimSpace(b), []byte("null")) {
+		n.V = nil
+		return nil
+	}
+	if err := json.Unmarshal(b, &n.V); err != nil {
+		return fmt.Errorf("httphdlr.optionalNutrition.UnmarshalJSON: %w", err)
+	}
+	return nil
+}
+
+// menuItemsPatchReq changes the items it selects at once: the items in
+// item_ids, the items of the categories in category_ids, or every item with
+// all. It sets price, adds price_delta or changes prices by price_percent,
+// such as 5 or -2.5, rounded to the currency's minor unit. category_id moves
+// the items to the end of that category, or out of their category when
+// empty.
+type menuItemsPatchReq struct {
+	ItemIDs      []string           `json:"item_ids"`
+	CategoryIDs  []string           `json:"category_ids"`
+	All          bool               `json:"all"`
+	Price        *moneyutil.Decimal `json:"price"`
+	PriceDelta   *moneyutil.Decimal `json:"price_delta"`
+	PricePercent *moneyutil.Decimal `json:"price_percent"`
+	CategoryID   *string            `json:"category_id"`
+}
+
+type menuItemsRes struct {
+	Items []menuItemRes `json:"items"`
+}
+
+func modelFromItemCreateReq(req menuItemCreateReq, priceScale int) (entities.MenuItem, error) {
+	var errs validatorutil.FieldErrors
+	price, err := moneyutil.ParseMoney(string(req.Price), priceScale)
+	if err != nil {
+		addPriceErr(&errs, err, "price", req.Price, priceScale)
+	}
+	if err := errs.Err(); err != nil {
+		return entities.MenuItem{}, fmt.Errorf("httphdlr.modelFromItemCreateReq: %w", err)
+	}
+	item := entities.MenuItem{
+		MenuItemName:   req.Name,
+		PriceScaled:    int64(price),
+		CategoryID:     req.CategoryID,
+		OptionGroupIDs: req.OptionGroupIDs,
+		Aliases:        req.Aliases,
+		Translations:   translationsFromBody(req.Translations),
+	}
+	req.menuItemInfo.applyTo(&item)
+	return item, nil
+}
+
+func modelFromItemPatchReq(req menuItemPatchReq, priceScale int) (entities.MenuItemPatch, error) {
+	patch := entities.MenuItemPatch{
+		Name:           req.Name,
+		CategoryID:     req.CategoryID,
+		OptionGroupIDs: req.OptionGroupIDs,
+		Aliases:        req.Aliases,
+		Description:    req.Description,
+		SpicyLevel:     req.SpicyLevel,
+		SetNutrition:   req.Nutrition.Set,
+		Nutrition:      req.Nutrition.V.toModel(),
+	}
+	var errs validatorutil.FieldErrors
+	patch.PriceScaled = parseOptionalMoney(&errs, "price", req.Price, priceScale)
+	if err := errs.Err(); err != nil {
+		return entities.MenuItemPatch{}, fmt.Errorf("httphdlr.modelFromItemPatchReq: %w", err)
+	}
+	if req.Translations != nil {
+		translations := translationsFromBody(*req.Translations)
+		patch.Translations = &translations
+	}
+	if req.Allergens != nil {
+		allergens := allergensFromReq(*req.Allergens)
+		patch.Allergens = &allergens
+	}
+	if req.DietaryTags != nil {
+		tags := dietaryTagsFromReq(*req.DietaryTags)
+		patch.DietaryTags = &tags
+	}
+	return patch, nil
+}
+
+func modelFromItemsPatchReq(req menuItemsPatchReq, priceScale int) (entities.MenuItemBulkPatch, error) {
+	var errs validatorutil.FieldErrors
+	patch := entities.MenuItemBulkPatch{
+		ItemIDs:          req.ItemIDs,
+		CategoryIDs:      req.CategoryIDs,
+		All:              req.All,
+		PriceScaled:      parseOptionalMoney(&errs, "price", req.Price, priceScale),
+		PriceDeltaScaled: parseOptionalMoney(&errs, "price_delta", req.PriceDelta, priceScale),
+		CategoryID:       req.CategoryID,
+	}
+	if req.PricePercent != nil {
+		basisPoints, err := moneyutil.ParseMoney(string(*req.PricePercent), percentScale)
+		switch {
+		case errors.Is(err, moneyutil.ErrTooPrecise):
+			errs.Add(err, "price_percent", validatorutil.CodePrecision, "%s has more than %d decimals", *req.PricePercent, percentScale)
+		case err != nil:
+			errs.Add(err, "price_percent", validatorutil.CodeInvalid, "%q is not a percentage", *req.PricePercent)
+		default:
+			patch.PricePercent = (*int64)(&basisPoints)
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return entities.MenuItemBulkPatch{}, fmt.Errorf("httphdlr.modelFromItemsPatchReq: %w", err)
+	}
+	return patch, nil
+}
+
+// parseOptionalMoney parses a price that may be left out, which gives nil.
+func parseOptionalMoney(errs *validatorutil.FieldErrors, field string, price *moneyutil.Decimal, priceScale int) *int64 {
+	if price == nil {
+		return nil
+	}
+	money, err := moneyutil.ParseMoney(string(*price), priceScale)
+	if err != nil {
+		addPriceErr(errs, err, field, *price, priceScale)
+		return nil
+	}
+	return (*int64)(&money)
+}
+
+func menuItemsResFromModel(bot entities.Bot, items []entities.MenuItem, locale string) menuItemsRes {
+	resItems := make([]menuItemRes, 0, len(items))
+	for _, item := range items {
+		resItems = append(resItems, menuItemResFromModel(bot, item, locale))
+	}
+	return menuItemsRes{Items: resItems}
+}
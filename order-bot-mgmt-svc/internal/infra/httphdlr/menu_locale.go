@@ -0,0 +1,49 @@
+package httphdlr
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/localeutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+// translationBody is the text of an item or category in one locale. Category
+// translations only take a name.
+type translationBody struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+func translationsFromBody(body map[string]translationBody) entities.Translations {
+	if len(body) == 0 {
+		return nil
+	}
+	translations := make(entities.Translations, len(body))
+	for locale, translation := range body {
+		translations[locale] = entities.Translation{Name: translation.Name, Description: translation.Description}
+	}
+	return translations
+}
+
+func translationsBodyFromModel(translations entities.Translations) map[string]translationBody {
+	body := make(map[string]translationBody, len(translations))
+	for locale, translation := range translations {
+		body[locale] = translationBody{Name: translation.Name, Description: translation.Description}
+	}
+	return body
+}
+
+// menuLocale picks the locale menu responses are shown in: the locale query
+// parameter, else the Accept-Language header, matched against the bot's
+// locales. It falls back to the bot's default locale.
+func menuLocale(c *gin.Context, bot entities.Bot) string {
+	var preferred []string
+	if locale, ok := localeutil.Normalize(c.Query("locale")); ok {
+		preferred = append(preferred, locale)
+	}
+	preferred = append(preferred, localeutil.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+	if locale, ok := localeutil.Match(preferred, bot.Locales); ok {
+		return locale
+	}
+	return bot.DefaultLocale
+}
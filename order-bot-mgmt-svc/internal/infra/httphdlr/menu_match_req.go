@@ -0,0 +1,68 @@
+package httphdlr
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"time"
+)
+
+// defaultMatchLimit is the number of candidates per phrase when the request
+// leaves limit out.
+const defaultMatchLimit = 3
+
+type menuMatchReq struct {
+	Text  string `json:"text" binding:"required"`
+	Limit int    `json:"limit"`
+}
+
+// menuMatchRes splits the text into phrases, one per item it names. Prices
+// are in the currency and scale of the published menu.
+type menuMatchRes struct {
+	MenuID   string               `json:"menu_id"`
+	Currency string               `json:"currency"`
+	Phrases  []menuPhraseMatchRes `json:"phrases"`
+}
+
+type menuPhraseMatchRes struct {
+	Text       string                 `json:"text"`
+	Quantity   int                    `json:"quantity"`
+	Candidates []menuItemCandidateRes `json:"candidates"`
+}
+
+type menuItemCandidateRes struct {
+	ItemID      string  `json:"item_id"`
+	Name        string  `json:"name"`
+	MatchedName string  `json:"matched_name"`
+	Score       float64 `json:"score"`
+	Price       string  `json:"price"`
+	PriceScaled int64   `json:"price_scaled"`
+	Available   bool    `json:"available"`
+}
+
+func menuMatchResFromModel(match entities.MenuMatch, now time.Time) menuMatchRes {
+	res := menuMatchRes{
+		MenuID:   match.Menu.ID,
+		Currency: match.Currency,
+		Phrases:  make([]menuPhraseMatchRes, 0, len(match.Phrases)),
+	}
+	for _, phrase := range match.Phrases {
+		resPhrase := menuPhraseMatchRes{
+			Text:       phrase.Text,
+			Quantity:   phrase.Quantity,
+			Candidates: make([]menuItemCandidateRes, 0, len(phrase.Candidates)),
+		}
+		for _, candidate := range phrase.Candidates {
+			resPhrase.Candidates = append(resPhrase.Candidates, menuItemCandidateRes{
+				ItemID:      candidate.Item.ID,
+				Name:        candidate.Item.MenuItemName,
+				MatchedName: candidate.MatchedName,
+				Score:       candidate.Score,
+				Price:       moneyutil.Money(candidate.Item.PriceScaled).Format(match.PriceScale),
+				PriceScaled: candidate.Item.PriceScaled,
+				Available:   candidate.Item.AvailableAt(now),
+			})
+		}
+		res.Phrases = append(res.Phrases, resPhrase)
+	}
+	return res
+}
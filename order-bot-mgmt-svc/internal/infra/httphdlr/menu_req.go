@@ -1,55 +1,691 @@
 package httphdlr
 
 import (
+	"errors"
+	"fmt"
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// menuReq lists categories and the items that belong to no category. The
+// order of each list is the display order. Option groups listed here are
+// shared: items link them through option_group_keys. MenuID picks the menu
+// to update and may be left out while the bot has one menu. AvailableFrom and
+// AvailableUntil are "HH:MM" in the bot's timezone; leaving both out serves
+// the menu all day.
 type menuReq struct {
-	BotID string        `json:"bot_id" binding:"required"`
-	Items []menuItemReq `json:"items"`
+	BotID          string            `json:"bot_id" binding:"required"`
+	MenuID         string            `json:"menu_id"`
+	Name           string            `json:"name"`
+	AvailableFrom  string            `json:"available_from"`
+	AvailableUntil string            `json:"available_until"`
+	Categories     []menuCategoryReq `json:"categories"`
+	OptionGroups   []optionGroupReq  `json:"option_groups"`
+	Items          []menuItemReq     `json:"items"`
 }
 
+// menuRes carries names in the bot's default locale, which is what updates
+// take. The display fields hold the text in Locale, picked from the request,
+// and fall back to the default-locale text.
 type menuRes struct {
-	BotID string        `json:"bot_id"`
-	Items []menuItemRes `json:"items"`
+	BotID          string            `json:"bot_id"`
+	MenuID         string            `json:"menu_id"`
+	Name           string            `json:"name"`
+	Version        int               `json:"version,omitempty"`
+	AvailableFrom  *string           `json:"available_from"`
+	AvailableUntil *string           `json:"available_until"`
+	Timezone       string            `json:"timezone"`
+	Currency       string            `json:"currency"`
+	PriceScale     int               `json:"price_scale"`
+	Locale         string            `json:"locale"`
+	DefaultLocale  string            `json:"default_locale"`
+	Locales        []string          `json:"locales"`
+	Categories     []menuCategoryRes `json:"categories"`
+	OptionGroups   []optionGroupRes  `json:"option_groups"`
+	Items          []menuItemRes     `json:"items"`
+	Bundles        []menuBundleRes   `json:"bundles"`
+}
+
+type menusRes struct {
+	Menus []menuRes `json:"menus"`
+}
+
+// menuSummaryRes is a menu without its content. The window is null for
+// menus served all day.
+type menuSummaryRes struct {
+	ID             string  `json:"id"`
+	Name           string  `json:"name"`
+	AvailableFrom  *string `json:"available_from"`
+	AvailableUntil *string `json:"available_until"`
+}
+
+type menuSummariesRes struct {
+	Timezone string           `json:"timezone"`
+	Menus    []menuSummaryRes `json:"menus"`
+}
+
+// menuCategoryReq takes translations keyed by locale, such as "zh-TW".
+type menuCategoryReq struct {
+	Name         string                     `json:"name"`
+	Translations map[string]translationBody `json:"translations"`
+	Items        []menuItemReq              `json:"items"`
+}
+
+type menuCategoryRes struct {
+	ID           string                     `json:"id"`
+	Name         string                     `json:"name"`
+	DisplayName  string                     `json:"display_name"`
+	Translations map[string]translationBody `json:"translations"`
+	SortPosition int                        `json:"sort_position"`
+	Items        []menuItemRes              `json:"items"`
+}
+
+type menuReorderReq struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+// menuAvailabilityReq marks items sold out or back. RestoreAt, RFC 3339,
+// brings sold-out items back automatically.
+type menuAvailabilityReq struct {
+	ItemIDs   []string   `json:"item_ids" binding:"required"`
+	SoldOut   bool       `json:"sold_out"`
+	RestoreAt *time.Time `json:"restore_at"`
+}
+
+type menuAvailabilityRes struct {
+	Items []menuItemAvailabilityRes `json:"items"`
+}
+
+type menuItemAvailabilityRes struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	SoldOut   bool       `json:"sold_out"`
+	RestoreAt *time.Time `json:"restore_at"`
+}
+
+type menuPublishReq struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// menuComparisonRes compares a draft menu with its published copy. The item
+// changes go from the published menu to the draft.
+type menuComparisonRes struct {
+	MenuID          string `json:"menu_id"`
+	Name            string `json:"name"`
+	Published       bool   `json:"published"`
+	InSync          bool   `json:"in_sync"`
+	LayoutChanged   bool   `json:"layout_changed"`
+	CurrencyChanged bool   `json:"currency_changed"`
+	ScheduleChanged bool   `json:"schedule_changed"`
+	LocalesChanged  bool   `json:"locales_changed"`
+	menuDiffRes
+}
+
+type menuComparisonsRes struct {
+	Menus []menuComparisonRes `json:"menus"`
+}
+
+// publishJobReq schedules a publish. RunAt is RFC 3339.
+type publishJobReq struct {
+	RunAt time.Time `json:"run_at" binding:"required"`
+}
+
+type publishJobsRes struct {
+	Jobs []publishJobRes `json:"jobs"`
+}
+
+type publishJobRes struct {
+	ID         string     `json:"id"`
+	RunAt      time.Time  `json:"run_at"`
+	Status     string     `json:"status"`
+	CreatedBy  string     `json:"created_by"`
+	Attempts   int        `json:"attempts"`
+	Error      string     `json:"error"`
+	FinishedAt *time.Time `json:"finished_at"`
+	CreatedAt  time.Time  `json:"created_at"`
 }
 
 type menuPublishedRes struct {
 	Exists bool `json:"exists"`
 }
 
+// menuItemReq links shared option groups by key and may also define groups
+// used only by this item. ID is the id from a menu response and keeps the
+// item's identity on update; leave it out for new items.
 type menuItemReq struct {
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+	ID              string            `json:"id"`
+	Name            string            `json:"name"`
+	Price           moneyutil.Decimal `json:"price"`
+	OptionGroupKeys []string          `json:"option_group_keys"`
+	OptionGroups    []optionGroupReq  `json:"option_groups"`
+	// Aliases are other names the bot matches the item by.
+	Aliases []string `json:"aliases"`
+	// Translations are keyed by locale, such as "zh-TW".
+	Translations map[string]translationBody `json:"translations"`
+	menuItemInfo
+}
+
+type itemAliasesReq struct {
+	Aliases []string `json:"aliases"`
+}
+
+// menuItemInfo is what the bot knows about an item beyond its name and price.
+// Allergens and dietary tags take the values of entities.Allergens and
+// entities.DietaryTags; spicy level runs from 0 (not spicy) to 3. Nutrition
+// is null when unknown.
+type menuItemInfo struct {
+	Description string         `json:"description"`
+	Allergens   []string       `json:"allergens"`
+	DietaryTags []string       `json:"dietary_tags"`
+	SpicyLevel  int            `json:"spicy_level"`
+	Nutrition   *nutritionInfo `json:"nutrition"`
+}
+
+type nutritionInfo struct {
+	Calories      int     `json:"calories"`
+	ProteinG      float64 `json:"protein_g"`
+	CarbohydrateG float64 `json:"carbohydrate_g"`
+	FatG          float64 `json:"fat_g"`
+	SugarG        float64 `json:"sugar_g"`
+	SaltG         float64 `json:"salt_g"`
 }
 
 type menuItemRes struct {
-	ID    string  `json:"id"`
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+	ID             string     `json:"id"`
+	Name           string     `json:"name"`
+	Price          string     `json:"price"`
+	PriceScaled    int64      `json:"price_scaled"`
+	CategoryID     *string    `json:"category_id"`
+	SortPosition   int        `json:"sort_position"`
+	OptionGroupIDs []string   `json:"option_group_ids"`
+	SoldOut        bool       `json:"sold_out"`
+	RestoreAt      *time.Time `json:"restore_at"`
+	// Stock is null for items without stock tracking.
+	Stock        *int     `json:"stock"`
+	ImageURL     string   `json:"image_url"`
+	ThumbnailURL string   `json:"thumbnail_url"`
+	Aliases      []string `json:"aliases"`
+	// DisplayName and DisplayDescription are in the response locale.
+	DisplayName        string                     `json:"display_name"`
+	DisplayDescription string                     `json:"display_description"`
+	Translations       map[string]translationBody `json:"translations"`
+	menuItemInfo
 }
 
-func modelFromMenReq(req menuReq) []entities.MenuItem {
-	items := make([]entities.MenuItem, 0, len(req.Items))
-	for _, item := range req.Items {
-		items = append(items, entities.MenuItem{
+// optionGroupReq is referenced by Key from menuItemReq.OptionGroupKeys. A
+// group ID from a menu response works as a key.
+type optionGroupReq struct {
+	Key           string      `json:"key"`
+	Name          string      `json:"name"`
+	SelectionType string      `json:"selection_type"`
+	MinChoices    int         `json:"min_choices"`
+	MaxChoices    int         `json:"max_choices"`
+	Required      bool        `json:"required"`
+	Options       []optionReq `json:"options"`
+}
+
+type optionGroupRes struct {
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	SelectionType string      `json:"selection_type"`
+	MinChoices    int         `json:"min_choices"`
+	MaxChoices    int         `json:"max_choices"`
+	Required      bool        `json:"required"`
+	SortPosition  int         `json:"sort_position"`
+	Options       []optionRes `json:"options"`
+}
+
+type optionReq struct {
+	Name       string            `json:"name"`
+	PriceDelta moneyutil.Decimal `json:"price_delta"`
+}
+
+type optionRes struct {
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	PriceDelta       string `json:"price_delta"`
+	PriceDeltaScaled int64  `json:"price_delta_scaled"`
+	SortPosition     int    `json:"sort_position"`
+}
+
+// menuReqParser turns a menuReq into a MenuDetail. Prices are parsed at the
+// bot's scale; values with more digits than the scale are rejected rather
+// than rounded. Sort positions follow the request order. Every problem is
+// collected rather than stopping at the first.
+type menuReqParser struct {
+	priceScale int
+	detail     entities.MenuDetail
+	groupIDs   map[string]string
+	fields     menuReqFields
+	errs       validatorutil.FieldErrors
+}
+
+// menuReqFields is where the items and option groups of a parsed menu are in
+// the request, such as "categories[0].items[2]", by their index in the
+// MenuDetail.
+type menuReqFields struct {
+	items        []string
+	optionGroups []string
+}
+
+func modelFromMenReq(req menuReq, priceScale int) (entities.MenuDetail, menuReqFields, error) {
+	p := menuReqParser{priceScale: priceScale, groupIDs: make(map[string]string, len(req.OptionGroups))}
+	window, err := parseMenuWindow(req.AvailableFrom, req.AvailableUntil)
+	if err != nil {
+		p.errs.Add(err, "available_from", validatorutil.CodeInvalid, "times of day are HH:MM, got %q to %q", req.AvailableFrom, req.AvailableUntil)
+	}
+	p.detail.Menu = entities.Menu{ID: req.MenuID, BotID: req.BotID, MenuName: req.Name, Window: window}
+	for idx, group := range req.OptionGroups {
+		field := fmt.Sprintf("option_groups[%d]", idx)
+		switch _, taken := p.groupIDs[group.Key]; {
+		case group.Key == "":
+			p.errs.Add(ErrMsgInvalidRequestBody, field+".key", validatorutil.CodeRequired, "key is required")
+		case taken:
+			p.errs.Add(ErrMsgInvalidRequestBody, field+".key", validatorutil.CodeDuplicate, "another option group has key %q", group.Key)
+		}
+		p.groupIDs[group.Key] = p.addGroup(group, field)
+	}
+	for catIdx, category := range req.Categories {
+		newCategory := entities.MenuCategory{
 			ID:           util.NewID(),
-			MenuItemName: item.Name,
-			Price:        item.Price,
+			CategoryName: category.Name,
+			Translations: translationsFromBody(category.Translations),
+			SortPosition: catIdx,
+		}
+		p.detail.Categories = append(p.detail.Categories, newCategory)
+		for idx, item := range category.Items {
+			p.addItem(item, newCategory.ID, idx, fmt.Sprintf("categories[%d].items[%d]", catIdx, idx))
+		}
+	}
+	for idx, item := range req.Items {
+		p.addItem(item, "", idx, fmt.Sprintf("items[%d]", idx))
+	}
+	if err := p.errs.Err(); err != nil {
+		return entities.MenuDetail{}, p.fields, fmt.Errorf("httphdlr.modelFromMenReq: %w", err)
+	}
+	return p.detail, p.fields, nil
+}
+
+func (p *menuReqParser) addItem(item menuItemReq, categoryID string, pos int, field string) {
+	price, err := moneyutil.ParseMoney(string(item.Price), p.priceScale)
+	if err != nil {
+		p.addPriceErr(err, field+".price", item.Price)
+	}
+	newItem := entities.MenuItem{
+		ID:           item.ID,
+		MenuItemName: item.Name,
+		PriceScaled:  int64(price),
+		CategoryID:   categoryID,
+		SortPosition: pos,
+		Aliases:      item.Aliases,
+		Translations: translationsFromBody(item.Translations),
+	}
+	item.menuItemInfo.applyTo(&newItem)
+	for idx, key := range item.OptionGroupKeys {
+		groupID, ok := p.groupIDs[key]
+		if !ok {
+			p.errs.Add(ErrMsgInvalidRequestBody, fmt.Sprintf("%s.option_group_keys[%d]", field, idx), validatorutil.CodeUnknown,
+				"no option group has key %q", key)
+			continue
+		}
+		newItem.OptionGroupIDs = append(newItem.OptionGroupIDs, groupID)
+	}
+	for idx, group := range item.OptionGroups {
+		newItem.OptionGroupIDs = append(newItem.OptionGroupIDs, p.addGroup(group, fmt.Sprintf("%s.option_groups[%d]", field, idx)))
+	}
+	p.detail.Items = append(p.detail.Items, newItem)
+	p.fields.items = append(p.fields.items, field)
+}
+
+func (p *menuReqParser) addPriceErr(err error, field string, price moneyutil.Decimal) {
+	addPriceErr(&p.errs, err, field, price, p.priceScale)
+}
+
+// addPriceErr reports why price did not parse at priceScale.
+func addPriceErr(errs *validatorutil.FieldErrors, err error, field string, price moneyutil.Decimal, priceScale int) {
+	if errors.Is(err, moneyutil.ErrTooPrecise) {
+		errs.Add(err, field, validatorutil.CodePrecision, "%s has more than %d decimals", price, priceScale)
+		return
+	}
+	errs.Add(err, field, validatorutil.CodeInvalid, "%q is not a price", price)
+}
+
+// requestErr moves the field errors of err from the parsed MenuDetail to
+// where they are in the request. err is returned as it is when it has no
+// field errors.
+func (f menuReqFields) requestErr(err error) error {
+	fields := validatorutil.Fields(err)
+	if len(fields) == 0 {
+		return err
+	}
+	moved := make(validatorutil.FieldErrors, 0, len(fields))
+	for _, fieldErr := range fields {
+		fieldErr.Field = f.requestField(fieldErr.Field)
+		moved = append(moved, fieldErr)
+	}
+	return moved
+}
+
+func (f menuReqFields) requestField(field string) string {
+	for _, list := range []struct {
+		name  string
+		paths []string
+	}{{"items", f.items}, {"option_groups", f.optionGroups}} {
+		rest, ok := strings.CutPrefix(field, list.name+"[")
+		if !ok {
+			continue
+		}
+		num, rest, ok := strings.Cut(rest, "]")
+		idx, err := strconv.Atoi(num)
+		if !ok || err != nil || idx < 0 || idx >= len(list.paths) {
+			return field
+		}
+		return list.paths[idx] + rest
+	}
+	return field
+}
+
+// applyTo sets the item info of item. Values are lower-cased; unknown ones
+// are left for the service to reject.
+func (info menuItemInfo) applyTo(item *entities.MenuItem) {
+	item.Description = info.Description
+	item.SpicyLevel = info.SpicyLevel
+	item.Allergens = allergensFromReq(info.Allergens)
+	item.DietaryTags = dietaryTagsFromReq(info.DietaryTags)
+	item.Nutrition = info.Nutrition.toModel()
+}
+
+func allergensFromReq(values []string) []entities.Allergen {
+	var allergens []entities.Allergen
+	for _, allergen := range values {
+		allergens = append(allergens, entities.Allergen(strings.ToLower(strings.TrimSpace(allergen))))
+	}
+	return allergens
+}
+
+func dietaryTagsFromReq(values []string) []entities.DietaryTag {
+	var tags []entities.DietaryTag
+	for _, tag := range values {
+		tags = append(tags, entities.DietaryTag(strings.ToLower(strings.TrimSpace(tag))))
+	}
+	return tags
+}
+
+// toModel maps nil to nil.
+func (n *nutritionInfo) toModel() *entities.Nutrition {
+	if n == nil {
+		return nil
+	}
+	return &entities.Nutrition{
+		Calories:      n.Calories,
+		ProteinG:      n.ProteinG,
+		CarbohydrateG: n.CarbohydrateG,
+		FatG:          n.FatG,
+		SugarG:        n.SugarG,
+		SaltG:         n.SaltG,
+	}
+}
+
+func menuItemInfoFromModel(item entities.MenuItem) menuItemInfo {
+	info := menuItemInfo{
+		Description: item.Description,
+		Allergens:   make([]string, 0, len(item.Allergens)),
+		DietaryTags: make([]string, 0, len(item.DietaryTags)),
+		SpicyLevel:  item.SpicyLevel,
+	}
+	for _, allergen := range item.Allergens {
+		info.Allergens = append(info.Allergens, string(allergen))
+	}
+	for _, tag := range item.DietaryTags {
+		info.DietaryTags = append(info.DietaryTags, string(tag))
+	}
+	if n := item.Nutrition; n != nil {
+		info.Nutrition = &nutritionInfo{
+			Calories:      n.Calories,
+			ProteinG:      n.ProteinG,
+			CarbohydrateG: n.CarbohydrateG,
+			FatG:          n.FatG,
+			SugarG:        n.SugarG,
+			SaltG:         n.SaltG,
+		}
+	}
+	return info
+}
+
+func (p *menuReqParser) addGroup(group optionGroupReq, field string) string {
+	newGroup := entities.MenuOptionGroup{
+		ID:            util.NewID(),
+		GroupName:     group.Name,
+		SelectionType: entities.OptionSelection(group.SelectionType),
+		MinChoices:    group.MinChoices,
+		MaxChoices:    group.MaxChoices,
+		Required:      group.Required,
+		SortPosition:  len(p.detail.OptionGroups),
+	}
+	for idx, option := range group.Options {
+		var delta moneyutil.Money
+		if option.PriceDelta != "" {
+			var err error
+			if delta, err = moneyutil.ParseMoney(string(option.PriceDelta), p.priceScale); err != nil {
+				p.addPriceErr(err, fmt.Sprintf("%s.options[%d].price_delta", field, idx), option.PriceDelta)
+			}
+		}
+		newGroup.Options = append(newGroup.Options, entities.MenuOption{
+			ID:               util.NewID(),
+			GroupID:          newGroup.ID,
+			OptionName:       option.Name,
+			PriceDeltaScaled: int64(delta),
+			SortPosition:     idx,
+		})
+	}
+	p.detail.OptionGroups = append(p.detail.OptionGroups, newGroup)
+	p.fields.optionGroups = append(p.fields.optionGroups, field)
+	return newGroup.ID
+}
+
+// menuResFromModel groups items under their categories and shows them in
+// locale.
+func menuResFromModel(bot entities.Bot, detail entities.MenuDetail, locale string) menuRes {
+	resCategories := make([]menuCategoryRes, 0, len(detail.Categories))
+	categoryIdx := make(map[string]int, len(detail.Categories))
+	for idx, category := range detail.Categories {
+		categoryIdx[category.ID] = idx
+		resCategories = append(resCategories, menuCategoryRes{
+			ID:           category.ID,
+			Name:         category.CategoryName,
+			DisplayName:  category.Translations.Name(locale, category.CategoryName),
+			Translations: translationsBodyFromModel(category.Translations),
+			SortPosition: category.SortPosition,
+			Items:        []menuItemRes{},
+		})
+	}
+	resGroups := make([]optionGroupRes, 0, len(detail.OptionGroups))
+	for _, group := range detail.OptionGroups {
+		resGroups = append(resGroups, optionGroupResFromModel(bot, group))
+	}
+	resItems := make([]menuItemRes, 0, len(detail.Items))
+	for _, item := range detail.Items {
+		resItem := menuItemResFromModel(bot, item, locale)
+		if idx, ok := categoryIdx[item.CategoryID]; ok {
+			resCategories[idx].Items = append(resCategories[idx].Items, resItem)
+			continue
+		}
+		resItems = append(resItems, resItem)
+	}
+	from, until := formatMenuWindow(detail.Menu.Window)
+	return menuRes{
+		BotID:          detail.Menu.BotID,
+		MenuID:         detail.Menu.ID,
+		Name:           detail.Menu.MenuName,
+		Version:        detail.Menu.Version,
+		AvailableFrom:  from,
+		AvailableUntil: until,
+		Timezone:       bot.Timezone,
+		Currency:       bot.Currency,
+		PriceScale:     bot.PriceScale,
+		Locale:         locale,
+		DefaultLocale:  bot.DefaultLocale,
+		Locales:        append([]string{}, bot.Locales...),
+		Categories:     resCategories,
+		OptionGroups:   resGroups,
+		Items:          resItems,
+		Bundles:        menuBundlesResFromModel(bot, detail.Bundles),
+	}
+}
+
+// menuItemResFromModel shows item in locale.
+func menuItemResFromModel(bot entities.Bot, item entities.MenuItem, locale string) menuItemRes {
+	resItem := menuItemRes{
+		ID:                 item.ID,
+		Name:               item.MenuItemName,
+		Price:              moneyutil.Money(item.PriceScaled).Format(bot.PriceScale),
+		PriceScaled:        item.PriceScaled,
+		CategoryID:         stringPtr(item.CategoryID),
+		SortPosition:       item.SortPosition,
+		OptionGroupIDs:     item.OptionGroupIDs,
+		SoldOut:            item.SoldOut,
+		RestoreAt:          timePtr(item.RestoreAt),
+		Stock:              stockPtr(item),
+		ImageURL:           item.Image.URL,
+		ThumbnailURL:       item.Image.ThumbnailURL,
+		Aliases:            append([]string{}, item.Aliases...),
+		DisplayName:        item.Translations.Name(locale, item.MenuItemName),
+		DisplayDescription: item.Translations.Description(locale, item.Description),
+		Translations:       translationsBodyFromModel(item.Translations),
+		menuItemInfo:       menuItemInfoFromModel(item),
+	}
+	if resItem.OptionGroupIDs == nil {
+		resItem.OptionGroupIDs = []string{}
+	}
+	return resItem
+}
+
+func menuSummariesResFromModel(bot entities.Bot, menus []entities.Menu) menuSummariesRes {
+	resMenus := make([]menuSummaryRes, 0, len(menus))
+	for _, menu := range menus {
+		from, until := formatMenuWindow(menu.Window)
+		resMenus = append(resMenus, menuSummaryRes{ID: menu.ID, Name: menu.MenuName, AvailableFrom: from, AvailableUntil: until})
+	}
+	return menuSummariesRes{Timezone: bot.Timezone, Menus: resMenus}
+}
+
+// parseMenuWindow reads a window of two "HH:MM" times. Both empty means all
+// day.
+func parseMenuWindow(from string, until string) (entities.MenuWindow, error) {
+	if from == "" && until == "" {
+		return entities.MenuWindow{}, nil
+	}
+	fromMinute, errFrom := parseMinuteOfDay(from)
+	untilMinute, errUntil := parseMinuteOfDay(until)
+	if errFrom != nil || errUntil != nil {
+		return entities.MenuWindow{}, fmt.Errorf("httphdlr.parseMenuWindow(), window %q-%q: %w", from, until, ErrMsgInvalidRequestBody)
+	}
+	return entities.MenuWindow{From: fromMinute, Until: untilMinute}, nil
+}
+
+func parseMinuteOfDay(s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// formatMenuWindow returns nil times for an all-day window.
+func formatMenuWindow(window entities.MenuWindow) (*string, *string) {
+	if window.AllDay() {
+		return nil, nil
+	}
+	from := fmt.Sprintf("%02d:%02d", window.From/60, window.From%60)
+	until := fmt.Sprintf("%02d:%02d", window.Until/60, window.Until%60)
+	return &from, &until
+}
+
+func optionGroupResFromModel(bot entities.Bot, group entities.MenuOptionGroup) optionGroupRes {
+	options := make([]optionRes, 0, len(group.Options))
+	for _, option := range group.Options {
+		options = append(options, optionRes{
+			ID:               option.ID,
+			Name:             option.OptionName,
+			PriceDelta:       moneyutil.Money(option.PriceDeltaScaled).Format(bot.PriceScale),
+			PriceDeltaScaled: option.PriceDeltaScaled,
+			SortPosition:     option.SortPosition,
 		})
 	}
-	return items
+	return optionGroupRes{
+		ID:            group.ID,
+		Name:          group.GroupName,
+		SelectionType: string(group.SelectionType),
+		MinChoices:    group.MinChoices,
+		MaxChoices:    group.MaxChoices,
+		Required:      group.Required,
+		SortPosition:  group.SortPosition,
+		Options:       options,
+	}
 }
 
-func menuResFromModel(menu entities.Menu, items []entities.MenuItem) menuRes {
-	resItems := make([]menuItemRes, 0, len(items))
+func menuAvailabilityResFromModel(items []entities.MenuItem) menuAvailabilityRes {
+	resItems := make([]menuItemAvailabilityRes, 0, len(items))
 	for _, item := range items {
-		resItems = append(resItems, menuItemRes{
-			ID:    item.ID,
-			Name:  item.MenuItemName,
-			Price: item.Price,
+		resItems = append(resItems, menuItemAvailabilityRes{
+			ID:        item.ID,
+			Name:      item.MenuItemName,
+			SoldOut:   item.SoldOut,
+			RestoreAt: timePtr(item.RestoreAt),
 		})
 	}
-	return menuRes{BotID: menu.BotID, Items: resItems}
+	return menuAvailabilityRes{Items: resItems}
+}
+
+func timePtr(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
+
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func stockPtr(item entities.MenuItem) *int {
+	if !item.TrackStock {
+		return nil
+	}
+	return &item.Stock
+}
+
+func menuComparisonResFromModel(bot entities.Bot, comparison entities.MenuComparison) menuComparisonRes {
+	return menuComparisonRes{
+		MenuID:          comparison.Menu.ID,
+		Name:            comparison.Menu.MenuName,
+		Published:       comparison.Published,
+		InSync:          comparison.InSync,
+		LayoutChanged:   comparison.LayoutChanged,
+		CurrencyChanged: comparison.CurrencyChanged,
+		ScheduleChanged: comparison.ScheduleChanged,
+		LocalesChanged:  comparison.LocalesChanged,
+		menuDiffRes:     menuDiffResFromModel(bot, comparison.Diff),
+	}
+}
+
+func publishJobResFromModel(job entities.PublishJob) publishJobRes {
+	return publishJobRes{
+		ID:         job.ID,
+		RunAt:      job.RunAt,
+		Status:     string(job.Status),
+		CreatedBy:  job.CreatedBy,
+		Attempts:   job.Attempts,
+		Error:      job.Error,
+		FinishedAt: timePtr(job.FinishedAt),
+		CreatedAt:  job.CreatedAt,
+	}
 }
@@ -0,0 +1,447 @@
+package httphdlr
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/services/menusvc"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestModelFromMenReqOptionGroups(t *testing.T) {
+	req := menuReq{
+		BotID: "bot",
+		OptionGroups: []optionGroupReq{{
+			Key:     "size",
+			Name:    "Size",
+			Options: []optionReq{{Name: "Small"}, {Name: "Large", PriceDelta: "0.50"}},
+		}},
+		Categories: []menuCategoryReq{{
+			Name: "Drinks",
+			Items: []menuItemReq{{
+				Name:            "Latte",
+				Price:           "3.20",
+				OptionGroupKeys: []string{"size"},
+				OptionGroups: []optionGroupReq{{
+					Name:          "Milk",
+					SelectionType: "single",
+					Options:       []optionReq{{Name: "Oat", PriceDelta: "0.3"}},
+				}},
+			}},
+		}},
+		Items: []menuItemReq{{Name: "Cookie", Price: "2", OptionGroupKeys: []string{"size"}}},
+	}
+	detail, _, err := modelFromMenReq(req, 2)
+	if err != nil {
+		t.Fatalf("modelFromMenReq() error = %v", err)
+	}
+	if len(detail.OptionGroups) != 2 || len(detail.Items) != 2 {
+		t.Fatalf("modelFromMenReq() = %d groups %d items, want 2 and 2", len(detail.OptionGroups), len(detail.Items))
+	}
+	size, milk := detail.OptionGroups[0], detail.OptionGroups[1]
+	if got := size.Options[1].PriceDeltaScaled; got != 50 {
+		t.Errorf("Large price delta = %d, want 50", got)
+	}
+	if got := milk.Options[0].PriceDeltaScaled; got != 30 {
+		t.Errorf("Oat price delta = %d, want 30", got)
+	}
+	latte, cookie := detail.Items[0], detail.Items[1]
+	if latte.CategoryID != detail.Categories[0].ID || latte.PriceScaled != 320 {
+		t.Errorf("latte = %+v, want category %s price 320", latte, detail.Categories[0].ID)
+	}
+	if len(latte.OptionGroupIDs) != 2 || latte.OptionGroupIDs[0] != size.ID || latte.OptionGroupIDs[1] != milk.ID {
+		t.Errorf("latte option groups = %v, want [%s %s]", latte.OptionGroupIDs, size.ID, milk.ID)
+	}
+	if len(cookie.OptionGroupIDs) != 1 || cookie.OptionGroupIDs[0] != size.ID || cookie.CategoryID != "" {
+		t.Errorf("cookie = %+v, want uncategorized with group %s", cookie, size.ID)
+	}
+}
+
+func TestMenuItemInfo(t *testing.T) {
+	info := menuItemInfo{
+		Description: "Rice noodles",
+		Allergens:   []string{" Peanuts", "SOY"},
+		DietaryTags: []string{"vegan"},
+		SpicyLevel:  2,
+		Nutrition:   &nutritionInfo{Calories: 640, ProteinG: 18.5},
+	}
+	var item entities.MenuItem
+	info.applyTo(&item)
+	if !slices.Equal(item.Allergens, []entities.Allergen{entities.AllergenPeanuts, entities.AllergenSoy}) {
+		t.Errorf("Allergens = %v, want lower-cased values", item.Allergens)
+	}
+	if item.Nutrition == nil || item.Nutrition.Calories != 640 || item.SpicyLevel != 2 {
+		t.Errorf("item = %+v, want nutrition and spicy level set", item)
+	}
+	want := info
+	want.Allergens = []string{"peanuts", "soy"}
+	if got := menuItemInfoFromModel(item); !reflect.DeepEqual(got, want) {
+		t.Errorf("menuItemInfoFromModel() = %+v, want %+v", got, want)
+	}
+	if got := menuItemInfoFromModel(entities.MenuItem{}); got.Allergens == nil || got.DietaryTags == nil || got.Nutrition != nil {
+		t.Errorf("menuItemInfoFromModel() of a bare item = %+v, want empty lists and no nutrition", got)
+	}
+}
+
+func TestModelFromMenReqErrors(t *testing.T) {
+	group := optionGroupReq{Key: "size", Name: "Size", Options: []optionReq{{Name: "Small"}}}
+	tests := []struct {
+		name    string
+		req     menuReq
+		wantErr error
+	}{
+		{
+			name:    "unknown key",
+			req:     menuReq{Items: []menuItemReq{{Name: "Tea", Price: "1", OptionGroupKeys: []string{"size"}}}},
+			wantErr: ErrMsgInvalidRequestBody,
+		},
+		{
+			name:    "duplicated key",
+			req:     menuReq{OptionGroups: []optionGroupReq{group, group}},
+			wantErr: ErrMsgInvalidRequestBody,
+		},
+		{
+			name:    "missing key",
+			req:     menuReq{OptionGroups: []optionGroupReq{{Name: "Size"}}},
+			wantErr: ErrMsgInvalidRequestBody,
+		},
+		{
+			name: "too precise delta",
+			req: menuReq{OptionGroups: []optionGroupReq{{
+				Key: "size", Name: "Size", Options: []optionReq{{Name: "Large", PriceDelta: "0.505"}},
+			}}},
+			wantErr: moneyutil.ErrTooPrecise,
+		},
+		{
+			name:    "half window",
+			req:     menuReq{AvailableFrom: "07:00"},
+			wantErr: ErrMsgInvalidRequestBody,
+		},
+		{
+			name:    "bad window time",
+			req:     menuReq{AvailableFrom: "07:00", AvailableUntil: "24:00"},
+			wantErr: ErrMsgInvalidRequestBody,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := modelFromMenReq(tt.req, 2); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("modelFromMenReq() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestModelFromMenReqFields(t *testing.T) {
+	req := menuReq{
+		Categories: []menuCategoryReq{{
+			Name: "Drinks",
+			Items: []menuItemReq{
+				{Name: "Latte", Price: "3.20"},
+				{Name: "Mocha", Price: "3.205", OptionGroupKeys: []string{"size"}},
+			},
+		}},
+		Items: []menuItemReq{{Name: "Cookie", Price: "two"}},
+	}
+	_, _, err := modelFromMenReq(req, 2)
+	if !errors.Is(err, moneyutil.ErrTooPrecise) || !errors.Is(err, moneyutil.ErrInvalidAmount) || !errors.Is(err, ErrMsgInvalidRequestBody) {
+		t.Fatalf("modelFromMenReq() error = %v, want every problem", err)
+	}
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+" "+fieldErr.Code)
+	}
+	want := []string{
+		"categories[0].items[1].price precision",
+		"categories[0].items[1].option_group_keys[0] unknown",
+		"items[0].price invalid",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("problems = %q, want %q", got, want)
+	}
+}
+
+func TestMenuReqFieldsRequestErr(t *testing.T) {
+	fields := menuReqFields{
+		items:        []string{"categories[0].items[0]", "items[0]"},
+		optionGroups: []string{"option_groups[0]", "categories[0].items[0].option_groups[0]"},
+	}
+	var errs validatorutil.FieldErrors
+	errs.Add(menusvc.ErrInvalidMenu, "items[1].price", validatorutil.CodeOutOfRange, "price must be greater than zero")
+	errs.Add(menusvc.ErrInvalidOptionGroup, "option_groups[1].options[0].name", validatorutil.CodeRequired, "name is required")
+	errs.Add(menusvc.ErrInvalidMenu, "categories[0].name", validatorutil.CodeRequired, "name is required")
+	errs.Add(menusvc.ErrInvalidMenu, "items[7].name", validatorutil.CodeRequired, "name is required")
+
+	err := fields.requestErr(fmt.Errorf("menusvc.UpdateMenu: %w", errs))
+	if !errors.Is(err, menusvc.ErrInvalidOptionGroup) {
+		t.Errorf("requestErr() = %v, want it to keep the option group error", err)
+	}
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field)
+	}
+	want := []string{
+		"items[0].price",
+		"categories[0].items[0].option_groups[0].options[0].name",
+		"categories[0].name",
+		"items[7].name",
+	}
+	if !slices.Equal(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+	if plain := errors.New("plain"); fields.requestErr(plain) != plain {
+		t.Error("requestErr() changed an error without field errors")
+	}
+}
+
+func TestMenuLocale(t *testing.T) {
+	bot := entities.Bot{DefaultLocale: "en", Locales: []string{"en", "zh-TW", "ja"}}
+	tests := []struct {
+		name           string
+		query          string
+		acceptLanguage string
+		want           string
+	}{
+		{name: "nothing asked", want: "en"},
+		{name: "query", query: "?locale=zh_tw", want: "zh-TW"},
+		{name: "query over header", query: "?locale=ja", acceptLanguage: "zh-TW", want: "ja"},
+		{name: "header", acceptLanguage: "fr;q=0.9, zh-Hant-TW, en;q=0.5", want: "zh-TW"},
+		{name: "unsupported query falls back to header", query: "?locale=ko", acceptLanguage: "ja-JP", want: "ja"},
+		{name: "unsupported", query: "?locale=ko", acceptLanguage: "fr", want: "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(http.MethodGet, "/menus/b1"+tt.query, nil)
+			if tt.acceptLanguage != "" {
+				c.Request.Header.Set("Accept-Language", tt.acceptLanguage)
+			}
+			if got := menuLocale(c, bot); got != tt.want {
+				t.Errorf("menuLocale() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMenuResFromModelTranslations(t *testing.T) {
+	bot := entities.Bot{PriceScale: 2, DefaultLocale: "en", Locales: []string{"en", "zh-TW"}}
+	detail := entities.MenuDetail{
+		Categories: []entities.MenuCategory{{ID: "c1", CategoryName: "Drinks", Translations: entities.Translations{"zh-TW": {Name: "飲料"}}}},
+		Items: []entities.MenuItem{
+			{ID: "i1", MenuItemName: "Latte", CategoryID: "c1", Description: "Oat milk", Translations: entities.Translations{
+				"zh-TW": {Name: "拿鐵"},
+			}},
+			{ID: "i2", MenuItemName: "Cookie"},
+		},
+	}
+	res := menuResFromModel(bot, detail, "zh-TW")
+	category := res.Categories[0]
+	if category.Name != "Drinks" || category.DisplayName != "飲料" {
+		t.Errorf("category = %q shown as %q, want Drinks shown as 飲料", category.Name, category.DisplayName)
+	}
+	latte := category.Items[0]
+	if latte.Name != "Latte" || latte.DisplayName != "拿鐵" || latte.DisplayDescription != "Oat milk" {
+		t.Errorf("latte = %q shown as %q, %q, want the description to fall back", latte.Name, latte.DisplayName, latte.DisplayDescription)
+	}
+	if cookie := res.Items[0]; cookie.DisplayName != "Cookie" || cookie.Translations == nil {
+		t.Errorf("cookie = %+v, want its own name and an empty translations object", cookie)
+	}
+	if res.Locale != "zh-TW" || res.DefaultLocale != "en" {
+		t.Errorf("locale = %q, default %q, want zh-TW and en", res.Locale, res.DefaultLocale)
+	}
+}
+
+func TestVersionFromIfMatch(t *testing.T) {
+	tests := []struct {
+		header string
+		want   int
+		wantOK bool
+	}{
+		{header: `"3"`, want: 3, wantOK: true},
+		{header: ` W/"12" `, want: 12, wantOK: true},
+		{header: "*", want: 0, wantOK: true},
+		{header: "3"},
+		{header: `"0"`},
+		{header: `"3", "4"`},
+		{header: `"abc"`},
+		{header: `"`},
+	}
+	for _, tt := range tests {
+		got, ok := versionFromIfMatch(tt.header)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("versionFromIfMatch(%q) = %d, %v, want %d, %v", tt.header, got, ok, tt.want, tt.wantOK)
+		}
+	}
+	if tag := menuETag(entities.Menu{Version: 7}); tag != `"7"` {
+		t.Errorf("menuETag() = %s, want \"7\"", tag)
+	}
+	if got, ok := versionFromIfMatch(menuETag(entities.Menu{Version: 7})); got != 7 || !ok {
+		t.Errorf("versionFromIfMatch(menuETag()) = %d, %v, want 7, true", got, ok)
+	}
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	tests := []struct {
+		header     string
+		want       int
+		wantOK     bool
+		wantStatus int
+	}{
+		{header: `"3"`, want: 3, wantOK: true, wantStatus: http.StatusOK},
+		{header: "*", want: 0, wantOK: true, wantStatus: http.StatusOK},
+		{header: "", wantStatus: http.StatusPreconditionRequired},
+		{header: "3", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodPut, "/menus/b1", nil)
+		if tt.header != "" {
+			c.Request.Header.Set("If-Match", tt.header)
+		}
+		got, ok := requireIfMatch(c)
+		if got != tt.want || ok != tt.wantOK || rec.Code != tt.wantStatus {
+			t.Errorf("requireIfMatch(%q) = %d, %v with %d, want %d, %v with %d",
+				tt.header, got, ok, rec.Code, tt.want, tt.wantOK, tt.wantStatus)
+		}
+	}
+}
+
+func TestOptionalIfMatch(t *testing.T) {
+	tests := []struct {
+		header     string
+		want       int
+		wantOK     bool
+		wantStatus int
+	}{
+		{header: `"3"`, want: 3, wantOK: true, wantStatus: http.StatusOK},
+		{header: "", want: 0, wantOK: true, wantStatus: http.StatusOK},
+		{header: "3", wantStatus: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(rec)
+		c.Request = httptest.NewRequest(http.MethodPut, "/menus/b1/availability", nil)
+		if tt.header != "" {
+			c.Request.Header.Set("If-Match", tt.header)
+		}
+		got, ok := optionalIfMatch(c)
+		if got != tt.want || ok != tt.wantOK || rec.Code != tt.wantStatus {
+			t.Errorf("optionalIfMatch(%q) = %d, %v with %d, want %d, %v with %d",
+				tt.header, got, ok, rec.Code, tt.want, tt.wantOK, tt.wantStatus)
+		}
+	}
+}
+
+func TestWriteMenuErrorChanged(t *testing.T) {
+	rec := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(rec)
+	writeMenuError(c, fmt.Errorf("menusvc.ReorderItems: %w", store.MenuChangedErr{Version: 5}))
+	if rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusPreconditionFailed)
+	}
+	if etag := rec.Header().Get("ETag"); etag != `"5"` {
+		t.Errorf("ETag = %s, want \"5\"", etag)
+	}
+	var body struct {
+		Version int `json:"version"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil || body.Version != 5 {
+		t.Errorf("body = %s, want version 5", rec.Body.String())
+	}
+}
+
+func TestModelFromItemPatchReq(t *testing.T) {
+	var req menuItemPatchReq
+	body := `{"price": "4.75", "category_id": "", "allergens": ["MILK"], "nutrition": null}`
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	patch, err := modelFromItemPatchReq(req, 2)
+	if err != nil {
+		t.Fatalf("modelFromItemPatchReq() = %v", err)
+	}
+	if patch.PriceScaled == nil || *patch.PriceScaled != 475 || patch.Name != nil {
+		t.Errorf("patch = %+v, want price 475 and name left as it is", patch)
+	}
+	if patch.CategoryID == nil || *patch.CategoryID != "" {
+		t.Errorf("CategoryID = %v, want moved out of its category", patch.CategoryID)
+	}
+	if patch.Allergens == nil || !slices.Equal(*patch.Allergens, []entities.Allergen{entities.AllergenMilk}) {
+		t.Errorf("Allergens = %v, want [milk]", patch.Allergens)
+	}
+	if !patch.SetNutrition || patch.Nutrition != nil {
+		t.Errorf("nutrition = %v %v, want cleared", patch.SetNutrition, patch.Nutrition)
+	}
+
+	req = menuItemPatchReq{}
+	if err := json.Unmarshal([]byte(`{"nutrition": {"calories": 90}}`), &req); err != nil {
+		t.Fatalf("Unmarshal() = %v", err)
+	}
+	if patch, err = modelFromItemPatchReq(req, 2); err != nil || !patch.SetNutrition || patch.Nutrition.Calories != 90 {
+		t.Errorf("patch = %+v, %v, want nutrition set", patch, err)
+	}
+	if patch, _ := modelFromItemPatchReq(menuItemPatchReq{}, 2); patch.SetNutrition {
+		t.Error("SetNutrition = true for a patch without nutrition")
+	}
+
+	price := moneyutil.Decimal("4.755")
+	_, err = modelFromItemPatchReq(menuItemPatchReq{Price: &price}, 2)
+	if fields := validatorutil.Fields(err); len(fields) != 1 || fields[0].Field != "price" || !errors.Is(err, moneyutil.ErrTooPrecise) {
+		t.Errorf("fields = %+v, want a precision error on price", fields)
+	}
+}
+
+func TestModelFromItemsPatchReq(t *testing.T) {
+	percent := moneyutil.Decimal("-2.5")
+	patch, err := modelFromItemsPatchReq(menuItemsPatchReq{CategoryIDs: []string{"drinks"}, PricePercent: &percent}, 2)
+	if err != nil || patch.PricePercent == nil || *patch.PricePercent != -250 {
+		t.Fatalf("patch = %+v, %v, want -250 basis points", patch, err)
+	}
+	percent, delta := moneyutil.Decimal("1.005"), moneyutil.Decimal("abc")
+	_, err = modelFromItemsPatchReq(menuItemsPatchReq{All: true, PricePercent: &percent, PriceDelta: &delta}, 2)
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	if want := []string{"price_delta:invalid", "price_percent:precision"}; !slices.Equal(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
+
+func TestModelFromBundlesReq(t *testing.T) {
+	req := menuBundlesReq{Bundles: []menuBundleReq{{
+		ID:    "b1",
+		Name:  "Burger combo",
+		Price: "9.5",
+		Slots: []bundleSlotReq{{Name: "Drink", Choices: []bundleChoiceReq{{ItemID: "cola"}, {ItemID: "shake", Upcharge: "1.25"}}}},
+	}}}
+	bundles, err := modelFromBundlesReq(req, 2)
+	if err != nil {
+		t.Fatalf("modelFromBundlesReq() = %v", err)
+	}
+	choices := bundles[0].Slots[0].Choices
+	if bundles[0].PriceScaled != 950 || choices[0].UpchargeScaled != 0 || choices[1].UpchargeScaled != 125 {
+		t.Errorf("bundles = %+v, want 950 with upcharges 0 and 125", bundles)
+	}
+	req.Bundles[0].Price = "abc"
+	req.Bundles[0].Slots[0].Choices[1].Upcharge = "1.255"
+	_, err = modelFromBundlesReq(req, 2)
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	if want := []string{"bundles[0].price:invalid", "bundles[0].slots[0].choices[1].upcharge:precision"}; !slices.Equal(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,181 @@
+package httphdlr
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	menuSheetFormatCSV  = "csv"
+	menuSheetFormatXLSX = "xlsx"
+
+	menuSheetContentTypeCSV  = "text/csv; charset=utf-8"
+	menuSheetContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+	// maxMenuSheetBytes caps uploaded spreadsheets.
+	maxMenuSheetBytes = 5 << 20
+)
+
+// menuSheetColumns is the header of exported sheets. Imports match headers
+// ignoring case and need at least name and price; other columns are ignored.
+var menuSheetColumns = []string{"id", "category", "name", "price", "option_groups"}
+
+type menuExportReq struct {
+	MenuID string `form:"menu_id"`
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+}
+
+// menuImportReq defaults to merging. Format defaults to the extension of the
+// uploaded file.
+type menuImportReq struct {
+	MenuID string `form:"menu_id"`
+	Format string `form:"format" binding:"omitempty,oneof=csv xlsx"`
+	Mode   string `form:"mode" binding:"omitempty,oneof=replace merge"`
+	DryRun bool   `form:"dry_run"`
+}
+
+type menuImportRes struct {
+	Mode    string               `json:"mode"`
+	DryRun  bool                 `json:"dry_run"`
+	Added   int                  `json:"added"`
+	Updated int                  `json:"updated"`
+	Removed int                  `json:"removed"`
+	Rows    []menuSheetRowRes    `json:"rows"`
+	Errors  []menuImportErrorRes `json:"errors"`
+	// Menu is the menu with the sheet applied, left out while there are
+	// errors.
+	Menu *menuRes `json:"menu"`
+}
+
+type menuSheetRowRes struct {
+	Line         int      `json:"line"`
+	ID           string   `json:"id"`
+	Category     string   `json:"category"`
+	Name         string   `json:"name"`
+	Price        string   `json:"price"`
+	OptionGroups []string `json:"option_groups"`
+}
+
+type menuImportErrorRes struct {
+	Line    int    `json:"line"`
+	Column  string `json:"column"`
+	Message string `json:"message"`
+}
+
+// menuSheetFormat picks the import format from the request or the file name.
+func menuSheetFormat(format string, fileName string) string {
+	if format != "" {
+		return format
+	}
+	if strings.EqualFold(filepath.Ext(fileName), ".xlsx") {
+		return menuSheetFormatXLSX
+	}
+	return menuSheetFormatCSV
+}
+
+// menuSheetRowsFromTable reads item rows below the header row. Blank rows are
+// skipped; option groups are separated by semicolons.
+func menuSheetRowsFromTable(table [][]string) ([]entities.MenuSheetRow, []entities.MenuImportError) {
+	if len(table) == 0 {
+		return nil, []entities.MenuImportError{{Message: "the sheet is empty"}}
+	}
+	columns := make(map[string]int, len(table[0]))
+	var errs []entities.MenuImportError
+	for idx, header := range table[0] {
+		key := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(header)), " ", "_")
+		if key == "" {
+			continue
+		}
+		if _, ok := columns[key]; ok {
+			errs = append(errs, entities.MenuImportError{Line: 1, Column: key, Message: "the column appears twice"})
+			continue
+		}
+		columns[key] = idx
+	}
+	for _, required := range []string{"name", "price"} {
+		if _, ok := columns[required]; !ok {
+			errs = append(errs, entities.MenuImportError{Line: 1, Column: required, Message: "the column is missing"})
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errs
+	}
+	cell := func(record []string, column string) string {
+		idx, ok := columns[column]
+		if !ok || idx >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[idx])
+	}
+	rows := make([]entities.MenuSheetRow, 0, len(table)-1)
+	for idx, record := range table[1:] {
+		if strings.TrimSpace(strings.Join(record, "")) == "" {
+			continue
+		}
+		row := entities.MenuSheetRow{
+			Line:     idx + 2,
+			ItemID:   cell(record, "id"),
+			Category: cell(record, "category"),
+			Name:     cell(record, "name"),
+			Price:    cell(record, "price"),
+		}
+		for _, group := range strings.Split(cell(record, "option_groups"), ";") {
+			if group = strings.TrimSpace(group); group != "" {
+				row.OptionGroups = append(row.OptionGroups, group)
+			}
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+func menuSheetTableFromRows(rows []entities.MenuSheetRow) [][]string {
+	table := make([][]string, 0, len(rows)+1)
+	table = append(table, menuSheetColumns)
+	for _, row := range rows {
+		table = append(table, []string{row.ItemID, row.Category, row.Name, row.Price, strings.Join(row.OptionGroups, "; ")})
+	}
+	return table
+}
+
+func menuImportResFromModel(
+	bot entities.Bot,
+	rows []entities.MenuSheetRow,
+	result entities.MenuImport,
+	dryRun bool,
+	locale string,
+) menuImportRes {
+	res := menuImportRes{
+		Mode:    string(result.Mode),
+		DryRun:  dryRun,
+		Added:   result.Added,
+		Updated: result.Updated,
+		Removed: result.Removed,
+		Rows:    make([]menuSheetRowRes, 0, len(rows)),
+		Errors:  menuImportErrorsRes(result.Errors),
+	}
+	for _, row := range rows {
+		res.Rows = append(res.Rows, menuSheetRowRes{
+			Line:         row.Line,
+			ID:           row.ItemID,
+			Category:     row.Category,
+			Name:         row.Name,
+			Price:        row.Price,
+			OptionGroups: append([]string{}, row.OptionGroups...),
+		})
+	}
+	if len(result.Errors) == 0 {
+		menu := menuResFromModel(bot, result.Detail, locale)
+		res.Menu = &menu
+	}
+	return res
+}
+
+func menuImportErrorsRes(errs []entities.MenuImportError) []menuImportErrorRes {
+	res := make([]menuImportErrorRes, 0, len(errs))
+	for _, err := range errs {
+		res = append(res, menuImportErrorRes{Line: err.Line, Column: err.Column, Message: err.Message})
+	}
+	return res
+}
@@ -0,0 +1,105 @@
+package httphdlr
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"time"
+)
+
+type menuVersionDiffReq struct {
+	From int `form:"from" binding:"required,min=1"`
+	To   int `form:"to" binding:"required,min=1"`
+}
+
+type menuVersionUri struct {
+	Version int `uri:"version" binding:"required,min=1"`
+}
+
+type menuVersionsRes struct {
+	Versions []menuVersionRes `json:"versions"`
+}
+
+type menuVersionRes struct {
+	Version   int       `json:"version"`
+	AuthorID  *string   `json:"author_id"`
+	Note      string    `json:"note"`
+	ItemCount int       `json:"item_count"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type menuVersionDiffRes struct {
+	From int `json:"from"`
+	To   int `json:"to"`
+	menuDiffRes
+}
+
+// menuDiffRes lists item changes. Renamed, re-priced and described entries
+// carry the item before and after the change.
+type menuDiffRes struct {
+	Added     []menuDiffItemRes   `json:"added"`
+	Removed   []menuDiffItemRes   `json:"removed"`
+	Renamed   []menuDiffChangeRes `json:"renamed"`
+	Repriced  []menuDiffChangeRes `json:"repriced"`
+	Described []menuDiffChangeRes `json:"described"`
+}
+
+type menuDiffItemRes struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Price       string `json:"price"`
+	PriceScaled int64  `json:"price_scaled"`
+}
+
+type menuDiffChangeRes struct {
+	Before menuDiffItemRes `json:"before"`
+	After  menuDiffItemRes `json:"after"`
+}
+
+func menuVersionsResFromModel(versions []entities.MenuVersion) menuVersionsRes {
+	resVersions := make([]menuVersionRes, 0, len(versions))
+	for _, version := range versions {
+		res := menuVersionRes{
+			Version:   version.Version,
+			Note:      version.Note,
+			ItemCount: version.ItemCount,
+			CreatedAt: version.CreatedAt,
+		}
+		if version.AuthorID != "" {
+			res.AuthorID = &version.AuthorID
+		}
+		resVersions = append(resVersions, res)
+	}
+	return menuVersionsRes{Versions: resVersions}
+}
+
+func menuDiffResFromModel(bot entities.Bot, diff entities.MenuDiff) menuDiffRes {
+	itemRes := func(item entities.MenuItem) menuDiffItemRes {
+		return menuDiffItemRes{
+			ID:          item.ID,
+			Name:        item.MenuItemName,
+			Price:       moneyutil.Money(item.PriceScaled).Format(bot.PriceScale),
+			PriceScaled: item.PriceScaled,
+		}
+	}
+	itemsRes := func(items []entities.MenuItem) []menuDiffItemRes {
+		res := make([]menuDiffItemRes, 0, len(items))
+		for _, item := range items {
+			res = append(res, itemRes(item))
+		}
+		return res
+	}
+	changesRes := func(changes []entities.MenuItemChange) []menuDiffChangeRes {
+		res := make([]menuDiffChangeRes, 0, len(changes))
+		for _, change := range changes {
+			res = append(res, menuDiffChangeRes{Before: itemRes(change.Before), After: itemRes(change.After)})
+		}
+		return res
+	}
+	return menuDiffRes{
+		Added:     itemsRes(diff.Added),
+		Removed:   itemsRes(diff.Removed),
+		Renamed:   changesRes(diff.Renamed),
+		Repriced:  changesRes(diff.Repriced),
+		Described: changesRes(diff.Described),
+	}
+}
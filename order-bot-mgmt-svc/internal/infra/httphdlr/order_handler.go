@@ -3,14 +3,17 @@ package httphdlr
 import (
 	"log/slog"
 	"net/http"
+	"order-bot-mgmt-svc/internal/services/botsvc"
 	"order-bot-mgmt-svc/internal/services/ordersvc"
 	"order-bot-mgmt-svc/internal/util/errutil"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
 
 	"github.com/gin-gonic/gin"
 )
 
 type OrderServer interface {
 	OrderService() *ordersvc.Svc
+	BotService() *botsvc.Svc
 }
 
 const OrderPrefix = "/orders"
@@ -22,7 +25,13 @@ func RegisterOrderRoutes(r gin.IRoutes, s OrderServer) {
 func getOrdersHdlrFunc(s OrderServer) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		botId := c.Param("botId")
-		ordersWithItems, err := s.OrderService().GetOrdersWithItems(nil, botId)
+		ordersWithItems, err := s.OrderService().GetOrdersWithItems(c.Request.Context(), botId)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load orders"})
+			return
+		}
+		bot, err := s.BotService().GetBot(c.Request.Context(), botId)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to load orders"})
@@ -40,6 +49,11 @@ func getOrdersHdlrFunc(s OrderServer) gin.HandlerFunc {
 					Quantity:         item.Quantity,
 					UnitPriceScaled:  item.UnitPriceScaled,
 					TotalPriceScaled: item.TotalPriceScaled,
+					UnitPrice:        moneyutil.FormatScaled(int64(item.UnitPriceScaled), bot.PriceScale),
+					TotalPrice:       moneyutil.FormatScaled(int64(item.TotalPriceScaled), bot.PriceScale),
+					TaxRateCode:      item.TaxRateCode,
+					Tax:              moneyutil.FormatScaled(item.TaxScaled, bot.PriceScale),
+					TaxScaled:        item.TaxScaled,
 				})
 			}
 			response = append(response, orderRes{
@@ -48,7 +62,11 @@ func getOrdersHdlrFunc(s OrderServer) gin.HandlerFunc {
 				CartID:      orderWithItems.Order.CartID,
 				SessionID:   orderWithItems.Order.SessionID,
 				TotalScaled: orderWithItems.Order.TotalScaled,
+				Total:       moneyutil.FormatScaled(int64(orderWithItems.Order.TotalScaled), bot.PriceScale),
+				Currency:    bot.Currency,
+				PriceScale:  bot.PriceScale,
 				Items:       items,
+				Tax:         orderTaxResFromModel(bot, orderWithItems.Order.Tax),
 			})
 		}
 		c.JSON(http.StatusOK, gin.H{"orders": response})
@@ -8,6 +8,11 @@ type orderItemRes struct {
 	Quantity         int    `json:"quantity"`
 	UnitPriceScaled  int    `json:"unit_price_scaled"`
 	TotalPriceScaled int    `json:"total_price_scaled"`
+	UnitPrice        string `json:"unit_price"`
+	TotalPrice       string `json:"total_price"`
+	TaxRateCode      string `json:"tax_rate_code"`
+	Tax              string `json:"tax"`
+	TaxScaled        int64  `json:"tax_scaled"`
 }
 
 type orderRes struct {
@@ -16,5 +21,9 @@ type orderRes struct {
 	CartID      string         `json:"cart_id"`
 	SessionID   string         `json:"session_id"`
 	TotalScaled int            `json:"total_scaled"`
+	Total       string         `json:"total"`
+	Currency    string         `json:"currency"`
+	PriceScale  int            `json:"price_scale"`
 	Items       []orderItemRes `json:"items"`
+	Tax         orderTaxRes    `json:"tax"`
 }
@@ -0,0 +1,194 @@
+package httphdlr
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/errutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type PromotionServer interface {
+	PromotionService() *promotionsvc.Svc
+	BotService() *botsvc.Svc
+}
+
+const PromotionPrefix = "/promotions"
+
+// RegisterPromotionRoutes registers the promotion routes. Every change
+// republishes the bot's promotions to the order side.
+func RegisterPromotionRoutes(r gin.IRoutes, s PromotionServer) {
+	r.GET("/:botId", listPromotionsHdlrFunc(s))
+	r.POST("/:botId", createPromotionHdlrFunc(s))
+	r.POST("/:botId/evaluate", evaluatePromotionsHdlrFunc(s))
+	r.GET("/:botId/:promotionId", getPromotionHdlrFunc(s))
+	r.PUT("/:botId/:promotionId", updatePromotionHdlrFunc(s))
+	r.DELETE("/:botId/:promotionId", deletePromotionHdlrFunc(s))
+}
+
+func listPromotionsHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		promotions, err := s.PromotionService().ListPromotions(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, promotionsResFromModel(bot, promotions))
+	}
+}
+
+func getPromotionHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		promotion, err := s.PromotionService().GetPromotion(c.Request.Context(), botID, c.Param("promotionId"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, promotionResFromModel(bot, promotion))
+	}
+}
+
+func createPromotionHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req promotionReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		promotion, err := modelFromPromotionReq(req, bot.PriceScale)
+		if err != nil {
+			writePromotionError(c, err)
+			return
+		}
+		promotion, err = s.PromotionService().CreatePromotion(c.Request.Context(), botID, promotion)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.JSON(http.StatusCreated, promotionResFromModel(bot, promotion))
+	}
+}
+
+func updatePromotionHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req promotionReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		promotion, err := modelFromPromotionReq(req, bot.PriceScale)
+		if err != nil {
+			writePromotionError(c, err)
+			return
+		}
+		promotion, err = s.PromotionService().UpdatePromotion(c.Request.Context(), botID, c.Param("promotionId"), promotion)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, promotionResFromModel(bot, promotion))
+	}
+}
+
+func deletePromotionHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if err := s.PromotionService().DeletePromotion(c.Request.Context(), c.Param("botId"), c.Param("promotionId")); err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.Status(http.StatusNoContent)
+	}
+}
+
+func evaluatePromotionsHdlrFunc(s PromotionServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req promotionEvaluateReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		botID := c.Param("botId")
+		bot, err := s.BotService().GetBot(c.Request.Context(), botID)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		at := time.Now()
+		if req.At != nil {
+			at = *req.At
+		}
+		lines := modelFromPromotionEvaluateReq(req)
+		result, err := s.PromotionService().EvaluateCart(c.Request.Context(), botID, req.MenuID, lines, at)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writePromotionError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, promotionEvaluateResFromModel(bot, lines, result))
+	}
+}
+
+func writePromotionError(c *gin.Context, err error) {
+	if fields := validatorutil.Fields(err); len(fields) > 0 {
+		msg := promotionsvc.ErrInvalidPromotion.Error()
+		if !errors.Is(err, promotionsvc.ErrInvalidPromotion) {
+			msg = ErrMsgInvalidRequestBody.Error()
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": msg, "fields": fields})
+		return
+	}
+	switch {
+	case errors.Is(err, promotionsvc.ErrInvalidPromotion):
+		c.JSON(http.StatusBadRequest, gin.H{"error": promotionsvc.ErrInvalidPromotion.Error()})
+	case errors.Is(err, store.ErrPromotionNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrPromotionNotFound.Error()})
+	case errors.Is(err, store.ErrBotNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrBotNotFound.Error()})
+	case errors.Is(err, store.ErrMenuNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrMenuNotFound.Error()})
+	case errors.Is(err, store.ErrMenuAmbiguous):
+		c.JSON(http.StatusBadRequest, gin.H{"error": store.ErrMenuAmbiguous.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "promotion request failed"})
+	}
+}
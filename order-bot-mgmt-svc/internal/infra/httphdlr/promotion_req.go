@@ -0,0 +1,254 @@
+package httphdlr
+
+import (
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"time"
+)
+
+// promotionReq creates or replaces a promotion. PercentOff is a percentage
+// such as "12.5"; a buy-X-get-Y promotion without one makes its units free.
+// AmountOff and MinSubtotal are at the bot's price scale, and an empty
+// MinSubtotal means no threshold. Leaving out both ItemIDs and CategoryIDs
+// targets the whole order. Enabled defaults to true.
+type promotionReq struct {
+	Name        string               `json:"name"`
+	Type        string               `json:"type"`
+	PercentOff  moneyutil.Decimal    `json:"percent_off"`
+	AmountOff   moneyutil.Decimal    `json:"amount_off"`
+	BuyQuantity int                  `json:"buy_quantity"`
+	GetQuantity int                  `json:"get_quantity"`
+	MinSubtotal moneyutil.Decimal    `json:"min_subtotal"`
+	ItemIDs     []string             `json:"item_ids"`
+	CategoryIDs []string             `json:"category_ids"`
+	Schedule    promotionScheduleReq `json:"schedule"`
+	Priority    int                  `json:"priority"`
+	Stackable   bool                 `json:"stackable"`
+	Enabled     *bool                `json:"enabled"`
+}
+
+// promotionScheduleReq takes weekdays as 0 for Sunday to 6 for Saturday, and
+// AvailableFrom and AvailableUntil as "HH:MM" in the bot's timezone. Every
+// field may be left out.
+type promotionScheduleReq struct {
+	StartsAt       *time.Time     `json:"starts_at"`
+	EndsAt         *time.Time     `json:"ends_at"`
+	Weekdays       []time.Weekday `json:"weekdays"`
+	AvailableFrom  string         `json:"available_from"`
+	AvailableUntil string         `json:"available_until"`
+}
+
+// promotionEvaluateReq is a cart to price. MenuID may be left out while the
+// bot has one menu, and At defaults to now.
+type promotionEvaluateReq struct {
+	MenuID string                     `json:"menu_id"`
+	At     *time.Time                 `json:"at"`
+	Items  []promotionEvaluateItemReq `json:"items"`
+}
+
+type promotionEvaluateItemReq struct {
+	ItemID   string `json:"item_id"`
+	Quantity int    `json:"quantity"`
+}
+
+type promotionRes struct {
+	ID                string               `json:"id"`
+	Name              string               `json:"name"`
+	Type              string               `json:"type"`
+	PercentOff        string               `json:"percent_off"`
+	AmountOff         string               `json:"amount_off"`
+	AmountOffScaled   int64                `json:"amount_off_scaled"`
+	BuyQuantity       int                  `json:"buy_quantity"`
+	GetQuantity       int                  `json:"get_quantity"`
+	MinSubtotal       string               `json:"min_subtotal"`
+	MinSubtotalScaled int64                `json:"min_subtotal_scaled"`
+	ItemIDs           []string             `json:"item_ids"`
+	CategoryIDs       []string             `json:"category_ids"`
+	Schedule          promotionScheduleRes `json:"schedule"`
+	Priority          int                  `json:"priority"`
+	Stackable         bool                 `json:"stackable"`
+	Enabled           bool                 `json:"enabled"`
+	CreatedAt         time.Time            `json:"created_at"`
+	UpdatedAt         time.Time            `json:"updated_at"`
+}
+
+// promotionScheduleRes has null times for open ends and an all-day window.
+type promotionScheduleRes struct {
+	StartsAt       *time.Time     `json:"starts_at"`
+	EndsAt         *time.Time     `json:"ends_at"`
+	Weekdays       []time.Weekday `json:"weekdays"`
+	AvailableFrom  *string        `json:"available_from"`
+	AvailableUntil *string        `json:"available_until"`
+}
+
+type promotionsRes struct {
+	Timezone   string         `json:"timezone"`
+	Promotions []promotionRes `json:"promotions"`
+}
+
+type promotionEvaluateRes struct {
+	Subtotal       string                     `json:"subtotal"`
+	SubtotalScaled int64                      `json:"subtotal_scaled"`
+	Discount       string                     `json:"discount"`
+	DiscountScaled int64                      `json:"discount_scaled"`
+	Total          string                     `json:"total"`
+	TotalScaled    int64                      `json:"total_scaled"`
+	Items          []promotionEvaluateItemRes `json:"items"`
+	Applied        []appliedPromotionRes      `json:"applied"`
+}
+
+type promotionEvaluateItemRes struct {
+	ItemID         string `json:"item_id"`
+	Quantity       int    `json:"quantity"`
+	Discount       string `json:"discount"`
+	DiscountScaled int64  `json:"discount_scaled"`
+}
+
+type appliedPromotionRes struct {
+	PromotionID    string `json:"promotion_id"`
+	Name           string `json:"name"`
+	Discount       string `json:"discount"`
+	DiscountScaled int64  `json:"discount_scaled"`
+}
+
+// modelFromPromotionReq parses percentages and amounts, the latter at the
+// bot's scale. Field errors match those of the service.
+func modelFromPromotionReq(req promotionReq, priceScale int) (entities.Promotion, error) {
+	var errs validatorutil.FieldErrors
+	promotion := entities.Promotion{
+		PromotionName: req.Name,
+		Type:          entities.PromotionType(req.Type),
+		BuyQuantity:   req.BuyQuantity,
+		GetQuantity:   req.GetQuantity,
+		ItemIDs:       req.ItemIDs,
+		CategoryIDs:   req.CategoryIDs,
+		Schedule: entities.PromotionSchedule{
+			Weekdays: req.Schedule.Weekdays,
+		},
+		Priority:  req.Priority,
+		Stackable: req.Stackable,
+		Enabled:   req.Enabled == nil || *req.Enabled,
+	}
+	if req.PercentOff != "" {
+		basisPoints, err := moneyutil.ParseMoney(string(req.PercentOff), percentScale)
+		switch {
+		case errors.Is(err, moneyutil.ErrTooPrecise):
+			errs.Add(err, "percent_off", validatorutil.CodePrecision, "%s has more than %d decimals", req.PercentOff, percentScale)
+		case err != nil:
+			errs.Add(err, "percent_off", validatorutil.CodeInvalid, "%q is not a percentage", req.PercentOff)
+		}
+		promotion.PercentOff = int64(basisPoints)
+	}
+	if amount := parseOptionalMoney(&errs, "amount_off", optionalDecimal(req.AmountOff), priceScale); amount != nil {
+		promotion.AmountOffScaled = *amount
+	}
+	if amount := parseOptionalMoney(&errs, "min_subtotal", optionalDecimal(req.MinSubtotal), priceScale); amount != nil {
+		promotion.MinSubtotalScaled = *amount
+	}
+	if req.Schedule.StartsAt != nil {
+		promotion.Schedule.StartsAt = *req.Schedule.StartsAt
+	}
+	if req.Schedule.EndsAt != nil {
+		promotion.Schedule.EndsAt = *req.Schedule.EndsAt
+	}
+	window, err := parseMenuWindow(req.Schedule.AvailableFrom, req.Schedule.AvailableUntil)
+	if err != nil {
+		errs.Add(err, "schedule.available_from", validatorutil.CodeInvalid, "times of day are HH:MM, got %q to %q",
+			req.Schedule.AvailableFrom, req.Schedule.AvailableUntil)
+	}
+	promotion.Schedule.Window = window
+	if err := errs.Err(); err != nil {
+		return entities.Promotion{}, fmt.Errorf("httphdlr.modelFromPromotionReq: %w", err)
+	}
+	return promotion, nil
+}
+
+// optionalDecimal reads an empty decimal as left out.
+func optionalDecimal(d moneyutil.Decimal) *moneyutil.Decimal {
+	if d == "" {
+		return nil
+	}
+	return &d
+}
+
+func modelFromPromotionEvaluateReq(req promotionEvaluateReq) []entities.PromotionLine {
+	lines := make([]entities.PromotionLine, 0, len(req.Items))
+	for _, item := range req.Items {
+		lines = append(lines, entities.PromotionLine{MenuItemID: item.ItemID, Quantity: item.Quantity})
+	}
+	return lines
+}
+
+func promotionResFromModel(bot entities.Bot, promotion entities.Promotion) promotionRes {
+	schedule := promotionScheduleRes{Weekdays: append([]time.Weekday{}, promotion.Schedule.Weekdays...)}
+	if !promotion.Schedule.StartsAt.IsZero() {
+		schedule.StartsAt = &promotion.Schedule.StartsAt
+	}
+	if !promotion.Schedule.EndsAt.IsZero() {
+		schedule.EndsAt = &promotion.Schedule.EndsAt
+	}
+	schedule.AvailableFrom, schedule.AvailableUntil = formatMenuWindow(promotion.Schedule.Window)
+	return promotionRes{
+		ID:                promotion.ID,
+		Name:              promotion.PromotionName,
+		Type:              string(promotion.Type),
+		PercentOff:        moneyutil.Money(promotion.PercentOff).Format(percentScale),
+		AmountOff:         moneyutil.Money(promotion.AmountOffScaled).Format(bot.PriceScale),
+		AmountOffScaled:   promotion.AmountOffScaled,
+		BuyQuantity:       promotion.BuyQuantity,
+		GetQuantity:       promotion.GetQuantity,
+		MinSubtotal:       moneyutil.Money(promotion.MinSubtotalScaled).Format(bot.PriceScale),
+		MinSubtotalScaled: promotion.MinSubtotalScaled,
+		ItemIDs:           append([]string{}, promotion.ItemIDs...),
+		CategoryIDs:       append([]string{}, promotion.CategoryIDs...),
+		Schedule:          schedule,
+		Priority:          promotion.Priority,
+		Stackable:         promotion.Stackable,
+		Enabled:           promotion.Enabled,
+		CreatedAt:         promotion.CreatedAt,
+		UpdatedAt:         promotion.UpdatedAt,
+	}
+}
+
+func promotionsResFromModel(bot entities.Bot, promotions []entities.Promotion) promotionsRes {
+	resPromotions := make([]promotionRes, 0, len(promotions))
+	for _, promotion := range promotions {
+		resPromotions = append(resPromotions, promotionResFromModel(bot, promotion))
+	}
+	return promotionsRes{Timezone: bot.Timezone, Promotions: resPromotions}
+}
+
+func promotionEvaluateResFromModel(bot entities.Bot, lines []entities.PromotionLine, result entities.PromotionResult) promotionEvaluateRes {
+	format := func(v int64) string { return moneyutil.Money(v).Format(bot.PriceScale) }
+	items := make([]promotionEvaluateItemRes, 0, len(lines))
+	for idx, line := range lines {
+		items = append(items, promotionEvaluateItemRes{
+			ItemID:         line.MenuItemID,
+			Quantity:       line.Quantity,
+			Discount:       format(result.LineDiscountsScaled[idx]),
+			DiscountScaled: result.LineDiscountsScaled[idx],
+		})
+	}
+	applied := make([]appliedPromotionRes, 0, len(result.Applied))
+	for _, promotion := range result.Applied {
+		applied = append(applied, appliedPromotionRes{
+			PromotionID:    promotion.PromotionID,
+			Name:           promotion.PromotionName,
+			Discount:       format(promotion.DiscountScaled),
+			DiscountScaled: promotion.DiscountScaled,
+		})
+	}
+	return promotionEvaluateRes{
+		Subtotal:       format(result.SubtotalScaled),
+		SubtotalScaled: result.SubtotalScaled,
+		Discount:       format(result.DiscountScaled),
+		DiscountScaled: result.DiscountScaled,
+		Total:          format(result.TotalScaled),
+		TotalScaled:    result.TotalScaled,
+		Items:          items,
+		Applied:        applied,
+	}
+}
@@ -0,0 +1,103 @@
+package httphdlr
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"order-bot-mgmt-svc/internal/services/stocksvc"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/errutil"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockServer interface {
+	StockService() *stocksvc.Svc
+}
+
+const StockPrefix = "/stock"
+
+// RegisterStockRoutes registers the stock routes. Listing routes take the
+// menu from the menu_id query parameter, which may be left out while the bot
+// has one menu.
+func RegisterStockRoutes(r gin.IRoutes, s StockServer) {
+	r.GET("/:botId", listStockHdlrFunc(s))
+	r.PUT("/:botId/items/:itemId", setStockHdlrFunc(s))
+	r.POST("/:botId/items/:itemId/restock", restockHdlrFunc(s))
+	r.GET("/:botId/movements", listStockMovementsHdlrFunc(s))
+}
+
+func listStockHdlrFunc(s StockServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		items, err := s.StockService().ListItems(c.Request.Context(), c.Param("botId"), c.Query("menu_id"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeStockError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, stockItemsResFromModel(items))
+	}
+}
+
+func setStockHdlrFunc(s StockServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req stockReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		item, err := s.StockService().SetStock(c.Request.Context(), c.Param("botId"), c.Param("itemId"), req.Track, req.Stock)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeStockError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, stockItemResFromModel(item))
+	}
+}
+
+func restockHdlrFunc(s StockServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req restockReq
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": ErrMsgInvalidRequestBody})
+			return
+		}
+		item, err := s.StockService().Restock(c.Request.Context(), c.Param("botId"), c.Param("itemId"), req.Quantity)
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeStockError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, stockItemResFromModel(item))
+	}
+}
+
+func listStockMovementsHdlrFunc(s StockServer) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		movements, err := s.StockService().ListMovements(c.Request.Context(), c.Param("botId"), c.Query("menu_id"), c.Query("item_id"))
+		if err != nil {
+			slog.Error(errutil.FormatErrChain(err))
+			writeStockError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, stockMovementsResFromModel(movements))
+	}
+}
+
+func writeStockError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, stocksvc.ErrInvalidStock):
+		c.JSON(http.StatusBadRequest, gin.H{"error": stocksvc.ErrInvalidStock.Error()})
+	case errors.Is(err, stocksvc.ErrStockNotTracked):
+		c.JSON(http.StatusConflict, gin.H{"error": stocksvc.ErrStockNotTracked.Error()})
+	case errors.Is(err, store.ErrMenuItemNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrMenuItemNotFound.Error()})
+	case errors.Is(err, store.ErrMenuNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": store.ErrMenuNotFound.Error()})
+	case errors.Is(err, store.ErrMenuAmbiguous):
+		c.JSON(http.StatusBadRequest, gin.H{"error": store.ErrMenuAmbiguous.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "stock request failed"})
+	}
+}
@@ -0,0 +1,80 @@
+package httphdlr
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
+)
+
+// stockReq sets an item's count. Track false stops counting the item; stock
+// must then be left out or zero.
+type stockReq struct {
+	Track bool `json:"track"`
+	Stock int  `json:"stock"`
+}
+
+type restockReq struct {
+	Quantity int `json:"quantity" binding:"required"`
+}
+
+type stockItemsRes struct {
+	Items []stockItemRes `json:"items"`
+}
+
+type stockItemRes struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	TrackStock bool   `json:"track_stock"`
+	Stock      *int   `json:"stock"`
+	SoldOut    bool   `json:"sold_out"`
+}
+
+type stockMovementsRes struct {
+	Movements []stockMovementRes `json:"movements"`
+}
+
+type stockMovementRes struct {
+	ID          string    `json:"id"`
+	MenuItemID  string    `json:"menu_item_id"`
+	Delta       int       `json:"delta"`
+	Balance     int       `json:"balance"`
+	Reason      string    `json:"reason"`
+	OrderItemID *string   `json:"order_item_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func stockItemResFromModel(item entities.MenuItem) stockItemRes {
+	return stockItemRes{
+		ID:         item.ID,
+		Name:       item.MenuItemName,
+		TrackStock: item.TrackStock,
+		Stock:      stockPtr(item),
+		SoldOut:    item.SoldOut,
+	}
+}
+
+func stockItemsResFromModel(items []entities.MenuItem) stockItemsRes {
+	resItems := make([]stockItemRes, 0, len(items))
+	for _, item := range items {
+		resItems = append(resItems, stockItemResFromModel(item))
+	}
+	return stockItemsRes{Items: resItems}
+}
+
+func stockMovementsResFromModel(movements []entities.StockMovement) stockMovementsRes {
+	resMovements := make([]stockMovementRes, 0, len(movements))
+	for _, movement := range movements {
+		res := stockMovementRes{
+			ID:         movement.ID,
+			MenuItemID: movement.MenuItemID,
+			Delta:      movement.Delta,
+			Balance:    movement.Balance,
+			Reason:     string(movement.Reason),
+			CreatedAt:  movement.CreatedAt,
+		}
+		if movement.OrderItemID != "" {
+			res.OrderItemID = &movement.OrderItemID
+		}
+		resMovements = append(resMovements, res)
+	}
+	return stockMovementsRes{Movements: resMovements}
+}
@@ -0,0 +1,157 @@
+package httphdlr
+
+import (
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"time"
+)
+
+// taxRateScale is the number of decimals a tax rate percentage may have, so
+// rates are in millionths like entities.TaxRate.
+const taxRateScale = 4
+
+// taxConfigReq replaces a bot's tax config. Mode is "exclusive", the
+// default, or "inclusive". DefaultRate, CategoryRates and ItemRates refer to
+// rates by code; CategoryRates and ItemRates are keyed by category and item
+// ID. An empty DefaultRate leaves items without an override untaxed.
+type taxConfigReq struct {
+	Mode          string            `json:"mode"`
+	Rates         []taxRateReq      `json:"rates"`
+	DefaultRate   string            `json:"default_rate"`
+	CategoryRates map[string]string `json:"category_rates"`
+	ItemRates     map[string]string `json:"item_rates"`
+}
+
+// taxRateReq takes Rate as a percentage such as "8.875".
+type taxRateReq struct {
+	Code string            `json:"code"`
+	Name string            `json:"name"`
+	Rate moneyutil.Decimal `json:"rate"`
+}
+
+type taxConfigRes struct {
+	Mode          string            `json:"mode"`
+	Rates         []taxRateRes      `json:"rates"`
+	DefaultRate   string            `json:"default_rate"`
+	CategoryRates map[string]string `json:"category_rates"`
+	ItemRates     map[string]string `json:"item_rates"`
+	// UpdatedAt is null until the config is first saved.
+	UpdatedAt *time.Time `json:"updated_at"`
+}
+
+type taxRateRes struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Rate string `json:"rate"`
+}
+
+// orderTaxRes is the tax breakdown of an order. Net plus tax is gross; with
+// exclusive pricing the order total is the net amount, with inclusive
+// pricing it is the gross amount.
+type orderTaxRes struct {
+	Mode        string            `json:"mode"`
+	Net         string            `json:"net"`
+	NetScaled   int64             `json:"net_scaled"`
+	Tax         string            `json:"tax"`
+	TaxScaled   int64             `json:"tax_scaled"`
+	Gross       string            `json:"gross"`
+	GrossScaled int64             `json:"gross_scaled"`
+	Rates       []orderTaxRateRes `json:"rates"`
+}
+
+type orderTaxRateRes struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Rate      string `json:"rate"`
+	Net       string `json:"net"`
+	NetScaled int64  `json:"net_scaled"`
+	Tax       string `json:"tax"`
+	TaxScaled int64  `json:"tax_scaled"`
+}
+
+// modelFromTaxConfigReq parses the rate percentages. Field errors match
+// those of the service.
+func modelFromTaxConfigReq(req taxConfigReq) (entities.TaxConfig, error) {
+	var errs validatorutil.FieldErrors
+	config := entities.TaxConfig{
+		Mode:              entities.TaxMode(req.Mode),
+		Rates:             make([]entities.TaxRate, 0, len(req.Rates)),
+		DefaultRateCode:   req.DefaultRate,
+		CategoryRateCodes: req.CategoryRates,
+		ItemRateCodes:     req.ItemRates,
+	}
+	for idx, rateReq := range req.Rates {
+		rate := entities.TaxRate{Code: rateReq.Code, RateName: rateReq.Name}
+		field := fmt.Sprintf("rates[%d].rate", idx)
+		millionths, err := moneyutil.ParseMoney(string(rateReq.Rate), taxRateScale)
+		switch {
+		case rateReq.Rate == "":
+			errs.Add(ErrMsgInvalidRequestBody, field, validatorutil.CodeRequired, "rate is required")
+		case errors.Is(err, moneyutil.ErrTooPrecise):
+			errs.Add(err, field, validatorutil.CodePrecision, "%s has more than %d decimals", rateReq.Rate, taxRateScale)
+		case err != nil:
+			errs.Add(err, field, validatorutil.CodeInvalid, "%q is not a percentage", rateReq.Rate)
+		}
+		rate.Rate = int64(millionths)
+		config.Rates = append(config.Rates, rate)
+	}
+	if err := errs.Err(); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("httphdlr.modelFromTaxConfigReq: %w", err)
+	}
+	return config, nil
+}
+
+func formatTaxRate(rate int64) string { return moneyutil.Money(rate).Format(taxRateScale) }
+
+func taxConfigResFromModel(config entities.TaxConfig) taxConfigRes {
+	rates := make([]taxRateRes, 0, len(config.Rates))
+	for _, rate := range config.Rates {
+		rates = append(rates, taxRateRes{Code: rate.Code, Name: rate.RateName, Rate: formatTaxRate(rate.Rate)})
+	}
+	res := taxConfigRes{
+		Mode:          string(config.Mode),
+		Rates:         rates,
+		DefaultRate:   config.DefaultRateCode,
+		CategoryRates: map[string]string{},
+		ItemRates:     map[string]string{},
+	}
+	for id, code := range config.CategoryRateCodes {
+		res.CategoryRates[id] = code
+	}
+	for id, code := range config.ItemRateCodes {
+		res.ItemRates[id] = code
+	}
+	if !config.UpdatedAt.IsZero() {
+		res.UpdatedAt = &config.UpdatedAt
+	}
+	return res
+}
+
+func orderTaxResFromModel(bot entities.Bot, tax entities.TaxBreakdown) orderTaxRes {
+	format := func(v int64) string { return moneyutil.Money(v).Format(bot.PriceScale) }
+	rates := make([]orderTaxRateRes, 0, len(tax.Rates))
+	for _, rate := range tax.Rates {
+		rates = append(rates, orderTaxRateRes{
+			Code:      rate.Code,
+			Name:      rate.RateName,
+			Rate:      formatTaxRate(rate.Rate),
+			Net:       format(rate.NetScaled),
+			NetScaled: rate.NetScaled,
+			Tax:       format(rate.TaxScaled),
+			TaxScaled: rate.TaxScaled,
+		})
+	}
+	return orderTaxRes{
+		Mode:        string(tax.Mode),
+		Net:         format(tax.NetScaled),
+		NetScaled:   tax.NetScaled,
+		Tax:         format(tax.TaxScaled),
+		TaxScaled:   tax.TaxScaled,
+		Gross:       format(tax.GrossScaled),
+		GrossScaled: tax.GrossScaled,
+		Rates:       rates,
+	}
+}
@@ -12,8 +12,11 @@ import (
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/services/authsvc"
 	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/services/ordersvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
+	"order-bot-mgmt-svc/internal/services/stocksvc"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util"
 	"strings"
@@ -60,7 +63,9 @@ type fakeUserStore struct {
 	users map[string]entities.User
 }
 
-type fakeBotStore struct{}
+// fakeBotStore and the other bot fakes embed their store interface, so
+// methods the signup flow does not call panic if they ever are.
+type fakeBotStore struct{ store.Bot }
 
 func (f *fakeBotStore) Create(_ context.Context, _ store.Tx, _ entities.Bot) error {
 	return nil
@@ -70,7 +75,7 @@ func (f *fakeBotStore) FindByID(_ context.Context, _ store.Tx, _ string) (entiti
 	return entities.Bot{}, nil
 }
 
-type fakeUserBotStore struct{}
+type fakeUserBotStore struct{ store.UserBot }
 
 func (f *fakeUserBotStore) Create(_ context.Context, _ store.Tx, _ entities.UserBot) error {
 	return nil
@@ -80,6 +85,22 @@ func (f *fakeUserBotStore) FindByUserID(_ context.Context, _ store.Tx, _ string)
 	return nil, nil
 }
 
+type fakeBotTemplateStore struct{ store.BotTemplate }
+
+type fakeMenuStore struct{ store.Menu }
+
+type fakeMenuItemStore struct{ store.MenuItem }
+
+type fakeMenuCategoryStore struct{ store.MenuCategory }
+
+type fakeMenuOptionGroupStore struct{ store.MenuOptionGroup }
+
+type fakeMenuBundleStore struct{ store.MenuBundle }
+
+type fakePromotionStore struct{ store.Promotion }
+
+type fakeTaxConfigStore struct{ store.TaxConfig }
+
 func (f *fakeUserStore) Create(_ context.Context, _ store.Tx, user entities.User) error {
 	if _, exists := f.users[user.Email]; exists {
 		return fmt.Errorf("fakeUserStore.Create: %w", store.ErrUserExists)
@@ -145,12 +166,20 @@ func TestServerDependencies(t *testing.T) {
 		},
 		func() *botsvc.Svc {
 			botInitCalls++
-			return botsvc.NewSvc(&sqldb.DB{}, nil, cfg, &fakeBotStore{}, &fakeUserBotStore{})
+			return botsvc.NewSvc(
+				&sqldb.DB{}, nil, nil, cfg,
+				&fakeBotStore{}, &fakeUserBotStore{}, &fakeBotTemplateStore{}, &fakeMenuStore{}, &fakeMenuItemStore{},
+				&fakeMenuCategoryStore{}, &fakeMenuOptionGroupStore{},
+				&fakeMenuBundleStore{}, &fakePromotionStore{}, &fakeTaxConfigStore{}, nil, nil, nil,
+			)
 		},
 		func() *ordersvc.Svc {
 			orderInitCalls++
 			return nil
 		},
+		func() *filesvc.Svc { return nil },
+		func() *stocksvc.Svc { return nil },
+		func() *promotionsvc.Svc { return nil },
 	)
 	server := NewServer(0, db, serviceContainer)
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"log/slog"
 	"net/http"
+	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util/errutil"
@@ -36,13 +37,13 @@ func createMenuHdlrFunc(s MenuServer) http.HandlerFunc {
 			WriteError(w, http.StatusBadRequest, ErrMsgInvalidRequestBody.Error())
 			return
 		}
-		menu, items, err := service.CreateMenu(r.Context(), req.BotID, modelFromMenReq(req))
+		detail, err := service.CreateMenu(r.Context(), req.BotID, "", entities.MenuDetail{Items: modelFromMenReq(req)})
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(w, err)
 			return
 		}
-		writeJSON(w, http.StatusCreated, menuResFromModel(menu, items))
+		writeJSON(w, http.StatusCreated, menuResFromModel(detail.Menu, detail.Items))
 	}
 }
 
@@ -50,13 +51,13 @@ func getMenuHdlrFunc(s MenuServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		service := s.MenuService()
 		botId := r.PathValue("botId")
-		menu, items, err := service.GetMenuMenuItems(r.Context(), botId)
+		detail, err := service.GetMenuMenuItems(r.Context(), botId, "")
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(w, err)
 			return
 		}
-		writeJSON(w, http.StatusOK, menuResFromModel(menu, items))
+		writeJSON(w, http.StatusOK, menuResFromModel(detail.Menu, detail.Items))
 	}
 }
 
@@ -71,13 +72,13 @@ func updateMenuHdlrFunc(s MenuServer) http.HandlerFunc {
 			WriteError(w, http.StatusBadRequest, ErrMsgInvalidRequestBody.Error())
 			return
 		}
-		menu, items, err := service.UpdateMenu(r.Context(), req.BotID, modelFromMenReq(req))
+		detail, err := service.UpdateMenu(r.Context(), req.BotID, "", entities.MenuDetail{Items: modelFromMenReq(req)})
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(w, err)
 			return
 		}
-		writeJSON(w, http.StatusOK, menuResFromModel(menu, items))
+		writeJSON(w, http.StatusOK, menuResFromModel(detail.Menu, detail.Items))
 	}
 }
 
@@ -85,13 +86,13 @@ func publishMenuHdlrFunc(s MenuServer) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		service := s.MenuService()
 		botId := r.PathValue("botId")
-		menu, items, err := service.PublishMenu(r.Context(), botId)
+		details, _, err := service.PublishMenus(r.Context(), botId, false)
 		if err != nil {
 			slog.Error(errutil.FormatErrChain(err))
 			writeMenuError(w, err)
 			return
 		}
-		writeJSON(w, http.StatusOK, menuResFromModel(menu, items))
+		writeJSON(w, http.StatusOK, menuResFromModel(details[0].Menu, details[0].Items))
 	}
 }
 
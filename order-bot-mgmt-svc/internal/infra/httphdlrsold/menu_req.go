@@ -1,10 +1,13 @@
 package httphdlrsold
 
 import (
+	"math"
 	"order-bot-mgmt-svc/internal/models/entities"
-	"order-bot-mgmt-svc/internal/util"
 )
 
+// legacyPriceScale is the fixed scale this legacy handler assumes for prices.
+const legacyPriceScale = 2
+
 type menuReq struct {
 	BotID string        `json:"bot_id" validate:"required"`
 	Items []menuItemReq `json:"items"`
@@ -30,9 +33,8 @@ func modelFromMenReq(req menuReq) []entities.MenuItem {
 	items := make([]entities.MenuItem, 0, len(req.Items))
 	for _, item := range req.Items {
 		items = append(items, entities.MenuItem{
-			ID:           util.NewID(),
 			MenuItemName: item.Name,
-			Price:        item.Price,
+			PriceScaled:  int64(math.Round(item.Price * math.Pow10(legacyPriceScale))),
 		})
 	}
 	return items
@@ -44,7 +46,7 @@ func menuResFromModel(menu entities.Menu, items []entities.MenuItem) menuRes {
 		resItems = append(resItems, menuItemRes{
 			ID:    item.ID,
 			Name:  item.MenuItemName,
-			Price: item.Price,
+			Price: float64(item.PriceScaled) / math.Pow10(legacyPriceScale),
 		})
 	}
 	return menuRes{
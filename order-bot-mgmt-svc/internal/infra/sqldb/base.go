@@ -1,6 +1,9 @@
 package sqldb
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 )
 
@@ -8,3 +11,64 @@ type BaseRecord struct {
 	CreatedAt time.Time `gorm:"autoCreateTime"`
 	UpdatedAt time.Time `gorm:"autoUpdateTime"`
 }
+
+// NullableString maps the empty string to NULL for optional foreign keys.
+func NullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// NonNilSlice maps nil to an empty slice, which is stored as [] rather than
+// NULL in JSON columns.
+func NonNilSlice[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// JSON stores V in a jsonb column. Values that encode to null are stored as
+// NULL.
+type JSON[T any] struct{ V T }
+
+func (j JSON[T]) Value() (driver.Value, error) {
+	data, err := json.Marshal(j.V)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.JSON.Value: %w", err)
+	}
+	if string(data) == "null" {
+		return nil, nil
+	}
+	return string(data), nil
+}
+
+// Scan leaves V at its zero value for NULL.
+func (j *JSON[T]) Scan(src any) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		var zero T
+		j.V = zero
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("sqldb.JSON.Scan(), unsupported type %T", src)
+	}
+	if err := json.Unmarshal(data, &j.V); err != nil {
+		return fmt.Errorf("sqldb.JSON.Scan: %w", err)
+	}
+	return nil
+}
+
+// NullableTime maps the zero time to NULL.
+func NullableTime(t time.Time) *time.Time {
+	if t.IsZero() {
+		return nil
+	}
+	return &t
+}
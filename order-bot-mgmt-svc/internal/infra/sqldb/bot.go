@@ -8,20 +8,77 @@ import (
 	"order-bot-mgmt-svc/internal/store"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type BotRecord struct {
-	Base    BaseRecord `gorm:"embedded"`
-	ID      string     `gorm:"column:id;primaryKey"`
-	BotName string     `gorm:"column:bot_name"`
+	Base                BaseRecord `gorm:"embedded"`
+	ID                  string     `gorm:"column:id;primaryKey"`
+	BotName             string     `gorm:"column:bot_name"`
+	DisplayName         string     `gorm:"column:display_name"`
+	Description         string     `gorm:"column:description"`
+	LogoURL             string     `gorm:"column:logo_url"`
+	LogoThumbnailURL    string     `gorm:"column:logo_thumbnail_url"`
+	ThemePrimaryColor   string     `gorm:"column:theme_primary_color"`
+	ThemeSecondaryColor string     `gorm:"column:theme_secondary_color"`
+	GreetingMessage     string     `gorm:"column:greeting_message"`
+	ContactPhone        string     `gorm:"column:contact_phone"`
+	ContactEmail        string     `gorm:"column:contact_email"`
+	ContactAddress      string     `gorm:"column:contact_address"`
+	Currency            string     `gorm:"column:currency"`
+	PriceScale          int        `gorm:"column:price_scale"`
+	Timezone            string     `gorm:"column:timezone"`
+	DefaultLocale       string     `gorm:"column:default_locale"`
+	// Locales is a jsonb array that starts with DefaultLocale.
+	Locales JSON[[]string] `gorm:"column:locales;type:jsonb"`
 }
 
 func (BotRecord) TableName() string { return "bot" }
 
 func BotRecordFromModel(bot entities.Bot) BotRecord {
-	return BotRecord{ID: bot.ID, BotName: bot.BotName}
+	return BotRecord{
+		ID:                  bot.ID,
+		BotName:             bot.BotName,
+		DisplayName:         bot.Profile.DisplayName,
+		Description:         bot.Profile.Description,
+		LogoURL:             bot.Profile.LogoURL,
+		LogoThumbnailURL:    bot.Profile.LogoThumbnailURL,
+		ThemePrimaryColor:   bot.Profile.ThemePrimaryColor,
+		ThemeSecondaryColor: bot.Profile.ThemeSecondaryColor,
+		GreetingMessage:     bot.Profile.GreetingMessage,
+		ContactPhone:        bot.Profile.ContactPhone,
+		ContactEmail:        bot.Profile.ContactEmail,
+		ContactAddress:      bot.Profile.ContactAddress,
+		Currency:            bot.Currency,
+		PriceScale:          bot.PriceScale,
+		Timezone:            bot.Timezone,
+		DefaultLocale:       bot.DefaultLocale,
+		Locales:             JSON[[]string]{V: NonNilSlice(bot.Locales)},
+	}
+}
+func (r BotRecord) ToModel() entities.Bot {
+	return entities.Bot{
+		ID:      r.ID,
+		BotName: r.BotName,
+		Profile: entities.BotProfile{
+			DisplayName:         r.DisplayName,
+			Description:         r.Description,
+			LogoURL:             r.LogoURL,
+			LogoThumbnailURL:    r.LogoThumbnailURL,
+			ThemePrimaryColor:   r.ThemePrimaryColor,
+			ThemeSecondaryColor: r.ThemeSecondaryColor,
+			GreetingMessage:     r.GreetingMessage,
+			ContactPhone:        r.ContactPhone,
+			ContactEmail:        r.ContactEmail,
+			ContactAddress:      r.ContactAddress,
+		},
+		Currency:      r.Currency,
+		PriceScale:    r.PriceScale,
+		Timezone:      r.Timezone,
+		DefaultLocale: r.DefaultLocale,
+		Locales:       r.Locales.V,
+	}
 }
-func (r BotRecord) ToModel() entities.Bot { return entities.Bot{ID: r.ID, BotName: r.BotName} }
 
 type BotStore struct{ db *gorm.DB }
 
@@ -58,3 +115,96 @@ func (s *BotStore) FindByID(ctx context.Context, tx store.Tx, id string) (entiti
 	}
 	return record.ToModel(), nil
 }
+
+func (s *BotStore) FindByIDForUpdate(ctx context.Context, tx store.Tx, id string) (entities.Bot, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("sqldb.BotStore.FindByIDForUpdate: %w", err)
+	}
+	var record BotRecord
+	err = db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).Where("id = ?", id).Take(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Bot{}, fmt.Errorf("sqldb.BotStore.FindByIDForUpdate: %w", store.ErrBotNotFound)
+		}
+		return entities.Bot{}, fmt.Errorf("sqldb.BotStore.FindByIDForUpdate: %w", err)
+	}
+	return record.ToModel(), nil
+}
+
+func (s *BotStore) UpdateProfile(ctx context.Context, tx store.Tx, id string, profile entities.BotProfile) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateProfile: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&BotRecord{}).Where("id = ?", id).Updates(map[string]any{
+		"display_name":          profile.DisplayName,
+		"description":           profile.Description,
+		"logo_url":              profile.LogoURL,
+		"logo_thumbnail_url":    profile.LogoThumbnailURL,
+		"theme_primary_color":   profile.ThemePrimaryColor,
+		"theme_secondary_color": profile.ThemeSecondaryColor,
+		"greeting_message":      profile.GreetingMessage,
+		"contact_phone":         profile.ContactPhone,
+		"contact_email":         profile.ContactEmail,
+		"contact_address":       profile.ContactAddress,
+	})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateProfile: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.BotStore.UpdateProfile: %w", store.ErrBotNotFound)
+	}
+	return nil
+}
+
+func (s *BotStore) UpdateCurrency(ctx context.Context, tx store.Tx, id string, currency string, priceScale int) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateCurrency: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&BotRecord{}).Where("id = ?", id).Updates(map[string]any{
+		"currency":    currency,
+		"price_scale": priceScale,
+	})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateCurrency: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.BotStore.UpdateCurrency: %w", store.ErrBotNotFound)
+	}
+	return nil
+}
+
+func (s *BotStore) UpdateTimezone(ctx context.Context, tx store.Tx, id string, timezone string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateTimezone: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&BotRecord{}).Where("id = ?", id).Update("timezone", timezone)
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateTimezone: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.BotStore.UpdateTimezone: %w", store.ErrBotNotFound)
+	}
+	return nil
+}
+
+func (s *BotStore) UpdateLocales(ctx context.Context, tx store.Tx, id string, defaultLocale string, locales []string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateLocales: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&BotRecord{}).Where("id = ?", id).Updates(map[string]any{
+		"default_locale": defaultLocale,
+		"locales":        JSON[[]string]{V: NonNilSlice(locales)},
+	})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.BotStore.UpdateLocales: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.BotStore.UpdateLocales: %w", store.ErrBotNotFound)
+	}
+	return nil
+}
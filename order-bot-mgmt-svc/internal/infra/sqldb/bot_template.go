@@ -0,0 +1,80 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+)
+
+type BotTemplateRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	ID           string     `gorm:"column:id;primaryKey"`
+	UserID       string     `gorm:"column:user_id"`
+	BotID        string     `gorm:"column:bot_id"`
+	TemplateName string     `gorm:"column:template_name"`
+}
+
+func (BotTemplateRecord) TableName() string { return "bot_template" }
+
+func BotTemplateRecordFromModel(template entities.BotTemplate) BotTemplateRecord {
+	return BotTemplateRecord{ID: template.ID, UserID: template.UserID, BotID: template.BotID, TemplateName: template.TemplateName}
+}
+func (r BotTemplateRecord) ToModel() entities.BotTemplate {
+	return entities.BotTemplate{ID: r.ID, UserID: r.UserID, BotID: r.BotID, TemplateName: r.TemplateName}
+}
+
+type BotTemplateStore struct{ db *gorm.DB }
+
+func NewBotTemplateStore(db *DB) *BotTemplateStore {
+	if db == nil {
+		panic("sqldb.NewBotTemplateStore(), the db ptr is nil")
+	}
+	return &BotTemplateStore{db: db.Gorm()}
+}
+
+func (s *BotTemplateStore) Create(ctx context.Context, tx store.Tx, template entities.BotTemplate) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.BotTemplateStore.Create: %w", err)
+	}
+	record := BotTemplateRecordFromModel(template)
+	if err := db.WithContext(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("sqldb.BotTemplateStore.Create: %w", err)
+	}
+	return nil
+}
+
+func (s *BotTemplateStore) FindByID(ctx context.Context, tx store.Tx, id string) (entities.BotTemplate, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("sqldb.BotTemplateStore.FindByID: %w", err)
+	}
+	var record BotTemplateRecord
+	if err := db.WithContext(ctx).Where("id = ?", id).First(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.BotTemplate{}, fmt.Errorf("sqldb.BotTemplateStore.FindByID: %w", store.ErrBotTemplateNotFound)
+		}
+		return entities.BotTemplate{}, fmt.Errorf("sqldb.BotTemplateStore.FindByID: %w", err)
+	}
+	return record.ToModel(), nil
+}
+
+func (s *BotTemplateStore) FindByUserID(ctx context.Context, tx store.Tx, userID string) ([]entities.BotTemplate, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.BotTemplateStore.FindByUserID: %w", err)
+	}
+	var records []BotTemplateRecord
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.BotTemplateStore.FindByUserID: %w", err)
+	}
+	results := make([]entities.BotTemplate, 0, len(records))
+	for _, record := range records {
+		results = append(results, record.ToModel())
+	}
+	return results, nil
+}
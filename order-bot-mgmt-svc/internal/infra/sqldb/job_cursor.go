@@ -0,0 +1,59 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/store"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type JobCursorRecord struct {
+	Base     BaseRecord `gorm:"embedded"`
+	Name     string     `gorm:"column:name;primaryKey"`
+	Position time.Time  `gorm:"column:position"`
+}
+
+func (JobCursorRecord) TableName() string { return "job_cursor" }
+
+type JobCursorStore struct{ db *gorm.DB }
+
+func NewJobCursorStore(db *DB) *JobCursorStore {
+	if db == nil {
+		panic("sqldb.NewJobCursorStore(), the db ptr is nil")
+	}
+	return &JobCursorStore{db: db.Gorm()}
+}
+
+func (s *JobCursorStore) Get(ctx context.Context, tx store.Tx, name string) (time.Time, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("sqldb.JobCursorStore.Get: %w", err)
+	}
+	var record JobCursorRecord
+	if err := db.WithContext(ctx).Where("name = ?", name).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return time.Time{}, nil
+		}
+		return time.Time{}, fmt.Errorf("sqldb.JobCursorStore.Get: %w", err)
+	}
+	return record.Position, nil
+}
+func (s *JobCursorStore) Set(ctx context.Context, tx store.Tx, name string, position time.Time) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.JobCursorStore.Set: %w", err)
+	}
+	record := JobCursorRecord{Name: name, Position: position}
+	err = db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "name"}},
+		DoUpdates: clause.AssignmentColumns([]string{"position", "updated_at"}),
+	}).Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("sqldb.JobCursorStore.Set: %w", err)
+	}
+	return nil
+}
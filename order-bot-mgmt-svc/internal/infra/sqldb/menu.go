@@ -11,17 +11,36 @@ import (
 )
 
 type MenuRecord struct {
-	Base  BaseRecord `gorm:"embedded"`
-	ID    string     `gorm:"column:id;primaryKey"`
-	BotID string     `gorm:"column:bot_id"`
+	Base           BaseRecord `gorm:"embedded"`
+	ID             string     `gorm:"column:id;primaryKey"`
+	BotID          string     `gorm:"column:bot_id"`
+	MenuName       string     `gorm:"column:menu_name"`
+	AvailableFrom  int        `gorm:"column:available_from"`
+	AvailableUntil int        `gorm:"column:available_until"`
+	Version        int        `gorm:"column:version"`
 }
 
 func (MenuRecord) TableName() string { return "menu" }
 
 func MenuRecordFromModel(menu entities.Menu) MenuRecord {
-	return MenuRecord{ID: menu.ID, BotID: menu.BotID}
+	return MenuRecord{
+		ID:             menu.ID,
+		BotID:          menu.BotID,
+		MenuName:       menu.MenuName,
+		AvailableFrom:  menu.Window.From,
+		AvailableUntil: menu.Window.Until,
+		Version:        menu.Version,
+	}
+}
+func (r MenuRecord) ToModel() entities.Menu {
+	return entities.Menu{
+		ID:       r.ID,
+		BotID:    r.BotID,
+		MenuName: r.MenuName,
+		Window:   entities.MenuWindow{From: r.AvailableFrom, Until: r.AvailableUntil},
+		Version:  r.Version,
+	}
 }
-func (r MenuRecord) ToModel() entities.Menu { return entities.Menu{ID: r.ID, BotID: r.BotID} }
 
 type MenuStore struct{ db *gorm.DB }
 
@@ -32,15 +51,42 @@ func NewMenuStore(db *DB) *MenuStore {
 	return &MenuStore{db: db.Gorm()}
 }
 
-func (s *MenuStore) FindByBotID(ctx context.Context, botID string) (entities.Menu, error) {
-	var record MenuRecord
-	if err := s.db.WithContext(ctx).Where("bot_id = ?", botID).First(&record).Error; err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return entities.Menu{}, fmt.Errorf("sqldb.MenuStore.FindByBotID: %w", store.ErrMenuNotFound)
-		}
+func (s *MenuStore) FindByBotID(ctx context.Context, tx store.Tx, botID string, menuID string) (entities.Menu, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.Menu{}, fmt.Errorf("sqldb.MenuStore.FindByBotID: %w", err)
+	}
+	query := db.WithContext(ctx).Where("bot_id = ?", botID)
+	if menuID != "" {
+		query = query.Where("id = ?", menuID)
+	}
+	var records []MenuRecord
+	if err := query.Order("created_at").Order("id").Limit(2).Find(&records).Error; err != nil {
 		return entities.Menu{}, fmt.Errorf("sqldb.MenuStore.FindByBotID: %w", err)
 	}
-	return record.ToModel(), nil
+	switch len(records) {
+	case 0:
+		return entities.Menu{}, fmt.Errorf("sqldb.MenuStore.FindByBotID: %w", store.ErrMenuNotFound)
+	case 1:
+		return records[0].ToModel(), nil
+	default:
+		return entities.Menu{}, fmt.Errorf("sqldb.MenuStore.FindByBotID: %w", store.ErrMenuAmbiguous)
+	}
+}
+func (s *MenuStore) ListByBotID(ctx context.Context, tx store.Tx, botID string) ([]entities.Menu, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuStore.ListByBotID: %w", err)
+	}
+	var records []MenuRecord
+	if err := db.WithContext(ctx).Where("bot_id = ?", botID).Order("created_at").Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuStore.ListByBotID: %w", err)
+	}
+	menus := make([]entities.Menu, 0, len(records))
+	for _, record := range records {
+		menus = append(menus, record.ToModel())
+	}
+	return menus, nil
 }
 func (s *MenuStore) CreateMenu(ctx context.Context, tx store.Tx, menu entities.Menu) error {
 	db, err := resolveDB(s.db, tx)
@@ -58,7 +104,11 @@ func (s *MenuStore) UpdateMenu(ctx context.Context, tx store.Tx, menu entities.M
 	if err != nil {
 		return fmt.Errorf("sqldb.MenuStore.UpdateMenu: %w", err)
 	}
-	res := db.WithContext(ctx).Model(&MenuRecord{}).Where("id = ?", menu.ID).Update("bot_id", menu.BotID)
+	res := db.WithContext(ctx).Model(&MenuRecord{}).Where("id = ?", menu.ID).Updates(map[string]any{
+		"menu_name":       menu.MenuName,
+		"available_from":  menu.Window.From,
+		"available_until": menu.Window.Until,
+	})
 	if res.Error != nil {
 		return fmt.Errorf("sqldb.MenuStore.UpdateMenu: %w", res.Error)
 	}
@@ -67,6 +117,36 @@ func (s *MenuStore) UpdateMenu(ctx context.Context, tx store.Tx, menu entities.M
 	}
 	return nil
 }
+
+// BumpVersion moves the menu from version to the next one. The update is
+// conditional, so of two edits based on the same version only the first
+// commits and the other fails with a store.MenuChangedErr carrying the
+// version the menu is at.
+func (s *MenuStore) BumpVersion(ctx context.Context, tx store.Tx, menuID string, version int) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuStore.BumpVersion: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuRecord{}).
+		Where("id = ? and version = ?", menuID, version).
+		Update("version", gorm.Expr("version + 1"))
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuStore.BumpVersion: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		var record MenuRecord
+		if err := db.WithContext(ctx).Select("version").Where("id = ?", menuID).Take(&record).Error; err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("sqldb.MenuStore.BumpVersion: %w", store.ErrMenuNotFound)
+			}
+			return fmt.Errorf("sqldb.MenuStore.BumpVersion: %w", err)
+		}
+		return fmt.Errorf("sqldb.MenuStore.BumpVersion(), menu %s at version %d: %w",
+			menuID, version, store.MenuChangedErr{Version: record.Version})
+	}
+	return nil
+}
+
 func (s *MenuStore) DeleteMenu(ctx context.Context, tx store.Tx, menuID string) error {
 	db, err := resolveDB(s.db, tx)
 	if err != nil {
@@ -0,0 +1,231 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+)
+
+type MenuBundleRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	ID           string     `gorm:"column:id;primaryKey"`
+	MenuID       string     `gorm:"column:menu_id"`
+	BundleName   string     `gorm:"column:bundle_name"`
+	PriceScaled  int64      `gorm:"column:price_scaled"`
+	Description  string     `gorm:"column:description"`
+	SortPosition int        `gorm:"column:sort_position"`
+}
+
+func (MenuBundleRecord) TableName() string { return "menu_bundle" }
+
+func MenuBundleRecordFromModel(bundle entities.MenuBundle) MenuBundleRecord {
+	return MenuBundleRecord{
+		ID:           bundle.ID,
+		MenuID:       bundle.MenuID,
+		BundleName:   bundle.BundleName,
+		PriceScaled:  bundle.PriceScaled,
+		Description:  bundle.Description,
+		SortPosition: bundle.SortPosition,
+	}
+}
+func (r MenuBundleRecord) ToModel() entities.MenuBundle {
+	return entities.MenuBundle{
+		ID:           r.ID,
+		MenuID:       r.MenuID,
+		BundleName:   r.BundleName,
+		PriceScaled:  r.PriceScaled,
+		Description:  r.Description,
+		SortPosition: r.SortPosition,
+	}
+}
+
+type MenuBundleSlotRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	ID           string     `gorm:"column:id;primaryKey"`
+	BundleID     string     `gorm:"column:bundle_id"`
+	SlotName     string     `gorm:"column:slot_name"`
+	SortPosition int        `gorm:"column:sort_position"`
+}
+
+func (MenuBundleSlotRecord) TableName() string { return "menu_bundle_slot" }
+
+func MenuBundleSlotRecordFromModel(slot entities.MenuBundleSlot) MenuBundleSlotRecord {
+	return MenuBundleSlotRecord{
+		ID:           slot.ID,
+		BundleID:     slot.BundleID,
+		SlotName:     slot.SlotName,
+		SortPosition: slot.SortPosition,
+	}
+}
+func (r MenuBundleSlotRecord) ToModel() entities.MenuBundleSlot {
+	return entities.MenuBundleSlot{
+		ID:           r.ID,
+		BundleID:     r.BundleID,
+		SlotName:     r.SlotName,
+		SortPosition: r.SortPosition,
+	}
+}
+
+type MenuBundleChoiceRecord struct {
+	Base           BaseRecord `gorm:"embedded"`
+	SlotID         string     `gorm:"column:slot_id;primaryKey"`
+	MenuItemID     string     `gorm:"column:menu_item_id;primaryKey"`
+	UpchargeScaled int64      `gorm:"column:upcharge_scaled"`
+	SortPosition   int        `gorm:"column:sort_position"`
+}
+
+func (MenuBundleChoiceRecord) TableName() string { return "menu_bundle_choice" }
+
+func MenuBundleChoiceRecordFromModel(choice entities.MenuBundleChoice) MenuBundleChoiceRecord {
+	return MenuBundleChoiceRecord{
+		SlotID:         choice.SlotID,
+		MenuItemID:     choice.MenuItemID,
+		UpchargeScaled: choice.UpchargeScaled,
+		SortPosition:   choice.SortPosition,
+	}
+}
+func (r MenuBundleChoiceRecord) ToModel() entities.MenuBundleChoice {
+	return entities.MenuBundleChoice{
+		SlotID:         r.SlotID,
+		MenuItemID:     r.MenuItemID,
+		UpchargeScaled: r.UpchargeScaled,
+		SortPosition:   r.SortPosition,
+	}
+}
+
+type MenuBundleStore struct{ db *gorm.DB }
+
+func NewMenuBundleStore(db *DB) *MenuBundleStore {
+	if db == nil {
+		panic("sqldb.NewMenuBundleStore(), the db ptr is nil")
+	}
+	return &MenuBundleStore{db: db.Gorm()}
+}
+
+func (s *MenuBundleStore) FindByMenuID(ctx context.Context, tx store.Tx, menuID string) ([]entities.MenuBundle, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuBundleStore.FindByMenuID: %w", err)
+	}
+	var bundleRecords []MenuBundleRecord
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&bundleRecords).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuBundleStore.FindByMenuID: %w", err)
+	}
+	if len(bundleRecords) == 0 {
+		return []entities.MenuBundle{}, nil
+	}
+	bundleIDs := make([]string, 0, len(bundleRecords))
+	for _, record := range bundleRecords {
+		bundleIDs = append(bundleIDs, record.ID)
+	}
+	var slotRecords []MenuBundleSlotRecord
+	if err := db.WithContext(ctx).Where("bundle_id IN ?", bundleIDs).Order("sort_position").Order("id").Find(&slotRecords).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuBundleStore.FindByMenuID(), slots: %w", err)
+	}
+	slotIDs := make([]string, 0, len(slotRecords))
+	for _, record := range slotRecords {
+		slotIDs = append(slotIDs, record.ID)
+	}
+	var choiceRecords []MenuBundleChoiceRecord
+	if len(slotIDs) > 0 {
+		if err := db.WithContext(ctx).Where("slot_id IN ?", slotIDs).Order("sort_position").Find(&choiceRecords).Error; err != nil {
+			return nil, fmt.Errorf("sqldb.MenuBundleStore.FindByMenuID(), choices: %w", err)
+		}
+	}
+	choices := make(map[string][]entities.MenuBundleChoice, len(slotRecords))
+	for _, record := range choiceRecords {
+		choices[record.SlotID] = append(choices[record.SlotID], record.ToModel())
+	}
+	slots := make(map[string][]entities.MenuBundleSlot, len(bundleRecords))
+	for _, record := range slotRecords {
+		slot := record.ToModel()
+		slot.Choices = choices[slot.ID]
+		slots[slot.BundleID] = append(slots[slot.BundleID], slot)
+	}
+	bundles := make([]entities.MenuBundle, 0, len(bundleRecords))
+	for _, record := range bundleRecords {
+		bundle := record.ToModel()
+		bundle.Slots = slots[bundle.ID]
+		bundles = append(bundles, bundle)
+	}
+	return bundles, nil
+}
+func (s *MenuBundleStore) CreateBundles(ctx context.Context, tx store.Tx, bundles []entities.MenuBundle) error {
+	if len(bundles) == 0 {
+		return nil
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.CreateBundles: %w", err)
+	}
+	bundleRecords := make([]MenuBundleRecord, 0, len(bundles))
+	var slotRecords []MenuBundleSlotRecord
+	var choiceRecords []MenuBundleChoiceRecord
+	for _, bundle := range bundles {
+		bundleRecords = append(bundleRecords, MenuBundleRecordFromModel(bundle))
+		for _, slot := range bundle.Slots {
+			slotRecords = append(slotRecords, MenuBundleSlotRecordFromModel(slot))
+			for _, choice := range slot.Choices {
+				choiceRecords = append(choiceRecords, MenuBundleChoiceRecordFromModel(choice))
+			}
+		}
+	}
+	if err := db.WithContext(ctx).Create(&bundleRecords).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.CreateBundles: %w", err)
+	}
+	if len(slotRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&slotRecords).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuBundleStore.CreateBundles(), slots: %w", err)
+		}
+	}
+	if len(choiceRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&choiceRecords).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuBundleStore.CreateBundles(), choices: %w", err)
+		}
+	}
+	return nil
+}
+func (s *MenuBundleStore) DeleteByMenuID(ctx context.Context, tx store.Tx, menuID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.DeleteByMenuID: %w", err)
+	}
+	bundleIDs := db.WithContext(ctx).Model(&MenuBundleRecord{}).Select("id").Where("menu_id = ?", menuID)
+	slotIDs := db.WithContext(ctx).Model(&MenuBundleSlotRecord{}).Select("id").Where("bundle_id IN (?)", bundleIDs)
+	if err := db.WithContext(ctx).Where("slot_id IN (?)", slotIDs).Delete(&MenuBundleChoiceRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.DeleteByMenuID(), choices: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("bundle_id IN (?)", bundleIDs).Delete(&MenuBundleSlotRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.DeleteByMenuID(), slots: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Delete(&MenuBundleRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.DeleteByMenuID: %w", err)
+	}
+	return nil
+}
+func (s *MenuBundleStore) UpdatePrices(ctx context.Context, tx store.Tx, bundles []entities.MenuBundle) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuBundleStore.UpdatePrices: %w", err)
+	}
+	for _, bundle := range bundles {
+		err := db.WithContext(ctx).Model(&MenuBundleRecord{}).Where("id = ?", bundle.ID).Update("price_scaled", bundle.PriceScaled).Error
+		if err != nil {
+			return fmt.Errorf("sqldb.MenuBundleStore.UpdatePrices(), bundle %s: %w", bundle.ID, err)
+		}
+		for _, slot := range bundle.Slots {
+			for _, choice := range slot.Choices {
+				err := db.WithContext(ctx).Model(&MenuBundleChoiceRecord{}).
+					Where("slot_id = ? AND menu_item_id = ?", slot.ID, choice.MenuItemID).
+					Update("upcharge_scaled", choice.UpchargeScaled).Error
+				if err != nil {
+					return fmt.Errorf("sqldb.MenuBundleStore.UpdatePrices(), choice of slot %s: %w", slot.ID, err)
+				}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+)
+
+type MenuCategoryRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	ID           string     `gorm:"column:id;primaryKey"`
+	MenuID       string     `gorm:"column:menu_id"`
+	CategoryName string     `gorm:"column:category_name"`
+	SortPosition int        `gorm:"column:sort_position"`
+	// Translations is a jsonb object keyed by locale.
+	Translations JSON[map[string]TranslationRecord] `gorm:"column:translations;type:jsonb"`
+}
+
+func (MenuCategoryRecord) TableName() string { return "menu_category" }
+
+func MenuCategoryRecordFromModel(category entities.MenuCategory) MenuCategoryRecord {
+	return MenuCategoryRecord{
+		ID:           category.ID,
+		MenuID:       category.MenuID,
+		CategoryName: category.CategoryName,
+		SortPosition: category.SortPosition,
+		Translations: TranslationRecordsFromModel(category.Translations),
+	}
+}
+func (r MenuCategoryRecord) ToModel() entities.MenuCategory {
+	return entities.MenuCategory{
+		ID:           r.ID,
+		MenuID:       r.MenuID,
+		CategoryName: r.CategoryName,
+		SortPosition: r.SortPosition,
+		Translations: TranslationsToModel(r.Translations),
+	}
+}
+
+type MenuCategoryStore struct{ db *gorm.DB }
+
+func NewMenuCategoryStore(db *DB) *MenuCategoryStore {
+	if db == nil {
+		panic("sqldb.NewMenuCategoryStore(), the db ptr is nil")
+	}
+	return &MenuCategoryStore{db: db.Gorm()}
+}
+
+func (s *MenuCategoryStore) FindByMenuID(ctx context.Context, tx store.Tx, menuID string) ([]entities.MenuCategory, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuCategoryStore.FindByMenuID: %w", err)
+	}
+	var records []MenuCategoryRecord
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuCategoryStore.FindByMenuID: %w", err)
+	}
+	categories := make([]entities.MenuCategory, 0, len(records))
+	for _, record := range records {
+		categories = append(categories, record.ToModel())
+	}
+	return categories, nil
+}
+func (s *MenuCategoryStore) CreateCategories(ctx context.Context, tx store.Tx, categories []entities.MenuCategory) error {
+	if len(categories) == 0 {
+		return nil
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.CreateCategories: %w", err)
+	}
+	records := make([]MenuCategoryRecord, 0, len(categories))
+	for _, category := range categories {
+		records = append(records, MenuCategoryRecordFromModel(category))
+	}
+	if err := db.WithContext(ctx).Create(&records).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.CreateCategories: %w", err)
+	}
+	return nil
+}
+func (s *MenuCategoryStore) DeleteByMenuID(ctx context.Context, tx store.Tx, menuID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.DeleteByMenuID: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Delete(&MenuCategoryRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.DeleteByMenuID: %w", err)
+	}
+	return nil
+}
+func (s *MenuCategoryStore) UpdateSortPositions(ctx context.Context, tx store.Tx, menuID string, ids []string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.UpdateSortPositions: %w", err)
+	}
+	for pos, id := range ids {
+		res := db.WithContext(ctx).Model(&MenuCategoryRecord{}).
+			Where("menu_id = ? AND id = ?", menuID, id).
+			Update("sort_position", pos)
+		if res.Error != nil {
+			return fmt.Errorf("sqldb.MenuCategoryStore.UpdateSortPositions: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("sqldb.MenuCategoryStore.UpdateSortPositions(), id %s: %w", id, store.ErrNotFound)
+		}
+	}
+	return nil
+}
+
+func (s *MenuCategoryStore) UpdateTranslations(
+	ctx context.Context,
+	tx store.Tx,
+	menuID string,
+	id string,
+	translations entities.Translations,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.UpdateTranslations: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuCategoryRecord{}).
+		Where("menu_id = ? AND id = ?", menuID, id).
+		Update("translations", TranslationRecordsFromModel(translations))
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuCategoryStore.UpdateTranslations: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.MenuCategoryStore.UpdateTranslations(), id %s: %w", id, store.ErrNotFound)
+	}
+	return nil
+}
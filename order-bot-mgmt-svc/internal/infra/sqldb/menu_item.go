@@ -2,11 +2,14 @@ package sqldb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/store"
+	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type MenuItemRecord struct {
@@ -14,16 +17,145 @@ type MenuItemRecord struct {
 	ID           string     `gorm:"column:id;primaryKey"`
 	MenuID       string     `gorm:"column:menu_id"`
 	MenuItemName string     `gorm:"column:menu_item_name"`
-	Price        float64    `gorm:"column:price"`
+	PriceScaled  int64      `gorm:"column:price_scaled"`
+	CategoryID   *string    `gorm:"column:category_id"`
+	SortPosition int        `gorm:"column:sort_position"`
+	SoldOut      bool       `gorm:"column:sold_out"`
+	RestoreAt    *time.Time `gorm:"column:restore_at"`
+	TrackStock   bool       `gorm:"column:track_stock"`
+	Stock        int        `gorm:"column:stock"`
+	ImageURL     string     `gorm:"column:image_url"`
+	ThumbnailURL string     `gorm:"column:thumbnail_url"`
+	Description  string     `gorm:"column:description"`
+	// Allergens and DietaryTags are jsonb arrays; Nutrition is null when
+	// unknown.
+	Allergens   JSON[[]entities.Allergen]   `gorm:"column:allergens;type:jsonb"`
+	DietaryTags JSON[[]entities.DietaryTag] `gorm:"column:dietary_tags;type:jsonb"`
+	SpicyLevel  int                         `gorm:"column:spicy_level"`
+	Nutrition   JSON[*NutritionRecord]      `gorm:"column:nutrition;type:jsonb"`
+	// Translations is a jsonb object keyed by locale.
+	Translations JSON[map[string]TranslationRecord] `gorm:"column:translations;type:jsonb"`
+}
+
+// NutritionRecord is the jsonb form of entities.Nutrition, also read by
+// order-bot-svc from published items.
+type NutritionRecord struct {
+	Calories      int     `json:"calories"`
+	ProteinG      float64 `json:"protein_g"`
+	CarbohydrateG float64 `json:"carbohydrate_g"`
+	FatG          float64 `json:"fat_g"`
+	SugarG        float64 `json:"sugar_g"`
+	SaltG         float64 `json:"salt_g"`
+}
+
+// NutritionRecordFromModel maps nil to nil.
+func NutritionRecordFromModel(n *entities.Nutrition) *NutritionRecord {
+	if n == nil {
+		return nil
+	}
+	return &NutritionRecord{
+		Calories:      n.Calories,
+		ProteinG:      n.ProteinG,
+		CarbohydrateG: n.CarbohydrateG,
+		FatG:          n.FatG,
+		SugarG:        n.SugarG,
+		SaltG:         n.SaltG,
+	}
+}
+
+func (r *NutritionRecord) ToModel() *entities.Nutrition {
+	if r == nil {
+		return nil
+	}
+	return &entities.Nutrition{
+		Calories:      r.Calories,
+		ProteinG:      r.ProteinG,
+		CarbohydrateG: r.CarbohydrateG,
+		FatG:          r.FatG,
+		SugarG:        r.SugarG,
+		SaltG:         r.SaltG,
+	}
 }
 
 func (MenuItemRecord) TableName() string { return "menu_item" }
 
 func MenuItemRecordFromModel(item entities.MenuItem) MenuItemRecord {
-	return MenuItemRecord{ID: item.ID, MenuID: item.MenuID, MenuItemName: item.MenuItemName, Price: item.Price}
+	return MenuItemRecord{
+		ID:           item.ID,
+		MenuID:       item.MenuID,
+		MenuItemName: item.MenuItemName,
+		PriceScaled:  item.PriceScaled,
+		CategoryID:   NullableString(item.CategoryID),
+		SortPosition: item.SortPosition,
+		SoldOut:      item.SoldOut,
+		RestoreAt:    NullableTime(item.RestoreAt),
+		TrackStock:   item.TrackStock,
+		Stock:        item.Stock,
+		ImageURL:     item.Image.URL,
+		ThumbnailURL: item.Image.ThumbnailURL,
+		Description:  item.Description,
+		Allergens:    JSON[[]entities.Allergen]{V: NonNilSlice(item.Allergens)},
+		DietaryTags:  JSON[[]entities.DietaryTag]{V: NonNilSlice(item.DietaryTags)},
+		SpicyLevel:   item.SpicyLevel,
+		Nutrition:    JSON[*NutritionRecord]{V: NutritionRecordFromModel(item.Nutrition)},
+		Translations: TranslationRecordsFromModel(item.Translations),
+	}
 }
 func (r MenuItemRecord) ToModel() entities.MenuItem {
-	return entities.MenuItem{ID: r.ID, MenuID: r.MenuID, MenuItemName: r.MenuItemName, Price: r.Price}
+	item := entities.MenuItem{
+		ID:           r.ID,
+		MenuID:       r.MenuID,
+		MenuItemName: r.MenuItemName,
+		PriceScaled:  r.PriceScaled,
+		SortPosition: r.SortPosition,
+		SoldOut:      r.SoldOut,
+		TrackStock:   r.TrackStock,
+		Stock:        r.Stock,
+		Image:        entities.Image{URL: r.ImageURL, ThumbnailURL: r.ThumbnailURL},
+		Description:  r.Description,
+		Allergens:    r.Allergens.V,
+		DietaryTags:  r.DietaryTags.V,
+		SpicyLevel:   r.SpicyLevel,
+		Nutrition:    r.Nutrition.V.ToModel(),
+		Translations: TranslationsToModel(r.Translations),
+	}
+	if r.CategoryID != nil {
+		item.CategoryID = *r.CategoryID
+	}
+	if r.RestoreAt != nil {
+		item.RestoreAt = *r.RestoreAt
+	}
+	return item
+}
+
+// MenuItemOptionGroupRecord links an item to an option group.
+type MenuItemOptionGroupRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	MenuItemID   string     `gorm:"column:menu_item_id;primaryKey"`
+	GroupID      string     `gorm:"column:group_id;primaryKey"`
+	SortPosition int        `gorm:"column:sort_position"`
+}
+
+func (MenuItemOptionGroupRecord) TableName() string { return "menu_item_option_group" }
+
+// MenuItemAliasRecord is another name the bot matches an item by.
+type MenuItemAliasRecord struct {
+	Base         BaseRecord `gorm:"embedded"`
+	MenuItemID   string     `gorm:"column:menu_item_id;primaryKey"`
+	Alias        string     `gorm:"column:alias;primaryKey"`
+	SortPosition int        `gorm:"column:sort_position"`
+}
+
+func (MenuItemAliasRecord) TableName() string { return "menu_item_alias" }
+
+func menuItemAliasRecords(items []entities.MenuItem) []MenuItemAliasRecord {
+	var records []MenuItemAliasRecord
+	for _, item := range items {
+		for pos, alias := range item.Aliases {
+			records = append(records, MenuItemAliasRecord{MenuItemID: item.ID, Alias: alias, SortPosition: pos})
+		}
+	}
+	return records
 }
 
 type MenuItemStore struct{ db *gorm.DB }
@@ -35,14 +167,77 @@ func NewMenuItemStore(db *DB) *MenuItemStore {
 	return &MenuItemStore{db: db.Gorm()}
 }
 
-func (s *MenuItemStore) FindItems(ctx context.Context, menuID string) ([]entities.MenuItem, error) {
+// FindItems locks the items until commit inside a transaction, so writers
+// that skip the menu version, such as restores and stock changes, wait for
+// the edit that read them.
+func (s *MenuItemStore) FindItems(ctx context.Context, tx store.Tx, menuID string) ([]entities.MenuItem, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuItemStore.FindItems: %w", err)
+	}
+	qry := db.WithContext(ctx)
+	if tx != nil {
+		qry = qry.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
 	var records []MenuItemRecord
-	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("id").Find(&records).Error; err != nil {
+	if err := qry.Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuItemStore.FindItems: %w", err)
+	}
+	if len(records) == 0 {
+		return []entities.MenuItem{}, nil
+	}
+	items, err := itemsWithLinks(db.WithContext(ctx), records)
+	if err != nil {
 		return nil, fmt.Errorf("sqldb.MenuItemStore.FindItems: %w", err)
 	}
+	return items, nil
+}
+
+// FindItem returns the item with its option groups and aliases.
+func (s *MenuItemStore) FindItem(ctx context.Context, menuID string, id string) (entities.MenuItem, error) {
+	var record MenuItemRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ? AND id = ?", menuID, id).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItem: %w", store.ErrMenuItemNotFound)
+		}
+		return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItem: %w", err)
+	}
+	items, err := itemsWithLinks(s.db.WithContext(ctx), []MenuItemRecord{record})
+	if err != nil {
+		return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItem: %w", err)
+	}
+	return items[0], nil
+}
+
+// itemsWithLinks maps records to items with their option group links and
+// aliases.
+func itemsWithLinks(db *gorm.DB, records []MenuItemRecord) ([]entities.MenuItem, error) {
+	itemIDs := make([]string, 0, len(records))
+	for _, record := range records {
+		itemIDs = append(itemIDs, record.ID)
+	}
+	var links []MenuItemOptionGroupRecord
+	if err := db.Where("menu_item_id IN ?", itemIDs).Order("sort_position").Find(&links).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.itemsWithLinks(), option groups: %w", err)
+	}
+	groupIDs := make(map[string][]string, len(records))
+	for _, link := range links {
+		groupIDs[link.MenuItemID] = append(groupIDs[link.MenuItemID], link.GroupID)
+	}
+	var aliasRecords []MenuItemAliasRecord
+	if err := db.Where("menu_item_id IN ?", itemIDs).Order("sort_position").Find(&aliasRecords).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.itemsWithLinks(), aliases: %w", err)
+	}
+	aliases := make(map[string][]string, len(records))
+	for _, record := range aliasRecords {
+		aliases[record.MenuItemID] = append(aliases[record.MenuItemID], record.Alias)
+	}
 	items := make([]entities.MenuItem, 0, len(records))
 	for _, record := range records {
-		items = append(items, record.ToModel())
+		item := record.ToModel()
+		item.OptionGroupIDs = groupIDs[item.ID]
+		item.Aliases = aliases[item.ID]
+		items = append(items, item)
 	}
 	return items, nil
 }
@@ -51,22 +246,355 @@ func (s *MenuItemStore) DeleteMenuItems(ctx context.Context, tx store.Tx, menuID
 	if err != nil {
 		return fmt.Errorf("sqldb.MenuItemStore.DeleteMenuItems: %w", err)
 	}
+	itemIDs := db.WithContext(ctx).Model(&MenuItemRecord{}).Select("id").Where("menu_id = ?", menuID)
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteMenuItems(), option groups: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteMenuItems(), aliases: %w", err)
+	}
 	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Delete(&MenuItemRecord{}).Error; err != nil {
 		return fmt.Errorf("sqldb.MenuItemStore.DeleteMenuItems: %w", err)
 	}
 	return nil
 }
 func (s *MenuItemStore) CreateMenuItems(ctx context.Context, tx store.Tx, items []entities.MenuItem) error {
+	if len(items) == 0 {
+		return nil
+	}
 	db, err := resolveDB(s.db, tx)
 	if err != nil {
 		return fmt.Errorf("sqldb.MenuItemStore.CreateMenuItems: %w", err)
 	}
 	records := make([]MenuItemRecord, 0, len(items))
+	var links []MenuItemOptionGroupRecord
 	for _, item := range items {
 		records = append(records, MenuItemRecordFromModel(item))
+		for pos, groupID := range item.OptionGroupIDs {
+			links = append(links, MenuItemOptionGroupRecord{MenuItemID: item.ID, GroupID: groupID, SortPosition: pos})
+		}
 	}
 	if err := db.WithContext(ctx).Create(&records).Error; err != nil {
 		return fmt.Errorf("sqldb.MenuItemStore.CreateMenuItems: %w", err)
 	}
+	if len(links) > 0 {
+		if err := db.WithContext(ctx).Create(&links).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.CreateMenuItems(), option groups: %w", err)
+		}
+	}
+	if aliases := menuItemAliasRecords(items); len(aliases) > 0 {
+		if err := db.WithContext(ctx).Create(&aliases).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.CreateMenuItems(), aliases: %w", err)
+		}
+	}
+	return nil
+}
+func (s *MenuItemStore) DetachMenuItems(ctx context.Context, tx store.Tx, menuID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DetachMenuItems: %w", err)
+	}
+	itemIDs := db.WithContext(ctx).Model(&MenuItemRecord{}).Select("id").Where("menu_id = ?", menuID)
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DetachMenuItems(), option groups: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DetachMenuItems(), aliases: %w", err)
+	}
+	err = db.WithContext(ctx).Model(&MenuItemRecord{}).
+		Where("menu_id = ? AND category_id IS NOT NULL", menuID).
+		Update("category_id", nil).Error
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DetachMenuItems: %w", err)
+	}
+	return nil
+}
+func (s *MenuItemStore) UpdateMenuItems(ctx context.Context, tx store.Tx, items []entities.MenuItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateMenuItems: %w", err)
+	}
+	var links []MenuItemOptionGroupRecord
+	for _, item := range items {
+		if err := updateItemRow(db.WithContext(ctx), item); err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateMenuItems: %w", err)
+		}
+		for pos, groupID := range item.OptionGroupIDs {
+			links = append(links, MenuItemOptionGroupRecord{MenuItemID: item.ID, GroupID: groupID, SortPosition: pos})
+		}
+	}
+	if len(links) > 0 {
+		if err := db.WithContext(ctx).Create(&links).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateMenuItems(), option groups: %w", err)
+		}
+	}
+	if aliases := menuItemAliasRecords(items); len(aliases) > 0 {
+		if err := db.WithContext(ctx).Create(&aliases).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateMenuItems(), aliases: %w", err)
+		}
+	}
+	return nil
+}
+
+// updateItemRow writes the item's own columns, without its links.
+func updateItemRow(db *gorm.DB, item entities.MenuItem) error {
+	record := MenuItemRecordFromModel(item)
+	res := db.Model(&MenuItemRecord{}).
+		Where("menu_id = ? AND id = ?", item.MenuID, item.ID).
+		Updates(map[string]any{
+			"menu_item_name": record.MenuItemName,
+			"price_scaled":   record.PriceScaled,
+			"category_id":    record.CategoryID,
+			"sort_position":  record.SortPosition,
+			"description":    record.Description,
+			"allergens":      record.Allergens,
+			"dietary_tags":   record.DietaryTags,
+			"spicy_level":    record.SpicyLevel,
+			"nutrition":      record.Nutrition,
+			"translations":   record.Translations,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.updateItemRow: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.updateItemRow(), id %s: %w", item.ID, store.ErrMenuItemNotFound)
+	}
+	return nil
+}
+
+func (s *MenuItemStore) CreateItem(ctx context.Context, tx store.Tx, item entities.MenuItem) error {
+	if err := s.CreateMenuItems(ctx, tx, []entities.MenuItem{item}); err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.CreateItem: %w", err)
+	}
+	return nil
+}
+
+func (s *MenuItemStore) UpdateItem(ctx context.Context, tx store.Tx, item entities.MenuItem) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateItem: %w", err)
+	}
+	if err := updateItemRow(db.WithContext(ctx), item); err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateItem: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id = ?", item.ID).Delete(&MenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateItem(), option groups: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id = ?", item.ID).Delete(&MenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateItem(), aliases: %w", err)
+	}
+	links := make([]MenuItemOptionGroupRecord, 0, len(item.OptionGroupIDs))
+	for pos, groupID := range item.OptionGroupIDs {
+		links = append(links, MenuItemOptionGroupRecord{MenuItemID: item.ID, GroupID: groupID, SortPosition: pos})
+	}
+	if len(links) > 0 {
+		if err := db.WithContext(ctx).Create(&links).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateItem(), option groups: %w", err)
+		}
+	}
+	if aliases := menuItemAliasRecords([]entities.MenuItem{item}); len(aliases) > 0 {
+		if err := db.WithContext(ctx).Create(&aliases).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateItem(), aliases: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *MenuItemStore) DeleteItem(ctx context.Context, tx store.Tx, menuID string, id string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItem: %w", err)
+	}
+	itemIDs := db.WithContext(ctx).Model(&MenuItemRecord{}).Select("id").Where("menu_id = ? AND id = ?", menuID, id)
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItem(), option groups: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItem(), aliases: %w", err)
+	}
+	res := db.WithContext(ctx).Where("menu_id = ? AND id = ?", menuID, id).Delete(&MenuItemRecord{})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItem: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItem(), id %s: %w", id, store.ErrMenuItemNotFound)
+	}
+	return nil
+}
+func (s *MenuItemStore) DeleteItems(ctx context.Context, tx store.Tx, menuID string, ids []string) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItems: %w", err)
+	}
+	itemIDs := db.WithContext(ctx).Model(&MenuItemRecord{}).Select("id").Where("menu_id = ? AND id IN ?", menuID, ids)
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItems(), option groups: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&MenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItems(), aliases: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id = ? AND id IN ?", menuID, ids).Delete(&MenuItemRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.DeleteItems: %w", err)
+	}
+	return nil
+}
+func (s *MenuItemStore) UpdateSortPositions(ctx context.Context, tx store.Tx, menuID string, ids []string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateSortPositions: %w", err)
+	}
+	for pos, id := range ids {
+		res := db.WithContext(ctx).Model(&MenuItemRecord{}).
+			Where("menu_id = ? AND id = ?", menuID, id).
+			Update("sort_position", pos)
+		if res.Error != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateSortPositions: %w", res.Error)
+		}
+		if res.RowsAffected == 0 {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdateSortPositions(), id %s: %w", id, store.ErrNotFound)
+		}
+	}
+	return nil
+}
+func (s *MenuItemStore) UpdateAvailability(
+	ctx context.Context,
+	tx store.Tx,
+	menuID string,
+	ids []string,
+	soldOut bool,
+	restoreAt time.Time,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateAvailability: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuItemRecord{}).
+		Where("menu_id = ? AND id IN ?", menuID, ids).
+		Updates(map[string]any{"sold_out": soldOut, "restore_at": NullableTime(restoreAt)})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateAvailability: %w", res.Error)
+	}
+	if res.RowsAffected != int64(len(ids)) {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateAvailability(), %d of %d items updated: %w", res.RowsAffected, len(ids), store.ErrNotFound)
+	}
+	return nil
+}
+
+// RestoreDue clears the sold-out flag of every item whose restore time has
+// passed and returns the restored items.
+func (s *MenuItemStore) RestoreDue(ctx context.Context, tx store.Tx, now time.Time) ([]entities.MenuItem, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuItemStore.RestoreDue: %w", err)
+	}
+	var records []MenuItemRecord
+	res := db.WithContext(ctx).Model(&records).
+		Clauses(clause.Returning{}).
+		Where("sold_out AND restore_at <= ?", now).
+		Updates(map[string]any{"sold_out": false, "restore_at": nil})
+	if res.Error != nil {
+		return nil, fmt.Errorf("sqldb.MenuItemStore.RestoreDue: %w", res.Error)
+	}
+	items := make([]entities.MenuItem, 0, len(records))
+	for _, record := range records {
+		items = append(items, record.ToModel())
+	}
+	return items, nil
+}
+
+// FindItemByID returns the item without its option groups. Inside a
+// transaction the row is locked until commit.
+func (s *MenuItemStore) FindItemByID(ctx context.Context, tx store.Tx, id string) (entities.MenuItem, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItemByID: %w", err)
+	}
+	qry := db.WithContext(ctx)
+	if tx != nil {
+		qry = qry.Clauses(clause.Locking{Strength: "UPDATE"})
+	}
+	var record MenuItemRecord
+	if err := qry.Where("id = ?", id).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItemByID: %w", store.ErrMenuItemNotFound)
+		}
+		return entities.MenuItem{}, fmt.Errorf("sqldb.MenuItemStore.FindItemByID: %w", err)
+	}
+	return record.ToModel(), nil
+}
+
+func (s *MenuItemStore) UpdateImage(ctx context.Context, tx store.Tx, menuID string, id string, image entities.Image) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateImage: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuItemRecord{}).
+		Where("menu_id = ? AND id = ?", menuID, id).
+		Updates(map[string]any{"image_url": image.URL, "thumbnail_url": image.ThumbnailURL})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateImage: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateImage: %w", store.ErrMenuItemNotFound)
+	}
+	return nil
+}
+
+func (s *MenuItemStore) UpdateTranslations(
+	ctx context.Context,
+	tx store.Tx,
+	menuID string,
+	id string,
+	translations entities.Translations,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateTranslations: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuItemRecord{}).
+		Where("menu_id = ? AND id = ?", menuID, id).
+		Update("translations", TranslationRecordsFromModel(translations))
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateTranslations: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateTranslations: %w", store.ErrMenuItemNotFound)
+	}
+	return nil
+}
+
+func (s *MenuItemStore) UpdateStock(ctx context.Context, tx store.Tx, id string, trackStock bool, stock int) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateStock: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&MenuItemRecord{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"track_stock": trackStock, "stock": stock})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateStock: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdateStock: %w", store.ErrMenuItemNotFound)
+	}
+	return nil
+}
+func (s *MenuItemStore) UpdatePrices(ctx context.Context, tx store.Tx, items []entities.MenuItem) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuItemStore.UpdatePrices: %w", err)
+	}
+	for _, item := range items {
+		err := db.WithContext(ctx).Model(&MenuItemRecord{}).Where("id = ?", item.ID).Update("price_scaled", item.PriceScaled).Error
+		if err != nil {
+			return fmt.Errorf("sqldb.MenuItemStore.UpdatePrices(), item %s: %w", item.ID, err)
+		}
+	}
 	return nil
 }
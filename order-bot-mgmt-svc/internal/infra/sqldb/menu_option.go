@@ -0,0 +1,176 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+)
+
+type MenuOptionGroupRecord struct {
+	Base          BaseRecord `gorm:"embedded"`
+	ID            string     `gorm:"column:id;primaryKey"`
+	MenuID        string     `gorm:"column:menu_id"`
+	GroupName     string     `gorm:"column:group_name"`
+	SelectionType string     `gorm:"column:selection_type"`
+	MinChoices    int        `gorm:"column:min_choices"`
+	MaxChoices    int        `gorm:"column:max_choices"`
+	Required      bool       `gorm:"column:required"`
+	SortPosition  int        `gorm:"column:sort_position"`
+}
+
+func (MenuOptionGroupRecord) TableName() string { return "menu_option_group" }
+
+func MenuOptionGroupRecordFromModel(group entities.MenuOptionGroup) MenuOptionGroupRecord {
+	return MenuOptionGroupRecord{
+		ID:            group.ID,
+		MenuID:        group.MenuID,
+		GroupName:     group.GroupName,
+		SelectionType: string(group.SelectionType),
+		MinChoices:    group.MinChoices,
+		MaxChoices:    group.MaxChoices,
+		Required:      group.Required,
+		SortPosition:  group.SortPosition,
+	}
+}
+func (r MenuOptionGroupRecord) ToModel() entities.MenuOptionGroup {
+	return entities.MenuOptionGroup{
+		ID:            r.ID,
+		MenuID:        r.MenuID,
+		GroupName:     r.GroupName,
+		SelectionType: entities.OptionSelection(r.SelectionType),
+		MinChoices:    r.MinChoices,
+		MaxChoices:    r.MaxChoices,
+		Required:      r.Required,
+		SortPosition:  r.SortPosition,
+	}
+}
+
+type MenuOptionRecord struct {
+	Base             BaseRecord `gorm:"embedded"`
+	ID               string     `gorm:"column:id;primaryKey"`
+	GroupID          string     `gorm:"column:group_id"`
+	OptionName       string     `gorm:"column:option_name"`
+	PriceDeltaScaled int64      `gorm:"column:price_delta_scaled"`
+	SortPosition     int        `gorm:"column:sort_position"`
+}
+
+func (MenuOptionRecord) TableName() string { return "menu_option" }
+
+func MenuOptionRecordFromModel(option entities.MenuOption) MenuOptionRecord {
+	return MenuOptionRecord{
+		ID:               option.ID,
+		GroupID:          option.GroupID,
+		OptionName:       option.OptionName,
+		PriceDeltaScaled: option.PriceDeltaScaled,
+		SortPosition:     option.SortPosition,
+	}
+}
+func (r MenuOptionRecord) ToModel() entities.MenuOption {
+	return entities.MenuOption{
+		ID:               r.ID,
+		GroupID:          r.GroupID,
+		OptionName:       r.OptionName,
+		PriceDeltaScaled: r.PriceDeltaScaled,
+		SortPosition:     r.SortPosition,
+	}
+}
+
+type MenuOptionGroupStore struct{ db *gorm.DB }
+
+func NewMenuOptionGroupStore(db *DB) *MenuOptionGroupStore {
+	if db == nil {
+		panic("sqldb.NewMenuOptionGroupStore(), the db ptr is nil")
+	}
+	return &MenuOptionGroupStore{db: db.Gorm()}
+}
+
+func (s *MenuOptionGroupStore) FindByMenuID(ctx context.Context, tx store.Tx, menuID string) ([]entities.MenuOptionGroup, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuOptionGroupStore.FindByMenuID: %w", err)
+	}
+	var groupRecords []MenuOptionGroupRecord
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&groupRecords).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuOptionGroupStore.FindByMenuID: %w", err)
+	}
+	if len(groupRecords) == 0 {
+		return []entities.MenuOptionGroup{}, nil
+	}
+	groupIDs := make([]string, 0, len(groupRecords))
+	for _, record := range groupRecords {
+		groupIDs = append(groupIDs, record.ID)
+	}
+	var optionRecords []MenuOptionRecord
+	if err := db.WithContext(ctx).Where("group_id IN ?", groupIDs).Order("sort_position").Order("id").Find(&optionRecords).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.MenuOptionGroupStore.FindByMenuID(), options: %w", err)
+	}
+	options := make(map[string][]entities.MenuOption, len(groupRecords))
+	for _, record := range optionRecords {
+		options[record.GroupID] = append(options[record.GroupID], record.ToModel())
+	}
+	groups := make([]entities.MenuOptionGroup, 0, len(groupRecords))
+	for _, record := range groupRecords {
+		group := record.ToModel()
+		group.Options = options[group.ID]
+		groups = append(groups, group)
+	}
+	return groups, nil
+}
+func (s *MenuOptionGroupStore) CreateOptionGroups(ctx context.Context, tx store.Tx, groups []entities.MenuOptionGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.CreateOptionGroups: %w", err)
+	}
+	groupRecords := make([]MenuOptionGroupRecord, 0, len(groups))
+	var optionRecords []MenuOptionRecord
+	for _, group := range groups {
+		groupRecords = append(groupRecords, MenuOptionGroupRecordFromModel(group))
+		for _, option := range group.Options {
+			optionRecords = append(optionRecords, MenuOptionRecordFromModel(option))
+		}
+	}
+	if err := db.WithContext(ctx).Create(&groupRecords).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.CreateOptionGroups: %w", err)
+	}
+	if len(optionRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&optionRecords).Error; err != nil {
+			return fmt.Errorf("sqldb.MenuOptionGroupStore.CreateOptionGroups(), options: %w", err)
+		}
+	}
+	return nil
+}
+func (s *MenuOptionGroupStore) DeleteByMenuID(ctx context.Context, tx store.Tx, menuID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.DeleteByMenuID: %w", err)
+	}
+	groupIDs := db.WithContext(ctx).Model(&MenuOptionGroupRecord{}).Select("id").Where("menu_id = ?", menuID)
+	if err := db.WithContext(ctx).Where("group_id IN (?)", groupIDs).Delete(&MenuOptionRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.DeleteByMenuID(), options: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Delete(&MenuOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.DeleteByMenuID: %w", err)
+	}
+	return nil
+}
+func (s *MenuOptionGroupStore) UpdatePriceDeltas(ctx context.Context, tx store.Tx, groups []entities.MenuOptionGroup) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuOptionGroupStore.UpdatePriceDeltas: %w", err)
+	}
+	for _, group := range groups {
+		for _, option := range group.Options {
+			err := db.WithContext(ctx).Model(&MenuOptionRecord{}).Where("id = ?", option.ID).Update("price_delta_scaled", option.PriceDeltaScaled).Error
+			if err != nil {
+				return fmt.Errorf("sqldb.MenuOptionGroupStore.UpdatePriceDeltas(), option %s: %w", option.ID, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package sqldb
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
+)
+
+// MenuSnapshot is the jsonb form of entities.MenuDetail kept with each menu
+// version. Its keys are fixed by the tags, so renaming an entity field does
+// not break saved versions.
+type MenuSnapshot struct {
+	Menu         MenuSnapshotMenu          `json:"menu"`
+	Categories   []MenuSnapshotCategory    `json:"categories"`
+	OptionGroups []MenuSnapshotOptionGroup `json:"option_groups"`
+	Items        []MenuSnapshotItem        `json:"items"`
+	Bundles      []MenuSnapshotBundle      `json:"bundles"`
+}
+
+type MenuSnapshotMenu struct {
+	ID             string `json:"id"`
+	BotID          string `json:"bot_id"`
+	MenuName       string `json:"menu_name"`
+	AvailableFrom  int    `json:"available_from"`
+	AvailableUntil int    `json:"available_until"`
+	Version        int    `json:"version"`
+}
+
+type MenuSnapshotCategory struct {
+	ID           string                       `json:"id"`
+	CategoryName string                       `json:"category_name"`
+	SortPosition int                          `json:"sort_position"`
+	Translations map[string]TranslationRecord `json:"translations,omitempty"`
+}
+
+type MenuSnapshotOptionGroup struct {
+	ID            string               `json:"id"`
+	GroupName     string               `json:"group_name"`
+	SelectionType string               `json:"selection_type"`
+	MinChoices    int                  `json:"min_choices"`
+	MaxChoices    int                  `json:"max_choices"`
+	Required      bool                 `json:"required"`
+	SortPosition  int                  `json:"sort_position"`
+	Options       []MenuSnapshotOption `json:"options"`
+}
+
+type MenuSnapshotOption struct {
+	ID               string `json:"id"`
+	OptionName       string `json:"option_name"`
+	PriceDeltaScaled int64  `json:"price_delta_scaled"`
+	SortPosition     int    `json:"sort_position"`
+}
+
+type MenuSnapshotItem struct {
+	ID                string                       `json:"id"`
+	MenuItemName      string                       `json:"menu_item_name"`
+	PriceScaled       int64                        `json:"price_scaled"`
+	CategoryID        string                       `json:"category_id,omitempty"`
+	SortPosition      int                          `json:"sort_position"`
+	OptionGroupIDs    []string                     `json:"option_group_ids,omitempty"`
+	Aliases           []string                     `json:"aliases,omitempty"`
+	SoldOut           bool                         `json:"sold_out"`
+	RestoreAt         *time.Time                   `json:"restore_at,omitempty"`
+	TrackStock        bool                         `json:"track_stock"`
+	Stock             int                          `json:"stock"`
+	ImageURL          string                       `json:"image_url,omitempty"`
+	ImageThumbnailURL string                       `json:"image_thumbnail_url,omitempty"`
+	Description       string                       `json:"description,omitempty"`
+	Allergens         []string                     `json:"allergens,omitempty"`
+	DietaryTags       []string                     `json:"dietary_tags,omitempty"`
+	SpicyLevel        int                          `json:"spicy_level"`
+	Nutrition         *NutritionRecord             `json:"nutrition,omitempty"`
+	Translations      map[string]TranslationRecord `json:"translations,omitempty"`
+}
+
+type MenuSnapshotBundle struct {
+	ID           string             `json:"id"`
+	BundleName   string             `json:"bundle_name"`
+	PriceScaled  int64              `json:"price_scaled"`
+	Description  string             `json:"description,omitempty"`
+	SortPosition int                `json:"sort_position"`
+	Slots        []MenuSnapshotSlot `json:"slots"`
+}
+
+type MenuSnapshotSlot struct {
+	ID           string               `json:"id"`
+	SlotName     string               `json:"slot_name"`
+	SortPosition int                  `json:"sort_position"`
+	Choices      []MenuSnapshotChoice `json:"choices"`
+}
+
+type MenuSnapshotChoice struct {
+	MenuItemID     string `json:"menu_item_id"`
+	UpchargeScaled int64  `json:"upcharge_scaled"`
+	SortPosition   int    `json:"sort_position"`
+}
+
+func MenuSnapshotFromModel(detail entities.MenuDetail) MenuSnapshot {
+	menu := detail.Menu
+	snapshot := MenuSnapshot{
+		Menu: MenuSnapshotMenu{
+			ID:             menu.ID,
+			BotID:          menu.BotID,
+			MenuName:       menu.MenuName,
+			AvailableFrom:  menu.Window.From,
+			AvailableUntil: menu.Window.Until,
+			Version:        menu.Version,
+		},
+		Categories:   make([]MenuSnapshotCategory, 0, len(detail.Categories)),
+		OptionGroups: make([]MenuSnapshotOptionGroup, 0, len(detail.OptionGroups)),
+		Items:        make([]MenuSnapshotItem, 0, len(detail.Items)),
+		Bundles:      make([]MenuSnapshotBundle, 0, len(detail.Bundles)),
+	}
+	for _, category := range detail.Categories {
+		snapshot.Categories = append(snapshot.Categories, MenuSnapshotCategory{
+			ID:           category.ID,
+			CategoryName: category.CategoryName,
+			SortPosition: category.SortPosition,
+			Translations: TranslationRecordsFromModel(category.Translations).V,
+		})
+	}
+	for _, group := range detail.OptionGroups {
+		options := make([]MenuSnapshotOption, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, MenuSnapshotOption{
+				ID:               option.ID,
+				OptionName:       option.OptionName,
+				PriceDeltaScaled: option.PriceDeltaScaled,
+				SortPosition:     option.SortPosition,
+			})
+		}
+		snapshot.OptionGroups = append(snapshot.OptionGroups, MenuSnapshotOptionGroup{
+			ID:            group.ID,
+			GroupName:     group.GroupName,
+			SelectionType: string(group.SelectionType),
+			MinChoices:    group.MinChoices,
+			MaxChoices:    group.MaxChoices,
+			Required:      group.Required,
+			SortPosition:  group.SortPosition,
+			Options:       options,
+		})
+	}
+	for _, item := range detail.Items {
+		allergens := make([]string, 0, len(item.Allergens))
+		for _, allergen := range item.Allergens {
+			allergens = append(allergens, string(allergen))
+		}
+		tags := make([]string, 0, len(item.DietaryTags))
+		for _, tag := range item.DietaryTags {
+			tags = append(tags, string(tag))
+		}
+		snapshot.Items = append(snapshot.Items, MenuSnapshotItem{
+			ID:                item.ID,
+			MenuItemName:      item.MenuItemName,
+			PriceScaled:       item.PriceScaled,
+			CategoryID:        item.CategoryID,
+			SortPosition:      item.SortPosition,
+			OptionGroupIDs:    item.OptionGroupIDs,
+			Aliases:           item.Aliases,
+			SoldOut:           item.SoldOut,
+			RestoreAt:         NullableTime(item.RestoreAt),
+			TrackStock:        item.TrackStock,
+			Stock:             item.Stock,
+			ImageURL:          item.Image.URL,
+			ImageThumbnailURL: item.Image.ThumbnailURL,
+			Description:       item.Description,
+			Allergens:         allergens,
+			DietaryTags:       tags,
+			SpicyLevel:        item.SpicyLevel,
+			Nutrition:         NutritionRecordFromModel(item.Nutrition),
+			Translations:      TranslationRecordsFromModel(item.Translations).V,
+		})
+	}
+	for _, bundle := range detail.Bundles {
+		slots := make([]MenuSnapshotSlot, 0, len(bundle.Slots))
+		for _, slot := range bundle.Slots {
+			choices := make([]MenuSnapshotChoice, 0, len(slot.Choices))
+			for _, choice := range slot.Choices {
+				choices = append(choices, MenuSnapshotChoice{
+					MenuItemID:     choice.MenuItemID,
+					UpchargeScaled: choice.UpchargeScaled,
+					SortPosition:   choice.SortPosition,
+				})
+			}
+			slots = append(slots, MenuSnapshotSlot{ID: slot.ID, SlotName: slot.SlotName, SortPosition: slot.SortPosition, Choices: choices})
+		}
+		snapshot.Bundles = append(snapshot.Bundles, MenuSnapshotBundle{
+			ID:           bundle.ID,
+			BundleName:   bundle.BundleName,
+			PriceScaled:  bundle.PriceScaled,
+			Description:  bundle.Description,
+			SortPosition: bundle.SortPosition,
+			Slots:        slots,
+		})
+	}
+	return snapshot
+}
+
+// ToModel fills in the menu, group, slot and bundle IDs that the nested
+// entities repeat.
+func (s MenuSnapshot) ToModel() entities.MenuDetail {
+	menuID := s.Menu.ID
+	detail := entities.MenuDetail{
+		Menu: entities.Menu{
+			ID:       menuID,
+			BotID:    s.Menu.BotID,
+			MenuName: s.Menu.MenuName,
+			Window:   entities.MenuWindow{From: s.Menu.AvailableFrom, Until: s.Menu.AvailableUntil},
+			Version:  s.Menu.Version,
+		},
+		Categories:   make([]entities.MenuCategory, 0, len(s.Categories)),
+		OptionGroups: make([]entities.MenuOptionGroup, 0, len(s.OptionGroups)),
+		Items:        make([]entities.MenuItem, 0, len(s.Items)),
+		Bundles:      make([]entities.MenuBundle, 0, len(s.Bundles)),
+	}
+	for _, category := range s.Categories {
+		detail.Categories = append(detail.Categories, entities.MenuCategory{
+			ID:           category.ID,
+			MenuID:       menuID,
+			CategoryName: category.CategoryName,
+			SortPosition: category.SortPosition,
+			Translations: TranslationsToModel(JSON[map[string]TranslationRecord]{V: category.Translations}),
+		})
+	}
+	for _, group := range s.OptionGroups {
+		options := make([]entities.MenuOption, 0, len(group.Options))
+		for _, option := range group.Options {
+			options = append(options, entities.MenuOption{
+				ID:               option.ID,
+				GroupID:          group.ID,
+				OptionName:       option.OptionName,
+				PriceDeltaScaled: option.PriceDeltaScaled,
+				SortPosition:     option.SortPosition,
+			})
+		}
+		detail.OptionGroups = append(detail.OptionGroups, entities.MenuOptionGroup{
+			ID:            group.ID,
+			MenuID:        menuID,
+			GroupName:     group.GroupName,
+			SelectionType: entities.OptionSelection(group.SelectionType),
+			MinChoices:    group.MinChoices,
+			MaxChoices:    group.MaxChoices,
+			Required:      group.Required,
+			SortPosition:  group.SortPosition,
+			Options:       options,
+		})
+	}
+	for _, item := range s.Items {
+		allergens := make([]entities.Allergen, 0, len(item.Allergens))
+		for _, allergen := range item.Allergens {
+			allergens = append(allergens, entities.Allergen(allergen))
+		}
+		tags := make([]entities.DietaryTag, 0, len(item.DietaryTags))
+		for _, tag := range item.DietaryTags {
+			tags = append(tags, entities.DietaryTag(tag))
+		}
+		model := entities.MenuItem{
+			ID:             item.ID,
+			MenuID:         menuID,
+			MenuItemName:   item.MenuItemName,
+			PriceScaled:    item.PriceScaled,
+			CategoryID:     item.CategoryID,
+			SortPosition:   item.SortPosition,
+			OptionGroupIDs: item.OptionGroupIDs,
+			Aliases:        item.Aliases,
+			SoldOut:        item.SoldOut,
+			TrackStock:     item.TrackStock,
+			Stock:          item.Stock,
+			Image:          entities.Image{URL: item.ImageURL, ThumbnailURL: item.ImageThumbnailURL},
+			Description:    item.Description,
+			Allergens:      allergens,
+			DietaryTags:    tags,
+			SpicyLevel:     item.SpicyLevel,
+			Nutrition:      item.Nutrition.ToModel(),
+			Translations:   TranslationsToModel(JSON[map[string]TranslationRecord]{V: item.Translations}),
+		}
+		if item.RestoreAt != nil {
+			model.RestoreAt = *item.RestoreAt
+		}
+		detail.Items = append(detail.Items, model)
+	}
+	for _, bundle := range s.Bundles {
+		slots := make([]entities.MenuBundleSlot, 0, len(bundle.Slots))
+		for _, slot := range bundle.Slots {
+			choices := make([]entities.MenuBundleChoice, 0, len(slot.Choices))
+			for _, choice := range slot.Choices {
+				choices = append(choices, entities.MenuBundleChoice{
+					SlotID:         slot.ID,
+					MenuItemID:     choice.MenuItemID,
+					UpchargeScaled: choice.UpchargeScaled,
+					SortPosition:   choice.SortPosition,
+				})
+			}
+			slots = append(slots, entities.MenuBundleSlot{
+				ID:           slot.ID,
+				BundleID:     bundle.ID,
+				SlotName:     slot.SlotName,
+				SortPosition: slot.SortPosition,
+				Choices:      choices,
+			})
+		}
+		detail.Bundles = append(detail.Bundles, entities.MenuBundle{
+			ID:           bundle.ID,
+			MenuID:       menuID,
+			BundleName:   bundle.BundleName,
+			PriceScaled:  bundle.PriceScaled,
+			Description:  bundle.Description,
+			SortPosition: bundle.SortPosition,
+			Slots:        slots,
+		})
+	}
+	return detail
+}
@@ -0,0 +1,151 @@
+package sqldb
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+)
+
+type MenuVersionRecord struct {
+	Base       BaseRecord `gorm:"embedded"`
+	ID         string     `gorm:"column:id;primaryKey"`
+	MenuID     string     `gorm:"column:menu_id"`
+	Version    int        `gorm:"column:version"`
+	AuthorID   *string    `gorm:"column:author_id"`
+	Note       string     `gorm:"column:note"`
+	PriceScale int        `gorm:"column:price_scale"`
+	ItemCount  int        `gorm:"column:item_count"`
+	// Snapshot is the JSON encoded MenuSnapshot.
+	Snapshot string `gorm:"column:snapshot;type:jsonb"`
+}
+
+func (MenuVersionRecord) TableName() string { return "menu_version" }
+
+func MenuVersionRecordFromModel(version entities.MenuVersion) (MenuVersionRecord, error) {
+	snapshot, err := json.Marshal(MenuSnapshotFromModel(version.Detail))
+	if err != nil {
+		return MenuVersionRecord{}, fmt.Errorf("sqldb.MenuVersionRecordFromModel(), encode snapshot: %w", err)
+	}
+	return MenuVersionRecord{
+		ID:         version.ID,
+		MenuID:     version.MenuID,
+		Version:    version.Version,
+		AuthorID:   NullableString(version.AuthorID),
+		Note:       version.Note,
+		PriceScale: version.PriceScale,
+		ItemCount:  len(version.Detail.Items),
+		Snapshot:   string(snapshot),
+	}, nil
+}
+
+// ToModel decodes the snapshot when it was loaded.
+func (r MenuVersionRecord) ToModel() (entities.MenuVersion, error) {
+	version := entities.MenuVersion{
+		ID:         r.ID,
+		MenuID:     r.MenuID,
+		Version:    r.Version,
+		Note:       r.Note,
+		PriceScale: r.PriceScale,
+		ItemCount:  r.ItemCount,
+		CreatedAt:  r.Base.CreatedAt,
+	}
+	if r.AuthorID != nil {
+		version.AuthorID = *r.AuthorID
+	}
+	if r.Snapshot != "" {
+		var snapshot MenuSnapshot
+		if err := json.Unmarshal([]byte(r.Snapshot), &snapshot); err != nil {
+			return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionRecord.ToModel(), decode snapshot: %w", err)
+		}
+		version.Detail = snapshot.ToModel()
+	}
+	return version, nil
+}
+
+type MenuVersionStore struct{ db *gorm.DB }
+
+func NewMenuVersionStore(db *DB) *MenuVersionStore {
+	if db == nil {
+		panic("sqldb.NewMenuVersionStore(), the db ptr is nil")
+	}
+	return &MenuVersionStore{db: db.Gorm()}
+}
+
+func (s *MenuVersionStore) Create(ctx context.Context, tx store.Tx, version entities.MenuVersion) (entities.MenuVersion, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.Create: %w", err)
+	}
+	var latest int
+	err = db.WithContext(ctx).Model(&MenuVersionRecord{}).
+		Where("menu_id = ?", version.MenuID).
+		Select("coalesce(max(version), 0)").
+		Scan(&latest).Error
+	if err != nil {
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.Create: %w", err)
+	}
+	version.Version = latest + 1
+	record, err := MenuVersionRecordFromModel(version)
+	if err != nil {
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.Create: %w", err)
+	}
+	if err := db.WithContext(ctx).Create(&record).Error; err != nil {
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.Create: %w", err)
+	}
+	version.ItemCount = record.ItemCount
+	version.CreatedAt = record.Base.CreatedAt
+	return version, nil
+}
+
+func (s *MenuVersionStore) FindByMenuID(ctx context.Context, menuID string) ([]entities.MenuVersion, error) {
+	var records []MenuVersionRecord
+	err := s.db.WithContext(ctx).
+		Select("id", "menu_id", "version", "author_id", "note", "price_scale", "item_count", "created_at", "updated_at").
+		Where("menu_id = ?", menuID).
+		Order("version desc").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.MenuVersionStore.FindByMenuID: %w", err)
+	}
+	versions := make([]entities.MenuVersion, 0, len(records))
+	for _, record := range records {
+		version, err := record.ToModel()
+		if err != nil {
+			return nil, fmt.Errorf("sqldb.MenuVersionStore.FindByMenuID: %w", err)
+		}
+		versions = append(versions, version)
+	}
+	return versions, nil
+}
+
+func (s *MenuVersionStore) FindByVersion(ctx context.Context, menuID string, version int) (entities.MenuVersion, error) {
+	var record MenuVersionRecord
+	err := s.db.WithContext(ctx).Where("menu_id = ? AND version = ?", menuID, version).Take(&record).Error
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.FindByVersion: %w", store.ErrMenuVersionNotFound)
+		}
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.FindByVersion: %w", err)
+	}
+	model, err := record.ToModel()
+	if err != nil {
+		return entities.MenuVersion{}, fmt.Errorf("sqldb.MenuVersionStore.FindByVersion: %w", err)
+	}
+	return model, nil
+}
+
+func (s *MenuVersionStore) DeleteByMenuID(ctx context.Context, tx store.Tx, menuID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.MenuVersionStore.DeleteByMenuID: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id = ?", menuID).Delete(&MenuVersionRecord{}).Error; err != nil {
+		return fmt.Errorf("sqldb.MenuVersionStore.DeleteByMenuID: %w", err)
+	}
+	return nil
+}
@@ -17,17 +17,48 @@ type OrderRecord struct {
 	CartID      string     `gorm:"column:cart_id"`
 	SessionID   string     `gorm:"column:session_id"`
 	TotalScaled int        `gorm:"column:total_scaled"`
+	// The tax breakdown is written by order-bot-svc when the order is placed.
+	TaxMode     string                     `gorm:"column:tax_mode"`
+	NetScaled   int64                      `gorm:"column:net_scaled"`
+	TaxScaled   int64                      `gorm:"column:tax_scaled"`
+	GrossScaled int64                      `gorm:"column:gross_scaled"`
+	TaxRates    JSON[[]OrderTaxRateRecord] `gorm:"column:tax_rates;type:jsonb"`
 }
 
 func (OrderRecord) TableName() string { return "orders" }
 
+// OrderTaxRateRecord is an element of orders.tax_rates. Rate is in
+// millionths.
+type OrderTaxRateRecord struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	Rate      int64  `json:"rate"`
+	NetScaled int64  `json:"net_scaled"`
+	TaxScaled int64  `json:"tax_scaled"`
+}
+
 func (r OrderRecord) ToModel() entities.Order {
+	rates := make([]entities.TaxRateTotal, 0, len(r.TaxRates.V))
+	for _, rate := range r.TaxRates.V {
+		rates = append(rates, entities.TaxRateTotal{
+			TaxRate:   entities.TaxRate{Code: rate.Code, RateName: rate.Name, Rate: rate.Rate},
+			NetScaled: rate.NetScaled,
+			TaxScaled: rate.TaxScaled,
+		})
+	}
 	return entities.Order{
 		ID:          r.ID,
 		BotID:       r.BotID,
 		CartID:      r.CartID,
 		SessionID:   r.SessionID,
 		TotalScaled: r.TotalScaled,
+		Tax: entities.TaxBreakdown{
+			Mode:        entities.TaxMode(r.TaxMode),
+			NetScaled:   r.NetScaled,
+			TaxScaled:   r.TaxScaled,
+			GrossScaled: r.GrossScaled,
+			Rates:       rates,
+		},
 	}
 }
 
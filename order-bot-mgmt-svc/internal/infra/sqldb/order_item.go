@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -17,6 +18,8 @@ type OrderItemRecord struct {
 	Quantity         int        `gorm:"column:quantity"`
 	UnitPriceScaled  int        `gorm:"column:unit_price_scaled"`
 	TotalPriceScaled int        `gorm:"column:total_price_scaled"`
+	TaxRateCode      string     `gorm:"column:tax_rate_code"`
+	TaxScaled        int64      `gorm:"column:tax_scaled"`
 }
 
 func (OrderItemRecord) TableName() string { return "order_item" }
@@ -30,6 +33,9 @@ func (r OrderItemRecord) ToModel() entities.OrderItem {
 		Quantity:         r.Quantity,
 		UnitPriceScaled:  r.UnitPriceScaled,
 		TotalPriceScaled: r.TotalPriceScaled,
+		TaxRateCode:      r.TaxRateCode,
+		TaxScaled:        r.TaxScaled,
+		CreatedAt:        r.Base.CreatedAt,
 	}
 }
 
@@ -56,3 +62,22 @@ func (s *OrderItemStore) FindByOrderIDs(ctx context.Context, orderIDs []string)
 	}
 	return items, nil
 }
+
+// FindCreatedSince pages on (created_at, id), so items sharing a created_at
+// never stall the caller however many there are.
+func (s *OrderItemStore) FindCreatedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]entities.OrderItem, error) {
+	var records []OrderItemRecord
+	err := s.db.WithContext(ctx).
+		Where("created_at > ? OR (created_at = ? AND id > ?)", since, since, afterID).
+		Order("created_at").Order("id").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.OrderItemStore.FindCreatedSince: %w", err)
+	}
+	items := make([]entities.OrderItem, 0, len(records))
+	for _, rec := range records {
+		items = append(items, rec.ToModel())
+	}
+	return items, nil
+}
@@ -0,0 +1,77 @@
+package orderbotmgmtsqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PublishedBotProfileRecord struct {
+	Base                sqldb.BaseRecord `gorm:"embedded"`
+	BotID               string           `gorm:"column:bot_id;primaryKey"`
+	DisplayName         string           `gorm:"column:display_name"`
+	Description         string           `gorm:"column:description"`
+	LogoURL             string           `gorm:"column:logo_url"`
+	LogoThumbnailURL    string           `gorm:"column:logo_thumbnail_url"`
+	ThemePrimaryColor   string           `gorm:"column:theme_primary_color"`
+	ThemeSecondaryColor string           `gorm:"column:theme_secondary_color"`
+	GreetingMessage     string           `gorm:"column:greeting_message"`
+	ContactPhone        string           `gorm:"column:contact_phone"`
+	ContactEmail        string           `gorm:"column:contact_email"`
+	ContactAddress      string           `gorm:"column:contact_address"`
+}
+
+func (PublishedBotProfileRecord) TableName() string { return "published_bot_profile" }
+
+type PublishedBotProfileStore struct{ db *gorm.DB }
+
+func NewPublishedBotProfileStore(db *sqldb.DB) *PublishedBotProfileStore {
+	if db == nil {
+		panic("orderbotmgmtsqldb.NewPublishedBotProfileStore(), the db ptr is nil")
+	}
+	return &PublishedBotProfileStore{db: db.Gorm()}
+}
+
+func (s *PublishedBotProfileStore) Upsert(ctx context.Context, tx store.Tx, botID string, profile entities.BotProfile) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedBotProfileStore.Upsert: %w", err)
+	}
+	record := PublishedBotProfileRecord{
+		BotID:               botID,
+		DisplayName:         profile.DisplayName,
+		Description:         profile.Description,
+		LogoURL:             profile.LogoURL,
+		LogoThumbnailURL:    profile.LogoThumbnailURL,
+		ThemePrimaryColor:   profile.ThemePrimaryColor,
+		ThemeSecondaryColor: profile.ThemeSecondaryColor,
+		GreetingMessage:     profile.GreetingMessage,
+		ContactPhone:        profile.ContactPhone,
+		ContactEmail:        profile.ContactEmail,
+		ContactAddress:      profile.ContactAddress,
+	}
+	err = db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bot_id"}},
+		UpdateAll: true,
+	}).Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedBotProfileStore.Upsert: %w", err)
+	}
+	return nil
+}
+
+func (s *PublishedBotProfileStore) Delete(ctx context.Context, tx store.Tx, botID string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedBotProfileStore.Delete: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("bot_id = ?", botID).Delete(&PublishedBotProfileRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedBotProfileStore.Delete: %w", err)
+	}
+	return nil
+}
@@ -7,26 +7,133 @@ import (
 	"order-bot-mgmt-svc/internal/infra/sqldb"
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/store"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type PublishedMenuRecord struct {
-	ID    string `gorm:"column:id;primaryKey"`
-	BotID string `gorm:"column:bot_id"`
+	ID             string `gorm:"column:id;primaryKey"`
+	BotID          string `gorm:"column:bot_id"`
+	MenuName       string `gorm:"column:menu_name"`
+	Currency       string `gorm:"column:currency"`
+	PriceScale     int    `gorm:"column:price_scale"`
+	Timezone       string `gorm:"column:timezone"`
+	AvailableFrom  int    `gorm:"column:available_from"`
+	AvailableUntil int    `gorm:"column:available_until"`
+	DefaultLocale  string `gorm:"column:default_locale"`
+	// Locales is a jsonb array that starts with DefaultLocale.
+	Locales sqldb.JSON[[]string] `gorm:"column:locales;type:jsonb"`
 }
 
 func (PublishedMenuRecord) TableName() string { return "published_menu" }
 
+type PublishedMenuCategoryRecord struct {
+	ID           string `gorm:"column:id;primaryKey"`
+	MenuID       string `gorm:"column:menu_id"`
+	CategoryName string `gorm:"column:category_name"`
+	SortPosition int    `gorm:"column:sort_position"`
+	// Translations is a jsonb object keyed by locale.
+	Translations sqldb.JSON[map[string]sqldb.TranslationRecord] `gorm:"column:translations;type:jsonb"`
+}
+
+func (PublishedMenuCategoryRecord) TableName() string { return "published_menu_category" }
+
 type PublishedMenuItemRecord struct {
-	ID           string  `gorm:"column:id;primaryKey"`
-	MenuID       string  `gorm:"column:menu_id"`
-	MenuItemName string  `gorm:"column:menu_item_name"`
-	Price        float64 `gorm:"column:price"`
+	ID           string                             `gorm:"column:id;primaryKey"`
+	MenuID       string                             `gorm:"column:menu_id"`
+	MenuItemName string                             `gorm:"column:menu_item_name"`
+	PriceScaled  int64                              `gorm:"column:price_scaled"`
+	CategoryID   *string                            `gorm:"column:category_id"`
+	SortPosition int                                `gorm:"column:sort_position"`
+	SoldOut      bool                               `gorm:"column:sold_out"`
+	RestoreAt    *time.Time                         `gorm:"column:restore_at"`
+	ImageURL     string                             `gorm:"column:image_url"`
+	ThumbnailURL string                             `gorm:"column:thumbnail_url"`
+	Description  string                             `gorm:"column:description"`
+	Allergens    sqldb.JSON[[]entities.Allergen]    `gorm:"column:allergens;type:jsonb"`
+	DietaryTags  sqldb.JSON[[]entities.DietaryTag]  `gorm:"column:dietary_tags;type:jsonb"`
+	SpicyLevel   int                                `gorm:"column:spicy_level"`
+	Nutrition    sqldb.JSON[*sqldb.NutritionRecord] `gorm:"column:nutrition;type:jsonb"`
+	// Translations is a jsonb object keyed by locale.
+	Translations sqldb.JSON[map[string]sqldb.TranslationRecord] `gorm:"column:translations;type:jsonb"`
 }
 
 func (PublishedMenuItemRecord) TableName() string { return "published_menu_item" }
 
+type PublishedMenuOptionGroupRecord struct {
+	ID            string `gorm:"column:id;primaryKey"`
+	MenuID        string `gorm:"column:menu_id"`
+	GroupName     string `gorm:"column:group_name"`
+	SelectionType string `gorm:"column:selection_type"`
+	MinChoices    int    `gorm:"column:min_choices"`
+	MaxChoices    int    `gorm:"column:max_choices"`
+	Required      bool   `gorm:"column:required"`
+	SortPosition  int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuOptionGroupRecord) TableName() string { return "published_menu_option_group" }
+
+type PublishedMenuOptionRecord struct {
+	ID               string `gorm:"column:id;primaryKey"`
+	GroupID          string `gorm:"column:group_id"`
+	OptionName       string `gorm:"column:option_name"`
+	PriceDeltaScaled int64  `gorm:"column:price_delta_scaled"`
+	SortPosition     int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuOptionRecord) TableName() string { return "published_menu_option" }
+
+type PublishedMenuItemOptionGroupRecord struct {
+	MenuItemID   string `gorm:"column:menu_item_id;primaryKey"`
+	GroupID      string `gorm:"column:group_id;primaryKey"`
+	SortPosition int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuItemOptionGroupRecord) TableName() string {
+	return "published_menu_item_option_group"
+}
+
+// PublishedMenuItemAliasRecord carries the menu ID so the order bot can load
+// every alias of a menu at once.
+type PublishedMenuItemAliasRecord struct {
+	MenuItemID   string `gorm:"column:menu_item_id;primaryKey"`
+	Alias        string `gorm:"column:alias;primaryKey"`
+	MenuID       string `gorm:"column:menu_id"`
+	SortPosition int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuItemAliasRecord) TableName() string { return "published_menu_item_alias" }
+
+type PublishedMenuBundleRecord struct {
+	ID           string `gorm:"column:id;primaryKey"`
+	MenuID       string `gorm:"column:menu_id"`
+	BundleName   string `gorm:"column:bundle_name"`
+	PriceScaled  int64  `gorm:"column:price_scaled"`
+	Description  string `gorm:"column:description"`
+	SortPosition int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuBundleRecord) TableName() string { return "published_menu_bundle" }
+
+type PublishedMenuBundleSlotRecord struct {
+	ID           string `gorm:"column:id;primaryKey"`
+	BundleID     string `gorm:"column:bundle_id"`
+	SlotName     string `gorm:"column:slot_name"`
+	SortPosition int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuBundleSlotRecord) TableName() string { return "published_menu_bundle_slot" }
+
+type PublishedMenuBundleChoiceRecord struct {
+	SlotID         string `gorm:"column:slot_id;primaryKey"`
+	MenuItemID     string `gorm:"column:menu_item_id;primaryKey"`
+	UpchargeScaled int64  `gorm:"column:upcharge_scaled"`
+	SortPosition   int    `gorm:"column:sort_position"`
+}
+
+func (PublishedMenuBundleChoiceRecord) TableName() string { return "published_menu_bundle_choice" }
+
 type PublishedMenuStore struct{ db *gorm.DB }
 
 func NewPublishedMenuStore(db *sqldb.DB) *PublishedMenuStore {
@@ -48,27 +155,444 @@ func (s *PublishedMenuStore) IsMenuPublished(ctx context.Context, menuID string)
 	return true, nil
 }
 
-func (s *PublishedMenuStore) ReplaceMenuItems(ctx context.Context, tx store.Tx, menu entities.Menu, items []entities.MenuItem) error {
+// FindPublishedMenu loads the published copy of a menu. It fails with
+// store.ErrMenuNotFound when the menu was never published.
+func (s *PublishedMenuStore) FindPublishedMenu(ctx context.Context, menuID string) (entities.PublishedMenu, error) {
+	var menuRecord PublishedMenuRecord
+	if err := s.db.WithContext(ctx).Where("id = ?", menuID).Take(&menuRecord).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu: %w", store.ErrMenuNotFound)
+		}
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu: %w", err)
+	}
+	published := entities.PublishedMenu{
+		Currency:      menuRecord.Currency,
+		PriceScale:    menuRecord.PriceScale,
+		Timezone:      menuRecord.Timezone,
+		DefaultLocale: menuRecord.DefaultLocale,
+		Locales:       menuRecord.Locales.V,
+		Detail: entities.MenuDetail{Menu: entities.Menu{
+			ID:       menuRecord.ID,
+			BotID:    menuRecord.BotID,
+			MenuName: menuRecord.MenuName,
+			Window:   entities.MenuWindow{From: menuRecord.AvailableFrom, Until: menuRecord.AvailableUntil},
+		}},
+	}
+	var categoryRecords []PublishedMenuCategoryRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&categoryRecords).Error; err != nil {
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_category: %w", err)
+	}
+	for _, record := range categoryRecords {
+		published.Detail.Categories = append(published.Detail.Categories, entities.MenuCategory{
+			ID:           record.ID,
+			MenuID:       record.MenuID,
+			CategoryName: record.CategoryName,
+			SortPosition: record.SortPosition,
+			Translations: sqldb.TranslationsToModel(record.Translations),
+		})
+	}
+	var groupRecords []PublishedMenuOptionGroupRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&groupRecords).Error; err != nil {
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_option_group: %w", err)
+	}
+	groupIDs := make([]string, 0, len(groupRecords))
+	for _, record := range groupRecords {
+		groupIDs = append(groupIDs, record.ID)
+	}
+	var optionRecords []PublishedMenuOptionRecord
+	if len(groupIDs) > 0 {
+		if err := s.db.WithContext(ctx).Where("group_id IN ?", groupIDs).Order("sort_position").Order("id").Find(&optionRecords).Error; err != nil {
+			return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_option: %w", err)
+		}
+	}
+	options := make(map[string][]entities.MenuOption, len(groupRecords))
+	for _, record := range optionRecords {
+		options[record.GroupID] = append(options[record.GroupID], entities.MenuOption{
+			ID:               record.ID,
+			GroupID:          record.GroupID,
+			OptionName:       record.OptionName,
+			PriceDeltaScaled: record.PriceDeltaScaled,
+			SortPosition:     record.SortPosition,
+		})
+	}
+	for _, record := range groupRecords {
+		published.Detail.OptionGroups = append(published.Detail.OptionGroups, entities.MenuOptionGroup{
+			ID:            record.ID,
+			MenuID:        record.MenuID,
+			GroupName:     record.GroupName,
+			SelectionType: entities.OptionSelection(record.SelectionType),
+			MinChoices:    record.MinChoices,
+			MaxChoices:    record.MaxChoices,
+			Required:      record.Required,
+			SortPosition:  record.SortPosition,
+			Options:       options[record.ID],
+		})
+	}
+	var itemRecords []PublishedMenuItemRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&itemRecords).Error; err != nil {
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_item: %w", err)
+	}
+	itemIDs := make([]string, 0, len(itemRecords))
+	for _, record := range itemRecords {
+		itemIDs = append(itemIDs, record.ID)
+	}
+	var links []PublishedMenuItemOptionGroupRecord
+	if len(itemIDs) > 0 {
+		if err := s.db.WithContext(ctx).Where("menu_item_id IN ?", itemIDs).Order("sort_position").Find(&links).Error; err != nil {
+			return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_item_option_group: %w", err)
+		}
+	}
+	linkedGroups := make(map[string][]string, len(itemRecords))
+	for _, link := range links {
+		linkedGroups[link.MenuItemID] = append(linkedGroups[link.MenuItemID], link.GroupID)
+	}
+	var aliasRecords []PublishedMenuItemAliasRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Find(&aliasRecords).Error; err != nil {
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu(), menu_item_alias: %w", err)
+	}
+	aliases := make(map[string][]string, len(itemRecords))
+	for _, record := range aliasRecords {
+		aliases[record.MenuItemID] = append(aliases[record.MenuItemID], record.Alias)
+	}
+	for _, record := range itemRecords {
+		item := entities.MenuItem{
+			ID:             record.ID,
+			MenuID:         record.MenuID,
+			MenuItemName:   record.MenuItemName,
+			PriceScaled:    record.PriceScaled,
+			SortPosition:   record.SortPosition,
+			OptionGroupIDs: linkedGroups[record.ID],
+			Aliases:        aliases[record.ID],
+			SoldOut:        record.SoldOut,
+			Image:          entities.Image{URL: record.ImageURL, ThumbnailURL: record.ThumbnailURL},
+			Description:    record.Description,
+			Allergens:      record.Allergens.V,
+			DietaryTags:    record.DietaryTags.V,
+			SpicyLevel:     record.SpicyLevel,
+			Nutrition:      record.Nutrition.V.ToModel(),
+			Translations:   sqldb.TranslationsToModel(record.Translations),
+		}
+		if record.CategoryID != nil {
+			item.CategoryID = *record.CategoryID
+		}
+		if record.RestoreAt != nil {
+			item.RestoreAt = *record.RestoreAt
+		}
+		published.Detail.Items = append(published.Detail.Items, item)
+	}
+	bundles, err := s.findPublishedBundles(ctx, menuID)
+	if err != nil {
+		return entities.PublishedMenu{}, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.FindPublishedMenu: %w", err)
+	}
+	published.Detail.Bundles = bundles
+	return published, nil
+}
+
+func (s *PublishedMenuStore) findPublishedBundles(ctx context.Context, menuID string) ([]entities.MenuBundle, error) {
+	var bundleRecords []PublishedMenuBundleRecord
+	if err := s.db.WithContext(ctx).Where("menu_id = ?", menuID).Order("sort_position").Order("id").Find(&bundleRecords).Error; err != nil {
+		return nil, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.findPublishedBundles(), menu_bundle: %w", err)
+	}
+	if len(bundleRecords) == 0 {
+		return nil, nil
+	}
+	bundleIDs := make([]string, 0, len(bundleRecords))
+	for _, record := range bundleRecords {
+		bundleIDs = append(bundleIDs, record.ID)
+	}
+	var slotRecords []PublishedMenuBundleSlotRecord
+	if err := s.db.WithContext(ctx).Where("bundle_id IN ?", bundleIDs).Order("sort_position").Order("id").Find(&slotRecords).Error; err != nil {
+		return nil, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.findPublishedBundles(), menu_bundle_slot: %w", err)
+	}
+	slotIDs := make([]string, 0, len(slotRecords))
+	for _, record := range slotRecords {
+		slotIDs = append(slotIDs, record.ID)
+	}
+	var choiceRecords []PublishedMenuBundleChoiceRecord
+	if len(slotIDs) > 0 {
+		if err := s.db.WithContext(ctx).Where("slot_id IN ?", slotIDs).Order("sort_position").Find(&choiceRecords).Error; err != nil {
+			return nil, fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.findPublishedBundles(), menu_bundle_choice: %w", err)
+		}
+	}
+	choices := make(map[string][]entities.MenuBundleChoice, len(slotRecords))
+	for _, record := range choiceRecords {
+		choices[record.SlotID] = append(choices[record.SlotID], entities.MenuBundleChoice{
+			SlotID:         record.SlotID,
+			MenuItemID:     record.MenuItemID,
+			UpchargeScaled: record.UpchargeScaled,
+			SortPosition:   record.SortPosition,
+		})
+	}
+	slots := make(map[string][]entities.MenuBundleSlot, len(bundleRecords))
+	for _, record := range slotRecords {
+		slots[record.BundleID] = append(slots[record.BundleID], entities.MenuBundleSlot{
+			ID:           record.ID,
+			BundleID:     record.BundleID,
+			SlotName:     record.SlotName,
+			SortPosition: record.SortPosition,
+			Choices:      choices[record.ID],
+		})
+	}
+	bundles := make([]entities.MenuBundle, 0, len(bundleRecords))
+	for _, record := range bundleRecords {
+		bundles = append(bundles, entities.MenuBundle{
+			ID:           record.ID,
+			MenuID:       record.MenuID,
+			BundleName:   record.BundleName,
+			PriceScaled:  record.PriceScaled,
+			Description:  record.Description,
+			SortPosition: record.SortPosition,
+			Slots:        slots[record.ID],
+		})
+	}
+	return bundles, nil
+}
+
+// ReplaceMenus swaps the published copies of all the bot's menus for
+// details, so menus deleted from the draft leave the order bot too.
+func (s *PublishedMenuStore) ReplaceMenus(ctx context.Context, tx store.Tx, bot entities.Bot, details []entities.MenuDetail) error {
 	db, err := resolveDB(s.db, tx)
 	if err != nil {
-		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems: %w", err)
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus: %w", err)
+	}
+	menuIDs := db.WithContext(ctx).Model(&PublishedMenuRecord{}).Select("id").Where("bot_id = ?", bot.ID)
+	bundleIDs := db.WithContext(ctx).Model(&PublishedMenuBundleRecord{}).Select("id").Where("menu_id IN (?)", menuIDs)
+	slotIDs := db.WithContext(ctx).Model(&PublishedMenuBundleSlotRecord{}).Select("id").Where("bundle_id IN (?)", bundleIDs)
+	if err := db.WithContext(ctx).Where("slot_id IN (?)", slotIDs).Delete(&PublishedMenuBundleChoiceRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_bundle_choice: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("bundle_id IN (?)", bundleIDs).Delete(&PublishedMenuBundleSlotRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_bundle_slot: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id IN (?)", menuIDs).Delete(&PublishedMenuBundleRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_bundle: %w", err)
+	}
+	itemIDs := db.WithContext(ctx).Model(&PublishedMenuItemRecord{}).Select("id").Where("menu_id IN (?)", menuIDs)
+	if err := db.WithContext(ctx).Where("menu_item_id IN (?)", itemIDs).Delete(&PublishedMenuItemOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_item_option_group: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id IN (?)", menuIDs).Delete(&PublishedMenuItemAliasRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_item_alias: %w", err)
+	}
+	groupIDs := db.WithContext(ctx).Model(&PublishedMenuOptionGroupRecord{}).Select("id").Where("menu_id IN (?)", menuIDs)
+	if err := db.WithContext(ctx).Where("group_id IN (?)", groupIDs).Delete(&PublishedMenuOptionRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_option: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id IN (?)", menuIDs).Delete(&PublishedMenuOptionGroupRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_option_group: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id IN (?)", menuIDs).Delete(&PublishedMenuItemRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_item: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("menu_id IN (?)", menuIDs).Delete(&PublishedMenuCategoryRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu_category: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("bot_id = ?", bot.ID).Delete(&PublishedMenuRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus(), delete menu: %w", err)
+	}
+	for _, detail := range details {
+		if err := insertPublishedMenu(ctx, db, bot, detail); err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenus: %w", err)
+		}
 	}
-	if err := db.WithContext(ctx).Where("menu_id = ?", menu.ID).Delete(&PublishedMenuItemRecord{}).Error; err != nil {
-		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems(), delete menu_item: %w", err)
+	return nil
+}
+
+func insertPublishedMenu(ctx context.Context, db *gorm.DB, bot entities.Bot, detail entities.MenuDetail) error {
+	menu, categories, groups, items := detail.Menu, detail.Categories, detail.OptionGroups, detail.Items
+	menuRecord := PublishedMenuRecord{
+		ID:             menu.ID,
+		BotID:          menu.BotID,
+		MenuName:       menu.MenuName,
+		Currency:       bot.Currency,
+		PriceScale:     bot.PriceScale,
+		Timezone:       bot.Timezone,
+		AvailableFrom:  menu.Window.From,
+		AvailableUntil: menu.Window.Until,
+		DefaultLocale:  bot.DefaultLocale,
+		Locales:        sqldb.JSON[[]string]{V: sqldb.NonNilSlice(bot.Locales)},
+	}
+	if err := db.WithContext(ctx).Create(&menuRecord).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu(), insert menu: %w", err)
 	}
-	if err := db.WithContext(ctx).Where("bot_id = ?", menu.BotID).Delete(&PublishedMenuRecord{}).Error; err != nil {
-		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems(), delete menu: %w", err)
+	categoryRecords := make([]PublishedMenuCategoryRecord, 0, len(categories))
+	for _, category := range categories {
+		categoryRecords = append(categoryRecords, PublishedMenuCategoryRecord{
+			ID:           category.ID,
+			MenuID:       category.MenuID,
+			CategoryName: category.CategoryName,
+			SortPosition: category.SortPosition,
+			Translations: sqldb.TranslationRecordsFromModel(category.Translations),
+		})
 	}
-	if err := db.WithContext(ctx).Create(&PublishedMenuRecord{ID: menu.ID, BotID: menu.BotID}).Error; err != nil {
-		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems(), insert menu: %w", err)
+	if len(categoryRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&categoryRecords).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu(), insert menu_category: %w", err)
+		}
+	}
+	if err := insertPublishedOptionGroups(ctx, db, groups); err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu: %w", err)
 	}
 	records := make([]PublishedMenuItemRecord, 0, len(items))
 	for _, item := range items {
-		records = append(records, PublishedMenuItemRecord{ID: item.ID, MenuID: item.MenuID, MenuItemName: item.MenuItemName, Price: item.Price})
+		records = append(records, PublishedMenuItemRecord{
+			ID:           item.ID,
+			MenuID:       item.MenuID,
+			MenuItemName: item.MenuItemName,
+			PriceScaled:  item.PriceScaled,
+			CategoryID:   sqldb.NullableString(item.CategoryID),
+			SortPosition: item.SortPosition,
+			SoldOut:      item.SoldOut,
+			RestoreAt:    sqldb.NullableTime(item.RestoreAt),
+			ImageURL:     item.Image.URL,
+			ThumbnailURL: item.Image.ThumbnailURL,
+			Description:  item.Description,
+			Allergens:    sqldb.JSON[[]entities.Allergen]{V: sqldb.NonNilSlice(item.Allergens)},
+			DietaryTags:  sqldb.JSON[[]entities.DietaryTag]{V: sqldb.NonNilSlice(item.DietaryTags)},
+			SpicyLevel:   item.SpicyLevel,
+			Nutrition:    sqldb.JSON[*sqldb.NutritionRecord]{V: sqldb.NutritionRecordFromModel(item.Nutrition)},
+			Translations: sqldb.TranslationRecordsFromModel(item.Translations),
+		})
 	}
 	if len(records) > 0 {
 		if err := db.WithContext(ctx).Create(&records).Error; err != nil {
-			return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems(), insert: %w", err)
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu(), insert: %w", err)
+		}
+	}
+	var links []PublishedMenuItemOptionGroupRecord
+	for _, item := range items {
+		for pos, groupID := range item.OptionGroupIDs {
+			links = append(links, PublishedMenuItemOptionGroupRecord{MenuItemID: item.ID, GroupID: groupID, SortPosition: pos})
+		}
+	}
+	if len(links) > 0 {
+		if err := db.WithContext(ctx).Create(&links).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu(), insert menu_item_option_group: %w", err)
+		}
+	}
+	var aliases []PublishedMenuItemAliasRecord
+	for _, item := range items {
+		for pos, alias := range item.Aliases {
+			aliases = append(aliases, PublishedMenuItemAliasRecord{MenuItemID: item.ID, Alias: alias, MenuID: item.MenuID, SortPosition: pos})
+		}
+	}
+	if len(aliases) > 0 {
+		if err := db.WithContext(ctx).Create(&aliases).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu(), insert menu_item_alias: %w", err)
+		}
+	}
+	if err := insertPublishedBundles(ctx, db, detail.Bundles); err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.insertPublishedMenu: %w", err)
+	}
+	return nil
+}
+
+// UpdateAvailability sets the sold-out flag on published items in place.
+// Items that are not published yet are skipped.
+func (s *PublishedMenuStore) UpdateAvailability(
+	ctx context.Context,
+	tx store.Tx,
+	menuID string,
+	ids []string,
+	soldOut bool,
+	restoreAt time.Time,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.UpdateAvailability: %w", err)
+	}
+	err = db.WithContext(ctx).Model(&PublishedMenuItemRecord{}).
+		Where("menu_id = ? AND id IN ?", menuID, ids).
+		Updates(map[string]any{"sold_out": soldOut, "restore_at": sqldb.NullableTime(restoreAt)}).Error
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.UpdateAvailability: %w", err)
+	}
+	return nil
+}
+
+func insertPublishedOptionGroups(ctx context.Context, db *gorm.DB, groups []entities.MenuOptionGroup) error {
+	if len(groups) == 0 {
+		return nil
+	}
+	groupRecords := make([]PublishedMenuOptionGroupRecord, 0, len(groups))
+	var optionRecords []PublishedMenuOptionRecord
+	for _, group := range groups {
+		groupRecords = append(groupRecords, PublishedMenuOptionGroupRecord{
+			ID:            group.ID,
+			MenuID:        group.MenuID,
+			GroupName:     group.GroupName,
+			SelectionType: string(group.SelectionType),
+			MinChoices:    group.MinChoices,
+			MaxChoices:    group.MaxChoices,
+			Required:      group.Required,
+			SortPosition:  group.SortPosition,
+		})
+		for _, option := range group.Options {
+			optionRecords = append(optionRecords, PublishedMenuOptionRecord{
+				ID:               option.ID,
+				GroupID:          option.GroupID,
+				OptionName:       option.OptionName,
+				PriceDeltaScaled: option.PriceDeltaScaled,
+				SortPosition:     option.SortPosition,
+			})
+		}
+	}
+	if err := db.WithContext(ctx).Create(&groupRecords).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.insertPublishedOptionGroups(), menu_option_group: %w", err)
+	}
+	if len(optionRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&optionRecords).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedOptionGroups(), menu_option: %w", err)
+		}
+	}
+	return nil
+}
+
+// insertPublishedBundles goes after the items, which the choices reference.
+func insertPublishedBundles(ctx context.Context, db *gorm.DB, bundles []entities.MenuBundle) error {
+	if len(bundles) == 0 {
+		return nil
+	}
+	bundleRecords := make([]PublishedMenuBundleRecord, 0, len(bundles))
+	var slotRecords []PublishedMenuBundleSlotRecord
+	var choiceRecords []PublishedMenuBundleChoiceRecord
+	for _, bundle := range bundles {
+		bundleRecords = append(bundleRecords, PublishedMenuBundleRecord{
+			ID:           bundle.ID,
+			MenuID:       bundle.MenuID,
+			BundleName:   bundle.BundleName,
+			PriceScaled:  bundle.PriceScaled,
+			Description:  bundle.Description,
+			SortPosition: bundle.SortPosition,
+		})
+		for _, slot := range bundle.Slots {
+			slotRecords = append(slotRecords, PublishedMenuBundleSlotRecord{
+				ID:           slot.ID,
+				BundleID:     slot.BundleID,
+				SlotName:     slot.SlotName,
+				SortPosition: slot.SortPosition,
+			})
+			for _, choice := range slot.Choices {
+				choiceRecords = append(choiceRecords, PublishedMenuBundleChoiceRecord{
+					SlotID:         choice.SlotID,
+					MenuItemID:     choice.MenuItemID,
+					UpchargeScaled: choice.UpchargeScaled,
+					SortPosition:   choice.SortPosition,
+				})
+			}
+		}
+	}
+	if err := db.WithContext(ctx).Create(&bundleRecords).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.insertPublishedBundles(), menu_bundle: %w", err)
+	}
+	if len(slotRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&slotRecords).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedBundles(), menu_bundle_slot: %w", err)
+		}
+	}
+	if len(choiceRecords) > 0 {
+		if err := db.WithContext(ctx).Create(&choiceRecords).Error; err != nil {
+			return fmt.Errorf("orderbotmgmtsqldb.insertPublishedBundles(), menu_bundle_choice: %w", err)
 		}
 	}
 	return nil
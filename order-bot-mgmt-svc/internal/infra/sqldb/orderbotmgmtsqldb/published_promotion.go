@@ -0,0 +1,92 @@
+package orderbotmgmtsqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PublishedPromotionRecord struct {
+	ID                string `gorm:"column:id;primaryKey"`
+	BotID             string `gorm:"column:bot_id"`
+	PromotionName     string `gorm:"column:promotion_name"`
+	PromotionType     string `gorm:"column:promotion_type"`
+	PercentOff        int64  `gorm:"column:percent_off"`
+	AmountOffScaled   int64  `gorm:"column:amount_off_scaled"`
+	BuyQuantity       int    `gorm:"column:buy_quantity"`
+	GetQuantity       int    `gorm:"column:get_quantity"`
+	MinSubtotalScaled int64  `gorm:"column:min_subtotal_scaled"`
+	PriceScale        int    `gorm:"column:price_scale"`
+	// Timezone is the bot's, which the weekdays and window are in.
+	Timezone       string                     `gorm:"column:timezone"`
+	ItemIDs        sqldb.JSON[[]string]       `gorm:"column:item_ids;type:jsonb"`
+	CategoryIDs    sqldb.JSON[[]string]       `gorm:"column:category_ids;type:jsonb"`
+	StartsAt       *time.Time                 `gorm:"column:starts_at"`
+	EndsAt         *time.Time                 `gorm:"column:ends_at"`
+	Weekdays       sqldb.JSON[[]time.Weekday] `gorm:"column:weekdays;type:jsonb"`
+	AvailableFrom  int                        `gorm:"column:available_from"`
+	AvailableUntil int                        `gorm:"column:available_until"`
+	Priority       int                        `gorm:"column:priority"`
+	Stackable      bool                       `gorm:"column:stackable"`
+}
+
+func (PublishedPromotionRecord) TableName() string { return "published_promotion" }
+
+type PublishedPromotionStore struct{ db *gorm.DB }
+
+func NewPublishedPromotionStore(db *sqldb.DB) *PublishedPromotionStore {
+	if db == nil {
+		panic("orderbotmgmtsqldb.NewPublishedPromotionStore(), the db ptr is nil")
+	}
+	return &PublishedPromotionStore{db: db.Gorm()}
+}
+
+// ReplaceBotPromotions makes promotions the bot's published promotions.
+func (s *PublishedPromotionStore) ReplaceBotPromotions(
+	ctx context.Context, tx store.Tx, bot entities.Bot, promotions []entities.Promotion,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedPromotionStore.ReplaceBotPromotions: %w", err)
+	}
+	if err := db.WithContext(ctx).Where("bot_id = ?", bot.ID).Delete(&PublishedPromotionRecord{}).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedPromotionStore.ReplaceBotPromotions(), delete promotion: %w", err)
+	}
+	if len(promotions) == 0 {
+		return nil
+	}
+	records := make([]PublishedPromotionRecord, 0, len(promotions))
+	for _, promotion := range promotions {
+		records = append(records, PublishedPromotionRecord{
+			ID:                promotion.ID,
+			BotID:             bot.ID,
+			PromotionName:     promotion.PromotionName,
+			PromotionType:     string(promotion.Type),
+			PercentOff:        promotion.PercentOff,
+			AmountOffScaled:   promotion.AmountOffScaled,
+			BuyQuantity:       promotion.BuyQuantity,
+			GetQuantity:       promotion.GetQuantity,
+			MinSubtotalScaled: promotion.MinSubtotalScaled,
+			PriceScale:        bot.PriceScale,
+			Timezone:          bot.Timezone,
+			ItemIDs:           sqldb.JSON[[]string]{V: sqldb.NonNilSlice(promotion.ItemIDs)},
+			CategoryIDs:       sqldb.JSON[[]string]{V: sqldb.NonNilSlice(promotion.CategoryIDs)},
+			StartsAt:          sqldb.NullableTime(promotion.Schedule.StartsAt),
+			EndsAt:            sqldb.NullableTime(promotion.Schedule.EndsAt),
+			Weekdays:          sqldb.JSON[[]time.Weekday]{V: sqldb.NonNilSlice(promotion.Schedule.Weekdays)},
+			AvailableFrom:     promotion.Schedule.Window.From,
+			AvailableUntil:    promotion.Schedule.Window.Until,
+			Priority:          promotion.Priority,
+			Stackable:         promotion.Stackable,
+		})
+	}
+	if err := db.WithContext(ctx).Create(&records).Error; err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedPromotionStore.ReplaceBotPromotions(), insert promotion: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package orderbotmgmtsqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// PublishedTaxConfigRecord is the tax config the order bot prices carts
+// with. Rates are in millionths; items are taxed at their item_rates code,
+// else their category_rates code, else default_rate_code, and an empty
+// default leaves them untaxed.
+type PublishedTaxConfigRecord struct {
+	Base            sqldb.BaseRecord                  `gorm:"embedded"`
+	BotID           string                            `gorm:"column:bot_id;primaryKey"`
+	TaxMode         string                            `gorm:"column:tax_mode"`
+	DefaultRateCode string                            `gorm:"column:default_rate_code"`
+	Rates           sqldb.JSON[[]sqldb.TaxRateRecord] `gorm:"column:rates;type:jsonb"`
+	CategoryRates   sqldb.JSON[map[string]string]     `gorm:"column:category_rates;type:jsonb"`
+	ItemRates       sqldb.JSON[map[string]string]     `gorm:"column:item_rates;type:jsonb"`
+}
+
+func (PublishedTaxConfigRecord) TableName() string { return "published_tax_config" }
+
+type PublishedTaxConfigStore struct{ db *gorm.DB }
+
+func NewPublishedTaxConfigStore(db *sqldb.DB) *PublishedTaxConfigStore {
+	if db == nil {
+		panic("orderbotmgmtsqldb.NewPublishedTaxConfigStore(), the db ptr is nil")
+	}
+	return &PublishedTaxConfigStore{db: db.Gorm()}
+}
+
+func (s *PublishedTaxConfigStore) Upsert(ctx context.Context, tx store.Tx, config entities.TaxConfig) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedTaxConfigStore.Upsert: %w", err)
+	}
+	record := PublishedTaxConfigRecord{
+		BotID:           config.BotID,
+		TaxMode:         string(config.Mode),
+		DefaultRateCode: config.DefaultRateCode,
+		Rates:           sqldb.TaxRateRecordsFromModel(config.Rates),
+		CategoryRates:   sqldb.TaxRateCodesFromModel(config.CategoryRateCodes),
+		ItemRates:       sqldb.TaxRateCodesFromModel(config.ItemRateCodes),
+	}
+	err = db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bot_id"}},
+		UpdateAll: true,
+	}).Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("orderbotmgmtsqldb.PublishedTaxConfigStore.Upsert: %w", err)
+	}
+	return nil
+}
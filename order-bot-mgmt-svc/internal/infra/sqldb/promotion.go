@@ -0,0 +1,191 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type PromotionRecord struct {
+	Base              BaseRecord           `gorm:"embedded"`
+	ID                string               `gorm:"column:id;primaryKey"`
+	BotID             string               `gorm:"column:bot_id"`
+	PromotionName     string               `gorm:"column:promotion_name"`
+	PromotionType     string               `gorm:"column:promotion_type"`
+	PercentOff        int64                `gorm:"column:percent_off"`
+	AmountOffScaled   int64                `gorm:"column:amount_off_scaled"`
+	BuyQuantity       int                  `gorm:"column:buy_quantity"`
+	GetQuantity       int                  `gorm:"column:get_quantity"`
+	MinSubtotalScaled int64                `gorm:"column:min_subtotal_scaled"`
+	ItemIDs           JSON[[]string]       `gorm:"column:item_ids;type:jsonb"`
+	CategoryIDs       JSON[[]string]       `gorm:"column:category_ids;type:jsonb"`
+	StartsAt          *time.Time           `gorm:"column:starts_at"`
+	EndsAt            *time.Time           `gorm:"column:ends_at"`
+	Weekdays          JSON[[]time.Weekday] `gorm:"column:weekdays;type:jsonb"`
+	AvailableFrom     int                  `gorm:"column:available_from"`
+	AvailableUntil    int                  `gorm:"column:available_until"`
+	Priority          int                  `gorm:"column:priority"`
+	Stackable         bool                 `gorm:"column:stackable"`
+	Enabled           bool                 `gorm:"column:enabled"`
+}
+
+func (PromotionRecord) TableName() string { return "promotion" }
+
+func PromotionRecordFromModel(promotion entities.Promotion) PromotionRecord {
+	return PromotionRecord{
+		ID:                promotion.ID,
+		BotID:             promotion.BotID,
+		PromotionName:     promotion.PromotionName,
+		PromotionType:     string(promotion.Type),
+		PercentOff:        promotion.PercentOff,
+		AmountOffScaled:   promotion.AmountOffScaled,
+		BuyQuantity:       promotion.BuyQuantity,
+		GetQuantity:       promotion.GetQuantity,
+		MinSubtotalScaled: promotion.MinSubtotalScaled,
+		ItemIDs:           JSON[[]string]{V: NonNilSlice(promotion.ItemIDs)},
+		CategoryIDs:       JSON[[]string]{V: NonNilSlice(promotion.CategoryIDs)},
+		StartsAt:          NullableTime(promotion.Schedule.StartsAt),
+		EndsAt:            NullableTime(promotion.Schedule.EndsAt),
+		Weekdays:          JSON[[]time.Weekday]{V: NonNilSlice(promotion.Schedule.Weekdays)},
+		AvailableFrom:     promotion.Schedule.Window.From,
+		AvailableUntil:    promotion.Schedule.Window.Until,
+		Priority:          promotion.Priority,
+		Stackable:         promotion.Stackable,
+		Enabled:           promotion.Enabled,
+	}
+}
+func (r PromotionRecord) ToModel() entities.Promotion {
+	promotion := entities.Promotion{
+		ID:                r.ID,
+		BotID:             r.BotID,
+		PromotionName:     r.PromotionName,
+		Type:              entities.PromotionType(r.PromotionType),
+		PercentOff:        r.PercentOff,
+		AmountOffScaled:   r.AmountOffScaled,
+		BuyQuantity:       r.BuyQuantity,
+		GetQuantity:       r.GetQuantity,
+		MinSubtotalScaled: r.MinSubtotalScaled,
+		ItemIDs:           r.ItemIDs.V,
+		CategoryIDs:       r.CategoryIDs.V,
+		Schedule: entities.PromotionSchedule{
+			Weekdays: r.Weekdays.V,
+			Window:   entities.MenuWindow{From: r.AvailableFrom, Until: r.AvailableUntil},
+		},
+		Priority:  r.Priority,
+		Stackable: r.Stackable,
+		Enabled:   r.Enabled,
+		CreatedAt: r.Base.CreatedAt,
+		UpdatedAt: r.Base.UpdatedAt,
+	}
+	if r.StartsAt != nil {
+		promotion.Schedule.StartsAt = *r.StartsAt
+	}
+	if r.EndsAt != nil {
+		promotion.Schedule.EndsAt = *r.EndsAt
+	}
+	return promotion
+}
+
+type PromotionStore struct{ db *gorm.DB }
+
+func NewPromotionStore(db *DB) *PromotionStore {
+	if db == nil {
+		panic("sqldb.NewPromotionStore(), the db ptr is nil")
+	}
+	return &PromotionStore{db: db.Gorm()}
+}
+
+func (s *PromotionStore) FindByBotID(ctx context.Context, tx store.Tx, botID string) ([]entities.Promotion, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.PromotionStore.FindByBotID: %w", err)
+	}
+	var records []PromotionRecord
+	err = db.WithContext(ctx).
+		Where("bot_id = ?", botID).
+		Order("priority desc").Order("promotion_name").Order("id").
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.PromotionStore.FindByBotID: %w", err)
+	}
+	promotions := make([]entities.Promotion, 0, len(records))
+	for _, record := range records {
+		promotions = append(promotions, record.ToModel())
+	}
+	return promotions, nil
+}
+func (s *PromotionStore) FindByID(ctx context.Context, botID string, id string) (entities.Promotion, error) {
+	var record PromotionRecord
+	if err := s.db.WithContext(ctx).Where("bot_id = ? AND id = ?", botID, id).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.Promotion{}, fmt.Errorf("sqldb.PromotionStore.FindByID: %w", store.ErrPromotionNotFound)
+		}
+		return entities.Promotion{}, fmt.Errorf("sqldb.PromotionStore.FindByID: %w", err)
+	}
+	return record.ToModel(), nil
+}
+func (s *PromotionStore) Create(ctx context.Context, tx store.Tx, promotion entities.Promotion) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Create: %w", err)
+	}
+	record := PromotionRecordFromModel(promotion)
+	if err := db.WithContext(ctx).Create(&record).Error; err != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Create: %w", err)
+	}
+	return nil
+}
+func (s *PromotionStore) Update(ctx context.Context, tx store.Tx, promotion entities.Promotion) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Update: %w", err)
+	}
+	record := PromotionRecordFromModel(promotion)
+	res := db.WithContext(ctx).Model(&PromotionRecord{}).
+		Where("bot_id = ? AND id = ?", promotion.BotID, promotion.ID).
+		Updates(map[string]any{
+			"promotion_name":      record.PromotionName,
+			"promotion_type":      record.PromotionType,
+			"percent_off":         record.PercentOff,
+			"amount_off_scaled":   record.AmountOffScaled,
+			"buy_quantity":        record.BuyQuantity,
+			"get_quantity":        record.GetQuantity,
+			"min_subtotal_scaled": record.MinSubtotalScaled,
+			"item_ids":            record.ItemIDs,
+			"category_ids":        record.CategoryIDs,
+			"starts_at":           record.StartsAt,
+			"ends_at":             record.EndsAt,
+			"weekdays":            record.Weekdays,
+			"available_from":      record.AvailableFrom,
+			"available_until":     record.AvailableUntil,
+			"priority":            record.Priority,
+			"stackable":           record.Stackable,
+			"enabled":             record.Enabled,
+		})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Update: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.PromotionStore.Update(), id %s: %w", promotion.ID, store.ErrPromotionNotFound)
+	}
+	return nil
+}
+func (s *PromotionStore) Delete(ctx context.Context, tx store.Tx, botID string, id string) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Delete: %w", err)
+	}
+	res := db.WithContext(ctx).Where("bot_id = ? AND id = ?", botID, id).Delete(&PromotionRecord{})
+	if res.Error != nil {
+		return fmt.Errorf("sqldb.PromotionStore.Delete: %w", res.Error)
+	}
+	if res.RowsAffected == 0 {
+		return fmt.Errorf("sqldb.PromotionStore.Delete(), id %s: %w", id, store.ErrPromotionNotFound)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type PublishJobRecord struct {
+	Base       BaseRecord `gorm:"embedded"`
+	ID         string     `gorm:"column:id;primaryKey"`
+	BotID      string     `gorm:"column:bot_id"`
+	RunAt      time.Time  `gorm:"column:run_at"`
+	Status     string     `gorm:"column:status"`
+	CreatedBy  *string    `gorm:"column:created_by"`
+	Attempts   int        `gorm:"column:attempts"`
+	StartedAt  *time.Time `gorm:"column:started_at"`
+	Error      string     `gorm:"column:error"`
+	FinishedAt *time.Time `gorm:"column:finished_at"`
+}
+
+func (PublishJobRecord) TableName() string { return "publish_job" }
+
+func PublishJobRecordFromModel(job entities.PublishJob) PublishJobRecord {
+	return PublishJobRecord{
+		ID:         job.ID,
+		BotID:      job.BotID,
+		RunAt:      job.RunAt,
+		Status:     string(job.Status),
+		CreatedBy:  NullableString(job.CreatedBy),
+		Attempts:   job.Attempts,
+		StartedAt:  NullableTime(job.StartedAt),
+		Error:      job.Error,
+		FinishedAt: NullableTime(job.FinishedAt),
+	}
+}
+func (r PublishJobRecord) ToModel() entities.PublishJob {
+	job := entities.PublishJob{
+		ID:        r.ID,
+		BotID:     r.BotID,
+		RunAt:     r.RunAt,
+		Status:    entities.PublishJobStatus(r.Status),
+		Attempts:  r.Attempts,
+		Error:     r.Error,
+		CreatedAt: r.Base.CreatedAt,
+	}
+	if r.CreatedBy != nil {
+		job.CreatedBy = *r.CreatedBy
+	}
+	if r.StartedAt != nil {
+		job.StartedAt = *r.StartedAt
+	}
+	if r.FinishedAt != nil {
+		job.FinishedAt = *r.FinishedAt
+	}
+	return job
+}
+
+type PublishJobStore struct{ db *gorm.DB }
+
+func NewPublishJobStore(db *DB) *PublishJobStore {
+	if db == nil {
+		panic("sqldb.NewPublishJobStore(), the db ptr is nil")
+	}
+	return &PublishJobStore{db: db.Gorm()}
+}
+
+func (s *PublishJobStore) Create(ctx context.Context, tx store.Tx, job entities.PublishJob) (entities.PublishJob, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.Create: %w", err)
+	}
+	record := PublishJobRecordFromModel(job)
+	if err := db.WithContext(ctx).Create(&record).Error; err != nil {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.Create: %w", err)
+	}
+	return record.ToModel(), nil
+}
+func (s *PublishJobStore) FindByBotID(ctx context.Context, botID string, limit int) ([]entities.PublishJob, error) {
+	var records []PublishJobRecord
+	err := s.db.WithContext(ctx).
+		Where("bot_id = ?", botID).
+		Order("run_at desc").Order("id").
+		Limit(limit).
+		Find(&records).Error
+	if err != nil {
+		return nil, fmt.Errorf("sqldb.PublishJobStore.FindByBotID: %w", err)
+	}
+	jobs := make([]entities.PublishJob, 0, len(records))
+	for _, record := range records {
+		jobs = append(jobs, record.ToModel())
+	}
+	return jobs, nil
+}
+func (s *PublishJobStore) FindByID(ctx context.Context, tx store.Tx, botID string, id string) (entities.PublishJob, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.FindByID: %w", err)
+	}
+	var record PublishJobRecord
+	if err := db.WithContext(ctx).Where("bot_id = ? AND id = ?", botID, id).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.FindByID: %w", store.ErrPublishJobNotFound)
+		}
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.FindByID: %w", err)
+	}
+	return record.ToModel(), nil
+}
+func (s *PublishJobStore) Cancel(ctx context.Context, tx store.Tx, botID string, id string) (bool, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return false, fmt.Errorf("sqldb.PublishJobStore.Cancel: %w", err)
+	}
+	res := db.WithContext(ctx).Model(&PublishJobRecord{}).
+		Where("bot_id = ? AND id = ? AND status = ?", botID, id, string(entities.PublishJobPending)).
+		Update("status", string(entities.PublishJobCanceled))
+	if res.Error != nil {
+		return false, fmt.Errorf("sqldb.PublishJobStore.Cancel: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+func (s *PublishJobStore) ClaimDue(
+	ctx context.Context,
+	tx store.Tx,
+	now time.Time,
+	staleBefore time.Time,
+) (entities.PublishJob, bool, error) {
+	if tx == nil {
+		return entities.PublishJob{}, false, fmt.Errorf("sqldb.PublishJobStore.ClaimDue: %w", store.ErrInvalidTx)
+	}
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.PublishJob{}, false, fmt.Errorf("sqldb.PublishJobStore.ClaimDue: %w", err)
+	}
+	var records []PublishJobRecord
+	err = db.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+		Where("(status = ? AND run_at <= ?) OR (status = ? AND started_at < ?)",
+			string(entities.PublishJobPending), now, string(entities.PublishJobRunning), staleBefore).
+		Order("run_at").Order("id").
+		Limit(1).
+		Find(&records).Error
+	if err != nil {
+		return entities.PublishJob{}, false, fmt.Errorf("sqldb.PublishJobStore.ClaimDue: %w", err)
+	}
+	if len(records) == 0 {
+		return entities.PublishJob{}, false, nil
+	}
+	return records[0].ToModel(), true, nil
+}
+func (s *PublishJobStore) Start(ctx context.Context, tx store.Tx, id string, startedAt time.Time) (entities.PublishJob, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.Start: %w", err)
+	}
+	var records []PublishJobRecord
+	err = db.WithContext(ctx).Model(&records).
+		Clauses(clause.Returning{}).
+		Where("id = ?", id).
+		Updates(map[string]any{
+			"status":     string(entities.PublishJobRunning),
+			"attempts":   gorm.Expr("attempts + 1"),
+			"started_at": startedAt,
+		}).Error
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.Start: %w", err)
+	}
+	if len(records) == 0 {
+		return entities.PublishJob{}, fmt.Errorf("sqldb.PublishJobStore.Start: %w", store.ErrPublishJobNotFound)
+	}
+	return records[0].ToModel(), nil
+}
+func (s *PublishJobStore) Finish(
+	ctx context.Context,
+	tx store.Tx,
+	id string,
+	status entities.PublishJobStatus,
+	errMsg string,
+	finishedAt time.Time,
+) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.PublishJobStore.Finish: %w", err)
+	}
+	err = db.WithContext(ctx).Model(&PublishJobRecord{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"status": string(status), "error": errMsg, "finished_at": finishedAt}).Error
+	if err != nil {
+		return fmt.Errorf("sqldb.PublishJobStore.Finish: %w", err)
+	}
+	return nil
+}
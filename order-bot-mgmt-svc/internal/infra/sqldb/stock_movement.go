@@ -0,0 +1,88 @@
+package sqldb
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type StockMovementRecord struct {
+	Base        BaseRecord `gorm:"embedded"`
+	ID          string     `gorm:"column:id;primaryKey"`
+	MenuID      string     `gorm:"column:menu_id"`
+	MenuItemID  string     `gorm:"column:menu_item_id"`
+	Delta       int        `gorm:"column:delta"`
+	Balance     int        `gorm:"column:balance"`
+	Reason      string     `gorm:"column:reason"`
+	OrderItemID *string    `gorm:"column:order_item_id"`
+}
+
+func (StockMovementRecord) TableName() string { return "stock_movement" }
+
+func StockMovementRecordFromModel(movement entities.StockMovement) StockMovementRecord {
+	return StockMovementRecord{
+		ID:          movement.ID,
+		MenuID:      movement.MenuID,
+		MenuItemID:  movement.MenuItemID,
+		Delta:       movement.Delta,
+		Balance:     movement.Balance,
+		Reason:      string(movement.Reason),
+		OrderItemID: NullableString(movement.OrderItemID),
+	}
+}
+func (r StockMovementRecord) ToModel() entities.StockMovement {
+	movement := entities.StockMovement{
+		ID:         r.ID,
+		MenuID:     r.MenuID,
+		MenuItemID: r.MenuItemID,
+		Delta:      r.Delta,
+		Balance:    r.Balance,
+		Reason:     entities.StockReason(r.Reason),
+		CreatedAt:  r.Base.CreatedAt,
+	}
+	if r.OrderItemID != nil {
+		movement.OrderItemID = *r.OrderItemID
+	}
+	return movement
+}
+
+type StockMovementStore struct{ db *gorm.DB }
+
+func NewStockMovementStore(db *DB) *StockMovementStore {
+	if db == nil {
+		panic("sqldb.NewStockMovementStore(), the db ptr is nil")
+	}
+	return &StockMovementStore{db: db.Gorm()}
+}
+
+func (s *StockMovementStore) Create(ctx context.Context, tx store.Tx, movement entities.StockMovement) (bool, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return false, fmt.Errorf("sqldb.StockMovementStore.Create: %w", err)
+	}
+	record := StockMovementRecordFromModel(movement)
+	res := db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if res.Error != nil {
+		return false, fmt.Errorf("sqldb.StockMovementStore.Create: %w", res.Error)
+	}
+	return res.RowsAffected > 0, nil
+}
+func (s *StockMovementStore) FindByMenuID(ctx context.Context, menuID string, itemID string, limit int) ([]entities.StockMovement, error) {
+	qry := s.db.WithContext(ctx).Where("menu_id = ?", menuID)
+	if itemID != "" {
+		qry = qry.Where("menu_item_id = ?", itemID)
+	}
+	var records []StockMovementRecord
+	if err := qry.Order("created_at desc").Order("id desc").Limit(limit).Find(&records).Error; err != nil {
+		return nil, fmt.Errorf("sqldb.StockMovementStore.FindByMenuID: %w", err)
+	}
+	movements := make([]entities.StockMovement, 0, len(records))
+	for _, record := range records {
+		movements = append(movements, record.ToModel())
+	}
+	return movements, nil
+}
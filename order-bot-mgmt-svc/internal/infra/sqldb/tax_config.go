@@ -0,0 +1,118 @@
+package sqldb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type TaxConfigRecord struct {
+	Base            BaseRecord `gorm:"embedded"`
+	BotID           string     `gorm:"column:bot_id;primaryKey"`
+	TaxMode         string     `gorm:"column:tax_mode"`
+	DefaultRateCode string     `gorm:"column:default_rate_code"`
+	// Rates is a jsonb array; CategoryRates and ItemRates are jsonb objects
+	// from category and item IDs to rate codes.
+	Rates         JSON[[]TaxRateRecord]   `gorm:"column:rates;type:jsonb"`
+	CategoryRates JSON[map[string]string] `gorm:"column:category_rates;type:jsonb"`
+	ItemRates     JSON[map[string]string] `gorm:"column:item_rates;type:jsonb"`
+}
+
+func (TaxConfigRecord) TableName() string { return "tax_config" }
+
+// TaxRateRecord is the jsonb form of entities.TaxRate, also read by
+// order-bot-svc from the published config. Rate is in millionths.
+type TaxRateRecord struct {
+	Code string `json:"code"`
+	Name string `json:"name"`
+	Rate int64  `json:"rate"`
+}
+
+// TaxRateRecordsFromModel maps nil to an empty array, so the column is never
+// NULL.
+func TaxRateRecordsFromModel(rates []entities.TaxRate) JSON[[]TaxRateRecord] {
+	records := make([]TaxRateRecord, 0, len(rates))
+	for _, rate := range rates {
+		records = append(records, TaxRateRecord{Code: rate.Code, Name: rate.RateName, Rate: rate.Rate})
+	}
+	return JSON[[]TaxRateRecord]{V: records}
+}
+
+// TaxRateCodesFromModel maps nil to an empty object.
+func TaxRateCodesFromModel(codes map[string]string) JSON[map[string]string] {
+	records := make(map[string]string, len(codes))
+	maps.Copy(records, codes)
+	return JSON[map[string]string]{V: records}
+}
+
+func TaxConfigRecordFromModel(config entities.TaxConfig) TaxConfigRecord {
+	return TaxConfigRecord{
+		BotID:           config.BotID,
+		TaxMode:         string(config.Mode),
+		DefaultRateCode: config.DefaultRateCode,
+		Rates:           TaxRateRecordsFromModel(config.Rates),
+		CategoryRates:   TaxRateCodesFromModel(config.CategoryRateCodes),
+		ItemRates:       TaxRateCodesFromModel(config.ItemRateCodes),
+	}
+}
+
+func (r TaxConfigRecord) ToModel() entities.TaxConfig {
+	rates := make([]entities.TaxRate, 0, len(r.Rates.V))
+	for _, rate := range r.Rates.V {
+		rates = append(rates, entities.TaxRate{Code: rate.Code, RateName: rate.Name, Rate: rate.Rate})
+	}
+	return entities.TaxConfig{
+		BotID:             r.BotID,
+		Mode:              entities.TaxMode(r.TaxMode),
+		Rates:             rates,
+		DefaultRateCode:   r.DefaultRateCode,
+		CategoryRateCodes: r.CategoryRates.V,
+		ItemRateCodes:     r.ItemRates.V,
+		UpdatedAt:         r.Base.UpdatedAt,
+	}
+}
+
+type TaxConfigStore struct{ db *gorm.DB }
+
+func NewTaxConfigStore(db *DB) *TaxConfigStore {
+	if db == nil {
+		panic("sqldb.NewTaxConfigStore(), the db ptr is nil")
+	}
+	return &TaxConfigStore{db: db.Gorm()}
+}
+
+func (s *TaxConfigStore) FindByBotID(ctx context.Context, tx store.Tx, botID string) (entities.TaxConfig, error) {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("sqldb.TaxConfigStore.FindByBotID: %w", err)
+	}
+	var record TaxConfigRecord
+	if err := db.WithContext(ctx).Where("bot_id = ?", botID).Take(&record).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return entities.TaxConfig{BotID: botID}, nil
+		}
+		return entities.TaxConfig{}, fmt.Errorf("sqldb.TaxConfigStore.FindByBotID: %w", err)
+	}
+	return record.ToModel(), nil
+}
+func (s *TaxConfigStore) Upsert(ctx context.Context, tx store.Tx, config entities.TaxConfig) error {
+	db, err := resolveDB(s.db, tx)
+	if err != nil {
+		return fmt.Errorf("sqldb.TaxConfigStore.Upsert: %w", err)
+	}
+	record := TaxConfigRecordFromModel(config)
+	err = db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "bot_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"tax_mode", "default_rate_code", "rates", "category_rates", "item_rates", "updated_at"}),
+	}).Create(&record).Error
+	if err != nil {
+		return fmt.Errorf("sqldb.TaxConfigStore.Upsert: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package sqldb
+
+import "order-bot-mgmt-svc/internal/models/entities"
+
+// TranslationRecord is the jsonb form of entities.Translation, keyed by
+// locale in a translations column. order-bot-svc reads it from published
+// items and categories.
+type TranslationRecord struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+// TranslationRecordsFromModel maps nil to an empty object, so the column is
+// never NULL.
+func TranslationRecordsFromModel(translations entities.Translations) JSON[map[string]TranslationRecord] {
+	records := make(map[string]TranslationRecord, len(translations))
+	for locale, translation := range translations {
+		records[locale] = TranslationRecord{Name: translation.Name, Description: translation.Description}
+	}
+	return JSON[map[string]TranslationRecord]{V: records}
+}
+
+// TranslationsToModel maps an empty object to nil.
+func TranslationsToModel(records JSON[map[string]TranslationRecord]) entities.Translations {
+	if len(records.V) == 0 {
+		return nil
+	}
+	translations := make(entities.Translations, len(records.V))
+	for locale, record := range records.V {
+		translations[locale] = entities.Translation{Name: record.Name, Description: record.Description}
+	}
+	return translations
+}
@@ -52,7 +52,7 @@ func (s *UserBotStore) FindByUserID(ctx context.Context, tx store.Tx, userID str
 		return nil, fmt.Errorf("sqldb.UserBotStore.FindByUserID: %w", err)
 	}
 	var records []UserBotRecord
-	if err := db.WithContext(ctx).Where("user_id = ?", userID).Find(&records).Error; err != nil {
+	if err := db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at").Find(&records).Error; err != nil {
 		return nil, fmt.Errorf("sqldb.UserBotStore.FindByUserID: %w", err)
 	}
 	if len(records) == 0 {
@@ -14,7 +14,7 @@ type MenuItemRecord struct {
 	ID           string
 	MenuID       string
 	MenuItemName string
-	Price        float64
+	PriceScaled  int64
 }
 
 func MenuItemRecordFromModel(item entities.MenuItem) MenuItemRecord {
@@ -22,7 +22,7 @@ func MenuItemRecordFromModel(item entities.MenuItem) MenuItemRecord {
 		ID:           item.ID,
 		MenuID:       item.MenuID,
 		MenuItemName: item.MenuItemName,
-		Price:        item.Price,
+		PriceScaled:  item.PriceScaled,
 	}
 }
 
@@ -31,7 +31,7 @@ func (r MenuItemRecord) ToModel() entities.MenuItem {
 		ID:           r.ID,
 		MenuID:       r.MenuID,
 		MenuItemName: r.MenuItemName,
-		Price:        r.Price,
+		PriceScaled:  r.PriceScaled,
 	}
 }
 
@@ -40,8 +40,8 @@ type MenuItemStore struct {
 }
 
 const (
-	insertMenuItemQuery     = `INSERT INTO menu_item (id, menu_id, menu_item_name, price) VALUES ($1, $2, $3, $4);`
-	selectMenuItemsByMenuID = `SELECT id, menu_id, menu_item_name, price FROM menu_item WHERE menu_id = $1 ORDER BY id;`
+	insertMenuItemQuery     = `INSERT INTO menu_item (id, menu_id, menu_item_name, price_scaled) VALUES ($1, $2, $3, $4);`
+	selectMenuItemsByMenuID = `SELECT id, menu_id, menu_item_name, price_scaled FROM menu_item WHERE menu_id = $1 ORDER BY id;`
 	deleteMenuItemsByMenuID = `DELETE FROM menu_item WHERE menu_id = $1;`
 )
 
@@ -65,7 +65,7 @@ func (s *MenuItemStore) FindItems(ctx context.Context, menuID string) ([]entitie
 	var items []entities.MenuItem
 	for rows.Next() {
 		var record MenuItemRecord
-		if err := rows.Scan(&record.ID, &record.MenuID, &record.MenuItemName, &record.Price); err != nil {
+		if err := rows.Scan(&record.ID, &record.MenuID, &record.MenuItemName, &record.PriceScaled); err != nil {
 			return nil, fmt.Errorf("sqldb.MenuItemStore.FindItems(), Scan: %w", err)
 		}
 		items = append(items, record.ToModel())
@@ -98,7 +98,7 @@ func (s *MenuItemStore) CreateMenuItems(
 	}
 	for _, item := range items {
 		record := MenuItemRecordFromModel(item)
-		if _, err := execer.ExecContext(ctx, insertMenuItemQuery, record.ID, record.MenuID, record.MenuItemName, record.Price); err != nil {
+		if _, err := execer.ExecContext(ctx, insertMenuItemQuery, record.ID, record.MenuID, record.MenuItemName, record.PriceScaled); err != nil {
 			return fmt.Errorf("sqldb.MenuItemStore.CreateMenuItems(), ExecContext: %w", err)
 		}
 	}
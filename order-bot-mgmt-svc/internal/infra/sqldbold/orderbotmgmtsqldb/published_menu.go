@@ -18,7 +18,7 @@ const (
 	deletePublishedMenuQuery      = `DELETE FROM published_menu WHERE bot_id = $1;`
 	deletePublishedMenuItemsQuery = `DELETE FROM published_menu_item WHERE menu_id = $1;`
 	insertPublishedMenuQuery      = `INSERT INTO published_menu (id, bot_id) VALUES ($1, $2);`
-	insertPublishedMenuItemQuery  = `INSERT INTO published_menu_item (id, menu_id, menu_item_name, price) VALUES ($1, $2, $3, $4);`
+	insertPublishedMenuItemQuery  = `INSERT INTO published_menu_item (id, menu_id, menu_item_name, price_scaled) VALUES ($1, $2, $3, $4);`
 )
 
 func NewPublishedMenuStore(db *sqldb.DB) *PublishedMenuStore {
@@ -54,7 +54,7 @@ func (s *PublishedMenuStore) ReplaceMenuItems(
 			item.ID,
 			item.MenuID,
 			item.MenuItemName,
-			item.Price,
+			item.PriceScaled,
 		); err != nil {
 			return fmt.Errorf("orderbotmgmtsqldb.PublishedMenuStore.ReplaceMenuItems(), insert: %w", err)
 		}
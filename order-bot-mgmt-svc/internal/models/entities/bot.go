@@ -1,6 +1,42 @@
 package entities
 
+import "slices"
+
 type Bot struct {
 	ID      string
 	BotName string
+	Profile BotProfile
+	// Currency is an ISO 4217 code. PriceScale is the number of minor-unit
+	// digits implied by every scaled amount of the bot.
+	Currency   string
+	PriceScale int
+	// Timezone is an IANA name; menu windows are read in it.
+	Timezone string
+	// DefaultLocale is the BCP 47 tag that menu names and descriptions are
+	// written in. Locales lists every locale the bot answers in, starting
+	// with DefaultLocale; menu translations are only allowed for the others.
+	DefaultLocale string
+	Locales       []string
+}
+
+// Translatable reports whether menus can have translations into locale,
+// which is any supported locale but the default.
+func (b Bot) Translatable(locale string) bool {
+	return locale != b.DefaultLocale && slices.Contains(b.Locales, locale)
+}
+
+// BotProfile is the customer-facing branding of a bot shown by the C-side
+// page and chat.
+type BotProfile struct {
+	DisplayName string
+	Description string
+	LogoURL     string
+	// LogoThumbnailURL is a small copy of the logo made on upload.
+	LogoThumbnailURL    string
+	ThemePrimaryColor   string
+	ThemeSecondaryColor string
+	GreetingMessage     string
+	ContactPhone        string
+	ContactEmail        string
+	ContactAddress      string
 }
@@ -0,0 +1,10 @@
+package entities
+
+// BotTemplate points to a hidden bot that holds a standard setup (settings,
+// draft menu and items) which new bots can be created from.
+type BotTemplate struct {
+	ID           string
+	UserID       string
+	BotID        string
+	TemplateName string
+}
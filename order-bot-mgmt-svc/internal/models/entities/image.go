@@ -0,0 +1,8 @@
+package entities
+
+// Image is an uploaded picture and a smaller copy of it for lists, both as
+// public URLs. The zero value means no image.
+type Image struct {
+	URL          string
+	ThumbnailURL string
+}
@@ -1,6 +1,82 @@
 package entities
 
+import "fmt"
+
+// Limits of a menu. Names of menus, categories, items, option groups and
+// options are counted in characters.
+const (
+	MaxNameLen        = 100
+	MaxMenuCategories = 100
+	MaxMenuItems      = 1000
+)
+
+// Menu is one of a bot's menus. MenuName tells the menus of a bot apart and
+// may be empty while the bot has a single menu. Window is when the order bot
+// serves the menu, in the bot's timezone. Version counts the edits of the
+// draft, starting at 1, so that an edit can tell whether it is based on the
+// current draft.
 type Menu struct {
-	ID    string
-	BotID string
+	ID       string
+	BotID    string
+	MenuName string
+	Window   MenuWindow
+	Version  int
+}
+
+// MenuDetail is a menu with its categories, option groups, items and
+// bundles, each in display order.
+type MenuDetail struct {
+	Menu         Menu
+	Categories   []MenuCategory
+	OptionGroups []MenuOptionGroup
+	Items        []MenuItem
+	Bundles      []MenuBundle
+}
+
+// ConvertPrices replaces the item prices, option price deltas, bundle prices
+// and bundle upcharges of the detail with what convert returns for them. It
+// stops at the first error, which it returns naming the price.
+func (d *MenuDetail) ConvertPrices(convert func(int64) (int64, error)) error {
+	var err error
+	for idx := range d.Items {
+		item := &d.Items[idx]
+		if item.PriceScaled, err = convert(item.PriceScaled); err != nil {
+			return fmt.Errorf("price of %q: %w", item.MenuItemName, err)
+		}
+	}
+	for _, group := range d.OptionGroups {
+		for idx := range group.Options {
+			option := &group.Options[idx]
+			if option.PriceDeltaScaled, err = convert(option.PriceDeltaScaled); err != nil {
+				return fmt.Errorf("price of option %q: %w", option.OptionName, err)
+			}
+		}
+	}
+	for idx := range d.Bundles {
+		bundle := &d.Bundles[idx]
+		if bundle.PriceScaled, err = convert(bundle.PriceScaled); err != nil {
+			return fmt.Errorf("price of bundle %q: %w", bundle.BundleName, err)
+		}
+		for _, slot := range bundle.Slots {
+			for choiceIdx := range slot.Choices {
+				choice := &slot.Choices[choiceIdx]
+				if choice.UpchargeScaled, err = convert(choice.UpchargeScaled); err != nil {
+					return fmt.Errorf("upcharge in bundle %q: %w", bundle.BundleName, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// PublishedMenu is the copy of a menu the order bot serves, with the currency
+// and timezone it was published in.
+type PublishedMenu struct {
+	Currency   string
+	PriceScale int
+	Timezone   string
+	// DefaultLocale and Locales are the bot's at the time of publishing.
+	DefaultLocale string
+	Locales       []string
+	Detail        MenuDetail
 }
@@ -0,0 +1,42 @@
+package entities
+
+// Limits of bundles. Names of bundles and slots are counted in characters
+// and limited by MaxNameLen.
+const (
+	MaxMenuBundles   = 100
+	MaxBundleSlots   = 10
+	MaxBundleChoices = 50
+)
+
+// MenuBundle is a combo such as "burger + fries + drink", sold as one cart
+// line at a fixed price. Each slot is filled with one of its choices.
+type MenuBundle struct {
+	ID         string
+	MenuID     string
+	BundleName string
+	// PriceScaled is the price before upcharges, in minor units at the bot's
+	// PriceScale.
+	PriceScaled  int64
+	Description  string
+	SortPosition int
+	Slots        []MenuBundleSlot
+}
+
+// MenuBundleSlot is a component of a bundle, such as "Drink".
+type MenuBundleSlot struct {
+	ID           string
+	BundleID     string
+	SlotName     string
+	SortPosition int
+	Choices      []MenuBundleChoice
+}
+
+// MenuBundleChoice is an item of the bundle's menu that can fill a slot.
+type MenuBundleChoice struct {
+	SlotID     string
+	MenuItemID string
+	// UpchargeScaled is added to the bundle price when the item is picked,
+	// such as for a large drink. It is zero or positive.
+	UpchargeScaled int64
+	SortPosition   int
+}
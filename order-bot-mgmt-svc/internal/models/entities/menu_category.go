@@ -0,0 +1,10 @@
+package entities
+
+type MenuCategory struct {
+	ID           string
+	MenuID       string
+	CategoryName string
+	SortPosition int
+	// Translations only use Translation.Name.
+	Translations Translations
+}
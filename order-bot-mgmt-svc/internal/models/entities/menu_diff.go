@@ -0,0 +1,40 @@
+package entities
+
+// MenuDiff lists the item-level changes from one menu to another. An item
+// that was renamed and re-priced appears in both lists. Described holds items
+// whose aliases, description, allergens, dietary tags, spicy level,
+// nutrition or translations changed.
+type MenuDiff struct {
+	Added     []MenuItem
+	Removed   []MenuItem
+	Renamed   []MenuItemChange
+	Repriced  []MenuItemChange
+	Described []MenuItemChange
+}
+
+type MenuItemChange struct {
+	Before MenuItem
+	After  MenuItem
+}
+
+func (d MenuDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Renamed) == 0 && len(d.Repriced) == 0 &&
+		len(d.Described) == 0
+}
+
+// MenuComparison is the state of the draft menu against the published one.
+// Diff goes from the published menu to the draft. LayoutChanged covers
+// categories, item order, option groups and item images; CurrencyChanged means the bot's
+// currency or price scale changed since the last publish; ScheduleChanged
+// means the menu name, its window or the bot's timezone did; LocalesChanged
+// means the bot's default or supported locales did.
+type MenuComparison struct {
+	Menu            Menu
+	Published       bool
+	InSync          bool
+	Diff            MenuDiff
+	LayoutChanged   bool
+	CurrencyChanged bool
+	ScheduleChanged bool
+	LocalesChanged  bool
+}
@@ -1,8 +1,44 @@
 package entities
 
+import "time"
+
 type MenuItem struct {
 	ID           string
 	MenuID       string
 	MenuItemName string
-	Price        float64
+	// PriceScaled is in minor units at the bot's PriceScale.
+	PriceScaled int64
+	// CategoryID is empty for items that are not in a category.
+	CategoryID   string
+	SortPosition int
+	// OptionGroupIDs lists the linked option groups in display order.
+	OptionGroupIDs []string
+	// Aliases are other names customers use for the item, such as "coke".
+	// They are unique ignoring case across the names and aliases of the menu.
+	Aliases []string
+	// SoldOut hides the item from the bot. A non-zero RestoreAt brings it back
+	// automatically at that time.
+	SoldOut   bool
+	RestoreAt time.Time
+	// Stock is only meaningful when TrackStock is set. Orders decrement it
+	// and the item is sold out once it reaches zero.
+	TrackStock bool
+	Stock      int
+	// Image only changes through uploads, not through menu updates.
+	Image Image
+	// Description, Allergens, DietaryTags, SpicyLevel and Nutrition let the
+	// bot answer dietary questions. Allergens and tags are kept in the order
+	// of the Allergens and DietaryTags lists. Nutrition is nil when unknown.
+	Description string
+	Allergens   []Allergen
+	DietaryTags []DietaryTag
+	SpicyLevel  int
+	Nutrition   *Nutrition
+	// Translations hold the name and description in the bot's other locales.
+	Translations Translations
+}
+
+// AvailableAt reports whether the item can be ordered at t.
+func (i MenuItem) AvailableAt(t time.Time) bool {
+	return !i.SoldOut || !i.RestoreAt.IsZero() && !t.Before(i.RestoreAt)
 }
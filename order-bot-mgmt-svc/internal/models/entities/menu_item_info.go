@@ -0,0 +1,55 @@
+package entities
+
+// Allergen is one of the major food allergens that menus have to declare.
+type Allergen string
+
+const (
+	AllergenGluten      Allergen = "gluten"
+	AllergenCrustaceans Allergen = "crustaceans"
+	AllergenEggs        Allergen = "eggs"
+	AllergenFish        Allergen = "fish"
+	AllergenPeanuts     Allergen = "peanuts"
+	AllergenSoy         Allergen = "soy"
+	AllergenMilk        Allergen = "milk"
+	AllergenTreeNuts    Allergen = "tree_nuts"
+	AllergenCelery      Allergen = "celery"
+	AllergenMustard     Allergen = "mustard"
+	AllergenSesame      Allergen = "sesame"
+	AllergenSulphites   Allergen = "sulphites"
+	AllergenLupin       Allergen = "lupin"
+	AllergenMolluscs    Allergen = "molluscs"
+)
+
+// Allergens lists every allergen in display order.
+var Allergens = []Allergen{
+	AllergenGluten, AllergenCrustaceans, AllergenEggs, AllergenFish, AllergenPeanuts, AllergenSoy, AllergenMilk,
+	AllergenTreeNuts, AllergenCelery, AllergenMustard, AllergenSesame, AllergenSulphites, AllergenLupin, AllergenMolluscs,
+}
+
+type DietaryTag string
+
+const (
+	DietaryVegan      DietaryTag = "vegan"
+	DietaryVegetarian DietaryTag = "vegetarian"
+	DietaryHalal      DietaryTag = "halal"
+	DietaryGlutenFree DietaryTag = "gluten_free"
+)
+
+// DietaryTags lists every dietary tag in display order.
+var DietaryTags = []DietaryTag{DietaryVegan, DietaryVegetarian, DietaryHalal, DietaryGlutenFree}
+
+// MaxSpicyLevel is the hottest level. Zero means not spicy.
+const MaxSpicyLevel = 3
+
+// MaxDescriptionLen is the longest item description, in characters.
+const MaxDescriptionLen = 1000
+
+// Nutrition holds the nutrition facts of one serving.
+type Nutrition struct {
+	Calories      int
+	ProteinG      float64
+	CarbohydrateG float64
+	FatG          float64
+	SugarG        float64
+	SaltG         float64
+}
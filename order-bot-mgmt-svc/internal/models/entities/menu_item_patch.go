@@ -0,0 +1,36 @@
+package entities
+
+// MenuItemPatch changes the fields of an item that are set and leaves the
+// others as they are. An empty CategoryID moves the item out of its category.
+type MenuItemPatch struct {
+	Name           *string
+	PriceScaled    *int64
+	CategoryID     *string
+	OptionGroupIDs *[]string
+	Aliases        *[]string
+	Translations   *Translations
+	Description    *string
+	Allergens      *[]Allergen
+	DietaryTags    *[]DietaryTag
+	SpicyLevel     *int
+	// SetNutrition replaces the nutrition with Nutrition, which is nil to
+	// clear it.
+	SetNutrition bool
+	Nutrition    *Nutrition
+}
+
+// MenuItemBulkPatch changes several items of a menu at once. It selects the
+// items in ItemIDs and the items of the categories in CategoryIDs, or every
+// item with All. At most one of the price changes is set: PriceScaled sets
+// the price, PriceDeltaScaled adds to it and PricePercent changes it by
+// basis points, so 500 raises prices by 5%.
+type MenuItemBulkPatch struct {
+	ItemIDs          []string
+	CategoryIDs      []string
+	All              bool
+	PriceScaled      *int64
+	PriceDeltaScaled *int64
+	PricePercent     *int64
+	// CategoryID moves the items, to the end of the category.
+	CategoryID *string
+}
@@ -0,0 +1,27 @@
+package entities
+
+// MenuMatch is free text matched against a published menu. Prices of the
+// candidates are at the published PriceScale.
+type MenuMatch struct {
+	Menu       Menu
+	Currency   string
+	PriceScale int
+	Phrases    []MenuPhraseMatch
+}
+
+// MenuPhraseMatch is the part of the text that names one item, with the
+// quantity it asks for and the likely items, best first.
+type MenuPhraseMatch struct {
+	Text       string
+	Quantity   int
+	Candidates []MenuItemCandidate
+}
+
+type MenuItemCandidate struct {
+	Item MenuItem
+	// MatchedName is the item name or alias that matched best.
+	MatchedName string
+	// Score runs from 0 to 1, where 1 is an exact match of the name or an
+	// alias ignoring case, punctuation and plurals.
+	Score float64
+}
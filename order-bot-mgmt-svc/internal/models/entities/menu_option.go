@@ -0,0 +1,33 @@
+package entities
+
+type OptionSelection string
+
+const (
+	OptionSelectionSingle OptionSelection = "single"
+	OptionSelectionMulti  OptionSelection = "multi"
+)
+
+// MenuOptionGroup is a set of choices such as size or milk. A group belongs
+// to a menu and can be linked to any number of its items.
+type MenuOptionGroup struct {
+	ID            string
+	MenuID        string
+	GroupName     string
+	SelectionType OptionSelection
+	MinChoices    int
+	MaxChoices    int
+	Required      bool
+	SortPosition  int
+	Options       []MenuOption
+}
+
+type MenuOption struct {
+	ID      string
+	GroupID string
+	// OptionName is shown to the customer, e.g. "Oat milk".
+	OptionName string
+	// PriceDeltaScaled is added to the item price, in minor units at the bot's
+	// PriceScale. It may be negative.
+	PriceDeltaScaled int64
+	SortPosition     int
+}
@@ -0,0 +1,44 @@
+package entities
+
+// MenuSheetRow is one item row of a menu spreadsheet, as typed by the user.
+// Line is the row number in the file, counting the header as line 1.
+type MenuSheetRow struct {
+	Line         int
+	ItemID       string
+	Category     string
+	Name         string
+	Price        string
+	OptionGroups []string
+}
+
+// MenuImportMode tells how imported rows combine with the draft menu.
+type MenuImportMode string
+
+const (
+	// MenuImportReplace makes the sheet the whole menu: items missing from
+	// it are removed and categories follow the sheet.
+	MenuImportReplace MenuImportMode = "replace"
+	// MenuImportMerge updates the items found in the sheet, adds new ones
+	// and keeps the rest of the menu as it is.
+	MenuImportMerge MenuImportMode = "merge"
+)
+
+// MenuImportError points at the row and column of a sheet that could not be
+// imported. Line 0 is a problem with the sheet as a whole.
+type MenuImportError struct {
+	Line    int
+	Column  string
+	Message string
+}
+
+// MenuImport is the outcome of an import: the menu as it looks with the sheet
+// applied, what changed, and the row errors. Nothing is saved while Errors is
+// not empty.
+type MenuImport struct {
+	Mode    MenuImportMode
+	Detail  MenuDetail
+	Added   int
+	Updated int
+	Removed int
+	Errors  []MenuImportError
+}
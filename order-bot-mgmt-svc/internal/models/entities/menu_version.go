@@ -0,0 +1,18 @@
+package entities
+
+import "time"
+
+// MenuVersion is an immutable snapshot of a saved draft menu. Version counts
+// up from 1 per menu. PriceScale is the bot's scale when the snapshot was
+// taken.
+type MenuVersion struct {
+	ID         string
+	MenuID     string
+	Version    int
+	AuthorID   string
+	Note       string
+	PriceScale int
+	ItemCount  int
+	Detail     MenuDetail
+	CreatedAt  time.Time
+}
@@ -0,0 +1,53 @@
+package entities
+
+// MinutesPerDay bounds the minutes of a MenuWindow.
+const MinutesPerDay = 24 * 60
+
+// MenuWindow is a daily time range in minutes after midnight. From is
+// inclusive and Until exclusive; a window whose Until is before From runs
+// past midnight. From equal to Until, as in the zero value, means all day.
+type MenuWindow struct {
+	From  int
+	Until int
+}
+
+func (w MenuWindow) AllDay() bool {
+	return w.From == w.Until
+}
+
+// Contains reports whether the minute of the day falls in the window.
+func (w MenuWindow) Contains(minute int) bool {
+	switch {
+	case w.AllDay():
+		return true
+	case w.From < w.Until:
+		return minute >= w.From && minute < w.Until
+	default:
+		return minute >= w.From || minute < w.Until
+	}
+}
+
+// Overlaps reports whether two windows share a minute. All-day windows
+// overlap everything.
+func (w MenuWindow) Overlaps(other MenuWindow) bool {
+	for _, a := range w.spans() {
+		for _, b := range other.spans() {
+			if a[0] < b[1] && b[0] < a[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// spans splits the window into half-open ranges that do not wrap.
+func (w MenuWindow) spans() [][2]int {
+	switch {
+	case w.AllDay():
+		return [][2]int{{0, MinutesPerDay}}
+	case w.From < w.Until:
+		return [][2]int{{w.From, w.Until}}
+	default:
+		return [][2]int{{w.From, MinutesPerDay}, {0, w.Until}}
+	}
+}
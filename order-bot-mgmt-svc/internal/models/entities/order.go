@@ -6,4 +6,7 @@ type Order struct {
 	CartID      string
 	SessionID   string
 	TotalScaled int
+	// Tax is stored by the order bot when the order is placed, so later
+	// changes to the tax config or the menu leave it as it was.
+	Tax TaxBreakdown
 }
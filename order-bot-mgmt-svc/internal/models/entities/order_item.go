@@ -1,5 +1,7 @@
 package entities
 
+import "time"
+
 type OrderItem struct {
 	ID               string
 	OrderID          string
@@ -8,4 +10,9 @@ type OrderItem struct {
 	Quantity         int
 	UnitPriceScaled  int
 	TotalPriceScaled int
+	// TaxRateCode is empty for untaxed items. TaxScaled is the item's share
+	// of the order's tax.
+	TaxRateCode string
+	TaxScaled   int64
+	CreatedAt   time.Time
 }
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"slices"
+	"time"
+)
+
+type PromotionType string
+
+const (
+	// PromotionPercentOff takes PercentOff off its targets.
+	PromotionPercentOff PromotionType = "percent_off"
+	// PromotionFixedOff takes AmountOffScaled off each target unit, or once
+	// off the order when the promotion has no targets.
+	PromotionFixedOff PromotionType = "fixed_off"
+	// PromotionBuyXGetY takes PercentOff off GetQuantity units for every
+	// BuyQuantity units bought, such as "second drink half price". The
+	// cheapest target units are the discounted ones.
+	PromotionBuyXGetY PromotionType = "buy_x_get_y"
+)
+
+const (
+	// FullPercent is 100% in basis points.
+	FullPercent = 10000
+	// MaxPromotionTargets bounds the items and, separately, the categories
+	// of a promotion.
+	MaxPromotionTargets = 200
+	// MaxPromotionQuantity bounds the buy and get quantities.
+	MaxPromotionQuantity = 99
+)
+
+// Promotion is a discount rule of a bot. It applies to the items in ItemIDs
+// and the categories in CategoryIDs, or to the whole order when both are
+// empty.
+type Promotion struct {
+	ID            string
+	BotID         string
+	PromotionName string
+	Type          PromotionType
+	// PercentOff is in basis points, so 1000 is 10% and FullPercent makes
+	// the units of a buy-X-get-Y promotion free.
+	PercentOff int64
+	// AmountOffScaled is in minor units at the bot's PriceScale.
+	AmountOffScaled int64
+	BuyQuantity     int
+	GetQuantity     int
+	// MinSubtotalScaled is the order threshold: the promotion only applies
+	// to carts whose subtotal before discounts reaches it. Zero means none.
+	MinSubtotalScaled int64
+	ItemIDs           []string
+	CategoryIDs       []string
+	Schedule          PromotionSchedule
+	// Priority orders the promotions of a cart, highest first.
+	Priority int
+	// Stackable promotions combine with each other. One that is not only
+	// applies when no promotion applied before it, and none apply after it.
+	Stackable bool
+	Enabled   bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Targets reports whether the promotion applies to a line of the item and
+// category.
+func (p Promotion) Targets(itemID string, categoryID string) bool {
+	if len(p.ItemIDs) == 0 && len(p.CategoryIDs) == 0 {
+		return true
+	}
+	return slices.Contains(p.ItemIDs, itemID) || categoryID != "" && slices.Contains(p.CategoryIDs, categoryID)
+}
+
+// Live reports whether the promotion is published at t: it is enabled and
+// its schedule has not ended.
+func (p Promotion) Live(t time.Time) bool { return p.Enabled && !p.Schedule.Ended(t) }
+
+// PromotionSchedule limits when a promotion runs. The zero value runs
+// always.
+type PromotionSchedule struct {
+	// StartsAt is inclusive and EndsAt exclusive; zero leaves that side open.
+	StartsAt time.Time
+	EndsAt   time.Time
+	// Weekdays are the days of the week the promotion runs, every day when
+	// empty.
+	Weekdays []time.Weekday
+	// Window is a daily time range, such as a happy hour, in the bot's
+	// timezone.
+	Window MenuWindow
+}
+
+// Active reports whether the schedule runs at t, which must be in the bot's
+// timezone. A window past midnight counts toward the day it is in at t.
+func (s PromotionSchedule) Active(t time.Time) bool {
+	if !s.StartsAt.IsZero() && t.Before(s.StartsAt) {
+		return false
+	}
+	if !s.EndsAt.IsZero() && !t.Before(s.EndsAt) {
+		return false
+	}
+	if len(s.Weekdays) > 0 && !slices.Contains(s.Weekdays, t.Weekday()) {
+		return false
+	}
+	return s.Window.Contains(t.Hour()*60 + t.Minute())
+}
+
+// Ended reports whether the schedule will not run again after t.
+func (s PromotionSchedule) Ended(t time.Time) bool {
+	return !s.EndsAt.IsZero() && !t.Before(s.EndsAt)
+}
+
+// PromotionLine is a cart line that promotions are evaluated against.
+type PromotionLine struct {
+	MenuItemID      string
+	CategoryID      string
+	Quantity        int
+	UnitPriceScaled int64
+}
+
+// PromotionResult is the outcome of evaluating promotions against a cart.
+// Amounts are in minor units at the bot's PriceScale.
+type PromotionResult struct {
+	SubtotalScaled int64
+	DiscountScaled int64
+	TotalScaled    int64
+	// LineDiscountsScaled holds the discount of each cart line, in line
+	// order; they add up to DiscountScaled.
+	LineDiscountsScaled []int64
+	Applied             []AppliedPromotion
+}
+
+type AppliedPromotion struct {
+	PromotionID    string
+	PromotionName  string
+	DiscountScaled int64
+}
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+type PublishJobStatus string
+
+const (
+	PublishJobPending   PublishJobStatus = "pending"
+	PublishJobRunning   PublishJobStatus = "running"
+	PublishJobSucceeded PublishJobStatus = "succeeded"
+	PublishJobFailed    PublishJobStatus = "failed"
+	PublishJobCanceled  PublishJobStatus = "canceled"
+)
+
+// PublishJob publishes a bot's draft menu at RunAt. Attempts and StartedAt
+// track the runs so far; Error and FinishedAt record the outcome once the
+// job ran.
+type PublishJob struct {
+	ID         string
+	BotID      string
+	RunAt      time.Time
+	Status     PublishJobStatus
+	CreatedBy  string
+	Attempts   int
+	StartedAt  time.Time
+	Error      string
+	FinishedAt time.Time
+	CreatedAt  time.Time
+}
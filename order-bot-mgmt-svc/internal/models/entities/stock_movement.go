@@ -0,0 +1,25 @@
+package entities
+
+import "time"
+
+type StockReason string
+
+const (
+	StockReasonSet     StockReason = "set"
+	StockReasonRestock StockReason = "restock"
+	StockReasonOrder   StockReason = "order"
+)
+
+// StockMovement is one ledger entry for a stock-tracked menu item. Balance is
+// the stock after Delta was applied.
+type StockMovement struct {
+	ID         string
+	MenuID     string
+	MenuItemID string
+	Delta      int
+	Balance    int
+	Reason     StockReason
+	// OrderItemID is set for StockReasonOrder movements.
+	OrderItemID string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,99 @@
+package entities
+
+import "time"
+
+// TaxMode says whether a bot's prices include tax.
+type TaxMode string
+
+const (
+	// TaxExclusive prices are net; tax is added on top of them.
+	TaxExclusive TaxMode = "exclusive"
+	// TaxInclusive prices are gross; the tax is the part of them above the
+	// net amount.
+	TaxInclusive TaxMode = "inclusive"
+)
+
+const (
+	// FullTaxRate is 100% in millionths, so 88750 is 8.875%.
+	FullTaxRate = 1_000_000
+	// MaxTaxRates bounds the rates of a bot.
+	MaxTaxRates = 20
+	// MaxTaxOverrides bounds the category and, separately, the item
+	// overrides of a bot.
+	MaxTaxOverrides = 1000
+)
+
+// TaxRate is a named rate that items are taxed at. Code is chosen by the
+// owner and stays the same when the rate or name changes, so overrides and
+// accounting exports can refer to it.
+type TaxRate struct {
+	Code     string
+	RateName string
+	// Rate is in millionths of the taxed amount, up to FullTaxRate.
+	Rate int64
+}
+
+// TaxConfig is the tax setup of a bot. Items are taxed at the rate of their
+// own override, else that of their category, else the default. A config
+// without a default leaves the other items untaxed.
+type TaxConfig struct {
+	BotID           string
+	Mode            TaxMode
+	Rates           []TaxRate
+	DefaultRateCode string
+	// CategoryRateCodes and ItemRateCodes map category and item IDs of the
+	// bot's menus to rate codes.
+	CategoryRateCodes map[string]string
+	ItemRateCodes     map[string]string
+	UpdatedAt         time.Time
+}
+
+// Inclusive reports whether prices include tax. A config that was never
+// saved is exclusive.
+func (c TaxConfig) Inclusive() bool { return c.Mode == TaxInclusive }
+
+// Rate returns the rate with code.
+func (c TaxConfig) Rate(code string) (TaxRate, bool) {
+	for _, rate := range c.Rates {
+		if rate.Code == code {
+			return rate, true
+		}
+	}
+	return TaxRate{}, false
+}
+
+// RateFor returns the rate an item in categoryID is taxed at, and false when
+// it is untaxed. Lines that are not menu items, such as bundles, pass empty
+// IDs and get the default.
+func (c TaxConfig) RateFor(itemID string, categoryID string) (TaxRate, bool) {
+	if code, ok := c.ItemRateCodes[itemID]; ok && itemID != "" {
+		return c.Rate(code)
+	}
+	if code, ok := c.CategoryRateCodes[categoryID]; ok && categoryID != "" {
+		return c.Rate(code)
+	}
+	if c.DefaultRateCode == "" {
+		return TaxRate{}, false
+	}
+	return c.Rate(c.DefaultRateCode)
+}
+
+// TaxBreakdown splits an order's total into net amount and tax. Amounts are
+// in minor units at the bot's PriceScale.
+type TaxBreakdown struct {
+	Mode        TaxMode
+	NetScaled   int64
+	TaxScaled   int64
+	GrossScaled int64
+	// Rates has the totals of each rate the order used, in the order its
+	// items first used them.
+	Rates []TaxRateTotal
+}
+
+// TaxRateTotal keeps the code, name and rate as they were when the order was
+// placed.
+type TaxRateTotal struct {
+	TaxRate
+	NetScaled int64
+	TaxScaled int64
+}
@@ -0,0 +1,44 @@
+package entities
+
+// Translation is the text of an item or category in one locale. Empty fields
+// fall back to the text in the bot's default locale.
+type Translation struct {
+	Name        string
+	Description string
+}
+
+// Translations maps a BCP 47 locale such as "zh-TW" to its translation.
+type Translations map[string]Translation
+
+// Name returns the name in locale, or fallback when it is not translated.
+func (t Translations) Name(locale string, fallback string) string {
+	if name := t[locale].Name; name != "" {
+		return name
+	}
+	return fallback
+}
+
+// Description returns the description in locale, or fallback when it is not
+// translated.
+func (t Translations) Description(locale string, fallback string) string {
+	if description := t[locale].Description; description != "" {
+		return description
+	}
+	return fallback
+}
+
+// Supported returns the translations into locales the bot can translate to,
+// or nil when none are left.
+func (t Translations) Supported(bot Bot) Translations {
+	var kept Translations
+	for locale, translation := range t {
+		if !bot.Translatable(locale) {
+			continue
+		}
+		if kept == nil {
+			kept = make(Translations, len(t))
+		}
+		kept[locale] = translation
+	}
+	return kept
+}
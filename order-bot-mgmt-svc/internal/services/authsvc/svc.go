@@ -104,21 +104,22 @@ func (s *Svc) Logout(ctx context.Context, refreshToken string) error {
 	return nil
 }
 
-func (s *Svc) ValidateAccessToken(_ context.Context, accessToken string) error {
+// ValidateAccessToken returns the ID of the user the token was issued to.
+func (s *Svc) ValidateAccessToken(_ context.Context, accessToken string) (string, error) {
 	if accessToken == "" {
-		return fmt.Errorf("authsvc.ValidateAccessToken(): accessToken is empty %w", jwtutil.ErrInvalidToken)
+		return "", fmt.Errorf("authsvc.ValidateAccessToken(): accessToken is empty %w", jwtutil.ErrInvalidToken)
 	}
 	claims, err := jwtutil.ParseJWT(s.accessSecret, accessToken)
 	if err != nil {
-		return fmt.Errorf("authsvc.ValidateAccessToken(): %w", err)
+		return "", fmt.Errorf("authsvc.ValidateAccessToken(): %w", err)
 	}
 	if claims.Typ != "access" {
-		return fmt.Errorf("authsvc.ValidateAccessToken(), claims.Typ != 'access': %w", jwtutil.ErrInvalidToken)
+		return "", fmt.Errorf("authsvc.ValidateAccessToken(), claims.Typ != 'access': %w", jwtutil.ErrInvalidToken)
 	}
 	if claims.Exp > time.Now().UnixMilli() {
-		return fmt.Errorf("authsvc.ValidateAccessToken(), accessToken is expired: %w", jwtutil.ErrExpiredToken)
+		return "", fmt.Errorf("authsvc.ValidateAccessToken(), accessToken is expired: %w", jwtutil.ErrExpiredToken)
 	}
-	return nil
+	return claims.Sub, nil
 }
 
 func (s *Svc) ValidateRefreshToken(ctx context.Context, refreshToken string) (string, error) {
@@ -0,0 +1,428 @@
+package botsvc
+
+import (
+	"context"
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// cloneTx stands in for the transaction of a clone. The fakes fail reads made
+// with any other tx, so a read outside the transaction fails the test.
+var cloneTx store.Tx = &struct{ name string }{"clone"}
+
+var errOutsideTx = errors.New("read outside the transaction")
+
+func checkTx(tx store.Tx) error {
+	if tx != cloneTx {
+		return errOutsideTx
+	}
+	return nil
+}
+
+// The fakes embed their store interface, so methods a clone or a rescale does
+// not call panic if they ever are. None of them deletes rows.
+type fakeBotStore struct {
+	store.Bot
+	bots map[string]entities.Bot
+}
+
+func (f *fakeBotStore) Create(_ context.Context, _ store.Tx, bot entities.Bot) error {
+	f.bots[bot.ID] = bot
+	return nil
+}
+
+func (f *fakeBotStore) FindByID(_ context.Context, tx store.Tx, id string) (entities.Bot, error) {
+	if err := checkTx(tx); err != nil {
+		return entities.Bot{}, err
+	}
+	bot, ok := f.bots[id]
+	if !ok {
+		return entities.Bot{}, store.ErrBotNotFound
+	}
+	return bot, nil
+}
+
+func (f *fakeBotStore) FindByIDForUpdate(ctx context.Context, tx store.Tx, id string) (entities.Bot, error) {
+	return f.FindByID(ctx, tx, id)
+}
+
+func (f *fakeBotStore) UpdateProfile(_ context.Context, tx store.Tx, id string, profile entities.BotProfile) error {
+	if err := checkTx(tx); err != nil {
+		return err
+	}
+	bot, ok := f.bots[id]
+	if !ok {
+		return store.ErrBotNotFound
+	}
+	bot.Profile = profile
+	f.bots[id] = bot
+	return nil
+}
+
+type fakeUserBotStore struct {
+	store.UserBot
+	created []entities.UserBot
+}
+
+func (f *fakeUserBotStore) Create(_ context.Context, _ store.Tx, userBot entities.UserBot) error {
+	f.created = append(f.created, userBot)
+	return nil
+}
+
+type fakeBotTemplateStore struct {
+	store.BotTemplate
+	created []entities.BotTemplate
+}
+
+func (f *fakeBotTemplateStore) Create(_ context.Context, _ store.Tx, template entities.BotTemplate) error {
+	f.created = append(f.created, template)
+	return nil
+}
+
+type fakeMenuStore struct {
+	store.Menu
+	menus  []entities.Menu
+	bumped map[string]int
+}
+
+func (f *fakeMenuStore) ListByBotID(_ context.Context, tx store.Tx, botID string) ([]entities.Menu, error) {
+	if err := checkTx(tx); err != nil {
+		return nil, err
+	}
+	var menus []entities.Menu
+	for _, menu := range f.menus {
+		if menu.BotID == botID {
+			menus = append(menus, menu)
+		}
+	}
+	return menus, nil
+}
+
+func (f *fakeMenuStore) CreateMenu(_ context.Context, _ store.Tx, menu entities.Menu) error {
+	f.menus = append(f.menus, menu)
+	return nil
+}
+
+func (f *fakeMenuStore) BumpVersion(_ context.Context, _ store.Tx, menuID string, version int) error {
+	f.bumped[menuID] = version
+	return nil
+}
+
+type fakeMenuCategoryStore struct {
+	store.MenuCategory
+	byMenu map[string][]entities.MenuCategory
+}
+
+func (f *fakeMenuCategoryStore) FindByMenuID(_ context.Context, tx store.Tx, menuID string) ([]entities.MenuCategory, error) {
+	return f.byMenu[menuID], checkTx(tx)
+}
+
+func (f *fakeMenuCategoryStore) CreateCategories(_ context.Context, _ store.Tx, categories []entities.MenuCategory) error {
+	for _, category := range categories {
+		f.byMenu[category.MenuID] = append(f.byMenu[category.MenuID], category)
+	}
+	return nil
+}
+
+type fakeMenuOptionGroupStore struct {
+	store.MenuOptionGroup
+	byMenu  map[string][]entities.MenuOptionGroup
+	updated []entities.MenuOptionGroup
+}
+
+func (f *fakeMenuOptionGroupStore) FindByMenuID(_ context.Context, tx store.Tx, menuID string) ([]entities.MenuOptionGroup, error) {
+	return f.byMenu[menuID], checkTx(tx)
+}
+
+func (f *fakeMenuOptionGroupStore) CreateOptionGroups(_ context.Context, _ store.Tx, groups []entities.MenuOptionGroup) error {
+	for _, group := range groups {
+		f.byMenu[group.MenuID] = append(f.byMenu[group.MenuID], group)
+	}
+	return nil
+}
+
+func (f *fakeMenuOptionGroupStore) UpdatePriceDeltas(_ context.Context, _ store.Tx, groups []entities.MenuOptionGroup) error {
+	f.updated = append(f.updated, groups...)
+	return nil
+}
+
+type fakeMenuItemStore struct {
+	store.MenuItem
+	byMenu  map[string][]entities.MenuItem
+	updated []entities.MenuItem
+}
+
+func (f *fakeMenuItemStore) FindItems(_ context.Context, tx store.Tx, menuID string) ([]entities.MenuItem, error) {
+	return f.byMenu[menuID], checkTx(tx)
+}
+
+func (f *fakeMenuItemStore) CreateMenuItems(_ context.Context, _ store.Tx, items []entities.MenuItem) error {
+	for _, item := range items {
+		f.byMenu[item.MenuID] = append(f.byMenu[item.MenuID], item)
+	}
+	return nil
+}
+
+func (f *fakeMenuItemStore) UpdatePrices(_ context.Context, _ store.Tx, items []entities.MenuItem) error {
+	f.updated = append(f.updated, items...)
+	return nil
+}
+
+type fakeMenuBundleStore struct {
+	store.MenuBundle
+	byMenu  map[string][]entities.MenuBundle
+	updated []entities.MenuBundle
+}
+
+func (f *fakeMenuBundleStore) FindByMenuID(_ context.Context, tx store.Tx, menuID string) ([]entities.MenuBundle, error) {
+	return f.byMenu[menuID], checkTx(tx)
+}
+
+func (f *fakeMenuBundleStore) CreateBundles(_ context.Context, _ store.Tx, bundles []entities.MenuBundle) error {
+	for _, bundle := range bundles {
+		f.byMenu[bundle.MenuID] = append(f.byMenu[bundle.MenuID], bundle)
+	}
+	return nil
+}
+
+func (f *fakeMenuBundleStore) UpdatePrices(_ context.Context, _ store.Tx, bundles []entities.MenuBundle) error {
+	f.updated = append(f.updated, bundles...)
+	return nil
+}
+
+type fakePromotionStore struct {
+	store.Promotion
+	promotions []entities.Promotion
+	updated    []entities.Promotion
+}
+
+func (f *fakePromotionStore) FindByBotID(_ context.Context, tx store.Tx, botID string) ([]entities.Promotion, error) {
+	if err := checkTx(tx); err != nil {
+		return nil, err
+	}
+	var promotions []entities.Promotion
+	for _, promotion := range f.promotions {
+		if promotion.BotID == botID {
+			promotions = append(promotions, promotion)
+		}
+	}
+	return promotions, nil
+}
+
+func (f *fakePromotionStore) Create(_ context.Context, _ store.Tx, promotion entities.Promotion) error {
+	f.promotions = append(f.promotions, promotion)
+	return nil
+}
+
+func (f *fakePromotionStore) Update(_ context.Context, _ store.Tx, promotion entities.Promotion) error {
+	f.updated = append(f.updated, promotion)
+	return nil
+}
+
+type fakeTaxConfigStore struct {
+	store.TaxConfig
+	configs map[string]entities.TaxConfig
+}
+
+func (f *fakeTaxConfigStore) FindByBotID(_ context.Context, tx store.Tx, botID string) (entities.TaxConfig, error) {
+	if err := checkTx(tx); err != nil {
+		return entities.TaxConfig{}, err
+	}
+	if config, ok := f.configs[botID]; ok {
+		return config, nil
+	}
+	return entities.TaxConfig{BotID: botID}, nil
+}
+
+func (f *fakeTaxConfigStore) Upsert(_ context.Context, _ store.Tx, config entities.TaxConfig) error {
+	f.configs[config.BotID] = config
+	return nil
+}
+
+type cloneFixture struct {
+	svc        *Svc
+	bots       *fakeBotStore
+	userBots   *fakeUserBotStore
+	templates  *fakeBotTemplateStore
+	menus      *fakeMenuStore
+	categories *fakeMenuCategoryStore
+	groups     *fakeMenuOptionGroupStore
+	items      *fakeMenuItemStore
+	bundles    *fakeMenuBundleStore
+	promotions *fakePromotionStore
+	taxConfigs *fakeTaxConfigStore
+}
+
+// newCloneFixture holds bot "src" with one menu whose item, option group,
+// category and bundle refer to each other, a promotion and a tax config.
+// The promotion and the tax config also refer to an item that is gone.
+func newCloneFixture() cloneFixture {
+	f := cloneFixture{
+		bots: &fakeBotStore{bots: map[string]entities.Bot{
+			"src": {ID: "src", BotName: "Diner", Currency: "EUR", PriceScale: 2, Timezone: "Europe/Berlin", DefaultLocale: "de", Locales: []string{"de", "en"}},
+		}},
+		userBots:  &fakeUserBotStore{},
+		templates: &fakeBotTemplateStore{},
+		menus: &fakeMenuStore{
+			menus:  []entities.Menu{{ID: "menu", BotID: "src", MenuName: "Lunch", Version: 7}},
+			bumped: map[string]int{},
+		},
+		categories: &fakeMenuCategoryStore{byMenu: map[string][]entities.MenuCategory{
+			"menu": {{ID: "mains", MenuID: "menu", CategoryName: "Mains", SortPosition: 1}},
+		}},
+		groups: &fakeMenuOptionGroupStore{byMenu: map[string][]entities.MenuOptionGroup{
+			"menu": {{ID: "size", MenuID: "menu", GroupName: "Size", MaxChoices: 1, Options: []entities.MenuOption{
+				{ID: "large", GroupID: "size", OptionName: "Large", PriceDeltaScaled: 150},
+			}}},
+		}},
+		items: &fakeMenuItemStore{byMenu: map[string][]entities.MenuItem{
+			"menu": {{ID: "burger", MenuID: "menu", MenuItemName: "Burger", PriceScaled: 950, CategoryID: "mains",
+				OptionGroupIDs: []string{"size"}, Aliases: []string{"cheeseburger"}}},
+		}},
+		bundles: &fakeMenuBundleStore{byMenu: map[string][]entities.MenuBundle{
+			"menu": {{ID: "combo", MenuID: "menu", BundleName: "Combo", PriceScaled: 1200, Slots: []entities.MenuBundleSlot{
+				{ID: "main", BundleID: "combo", SlotName: "Main", Choices: []entities.MenuBundleChoice{
+					{SlotID: "main", MenuItemID: "burger", UpchargeScaled: 50},
+				}},
+			}}},
+		}},
+		promotions: &fakePromotionStore{promotions: []entities.Promotion{
+			{ID: "promo", BotID: "src", PromotionName: "Happy hour", Type: entities.PromotionPercentOff, PercentOff: 1000,
+				ItemIDs: []string{"burger", "gone"}, CategoryIDs: []string{"mains"}, Priority: 3, Enabled: true},
+		}},
+		taxConfigs: &fakeTaxConfigStore{configs: map[string]entities.TaxConfig{
+			"src": {BotID: "src", Mode: entities.TaxInclusive, Rates: []entities.TaxRate{{Code: "std", RateName: "Standard", Rate: 190000}},
+				DefaultRateCode: "std", CategoryRateCodes: map[string]string{"mains": "std"},
+				ItemRateCodes: map[string]string{"burger": "std", "gone": "std"}, UpdatedAt: time.Unix(1700000000, 0)},
+		}},
+	}
+	f.svc = &Svc{
+		botStore:             f.bots,
+		userBotStore:         f.userBots,
+		botTemplateStore:     f.templates,
+		menuStore:            f.menus,
+		menuCategoryStore:    f.categories,
+		menuOptionGroupStore: f.groups,
+		menuItemStore:        f.items,
+		menuBundleStore:      f.bundles,
+		promotionStore:       f.promotions,
+		taxConfigStore:       f.taxConfigs,
+	}
+	return f
+}
+
+func TestCopyBot(t *testing.T) {
+	f := newCloneFixture()
+	newBot, err := f.svc.copyBot(context.Background(), cloneTx, "src", "Diner copy")
+	if err != nil {
+		t.Fatalf("copyBot() error = %v", err)
+	}
+	if newBot.ID == "src" || newBot.BotName != "Diner copy" {
+		t.Fatalf("copyBot() = %+v, want a new ID and the new name", newBot)
+	}
+	wantBot := f.bots.bots["src"]
+	wantBot.ID, wantBot.BotName = newBot.ID, "Diner copy"
+	if !reflect.DeepEqual(f.bots.bots[newBot.ID], wantBot) {
+		t.Errorf("copied bot = %+v, want %+v", f.bots.bots[newBot.ID], wantBot)
+	}
+	if len(f.userBots.created) != 0 {
+		t.Errorf("copyBot() linked the copy to users %+v, want ownership left to the caller", f.userBots.created)
+	}
+
+	if len(f.menus.menus) != 2 {
+		t.Fatalf("menus = %+v, want the source menu and its copy", f.menus.menus)
+	}
+	menu := f.menus.menus[1]
+	if menu.ID == "menu" || menu.BotID != newBot.ID || menu.MenuName != "Lunch" || menu.Version != 7 {
+		t.Errorf("copied menu = %+v", menu)
+	}
+	categories := f.categories.byMenu[menu.ID]
+	groups := f.groups.byMenu[menu.ID]
+	items := f.items.byMenu[menu.ID]
+	bundles := f.bundles.byMenu[menu.ID]
+	if len(categories) != 1 || len(groups) != 1 || len(items) != 1 || len(bundles) != 1 {
+		t.Fatalf("copied menu has %d categories, %d option groups, %d items and %d bundles, want 1 of each",
+			len(categories), len(groups), len(items), len(bundles))
+	}
+	category, group, item, bundle := categories[0], groups[0], items[0], bundles[0]
+	if category.ID == "mains" || category.CategoryName != "Mains" || category.SortPosition != 1 {
+		t.Errorf("copied category = %+v", category)
+	}
+	if group.ID == "size" || group.GroupName != "Size" || group.MaxChoices != 1 || len(group.Options) != 1 ||
+		group.Options[0].ID == "large" || group.Options[0].GroupID != group.ID || group.Options[0].PriceDeltaScaled != 150 {
+		t.Errorf("copied option group = %+v", group)
+	}
+	if item.ID == "burger" || item.MenuItemName != "Burger" || item.PriceScaled != 950 || item.CategoryID != category.ID ||
+		!reflect.DeepEqual(item.OptionGroupIDs, []string{group.ID}) || !reflect.DeepEqual(item.Aliases, []string{"cheeseburger"}) {
+		t.Errorf("copied item = %+v", item)
+	}
+	if bundle.ID == "combo" || bundle.PriceScaled != 1200 || len(bundle.Slots) != 1 || bundle.Slots[0].BundleID != bundle.ID ||
+		len(bundle.Slots[0].Choices) != 1 || bundle.Slots[0].Choices[0].MenuItemID != item.ID || bundle.Slots[0].Choices[0].UpchargeScaled != 50 {
+		t.Errorf("copied bundle = %+v", bundle)
+	}
+
+	if len(f.promotions.promotions) != 2 {
+		t.Fatalf("promotions = %+v, want the source promotion and its copy", f.promotions.promotions)
+	}
+	promotion := f.promotions.promotions[1]
+	wantPromotion := f.promotions.promotions[0]
+	wantPromotion.ID, wantPromotion.BotID = promotion.ID, newBot.ID
+	wantPromotion.ItemIDs, wantPromotion.CategoryIDs = []string{item.ID}, []string{category.ID}
+	if promotion.ID == "promo" || !reflect.DeepEqual(promotion, wantPromotion) {
+		t.Errorf("copied promotion = %+v, want %+v", promotion, wantPromotion)
+	}
+
+	wantConfig := f.taxConfigs.configs["src"]
+	wantConfig.BotID = newBot.ID
+	wantConfig.CategoryRateCodes = map[string]string{category.ID: "std"}
+	wantConfig.ItemRateCodes = map[string]string{item.ID: "std"}
+	if got := f.taxConfigs.configs[newBot.ID]; !reflect.DeepEqual(got, wantConfig) {
+		t.Errorf("copied tax config = %+v, want %+v", got, wantConfig)
+	}
+}
+
+func TestCopyBotWithoutTaxConfig(t *testing.T) {
+	f := newCloneFixture()
+	delete(f.taxConfigs.configs, "src")
+	newBot, err := f.svc.copyBot(context.Background(), cloneTx, "src", "Diner copy")
+	if err != nil {
+		t.Fatalf("copyBot() error = %v", err)
+	}
+	if config, ok := f.taxConfigs.configs[newBot.ID]; ok {
+		t.Errorf("copyBot() saved tax config %+v for a bot that never saved one", config)
+	}
+}
+
+func TestCopyBotReadsInTx(t *testing.T) {
+	f := newCloneFixture()
+	if _, err := f.svc.copyBot(context.Background(), nil, "src", "Diner copy"); !errors.Is(err, errOutsideTx) {
+		t.Errorf("copyBot() outside the transaction error = %v, want errOutsideTx", err)
+	}
+}
+
+func TestSaveTemplate(t *testing.T) {
+	f := newCloneFixture()
+	template, err := f.svc.saveTemplate(context.Background(), cloneTx, "user-1", "src", "Weekday lunch")
+	if err != nil {
+		t.Fatalf("saveTemplate() error = %v", err)
+	}
+	if template.UserID != "user-1" || template.TemplateName != "Weekday lunch" || template.BotID == "src" {
+		t.Errorf("saveTemplate() = %+v", template)
+	}
+	if !reflect.DeepEqual(f.templates.created, []entities.BotTemplate{template}) {
+		t.Errorf("templates = %+v, want %+v", f.templates.created, template)
+	}
+	snapshot, ok := f.bots.bots[template.BotID]
+	if !ok || snapshot.BotName != "Diner" {
+		t.Errorf("snapshot bot = %+v, want a copy named after the source bot", snapshot)
+	}
+	// The snapshot is hidden: no user owns it, so it is only reachable
+	// through the template.
+	if len(f.userBots.created) != 0 {
+		t.Errorf("saveTemplate() linked the snapshot to users %+v", f.userBots.created)
+	}
+}
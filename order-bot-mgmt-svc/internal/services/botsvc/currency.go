@@ -0,0 +1,155 @@
+package botsvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"time"
+)
+
+// UpdateCurrency sets the bot's currency and minor-unit scale. A nil scale
+// falls back to the ISO 4217 minor units of the currency. Draft menu prices
+// and promotion amounts are rescaled in place in the same transaction, each
+// rescaled menu moves to its next version, and the published promotions are
+// republished at the new scale before the transaction commits. The change is
+// rejected when an amount would lose digits.
+func (s *Svc) UpdateCurrency(ctx context.Context, tokenStr string, botID string, currency string, priceScale *int) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateCurrency: %w", err)
+	}
+	code, ok := moneyutil.NormalizeCurrency(currency)
+	if !ok {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateCurrency(), unknown currency %q: %w", currency, ErrInvalidCurrency)
+	}
+	scale, _ := moneyutil.MinorUnits(code)
+	if priceScale != nil {
+		scale = *priceScale
+	}
+	if scale < 0 || scale > moneyutil.MaxScale {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateCurrency(), scale %d out of range: %w", scale, ErrInvalidCurrency)
+	}
+	var bot entities.Bot
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		// The bot row is locked before the menus, in the order menu saves
+		// take them.
+		var errFinding error
+		bot, errFinding = s.botStore.FindByIDForUpdate(ctx, tx, botID)
+		if errFinding != nil {
+			return errFinding
+		}
+		from := bot.PriceScale
+		if err := s.rescaleMenus(ctx, tx, botID, from, scale); err != nil {
+			return err
+		}
+		promotions, err := s.rescalePromotions(ctx, tx, botID, from, scale)
+		if err != nil {
+			return err
+		}
+		bot.Currency, bot.PriceScale = code, scale
+		if err := s.botStore.UpdateCurrency(ctx, tx, botID, code, scale); err != nil {
+			return err
+		}
+		if from == scale {
+			return nil
+		}
+		// The order-bot schema is another database, so the published
+		// promotions are replaced last: a failure there rolls the draft
+		// changes back.
+		now := time.Now()
+		live := make([]entities.Promotion, 0, len(promotions))
+		for _, promotion := range promotions {
+			if promotion.Live(now) {
+				live = append(live, promotion)
+			}
+		}
+		return s.orderBotDb.WithTx(ctx, func(ctx context.Context, orderBotTx store.Tx) error {
+			return s.publishedPromotionStore.ReplaceBotPromotions(ctx, orderBotTx, bot, live)
+		})
+	})
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateCurrency: %w", err)
+	}
+	return bot, nil
+}
+
+// rescaleMenus converts item prices, option price deltas, bundle prices and
+// upcharges of the bot's draft menus from one scale to another.
+func (s *Svc) rescaleMenus(ctx context.Context, tx store.Tx, botID string, from int, to int) error {
+	if from == to {
+		return nil
+	}
+	menus, err := s.menuStore.ListByBotID(ctx, tx, botID)
+	if err != nil {
+		return fmt.Errorf("botsvc.rescaleMenus: %w", err)
+	}
+	for _, menu := range menus {
+		if err := s.rescaleMenu(ctx, tx, menu, from, to); err != nil {
+			return fmt.Errorf("botsvc.rescaleMenus: %w", err)
+		}
+	}
+	return nil
+}
+
+// rescaleMenu updates the prices of the menu in place, so items, options and
+// bundles keep their IDs, and moves the menu to its next version.
+func (s *Svc) rescaleMenu(ctx context.Context, tx store.Tx, menu entities.Menu, from int, to int) error {
+	detail := entities.MenuDetail{Menu: menu}
+	var err error
+	if detail.Items, err = s.menuItemStore.FindItems(ctx, tx, menu.ID); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if detail.OptionGroups, err = s.menuOptionGroupStore.FindByMenuID(ctx, tx, menu.ID); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if detail.Bundles, err = s.menuBundleStore.FindByMenuID(ctx, tx, menu.ID); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if err := detail.ConvertPrices(moneyutil.Rescaler(from, to)); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu(), %v: %w", err, ErrInvalidCurrency)
+	}
+	if err := s.menuItemStore.UpdatePrices(ctx, tx, detail.Items); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if err := s.menuOptionGroupStore.UpdatePriceDeltas(ctx, tx, detail.OptionGroups); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if err := s.menuBundleStore.UpdatePrices(ctx, tx, detail.Bundles); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	if err := s.menuStore.BumpVersion(ctx, tx, menu.ID, menu.Version); err != nil {
+		return fmt.Errorf("botsvc.rescaleMenu: %w", err)
+	}
+	return nil
+}
+
+// rescalePromotions converts the amounts off and thresholds of the bot's
+// promotions from one scale to another and returns the promotions at the new
+// scale.
+func (s *Svc) rescalePromotions(ctx context.Context, tx store.Tx, botID string, from int, to int) ([]entities.Promotion, error) {
+	promotions, err := s.promotionStore.FindByBotID(ctx, tx, botID)
+	if err != nil {
+		return nil, fmt.Errorf("botsvc.rescalePromotions: %w", err)
+	}
+	if from == to {
+		return promotions, nil
+	}
+	rescale := moneyutil.Rescaler(from, to)
+	for idx := range promotions {
+		promotion := &promotions[idx]
+		if promotion.AmountOffScaled, err = rescale(promotion.AmountOffScaled); err != nil {
+			return nil, fmt.Errorf("botsvc.rescalePromotions(), amount off of %q: %w", promotion.PromotionName, ErrInvalidCurrency)
+		}
+		if promotion.MinSubtotalScaled, err = rescale(promotion.MinSubtotalScaled); err != nil {
+			return nil, fmt.Errorf("botsvc.rescalePromotions(), minimum subtotal of %q: %w", promotion.PromotionName, ErrInvalidCurrency)
+		}
+		if err := s.promotionStore.Update(ctx, tx, *promotion); err != nil {
+			return nil, fmt.Errorf("botsvc.rescalePromotions: %w", err)
+		}
+	}
+	return promotions, nil
+}
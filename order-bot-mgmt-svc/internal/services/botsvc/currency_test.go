@@ -0,0 +1,52 @@
+package botsvc
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestRescaleMenus(t *testing.T) {
+	f := newCloneFixture()
+	if err := f.svc.rescaleMenus(context.Background(), cloneTx, "src", 2, 3); err != nil {
+		t.Fatalf("rescaleMenus() error = %v", err)
+	}
+	if len(f.items.updated) != 1 || f.items.updated[0].ID != "burger" || f.items.updated[0].PriceScaled != 9500 {
+		t.Errorf("updated items = %+v, want burger at 9500", f.items.updated)
+	}
+	if len(f.groups.updated) != 1 || f.groups.updated[0].Options[0].ID != "large" || f.groups.updated[0].Options[0].PriceDeltaScaled != 1500 {
+		t.Errorf("updated option groups = %+v, want large at 1500", f.groups.updated)
+	}
+	if len(f.bundles.updated) != 1 || f.bundles.updated[0].ID != "combo" || f.bundles.updated[0].PriceScaled != 12000 ||
+		f.bundles.updated[0].Slots[0].Choices[0].UpchargeScaled != 500 {
+		t.Errorf("updated bundles = %+v, want combo at 12000 with a 500 upcharge", f.bundles.updated)
+	}
+	if got, ok := f.menus.bumped["menu"]; !ok || got != 7 {
+		t.Errorf("BumpVersion() from %d (called %t), want from 7", got, ok)
+	}
+}
+
+func TestRescaleMenusTooPrecise(t *testing.T) {
+	f := newCloneFixture()
+	if err := f.svc.rescaleMenus(context.Background(), cloneTx, "src", 2, 0); !errors.Is(err, ErrInvalidCurrency) {
+		t.Fatalf("rescaleMenus() error = %v, want ErrInvalidCurrency", err)
+	}
+	if len(f.items.updated) != 0 || len(f.menus.bumped) != 0 {
+		t.Errorf("rescaleMenus() wrote items %+v and bumped %v after failing", f.items.updated, f.menus.bumped)
+	}
+}
+
+func TestRescalePromotions(t *testing.T) {
+	f := newCloneFixture()
+	f.promotions.promotions[0].AmountOffScaled, f.promotions.promotions[0].MinSubtotalScaled = 250, 2000
+	got, err := f.svc.rescalePromotions(context.Background(), cloneTx, "src", 2, 3)
+	if err != nil {
+		t.Fatalf("rescalePromotions() error = %v", err)
+	}
+	if len(got) != 1 || got[0].AmountOffScaled != 2500 || got[0].MinSubtotalScaled != 20000 {
+		t.Errorf("rescalePromotions() = %+v, want 2500 off from 20000", got)
+	}
+	if len(f.promotions.updated) != 1 || f.promotions.updated[0].ID != "promo" || f.promotions.updated[0].AmountOffScaled != 2500 {
+		t.Errorf("updated promotions = %+v", f.promotions.updated)
+	}
+}
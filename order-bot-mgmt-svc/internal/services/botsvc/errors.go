@@ -0,0 +1,30 @@
+package botsvc
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidBot = apperr.Err{
+		Code: "ErrInvalidBot",
+		Msg:  "invalid bot request",
+	}
+	ErrInvalidCurrency = apperr.Err{
+		Code: "ErrInvalidCurrency",
+		Msg:  "invalid currency or price scale",
+	}
+	ErrInvalidTimezone = apperr.Err{
+		Code: "ErrInvalidTimezone",
+		Msg:  "unknown timezone",
+	}
+	ErrInvalidLocale = apperr.Err{
+		Code: "ErrInvalidLocale",
+		Msg:  "invalid locale",
+	}
+	ErrInvalidTaxConfig = apperr.Err{
+		Code: "ErrInvalidTaxConfig",
+		Msg:  "invalid tax config",
+	}
+	ErrBotNotOwned = apperr.Err{
+		Code: "ErrBotNotOwned",
+		Msg:  "bot does not belong to the user",
+	}
+)
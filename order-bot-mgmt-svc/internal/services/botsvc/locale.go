@@ -0,0 +1,106 @@
+package botsvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/localeutil"
+	"slices"
+)
+
+// MaxLocales is the most locales a bot can support, its default included.
+const MaxLocales = 10
+
+// UpdateLocales sets the locale the bot's menus are written in and the
+// locales it answers in. The default locale is added to locales when missing
+// and always comes first. Draft menu translations into locales the bot no
+// longer translates to are dropped in the same transaction. The order bot
+// picks the change up with the next menu publish.
+func (s *Svc) UpdateLocales(ctx context.Context, tokenStr string, botID string, defaultLocale string, locales []string) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateLocales: %w", err)
+	}
+	defaultLocale, locales, err := normalizeLocales(defaultLocale, locales)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateLocales: %w", err)
+	}
+	var bot entities.Bot
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var errFinding error
+		bot, errFinding = s.botStore.FindByID(ctx, tx, botID)
+		if errFinding != nil {
+			return errFinding
+		}
+		bot.DefaultLocale, bot.Locales = defaultLocale, locales
+		if err := s.botStore.UpdateLocales(ctx, tx, botID, defaultLocale, locales); err != nil {
+			return err
+		}
+		return s.pruneTranslations(ctx, tx, bot)
+	})
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateLocales: %w", err)
+	}
+	return bot, nil
+}
+
+// pruneTranslations drops translations of the bot's draft menus into locales
+// it no longer translates to, the new default locale included. Earlier menu
+// versions keep them.
+func (s *Svc) pruneTranslations(ctx context.Context, tx store.Tx, bot entities.Bot) error {
+	menus, err := s.menuStore.ListByBotID(ctx, nil, bot.ID)
+	if err != nil {
+		return fmt.Errorf("botsvc.pruneTranslations: %w", err)
+	}
+	for _, menu := range menus {
+		categories, err := s.menuCategoryStore.FindByMenuID(ctx, nil, menu.ID)
+		if err != nil {
+			return fmt.Errorf("botsvc.pruneTranslations: %w", err)
+		}
+		for _, category := range categories {
+			if kept := category.Translations.Supported(bot); len(kept) != len(category.Translations) {
+				if err := s.menuCategoryStore.UpdateTranslations(ctx, tx, menu.ID, category.ID, kept); err != nil {
+					return fmt.Errorf("botsvc.pruneTranslations: %w", err)
+				}
+			}
+		}
+		items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+		if err != nil {
+			return fmt.Errorf("botsvc.pruneTranslations: %w", err)
+		}
+		for _, item := range items {
+			if kept := item.Translations.Supported(bot); len(kept) != len(item.Translations) {
+				if err := s.menuItemStore.UpdateTranslations(ctx, tx, menu.ID, item.ID, kept); err != nil {
+					return fmt.Errorf("botsvc.pruneTranslations: %w", err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// normalizeLocales puts every tag in canonical case, drops duplicates and
+// moves the default locale to the front.
+func normalizeLocales(defaultLocale string, locales []string) (string, []string, error) {
+	normalizedDefault, ok := localeutil.Normalize(defaultLocale)
+	if !ok {
+		return "", nil, fmt.Errorf("botsvc.normalizeLocales(), default locale %q: %w", defaultLocale, ErrInvalidLocale)
+	}
+	out := []string{normalizedDefault}
+	for _, locale := range locales {
+		normalized, ok := localeutil.Normalize(locale)
+		if !ok {
+			return "", nil, fmt.Errorf("botsvc.normalizeLocales(), locale %q: %w", locale, ErrInvalidLocale)
+		}
+		if !slices.Contains(out, normalized) {
+			out = append(out, normalized)
+		}
+	}
+	if len(out) > MaxLocales {
+		return "", nil, fmt.Errorf("botsvc.normalizeLocales(), %d locales, the limit is %d: %w", len(out), MaxLocales, ErrInvalidLocale)
+	}
+	return normalizedDefault, out, nil
+}
@@ -0,0 +1,42 @@
+package botsvc
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestNormalizeLocales(t *testing.T) {
+	tests := []struct {
+		name          string
+		defaultLocale string
+		locales       []string
+		wantDefault   string
+		want          []string
+		wantErr       bool
+	}{
+		{name: "default added first", defaultLocale: "zh_tw", locales: []string{"en", "ja"}, wantDefault: "zh-TW", want: []string{"zh-TW", "en", "ja"}},
+		{name: "duplicates dropped", defaultLocale: "en", locales: []string{"EN", "zh-TW", "zh-tw"}, wantDefault: "en", want: []string{"en", "zh-TW"}},
+		{name: "no other locales", defaultLocale: "en", wantDefault: "en", want: []string{"en"}},
+		{name: "bad default", defaultLocale: "english", wantErr: true},
+		{name: "bad locale", defaultLocale: "en", locales: []string{"en-USA"}, wantErr: true},
+		{
+			name:          "too many",
+			defaultLocale: "en",
+			locales:       []string{"de", "fr", "it", "es", "pt", "nl", "ja", "ko", "zh", "th"},
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		gotDefault, got, err := normalizeLocales(tt.defaultLocale, tt.locales)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidLocale) {
+				t.Errorf("%s: err = %v, want ErrInvalidLocale", tt.name, err)
+			}
+			continue
+		}
+		if err != nil || gotDefault != tt.wantDefault || !slices.Equal(got, tt.want) {
+			t.Errorf("%s: normalizeLocales() = %q, %q, %v, want %q, %q", tt.name, gotDefault, got, err, tt.wantDefault, tt.want)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package botsvc
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var hexColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func (s *Svc) GetProfile(ctx context.Context, tokenStr string, botID string) (entities.BotProfile, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.GetProfile: %w", err)
+	}
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.GetProfile: %w", err)
+	}
+	return bot.Profile, nil
+}
+
+// UpdateProfile replaces the profile and publishes it to the order-bot schema.
+// The logo is kept as is; it only changes through SetLogo.
+func (s *Svc) UpdateProfile(ctx context.Context, tokenStr string, botID string, profile entities.BotProfile) (entities.BotProfile, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.UpdateProfile: %w", err)
+	}
+	profile = normalizeProfile(profile)
+	if err := validateProfile(profile); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.UpdateProfile: %w", err)
+	}
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var errSaving error
+		if profile, errSaving = s.saveProfile(ctx, tx, botID, profile); errSaving != nil {
+			return errSaving
+		}
+		return s.publishProfile(ctx, botID, profile)
+	})
+	if err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.UpdateProfile: %w", err)
+	}
+	return profile, nil
+}
+
+func (s *Svc) SetLogo(ctx context.Context, tokenStr string, botID string, logo entities.Image) (entities.BotProfile, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.SetLogo: %w", err)
+	}
+	var profile entities.BotProfile
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var errSaving error
+		if profile, errSaving = s.saveLogo(ctx, tx, botID, logo); errSaving != nil {
+			return errSaving
+		}
+		return s.publishProfile(ctx, botID, profile)
+	})
+	if err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.SetLogo: %w", err)
+	}
+	return profile, nil
+}
+
+// DeleteProfile clears the profile and unpublishes it, so the C-side falls back
+// to its generic UI.
+func (s *Svc) DeleteProfile(ctx context.Context, tokenStr string, botID string) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return fmt.Errorf("botsvc.DeleteProfile: %w", err)
+	}
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		if err := s.botStore.UpdateProfile(ctx, tx, botID, entities.BotProfile{}); err != nil {
+			return err
+		}
+		return s.orderBotDb.WithTx(ctx, func(ctx context.Context, orderBotTx store.Tx) error {
+			return s.publishedBotProfileStore.Delete(ctx, orderBotTx, botID)
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("botsvc.DeleteProfile: %w", err)
+	}
+	return nil
+}
+
+// saveProfile saves profile with the bot's current logo. The bot row is
+// locked first, so a logo uploaded meanwhile is not written over.
+func (s *Svc) saveProfile(ctx context.Context, tx store.Tx, botID string, profile entities.BotProfile) (entities.BotProfile, error) {
+	bot, err := s.botStore.FindByIDForUpdate(ctx, tx, botID)
+	if err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.saveProfile: %w", err)
+	}
+	profile.LogoURL, profile.LogoThumbnailURL = bot.Profile.LogoURL, bot.Profile.LogoThumbnailURL
+	if err := s.botStore.UpdateProfile(ctx, tx, botID, profile); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.saveProfile: %w", err)
+	}
+	return profile, nil
+}
+
+// saveLogo saves the bot's current profile with logo, locked as in
+// saveProfile.
+func (s *Svc) saveLogo(ctx context.Context, tx store.Tx, botID string, logo entities.Image) (entities.BotProfile, error) {
+	bot, err := s.botStore.FindByIDForUpdate(ctx, tx, botID)
+	if err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.saveLogo: %w", err)
+	}
+	profile := bot.Profile
+	profile.LogoURL, profile.LogoThumbnailURL = logo.URL, logo.ThumbnailURL
+	if err := s.botStore.UpdateProfile(ctx, tx, botID, profile); err != nil {
+		return entities.BotProfile{}, fmt.Errorf("botsvc.saveLogo: %w", err)
+	}
+	return profile, nil
+}
+
+// publishProfile runs inside the transaction that saved the profile, so a
+// failed publish rolls the save back.
+func (s *Svc) publishProfile(ctx context.Context, botID string, profile entities.BotProfile) error {
+	if err := s.orderBotDb.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		return s.publishedBotProfileStore.Upsert(ctx, tx, botID, profile)
+	}); err != nil {
+		return fmt.Errorf("botsvc.publishProfile: %w", err)
+	}
+	return nil
+}
+
+func normalizeProfile(profile entities.BotProfile) entities.BotProfile {
+	profile.DisplayName = strings.TrimSpace(profile.DisplayName)
+	profile.Description = strings.TrimSpace(profile.Description)
+	profile.ThemePrimaryColor = strings.TrimSpace(profile.ThemePrimaryColor)
+	profile.ThemeSecondaryColor = strings.TrimSpace(profile.ThemeSecondaryColor)
+	profile.GreetingMessage = strings.TrimSpace(profile.GreetingMessage)
+	profile.ContactPhone = strings.TrimSpace(profile.ContactPhone)
+	profile.ContactEmail = strings.TrimSpace(profile.ContactEmail)
+	profile.ContactAddress = strings.TrimSpace(profile.ContactAddress)
+	return profile
+}
+
+func validateProfile(profile entities.BotProfile) error {
+	limits := []struct {
+		name  string
+		value string
+		max   int
+	}{
+		{"display name", profile.DisplayName, 100},
+		{"description", profile.Description, 1000},
+		{"greeting message", profile.GreetingMessage, 500},
+		{"contact phone", profile.ContactPhone, 30},
+		{"contact email", profile.ContactEmail, 254},
+		{"contact address", profile.ContactAddress, 300},
+	}
+	for _, limit := range limits {
+		if utf8.RuneCountInString(limit.value) > limit.max {
+			return fmt.Errorf("botsvc.validateProfile(), %s longer than %d: %w", limit.name, limit.max, ErrInvalidBot)
+		}
+	}
+	for _, color := range []string{profile.ThemePrimaryColor, profile.ThemeSecondaryColor} {
+		if color != "" && !hexColorPattern.MatchString(color) {
+			return fmt.Errorf("botsvc.validateProfile(), invalid color %q: %w", color, ErrInvalidBot)
+		}
+	}
+	if profile.ContactEmail != "" {
+		if _, err := mail.ParseAddress(profile.ContactEmail); err != nil {
+			return fmt.Errorf("botsvc.validateProfile(), invalid contact email: %w", ErrInvalidBot)
+		}
+	}
+	return nil
+}
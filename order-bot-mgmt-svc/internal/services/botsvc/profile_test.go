@@ -0,0 +1,136 @@
+package botsvc
+
+import (
+	"context"
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile entities.BotProfile
+		wantErr bool
+	}{
+		{name: "empty", profile: entities.BotProfile{}},
+		{
+			name: "full",
+			profile: entities.BotProfile{
+				DisplayName:         "Diner",
+				Description:         "Burgers and shakes",
+				ThemePrimaryColor:   "#1a2B3c",
+				ThemeSecondaryColor: "#FFFFFF",
+				GreetingMessage:     "Hi! What can I get you?",
+				ContactPhone:        "+49 30 1234567",
+				ContactEmail:        "hello@diner.example",
+				ContactAddress:      "Hauptstr. 1, Berlin",
+			},
+		},
+		{name: "display name at limit", profile: entities.BotProfile{DisplayName: strings.Repeat("ü", 100)}},
+		{name: "display name too long", profile: entities.BotProfile{DisplayName: strings.Repeat("a", 101)}, wantErr: true},
+		{name: "description too long", profile: entities.BotProfile{Description: strings.Repeat("a", 1001)}, wantErr: true},
+		{name: "greeting too long", profile: entities.BotProfile{GreetingMessage: strings.Repeat("a", 501)}, wantErr: true},
+		{name: "phone too long", profile: entities.BotProfile{ContactPhone: strings.Repeat("1", 31)}, wantErr: true},
+		{name: "address too long", profile: entities.BotProfile{ContactAddress: strings.Repeat("a", 301)}, wantErr: true},
+		{
+			name:    "email too long",
+			profile: entities.BotProfile{ContactEmail: strings.Repeat("a", 250) + "@x.io"},
+			wantErr: true,
+		},
+		{name: "short color", profile: entities.BotProfile{ThemePrimaryColor: "#fff"}, wantErr: true},
+		{name: "color without hash", profile: entities.BotProfile{ThemePrimaryColor: "ffffff"}, wantErr: true},
+		{name: "color not hex", profile: entities.BotProfile{ThemeSecondaryColor: "#gggggg"}, wantErr: true},
+		{name: "color name", profile: entities.BotProfile{ThemeSecondaryColor: "red"}, wantErr: true},
+		{name: "email without domain", profile: entities.BotProfile{ContactEmail: "hello"}, wantErr: true},
+		{name: "email with name", profile: entities.BotProfile{ContactEmail: "Diner <hello@diner.example>"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateProfile(tt.profile)
+			if tt.wantErr && !errors.Is(err, ErrInvalidBot) {
+				t.Errorf("validateProfile() error = %v, want ErrInvalidBot", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("validateProfile() error = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeProfile(t *testing.T) {
+	got := normalizeProfile(entities.BotProfile{
+		DisplayName:         "  Diner ",
+		Description:         "\tBurgers\n",
+		LogoURL:             " /files/bots/b/logo.png ",
+		ThemePrimaryColor:   " #123456",
+		ThemeSecondaryColor: "#654321 ",
+		GreetingMessage:     " Hi ",
+		ContactPhone:        " 123 ",
+		ContactEmail:        " hello@diner.example ",
+		ContactAddress:      " Main St ",
+	})
+	want := entities.BotProfile{
+		DisplayName:         "Diner",
+		Description:         "Burgers",
+		LogoURL:             " /files/bots/b/logo.png ",
+		ThemePrimaryColor:   "#123456",
+		ThemeSecondaryColor: "#654321",
+		GreetingMessage:     "Hi",
+		ContactPhone:        "123",
+		ContactEmail:        "hello@diner.example",
+		ContactAddress:      "Main St",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeProfile() = %+v, want %+v", got, want)
+	}
+}
+
+func TestSaveProfileKeepsLogo(t *testing.T) {
+	f := newCloneFixture()
+	bot := f.bots.bots["src"]
+	bot.Profile = entities.BotProfile{DisplayName: "Old", LogoURL: "/files/logo.png", LogoThumbnailURL: "/files/logo_thumb.png"}
+	f.bots.bots["src"] = bot
+
+	got, err := f.svc.saveProfile(context.Background(), cloneTx, "src", entities.BotProfile{DisplayName: "New", LogoURL: "/elsewhere.png"})
+	if err != nil {
+		t.Fatalf("saveProfile() error = %v", err)
+	}
+	want := entities.BotProfile{DisplayName: "New", LogoURL: "/files/logo.png", LogoThumbnailURL: "/files/logo_thumb.png"}
+	if got != want {
+		t.Errorf("saveProfile() = %+v, want %+v", got, want)
+	}
+	if f.bots.bots["src"].Profile != want {
+		t.Errorf("saved profile = %+v, want %+v", f.bots.bots["src"].Profile, want)
+	}
+}
+
+func TestSaveLogoKeepsProfile(t *testing.T) {
+	f := newCloneFixture()
+	bot := f.bots.bots["src"]
+	bot.Profile = entities.BotProfile{DisplayName: "Diner", ContactEmail: "hello@diner.example"}
+	f.bots.bots["src"] = bot
+
+	got, err := f.svc.saveLogo(context.Background(), cloneTx, "src", entities.Image{URL: "/files/logo.png", ThumbnailURL: "/files/logo_thumb.png"})
+	if err != nil {
+		t.Fatalf("saveLogo() error = %v", err)
+	}
+	want := entities.BotProfile{
+		DisplayName:      "Diner",
+		ContactEmail:     "hello@diner.example",
+		LogoURL:          "/files/logo.png",
+		LogoThumbnailURL: "/files/logo_thumb.png",
+	}
+	if got != want || f.bots.bots["src"].Profile != want {
+		t.Errorf("saveLogo() = %+v, saved %+v, want %+v", got, f.bots.bots["src"].Profile, want)
+	}
+}
+
+func TestSaveProfileInTx(t *testing.T) {
+	f := newCloneFixture()
+	if _, err := f.svc.saveProfile(context.Background(), nil, "src", entities.BotProfile{}); !errors.Is(err, errOutsideTx) {
+		t.Errorf("saveProfile() outside the transaction error = %v, want errOutsideTx", err)
+	}
+}
@@ -2,33 +2,87 @@ package botsvc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"order-bot-mgmt-svc/internal/config"
 	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/infra/sqldb/orderbotmgmtsqldb"
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util"
 	"order-bot-mgmt-svc/internal/util/jwtutil"
+	"strings"
+)
+
+const (
+	DefaultCurrency   = "USD"
+	DefaultPriceScale = 2
+	DefaultTimezone   = "UTC"
+	DefaultLocale     = "en"
 )
 
 type Svc struct {
-	db           *sqldb.DB
-	ctxFunc      util.CtxFunc
-	botStore     store.Bot
-	userBotStore store.UserBot
-	accessSecret []byte
+	db                       *sqldb.DB
+	orderBotDb               *sqldb.DB
+	ctxFunc                  util.CtxFunc
+	botStore                 store.Bot
+	userBotStore             store.UserBot
+	botTemplateStore         store.BotTemplate
+	menuStore                store.Menu
+	menuItemStore            store.MenuItem
+	menuCategoryStore        store.MenuCategory
+	menuOptionGroupStore     store.MenuOptionGroup
+	menuBundleStore          store.MenuBundle
+	promotionStore           store.Promotion
+	taxConfigStore           store.TaxConfig
+	publishedBotProfileStore *orderbotmgmtsqldb.PublishedBotProfileStore
+	publishedTaxConfigStore  *orderbotmgmtsqldb.PublishedTaxConfigStore
+	publishedPromotionStore  *orderbotmgmtsqldb.PublishedPromotionStore
+	accessSecret             []byte
 }
 
-func NewSvc(db *sqldb.DB, ctxFunc util.CtxFunc, cfg config.Config, botStore store.Bot, userBotStore store.UserBot) *Svc {
-	if botStore == nil || db == nil {
-		panic("botsvc.NewSvc(), botStore, menuItemStore or db is nil")
+func NewSvc(
+	db *sqldb.DB,
+	orderBotDb *sqldb.DB,
+	ctxFunc util.CtxFunc,
+	cfg config.Config,
+	botStore store.Bot,
+	userBotStore store.UserBot,
+	botTemplateStore store.BotTemplate,
+	menuStore store.Menu,
+	menuItemStore store.MenuItem,
+	menuCategoryStore store.MenuCategory,
+	menuOptionGroupStore store.MenuOptionGroup,
+	menuBundleStore store.MenuBundle,
+	promotionStore store.Promotion,
+	taxConfigStore store.TaxConfig,
+	publishedBotProfileStore *orderbotmgmtsqldb.PublishedBotProfileStore,
+	publishedTaxConfigStore *orderbotmgmtsqldb.PublishedTaxConfigStore,
+	publishedPromotionStore *orderbotmgmtsqldb.PublishedPromotionStore,
+) *Svc {
+	if botStore == nil || menuStore == nil || menuItemStore == nil || menuCategoryStore == nil || menuOptionGroupStore == nil ||
+		menuBundleStore == nil || promotionStore == nil || taxConfigStore == nil || db == nil {
+		panic("botsvc.NewSvc(), botStore, menuStore, menuItemStore, menuCategoryStore, menuOptionGroupStore, menuBundleStore, " +
+			"promotionStore, taxConfigStore or db is nil")
 	}
 	return &Svc{
-		botStore:     botStore,
-		userBotStore: userBotStore,
-		db:           db,
-		ctxFunc:      ctxFunc,
-		accessSecret: []byte(cfg.Auth.AccessSecret),
+		botStore:                 botStore,
+		userBotStore:             userBotStore,
+		botTemplateStore:         botTemplateStore,
+		menuStore:                menuStore,
+		menuItemStore:            menuItemStore,
+		menuCategoryStore:        menuCategoryStore,
+		menuOptionGroupStore:     menuOptionGroupStore,
+		menuBundleStore:          menuBundleStore,
+		promotionStore:           promotionStore,
+		taxConfigStore:           taxConfigStore,
+		publishedBotProfileStore: publishedBotProfileStore,
+		publishedTaxConfigStore:  publishedTaxConfigStore,
+		publishedPromotionStore:  publishedPromotionStore,
+		db:                       db,
+		orderBotDb:               orderBotDb,
+		ctxFunc:                  ctxFunc,
+		accessSecret:             []byte(cfg.Auth.AccessSecret),
 	}
 }
 
@@ -36,8 +90,13 @@ func (s *Svc) CreateBot(ctx context.Context, tx store.Tx, name string, userId st
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
 	newBot := entities.Bot{
-		ID:      util.NewID(),
-		BotName: name,
+		ID:            util.NewID(),
+		BotName:       name,
+		Currency:      DefaultCurrency,
+		PriceScale:    DefaultPriceScale,
+		Timezone:      DefaultTimezone,
+		DefaultLocale: DefaultLocale,
+		Locales:       []string{DefaultLocale},
 	}
 	if err := s.botStore.Create(ctx, tx, newBot); err != nil {
 		return fmt.Errorf("botsvc.CreateBot: %w", err)
@@ -67,3 +126,369 @@ func (s *Svc) GetBotId(ctx context.Context, tokenStr string) (botId string, err
 	}
 	return userBots[0].BotID, err
 }
+
+// GetBot loads the bot without an ownership check, for callers that already
+// resolved the bot ID.
+func (s *Svc) GetBot(ctx context.Context, botID string) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.GetBot: %w", err)
+	}
+	return bot, nil
+}
+
+// CloneBot copies the bot's settings, draft menus, promotions and tax config
+// into a new bot owned by the caller. Every copied row gets a fresh ID. The
+// copied tax config is published; publishing the promotions is left to the
+// caller.
+func (s *Svc) CloneBot(ctx context.Context, tokenStr string, botID string, botName string) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	userID, err := s.ownedBotUserID(ctx, tokenStr, botID)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CloneBot: %w", err)
+	}
+	var newBot entities.Bot
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var errCopy error
+		newBot, errCopy = s.copyBot(ctx, tx, botID, botName)
+		if errCopy != nil {
+			return errCopy
+		}
+		return s.userBotStore.Create(ctx, tx, entities.UserBot{ID: util.NewID(), UserID: userID, BotID: newBot.ID})
+	})
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CloneBot: %w", err)
+	}
+	if err := s.publishTaxConfig(ctx, newBot.ID); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CloneBot: %w", err)
+	}
+	return newBot, nil
+}
+
+// SaveAsTemplate snapshots the bot into a hidden bot that is referenced by a
+// template of the caller. Later edits to the source bot do not affect it.
+func (s *Svc) SaveAsTemplate(ctx context.Context, tokenStr string, botID string, templateName string) (entities.BotTemplate, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if strings.TrimSpace(templateName) == "" {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.SaveAsTemplate(), empty template name: %w", ErrInvalidBot)
+	}
+	userID, err := s.ownedBotUserID(ctx, tokenStr, botID)
+	if err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.SaveAsTemplate: %w", err)
+	}
+	var template entities.BotTemplate
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var errSaving error
+		template, errSaving = s.saveTemplate(ctx, tx, userID, botID, templateName)
+		return errSaving
+	})
+	if err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.SaveAsTemplate: %w", err)
+	}
+	return template, nil
+}
+
+// saveTemplate copies the bot without linking the copy to any user, so the
+// snapshot is only reachable through the template.
+func (s *Svc) saveTemplate(ctx context.Context, tx store.Tx, userID string, botID string, templateName string) (entities.BotTemplate, error) {
+	srcBot, err := s.botStore.FindByID(ctx, tx, botID)
+	if err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.saveTemplate: %w", err)
+	}
+	snapshot, err := s.copyBot(ctx, tx, botID, srcBot.BotName)
+	if err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.saveTemplate: %w", err)
+	}
+	template := entities.BotTemplate{
+		ID:           util.NewID(),
+		UserID:       userID,
+		BotID:        snapshot.ID,
+		TemplateName: templateName,
+	}
+	if err := s.botTemplateStore.Create(ctx, tx, template); err != nil {
+		return entities.BotTemplate{}, fmt.Errorf("botsvc.saveTemplate: %w", err)
+	}
+	return template, nil
+}
+
+func (s *Svc) ListTemplates(ctx context.Context, tokenStr string) ([]entities.BotTemplate, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	claims, err := jwtutil.ParseJWT(s.accessSecret, tokenStr)
+	if err != nil {
+		return nil, fmt.Errorf("botsvc.ListTemplates(): %w", err)
+	}
+	templates, err := s.botTemplateStore.FindByUserID(ctx, nil, claims.Sub)
+	if err != nil {
+		return nil, fmt.Errorf("botsvc.ListTemplates: %w", err)
+	}
+	return templates, nil
+}
+
+// CreateBotFromTemplate creates a new bot of the caller from one of the
+// caller's templates. As with CloneBot, the tax config is published and the
+// promotions are not.
+func (s *Svc) CreateBotFromTemplate(ctx context.Context, tokenStr string, templateID string, botName string) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	claims, err := jwtutil.ParseJWT(s.accessSecret, tokenStr)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CreateBotFromTemplate(): %w", err)
+	}
+	var newBot entities.Bot
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		template, errFinding := s.botTemplateStore.FindByID(ctx, tx, templateID)
+		if errFinding != nil {
+			return errFinding
+		}
+		if template.UserID != claims.Sub {
+			return fmt.Errorf("template %s: %w", templateID, store.ErrBotTemplateNotFound)
+		}
+		var errCopy error
+		newBot, errCopy = s.copyBot(ctx, tx, template.BotID, botName)
+		if errCopy != nil {
+			return errCopy
+		}
+		return s.userBotStore.Create(ctx, tx, entities.UserBot{ID: util.NewID(), UserID: claims.Sub, BotID: newBot.ID})
+	})
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CreateBotFromTemplate: %w", err)
+	}
+	if err := s.publishTaxConfig(ctx, newBot.ID); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.CreateBotFromTemplate: %w", err)
+	}
+	return newBot, nil
+}
+
+// AuthorizeBot fails with ErrBotNotOwned unless the caller owns the bot.
+func (s *Svc) AuthorizeBot(ctx context.Context, tokenStr string, botID string) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return fmt.Errorf("botsvc.AuthorizeBot: %w", err)
+	}
+	return nil
+}
+
+// ownedBotUserID returns the caller's user ID if the caller owns the bot.
+func (s *Svc) ownedBotUserID(ctx context.Context, tokenStr string, botID string) (string, error) {
+	claims, err := jwtutil.ParseJWT(s.accessSecret, tokenStr)
+	if err != nil {
+		return "", fmt.Errorf("botsvc.ownedBotUserID(): %w", err)
+	}
+	userBots, err := s.userBotStore.FindByUserID(ctx, nil, claims.Sub)
+	if err != nil {
+		if errors.Is(err, store.ErrUserBotNotFound) {
+			return "", fmt.Errorf("botsvc.ownedBotUserID: %w", ErrBotNotOwned)
+		}
+		return "", fmt.Errorf("botsvc.ownedBotUserID: %w", err)
+	}
+	for _, userBot := range userBots {
+		if userBot.BotID == botID {
+			return claims.Sub, nil
+		}
+	}
+	return "", fmt.Errorf("botsvc.ownedBotUserID(), bot %s: %w", botID, ErrBotNotOwned)
+}
+
+// copyBot copies the bot row, its draft menus, promotions and tax config with
+// fresh IDs. Everything is read inside tx, so the copy is consistent even
+// while the source bot is being edited. Ownership of the new bot is left to
+// the caller.
+func (s *Svc) copyBot(ctx context.Context, tx store.Tx, srcBotID string, botName string) (entities.Bot, error) {
+	if strings.TrimSpace(botName) == "" {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot(), empty bot name: %w", ErrInvalidBot)
+	}
+	srcBot, err := s.botStore.FindByID(ctx, tx, srcBotID)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+	}
+	newBot := srcBot
+	newBot.ID = util.NewID()
+	newBot.BotName = botName
+	if err := s.botStore.Create(ctx, tx, newBot); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+	}
+	srcMenus, err := s.menuStore.ListByBotID(ctx, tx, srcBotID)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+	}
+	ids := copiedIDs{categories: map[string]string{}, items: map[string]string{}}
+	for _, srcMenu := range srcMenus {
+		if err := s.copyMenu(ctx, tx, srcMenu, newBot.ID, ids); err != nil {
+			return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+		}
+	}
+	if err := s.copyPromotions(ctx, tx, srcBotID, newBot.ID, ids); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+	}
+	if err := s.copyTaxConfig(ctx, tx, srcBotID, newBot.ID, ids); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.copyBot: %w", err)
+	}
+	return newBot, nil
+}
+
+// copiedIDs maps the category and item IDs of the source bot to those of the
+// copy, for the promotions and tax overrides that refer to them.
+type copiedIDs struct {
+	categories map[string]string
+	items      map[string]string
+}
+
+// remap returns the copies of ids, leaving out IDs that were not copied.
+func remap(ids []string, copies map[string]string) []string {
+	out := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if newID, ok := copies[id]; ok {
+			out = append(out, newID)
+		}
+	}
+	return out
+}
+
+func (s *Svc) copyPromotions(ctx context.Context, tx store.Tx, srcBotID string, botID string, ids copiedIDs) error {
+	promotions, err := s.promotionStore.FindByBotID(ctx, tx, srcBotID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyPromotions: %w", err)
+	}
+	for _, promotion := range promotions {
+		promotion.ID = util.NewID()
+		promotion.BotID = botID
+		promotion.ItemIDs = remap(promotion.ItemIDs, ids.items)
+		promotion.CategoryIDs = remap(promotion.CategoryIDs, ids.categories)
+		if err := s.promotionStore.Create(ctx, tx, promotion); err != nil {
+			return fmt.Errorf("botsvc.copyPromotions: %w", err)
+		}
+	}
+	return nil
+}
+
+// copyTaxConfig copies a saved tax config; a bot that never saved one keeps
+// the default.
+func (s *Svc) copyTaxConfig(ctx context.Context, tx store.Tx, srcBotID string, botID string, ids copiedIDs) error {
+	config, err := s.taxConfigStore.FindByBotID(ctx, tx, srcBotID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyTaxConfig: %w", err)
+	}
+	if config.UpdatedAt.IsZero() {
+		return nil
+	}
+	remapCodes := func(codes map[string]string, copies map[string]string) map[string]string {
+		out := make(map[string]string, len(codes))
+		for id, code := range codes {
+			if newID, ok := copies[id]; ok {
+				out[newID] = code
+			}
+		}
+		return out
+	}
+	config.BotID = botID
+	config.CategoryRateCodes = remapCodes(config.CategoryRateCodes, ids.categories)
+	config.ItemRateCodes = remapCodes(config.ItemRateCodes, ids.items)
+	if err := s.taxConfigStore.Upsert(ctx, tx, config); err != nil {
+		return fmt.Errorf("botsvc.copyTaxConfig: %w", err)
+	}
+	return nil
+}
+
+func (s *Svc) copyMenu(ctx context.Context, tx store.Tx, srcMenu entities.Menu, botID string, ids copiedIDs) error {
+	newMenu := srcMenu
+	newMenu.ID = util.NewID()
+	newMenu.BotID = botID
+	if err := s.menuStore.CreateMenu(ctx, tx, newMenu); err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	srcCategories, err := s.menuCategoryStore.FindByMenuID(ctx, tx, srcMenu.ID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	categoryIDs := ids.categories
+	newCategories := make([]entities.MenuCategory, 0, len(srcCategories))
+	for _, category := range srcCategories {
+		categoryIDs[category.ID] = util.NewID()
+		category.ID = categoryIDs[category.ID]
+		category.MenuID = newMenu.ID
+		newCategories = append(newCategories, category)
+	}
+	if err := s.menuCategoryStore.CreateCategories(ctx, tx, newCategories); err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	srcGroups, err := s.menuOptionGroupStore.FindByMenuID(ctx, tx, srcMenu.ID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	groupIDs := make(map[string]string, len(srcGroups))
+	newGroups := make([]entities.MenuOptionGroup, 0, len(srcGroups))
+	for _, group := range srcGroups {
+		groupIDs[group.ID] = util.NewID()
+		group.ID = groupIDs[group.ID]
+		group.MenuID = newMenu.ID
+		options := make([]entities.MenuOption, 0, len(group.Options))
+		for _, option := range group.Options {
+			option.ID = util.NewID()
+			option.GroupID = group.ID
+			options = append(options, option)
+		}
+		group.Options = options
+		newGroups = append(newGroups, group)
+	}
+	if err := s.menuOptionGroupStore.CreateOptionGroups(ctx, tx, newGroups); err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	srcItems, err := s.menuItemStore.FindItems(ctx, tx, srcMenu.ID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	if len(srcItems) == 0 {
+		return nil
+	}
+	itemIDs := ids.items
+	newItems := make([]entities.MenuItem, 0, len(srcItems))
+	for _, item := range srcItems {
+		itemIDs[item.ID] = util.NewID()
+		item.ID = itemIDs[item.ID]
+		item.MenuID = newMenu.ID
+		item.CategoryID = categoryIDs[item.CategoryID]
+		itemGroupIDs := make([]string, 0, len(item.OptionGroupIDs))
+		for _, groupID := range item.OptionGroupIDs {
+			itemGroupIDs = append(itemGroupIDs, groupIDs[groupID])
+		}
+		item.OptionGroupIDs = itemGroupIDs
+		newItems = append(newItems, item)
+	}
+	if err := s.menuItemStore.CreateMenuItems(ctx, tx, newItems); err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	srcBundles, err := s.menuBundleStore.FindByMenuID(ctx, tx, srcMenu.ID)
+	if err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	newBundles := make([]entities.MenuBundle, 0, len(srcBundles))
+	for _, bundle := range srcBundles {
+		bundle.ID = util.NewID()
+		bundle.MenuID = newMenu.ID
+		slots := make([]entities.MenuBundleSlot, 0, len(bundle.Slots))
+		for _, slot := range bundle.Slots {
+			slot.ID = util.NewID()
+			slot.BundleID = bundle.ID
+			choices := make([]entities.MenuBundleChoice, 0, len(slot.Choices))
+			for _, choice := range slot.Choices {
+				choice.SlotID = slot.ID
+				choice.MenuItemID = itemIDs[choice.MenuItemID]
+				choices = append(choices, choice)
+			}
+			slot.Choices = choices
+			slots = append(slots, slot)
+		}
+		bundle.Slots = slots
+		newBundles = append(newBundles, bundle)
+	}
+	if err := s.menuBundleStore.CreateBundles(ctx, tx, newBundles); err != nil {
+		return fmt.Errorf("botsvc.copyMenu: %w", err)
+	}
+	return nil
+}
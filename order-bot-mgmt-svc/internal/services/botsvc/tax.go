@@ -0,0 +1,208 @@
+package botsvc
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+var taxCodePattern = regexp.MustCompile(`^[A-Za-z0-9_-]{1,32}$`)
+
+// taxTargets are the item and category IDs across a bot's menus.
+type taxTargets struct {
+	items      map[string]struct{}
+	categories map[string]struct{}
+}
+
+// GetTaxConfig returns the bot's tax config, which is exclusive and without
+// rates until it is first saved. Overrides of items and categories that are
+// no longer in the bot's menus are left out.
+func (s *Svc) GetTaxConfig(ctx context.Context, tokenStr string, botID string) (entities.TaxConfig, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.GetTaxConfig: %w", err)
+	}
+	config, err := s.taxConfigStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.GetTaxConfig: %w", err)
+	}
+	targets, err := s.taxTargets(ctx, botID)
+	if err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.GetTaxConfig: %w", err)
+	}
+	config = normalizeTaxConfig(config)
+	maps.DeleteFunc(config.CategoryRateCodes, func(id string, _ string) bool { return !hasKey(targets.categories, id) })
+	maps.DeleteFunc(config.ItemRateCodes, func(id string, _ string) bool { return !hasKey(targets.items, id) })
+	return config, nil
+}
+
+// UpdateTaxConfig replaces the bot's tax config and publishes it to the
+// order-bot schema. Overrides must name items and categories of the bot's
+// menus and every code must be one of the config's rates.
+func (s *Svc) UpdateTaxConfig(ctx context.Context, tokenStr string, botID string, config entities.TaxConfig) (entities.TaxConfig, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	config.BotID = botID
+	config = normalizeTaxConfig(config)
+	targets, err := s.taxTargets(ctx, botID)
+	if err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	if err := validateTaxConfig(config, targets); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	if err := s.taxConfigStore.Upsert(ctx, nil, config); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	if err := s.publishTaxConfig(ctx, botID); err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	saved, err := s.taxConfigStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return entities.TaxConfig{}, fmt.Errorf("botsvc.UpdateTaxConfig: %w", err)
+	}
+	return normalizeTaxConfig(saved), nil
+}
+
+// publishTaxConfig copies the bot's saved tax config to the order-bot schema.
+// A bot that never saved one has nothing to publish.
+func (s *Svc) publishTaxConfig(ctx context.Context, botID string) error {
+	config, err := s.taxConfigStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return fmt.Errorf("botsvc.publishTaxConfig: %w", err)
+	}
+	if config.UpdatedAt.IsZero() {
+		return nil
+	}
+	if err := s.orderBotDb.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		return s.publishedTaxConfigStore.Upsert(ctx, tx, normalizeTaxConfig(config))
+	}); err != nil {
+		return fmt.Errorf("botsvc.publishTaxConfig: %w", err)
+	}
+	return nil
+}
+
+func (s *Svc) taxTargets(ctx context.Context, botID string) (taxTargets, error) {
+	targets := taxTargets{items: map[string]struct{}{}, categories: map[string]struct{}{}}
+	menus, err := s.menuStore.ListByBotID(ctx, nil, botID)
+	if err != nil {
+		return taxTargets{}, fmt.Errorf("botsvc.taxTargets: %w", err)
+	}
+	for _, menu := range menus {
+		items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+		if err != nil {
+			return taxTargets{}, fmt.Errorf("botsvc.taxTargets: %w", err)
+		}
+		for _, item := range items {
+			targets.items[item.ID] = struct{}{}
+		}
+		categories, err := s.menuCategoryStore.FindByMenuID(ctx, nil, menu.ID)
+		if err != nil {
+			return taxTargets{}, fmt.Errorf("botsvc.taxTargets: %w", err)
+		}
+		for _, category := range categories {
+			targets.categories[category.ID] = struct{}{}
+		}
+	}
+	return targets, nil
+}
+
+// normalizeTaxConfig trims codes and names, makes an empty mode exclusive
+// and gives the config empty rather than nil collections.
+func normalizeTaxConfig(config entities.TaxConfig) entities.TaxConfig {
+	if config.Mode == "" {
+		config.Mode = entities.TaxExclusive
+	}
+	rates := make([]entities.TaxRate, 0, len(config.Rates))
+	for _, rate := range config.Rates {
+		rate.Code, rate.RateName = strings.TrimSpace(rate.Code), strings.TrimSpace(rate.RateName)
+		rates = append(rates, rate)
+	}
+	config.Rates = rates
+	config.DefaultRateCode = strings.TrimSpace(config.DefaultRateCode)
+	trimCodes := func(codes map[string]string) map[string]string {
+		out := make(map[string]string, len(codes))
+		for id, code := range codes {
+			out[id] = strings.TrimSpace(code)
+		}
+		return out
+	}
+	config.CategoryRateCodes = trimCodes(config.CategoryRateCodes)
+	config.ItemRateCodes = trimCodes(config.ItemRateCodes)
+	return config
+}
+
+// validateTaxConfig reports every problem of config as
+// validatorutil.FieldErrors with fields such as "rates[1].code" and
+// "item_rates.<item ID>". Overrides are checked in ID order.
+func validateTaxConfig(config entities.TaxConfig, targets taxTargets) error {
+	var errs validatorutil.FieldErrors
+	if config.Mode != entities.TaxExclusive && config.Mode != entities.TaxInclusive {
+		errs.Add(ErrInvalidTaxConfig, "mode", validatorutil.CodeInvalid, "mode must be %q or %q, got %q",
+			entities.TaxExclusive, entities.TaxInclusive, config.Mode)
+	}
+	if len(config.Rates) > entities.MaxTaxRates {
+		errs.Add(ErrInvalidTaxConfig, "rates", validatorutil.CodeTooMany, "%d rates, the limit is %d", len(config.Rates), entities.MaxTaxRates)
+	}
+	codes := make(map[string]struct{}, len(config.Rates))
+	for idx, rate := range config.Rates {
+		field := fmt.Sprintf("rates[%d]", idx)
+		switch {
+		case rate.Code == "":
+			errs.Add(ErrInvalidTaxConfig, field+".code", validatorutil.CodeRequired, "code is required")
+		case !taxCodePattern.MatchString(rate.Code):
+			errs.Add(ErrInvalidTaxConfig, field+".code", validatorutil.CodeInvalid,
+				"code %q must be up to 32 letters, digits, dashes or underscores", rate.Code)
+		case hasKey(codes, rate.Code):
+			errs.Add(ErrInvalidTaxConfig, field+".code", validatorutil.CodeDuplicate, "code %q is used by an earlier rate", rate.Code)
+		}
+		codes[rate.Code] = struct{}{}
+		if rate.RateName == "" {
+			errs.Add(ErrInvalidTaxConfig, field+".name", validatorutil.CodeRequired, "name is required")
+		} else if n := utf8.RuneCountInString(rate.RateName); n > entities.MaxNameLen {
+			errs.Add(ErrInvalidTaxConfig, field+".name", validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+		}
+		if rate.Rate < 0 || rate.Rate > entities.FullTaxRate {
+			errs.Add(ErrInvalidTaxConfig, field+".rate", validatorutil.CodeOutOfRange, "rate must be from 0 to 100")
+		}
+	}
+	if config.DefaultRateCode != "" && !hasKey(codes, config.DefaultRateCode) {
+		errs.Add(ErrInvalidTaxConfig, "default_rate", validatorutil.CodeUnknown, "no rate has code %q", config.DefaultRateCode)
+	}
+	validateOverrides := func(field string, overrides map[string]string, known map[string]struct{}, kind string) {
+		if len(overrides) > entities.MaxTaxOverrides {
+			errs.Add(ErrInvalidTaxConfig, field, validatorutil.CodeTooMany, "%d overrides, the limit is %d", len(overrides), entities.MaxTaxOverrides)
+		}
+		for _, id := range slices.Sorted(maps.Keys(overrides)) {
+			switch code := overrides[id]; {
+			case !hasKey(known, id):
+				errs.Add(ErrInvalidTaxConfig, field+"."+id, validatorutil.CodeUnknown, "no %s %q in the bot's menus", kind, id)
+			case !hasKey(codes, code):
+				errs.Add(ErrInvalidTaxConfig, field+"."+id, validatorutil.CodeUnknown, "no rate has code %q", code)
+			}
+		}
+	}
+	validateOverrides("category_rates", config.CategoryRateCodes, targets.categories, "category")
+	validateOverrides("item_rates", config.ItemRateCodes, targets.items, "item")
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("botsvc.validateTaxConfig: %w", err)
+	}
+	return nil
+}
+
+func hasKey(set map[string]struct{}, key string) bool {
+	_, ok := set[key]
+	return ok
+}
@@ -0,0 +1,82 @@
+package botsvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"reflect"
+	"testing"
+)
+
+func TestNormalizeTaxConfig(t *testing.T) {
+	got := normalizeTaxConfig(entities.TaxConfig{
+		Rates:           []entities.TaxRate{{Code: " std ", RateName: " Standard ", Rate: 100000}},
+		DefaultRateCode: " std",
+		ItemRateCodes:   map[string]string{"cola": "std "},
+	})
+	want := entities.TaxConfig{
+		Mode:              entities.TaxExclusive,
+		Rates:             []entities.TaxRate{{Code: "std", RateName: "Standard", Rate: 100000}},
+		DefaultRateCode:   "std",
+		CategoryRateCodes: map[string]string{},
+		ItemRateCodes:     map[string]string{"cola": "std"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("normalizeTaxConfig() = %+v, want %+v", got, want)
+	}
+}
+
+func TestValidateTaxConfig(t *testing.T) {
+	targets := taxTargets{
+		items:      map[string]struct{}{"burger": {}, "cola": {}},
+		categories: map[string]struct{}{"drinks": {}},
+	}
+	valid := entities.TaxConfig{
+		Mode: entities.TaxInclusive,
+		Rates: []entities.TaxRate{
+			{Code: "std", RateName: "Standard", Rate: 190000},
+			{Code: "reduced", RateName: "Reduced", Rate: 70000},
+		},
+		DefaultRateCode:   "std",
+		CategoryRateCodes: map[string]string{"drinks": "reduced"},
+		ItemRateCodes:     map[string]string{"cola": "std"},
+	}
+	if err := validateTaxConfig(valid, targets); err != nil {
+		t.Fatalf("validateTaxConfig() = %v, want nil", err)
+	}
+
+	invalid := entities.TaxConfig{
+		Mode: "gross",
+		Rates: []entities.TaxRate{
+			{Code: "std", RateName: "Standard", Rate: entities.FullTaxRate + 1},
+			{Code: "std", Rate: -1},
+			{Code: "no spaces", RateName: "Other"},
+		},
+		DefaultRateCode:   "vat",
+		CategoryRateCodes: map[string]string{"mains": "std"},
+		ItemRateCodes:     map[string]string{"cola": "reduced", "burger": "std", "salad": "std"},
+	}
+	err := validateTaxConfig(invalid, targets)
+	if !errors.Is(err, ErrInvalidTaxConfig) {
+		t.Fatalf("validateTaxConfig() error = %v, want ErrInvalidTaxConfig", err)
+	}
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	want := []string{
+		"mode:invalid",
+		"rates[0].rate:out_of_range",
+		"rates[1].code:duplicate",
+		"rates[1].name:required",
+		"rates[1].rate:out_of_range",
+		"rates[2].code:invalid",
+		"default_rate:unknown",
+		"category_rates.mains:unknown",
+		"item_rates.cola:unknown",
+		"item_rates.salad:unknown",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
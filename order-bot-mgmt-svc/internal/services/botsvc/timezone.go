@@ -0,0 +1,35 @@
+package botsvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util"
+	"time"
+)
+
+// UpdateTimezone sets the IANA timezone the bot's menu windows are read in.
+// The order bot picks it up with the next menu publish.
+func (s *Svc) UpdateTimezone(ctx context.Context, tokenStr string, botID string, timezone string) (entities.Bot, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if _, err := s.ownedBotUserID(ctx, tokenStr, botID); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateTimezone: %w", err)
+	}
+	// LoadLocation reads "" and "Local" as the server's own zone.
+	if timezone == "" || timezone == "Local" {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateTimezone(), timezone %q: %w", timezone, ErrInvalidTimezone)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateTimezone(), timezone %q: %w", timezone, ErrInvalidTimezone)
+	}
+	if err := s.botStore.UpdateTimezone(ctx, nil, botID, loc.String()); err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateTimezone: %w", err)
+	}
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.Bot{}, fmt.Errorf("botsvc.UpdateTimezone: %w", err)
+	}
+	return bot, nil
+}
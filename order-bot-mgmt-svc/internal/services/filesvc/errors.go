@@ -0,0 +1,14 @@
+package filesvc
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrUnsupportedType = apperr.Err{
+		Code: "ErrUnsupportedType",
+		Msg:  "unsupported file type",
+	}
+	ErrFileTooLarge = apperr.Err{
+		Code: "ErrFileTooLarge",
+		Msg:  "file too large",
+	}
+)
@@ -0,0 +1,141 @@
+package filesvc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"net/http"
+	"order-bot-mgmt-svc/internal/config"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/imageutil"
+	"path"
+	"strings"
+)
+
+// imageExts lists the accepted upload types. WebP is not accepted because
+// the standard library cannot decode it, so its metadata could not be
+// stripped.
+var imageExts = map[string]string{
+	"image/png":  ".png",
+	"image/jpeg": ".jpg",
+}
+
+const (
+	// maxImagePixels bounds the decoded size, which a small compressed file
+	// can blow up.
+	maxImagePixels = 40_000_000
+	// Stored images are scaled down to fit these edges in pixels.
+	maxImageEdge  = 2048
+	thumbnailEdge = 320
+)
+
+type Svc struct {
+	blobStore     store.Blob
+	ctxFunc       util.CtxFunc
+	publicBaseURL string
+	maxImageBytes int64
+}
+
+func NewSvc(ctxFunc util.CtxFunc, cfg config.Config, blobStore store.Blob) *Svc {
+	if blobStore == nil || ctxFunc == nil {
+		panic("filesvc.NewSvc(), blobStore or ctxFunc is nil")
+	}
+	return &Svc{
+		blobStore:     blobStore,
+		ctxFunc:       ctxFunc,
+		publicBaseURL: strings.TrimSuffix(cfg.Blob.PublicBaseURL, "/"),
+		maxImageBytes: cfg.Blob.MaxImageBytes,
+	}
+}
+
+// SaveImage checks the size and the sniffed content type of the upload, then
+// re-encodes it, which drops EXIF and any other metadata, and stores it with
+// a thumbnail under keyPrefix. JPEGs are turned upright first, since their
+// EXIF orientation is lost.
+func (s *Svc) SaveImage(ctx context.Context, keyPrefix string, data []byte) (entities.Image, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if int64(len(data)) > s.maxImageBytes {
+		return entities.Image{}, fmt.Errorf("filesvc.SaveImage(), %d bytes: %w", len(data), ErrFileTooLarge)
+	}
+	contentType := http.DetectContentType(data)
+	ext, ok := imageExts[contentType]
+	if !ok {
+		return entities.Image{}, fmt.Errorf("filesvc.SaveImage(), %s: %w", contentType, ErrUnsupportedType)
+	}
+	img, format, err := imageutil.Decode(data, maxImagePixels)
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("filesvc.SaveImage: %w", err)
+	}
+	id := util.NewID()
+	key := path.Join(keyPrefix, id+ext)
+	if err := s.putImage(ctx, key, contentType, imageutil.Fit(img, maxImageEdge), format); err != nil {
+		return entities.Image{}, fmt.Errorf("filesvc.SaveImage: %w", err)
+	}
+	thumbKey := path.Join(keyPrefix, id+"_thumb"+ext)
+	if err := s.putImage(ctx, thumbKey, contentType, imageutil.Fit(img, thumbnailEdge), format); err != nil {
+		return entities.Image{}, fmt.Errorf("filesvc.SaveImage: %w", err)
+	}
+	return entities.Image{URL: s.publicBaseURL + "/" + key, ThumbnailURL: s.publicBaseURL + "/" + thumbKey}, nil
+}
+
+func (s *Svc) putImage(ctx context.Context, key string, contentType string, img image.Image, format string) error {
+	var buf bytes.Buffer
+	if err := imageutil.Encode(&buf, img, format); err != nil {
+		return fmt.Errorf("filesvc.Svc.putImage: %w", err)
+	}
+	if err := s.blobStore.Put(ctx, key, contentType, buf.Bytes()); err != nil {
+		return fmt.Errorf("filesvc.Svc.putImage: %w", err)
+	}
+	return nil
+}
+
+// DeleteImage removes both files of an image saved by SaveImage. URLs from
+// elsewhere are ignored.
+func (s *Svc) DeleteImage(ctx context.Context, image entities.Image) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	for _, url := range []string{image.URL, image.ThumbnailURL} {
+		key, ok := strings.CutPrefix(url, s.publicBaseURL+"/")
+		if !ok || key == "" {
+			continue
+		}
+		if err := s.blobStore.Delete(ctx, key); err != nil {
+			return fmt.Errorf("filesvc.DeleteImage: %w", err)
+		}
+	}
+	return nil
+}
+
+// Open returns an image saved by SaveImage. These are served without auth,
+// since published menus link to them, so other keys are reported missing.
+func (s *Svc) Open(ctx context.Context, key string) (store.BlobObject, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if !isImageKey(key) {
+		return store.BlobObject{}, fmt.Errorf("filesvc.Open(), key %q: %w", key, store.ErrBlobNotFound)
+	}
+	obj, err := s.blobStore.Get(ctx, key)
+	if err != nil {
+		return store.BlobObject{}, fmt.Errorf("filesvc.Open: %w", err)
+	}
+	return obj, nil
+}
+
+func (s *Svc) MaxImageBytes() int64 { return s.maxImageBytes }
+
+// isImageKey reports whether key has the form SaveImage gives bot images.
+func isImageKey(key string) bool {
+	if !strings.HasPrefix(key, "bots/") || path.Clean(key) != key {
+		return false
+	}
+	for _, ext := range imageExts {
+		if path.Ext(key) == ext {
+			return true
+		}
+	}
+	return false
+}
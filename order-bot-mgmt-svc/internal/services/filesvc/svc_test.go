@@ -0,0 +1,22 @@
+package filesvc
+
+import "testing"
+
+func TestIsImageKey(t *testing.T) {
+	tests := map[string]bool{
+		"bots/b1/items/i1/abc.png":     true,
+		"bots/b1/logo/abc_thumb.jpg":   true,
+		"bots/b1/items/i1/abc.txt":     false,
+		"exports/b1/orders.csv":        false,
+		"bots/../secrets/abc.png":      false,
+		"bots/b1//items/abc.png":       false,
+		"bots/b1/items/i1/abc.png/":    false,
+		"../bots/b1/items/i1/abc.png":  false,
+		"bots/b1/items/i1/abc.png.bak": false,
+	}
+	for key, want := range tests {
+		if got := isImageKey(key); got != want {
+			t.Errorf("isImageKey(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
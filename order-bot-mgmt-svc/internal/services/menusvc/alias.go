@@ -0,0 +1,113 @@
+package menusvc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	maxAliasesPerItem = 20
+	maxAliasLen       = 100
+)
+
+// SetItemAliases replaces the aliases of an item of a draft menu and saves
+// the menu as a new version. The order bot gets them on the next publish.
+// version is the menu version the change is based on, as for UpdateMenu.
+func (s *Svc) SetItemAliases(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	itemID string,
+	aliases []string,
+) (entities.MenuDetail, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetItemAliases: %w", err)
+	}
+	found := false
+	var name string
+	for idx := range detail.Items {
+		if detail.Items[idx].ID == itemID {
+			detail.Items[idx].Aliases = aliases
+			found, name = true, detail.Items[idx].MenuItemName
+		}
+	}
+	if !found {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetItemAliases(), item %s: %w", itemID, store.ErrMenuItemNotFound)
+	}
+	detail.Menu.Version = cmp.Or(version, detail.Menu.Version)
+	detail, err = s.updateMenu(ctx, botID, authorID, fmt.Sprintf("aliases of %q changed", name), detail, false)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetItemAliases: %w", err)
+	}
+	return detail, nil
+}
+
+// normalizeAliases trims aliases and collapses inner whitespace. Empty
+// aliases are dropped.
+func normalizeAliases(items []entities.MenuItem) {
+	for idx := range items {
+		item := &items[idx]
+		aliases := make([]string, 0, len(item.Aliases))
+		for _, alias := range item.Aliases {
+			if alias = strings.Join(strings.Fields(alias), " "); alias != "" {
+				aliases = append(aliases, alias)
+			}
+		}
+		item.Aliases = aliases
+	}
+}
+
+// validateAliases checks the number and length of aliases and that no alias
+// matches, ignoring case, an item name or another alias of the menu.
+func validateAliases(items []entities.MenuItem) error {
+	owners := make(map[string]int, len(items))
+	for idx, item := range items {
+		if _, ok := owners[aliasKey(item.MenuItemName)]; !ok {
+			owners[aliasKey(item.MenuItemName)] = idx
+		}
+	}
+	var errs validatorutil.FieldErrors
+	for idx, item := range items {
+		if len(item.Aliases) > maxAliasesPerItem {
+			errs.Add(ErrInvalidMenu, fmt.Sprintf("items[%d].aliases", idx), validatorutil.CodeTooMany,
+				"an item has at most %d aliases, got %d", maxAliasesPerItem, len(item.Aliases))
+		}
+		for aliasIdx, alias := range item.Aliases {
+			field := fmt.Sprintf("items[%d].aliases[%d]", idx, aliasIdx)
+			if utf8.RuneCountInString(alias) > maxAliasLen {
+				errs.Add(ErrInvalidMenu, field, validatorutil.CodeTooLong, "alias is longer than %d characters", maxAliasLen)
+			}
+			key := aliasKey(alias)
+			owner, ok := owners[key]
+			switch {
+			case !ok:
+				owners[key] = idx
+			case owner == idx:
+				errs.Add(ErrDuplicateAlias, field, validatorutil.CodeDuplicate, "%q is already a name or alias of %q", alias, item.MenuItemName)
+			default:
+				errs.Add(ErrDuplicateAlias, field, validatorutil.CodeDuplicate,
+					"%q is already used by %q", alias, items[owner].MenuItemName)
+			}
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.validateAliases: %w", err)
+	}
+	return nil
+}
+
+func aliasKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
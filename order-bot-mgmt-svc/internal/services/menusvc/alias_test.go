@@ -0,0 +1,85 @@
+package menusvc
+
+import (
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeAliases(t *testing.T) {
+	items := []entities.MenuItem{{Aliases: []string{"  Coca   Cola ", "", " ", "coke"}}}
+	normalizeAliases(items)
+	if want := []string{"Coca Cola", "coke"}; !slices.Equal(items[0].Aliases, want) {
+		t.Errorf("Aliases = %q, want %q", items[0].Aliases, want)
+	}
+}
+
+func TestValidateAliases(t *testing.T) {
+	manyAliases := make([]string, maxAliasesPerItem+1)
+	for idx := range manyAliases {
+		manyAliases[idx] = fmt.Sprintf("alias %d", idx)
+	}
+	tests := []struct {
+		name    string
+		items   []entities.MenuItem
+		wantErr error
+	}{
+		{
+			name: "valid",
+			items: []entities.MenuItem{
+				{MenuItemName: "Coca-Cola", Aliases: []string{"coke", "cola"}},
+				{MenuItemName: "Pad Thai", Aliases: []string{"phad thai"}},
+				{MenuItemName: "Water"},
+			},
+		},
+		{
+			name:    "alias of another item",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola", Aliases: []string{"Cola"}}, {MenuItemName: "Pepsi", Aliases: []string{"cola"}}},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name:    "name of another item",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola"}, {MenuItemName: "Pepsi", Aliases: []string{"coca-cola"}}},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name:    "name of a later item",
+			items:   []entities.MenuItem{{MenuItemName: "Pepsi", Aliases: []string{"Coca-Cola"}}, {MenuItemName: "Coca-Cola"}},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name:    "own name",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola", Aliases: []string{"COCA-COLA"}}},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name:    "twice on one item",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola", Aliases: []string{"coke", "Coke"}}},
+			wantErr: ErrDuplicateAlias,
+		},
+		{
+			name:    "too many",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola", Aliases: manyAliases}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "too long",
+			items:   []entities.MenuItem{{MenuItemName: "Coca-Cola", Aliases: []string{strings.Repeat("a", maxAliasLen+1)}}},
+			wantErr: ErrInvalidMenu,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAliases(tt.items)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("validateAliases() = %v, want nil", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateAliases() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package menusvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SetAvailability marks items sold out or back in stock. The flag is written
+// to the draft and straight into the published menu, so no republish is
+// needed; the draft change rolls back if the published menu cannot be
+// updated. A non-zero restoreAt only applies to sold-out items and must be in
+// the future. Restore times are stored in UTC. Availability is not part of
+// the menu content, so the menu version stays as it is; a non-zero version
+// must still be the current one. The menu is returned at its version.
+func (s *Svc) SetAvailability(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	version int,
+	itemIDs []string,
+	soldOut bool,
+	restoreAt time.Time,
+) ([]entities.MenuItem, entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	ids := slices.Compact(slices.Sorted(slices.Values(itemIDs)))
+	if len(ids) == 0 || slices.Contains(ids, "") {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.SetAvailability(), no item ids: %w", ErrInvalidAvailability)
+	}
+	if !restoreAt.IsZero() && (!soldOut || !restoreAt.After(time.Now())) {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.SetAvailability(), restore time needs a sold-out item and a future time: %w", ErrInvalidAvailability)
+	}
+	restoreAt = restoreAt.UTC()
+	var menu entities.Menu
+	if err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var err error
+		if menu, err = s.menuStore.FindByBotID(ctx, tx, botID, menuID); err != nil {
+			return err
+		}
+		if version != 0 && version != menu.Version {
+			return store.MenuChangedErr{Version: menu.Version}
+		}
+		if err := s.menuItemStore.UpdateAvailability(ctx, tx, menu.ID, ids, soldOut, restoreAt); err != nil {
+			return err
+		}
+		return s.orderBotDb.WithTx(ctx, func(ctx context.Context, orderBotTx store.Tx) error {
+			return s.publishedMenuStore.UpdateAvailability(ctx, orderBotTx, menu.ID, ids, soldOut, restoreAt)
+		})
+	}); err != nil {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.SetAvailability: %w", err)
+	}
+	items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+	if err != nil {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.SetAvailability: %w", err)
+	}
+	return slices.DeleteFunc(items, func(item entities.MenuItem) bool {
+		_, found := slices.BinarySearch(ids, item.ID)
+		return !found
+	}), menu, nil
+}
+
+// RestoreDueItems brings back sold-out items whose restore time has passed,
+// in the draft and published menus. The published menus are updated before
+// the draft commits, so when they cannot be the restore times stay and the
+// next run tries again. It returns how many items came back.
+func (s *Svc) RestoreDueItems(ctx context.Context) (int, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	var restored []entities.MenuItem
+	if err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var err error
+		restored, err = s.menuItemStore.RestoreDue(ctx, tx, time.Now().UTC())
+		if err != nil {
+			return err
+		}
+		byMenu := make(map[string][]string)
+		for _, item := range restored {
+			byMenu[item.MenuID] = append(byMenu[item.MenuID], item.ID)
+		}
+		return s.orderBotDb.WithTx(ctx, func(ctx context.Context, orderBotTx store.Tx) error {
+			for menuID, ids := range byMenu {
+				if err := s.publishedMenuStore.UpdateAvailability(ctx, orderBotTx, menuID, ids, false, time.Time{}); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}); err != nil {
+		return 0, fmt.Errorf("menusvc.RestoreDueItems: %w", err)
+	}
+	return len(restored), nil
+}
+
+// carryItemState copies the sold-out and stock state and the image of existing
+// items to the new items with the same name, so saving the menu does not undo
+// an 86, reset a stock count or lose a photo.
+func carryItemState(existing []entities.MenuItem, items []entities.MenuItem) {
+	byName := make(map[string]entities.MenuItem, len(existing))
+	for _, item := range existing {
+		if item.SoldOut || item.TrackStock || item.Image.URL != "" {
+			byName[strings.ToLower(strings.TrimSpace(item.MenuItemName))] = item
+		}
+	}
+	for idx := range items {
+		if prev, ok := byName[strings.ToLower(strings.TrimSpace(items[idx].MenuItemName))]; ok {
+			items[idx].SoldOut, items[idx].RestoreAt = prev.SoldOut, prev.RestoreAt
+			items[idx].TrackStock, items[idx].Stock = prev.TrackStock, prev.Stock
+			items[idx].Image = prev.Image
+		}
+	}
+}
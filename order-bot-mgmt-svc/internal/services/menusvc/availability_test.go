@@ -0,0 +1,48 @@
+package menusvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"testing"
+	"time"
+)
+
+func TestMenuItemAvailableAt(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		item entities.MenuItem
+		want bool
+	}{
+		{name: "in stock", item: entities.MenuItem{}, want: true},
+		{name: "sold out", item: entities.MenuItem{SoldOut: true}, want: false},
+		{name: "restore later", item: entities.MenuItem{SoldOut: true, RestoreAt: now.Add(time.Minute)}, want: false},
+		{name: "restore passed", item: entities.MenuItem{SoldOut: true, RestoreAt: now}, want: true},
+	}
+	for _, tt := range tests {
+		if got := tt.item.AvailableAt(now); got != tt.want {
+			t.Errorf("%s: AvailableAt() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestCarryItemState(t *testing.T) {
+	restoreAt := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	existing := []entities.MenuItem{
+		{MenuItemName: "Croissant", SoldOut: true, RestoreAt: restoreAt},
+		{MenuItemName: "Latte", TrackStock: true, Stock: 7},
+	}
+	items := []entities.MenuItem{{MenuItemName: " croissant"}, {MenuItemName: "Latte"}, {MenuItemName: "Tea"}}
+	carryItemState(existing, items)
+	if !items[0].SoldOut || !items[0].RestoreAt.Equal(restoreAt) {
+		t.Errorf("croissant = %+v, want sold out until %s", items[0], restoreAt)
+	}
+	if items[1].SoldOut || items[2].SoldOut {
+		t.Errorf("latte and tea should stay available, got %+v %+v", items[1], items[2])
+	}
+	if !items[1].TrackStock || items[1].Stock != 7 {
+		t.Errorf("latte = %+v, want 7 in stock", items[1])
+	}
+	if items[2].TrackStock {
+		t.Errorf("tea should not track stock, got %+v", items[2])
+	}
+}
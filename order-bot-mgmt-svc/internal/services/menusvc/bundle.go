@@ -0,0 +1,156 @@
+package menusvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"strings"
+	"unicode/utf8"
+)
+
+// SetBundles replaces the bundles of a draft menu, in display order, and
+// saves the menu as a new version. A bundle with the ID of one of the menu's
+// bundles keeps it; bundles without an ID are added and unknown IDs are
+// rejected. Slots get new IDs on every save. version is the menu version the
+// edit is based on, as for the item edits. Field errors are relative to the
+// menu, such as "bundles[0].slots[1].choices[2].item_id".
+func (s *Svc) SetBundles(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	bundles []entities.MenuBundle,
+) (entities.MenuDetail, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetBundles: %w", err)
+	}
+	if version != 0 && version != detail.Menu.Version {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetBundles(), based on version %d of %d: %w",
+			version, detail.Menu.Version, store.MenuChangedErr{Version: detail.Menu.Version})
+	}
+	if err := prepareBundles(detail.Bundles, bundles, detail.Menu.ID); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetBundles: %w", err)
+	}
+	detail.Bundles = bundles
+	if err := validateMenu(detail); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.SetBundles: %w", err)
+	}
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		if err := s.menuStore.BumpVersion(ctx, tx, detail.Menu.ID, detail.Menu.Version); err != nil {
+			return fmt.Errorf("menusvc.SetBundles: %w", err)
+		}
+		detail.Menu.Version++
+		if err := s.menuBundleStore.DeleteByMenuID(ctx, tx, detail.Menu.ID); err != nil {
+			return fmt.Errorf("menusvc.SetBundles: %w", err)
+		}
+		if err := s.menuBundleStore.CreateBundles(ctx, tx, detail.Bundles); err != nil {
+			return fmt.Errorf("menusvc.SetBundles: %w", err)
+		}
+		if err := s.saveVersion(ctx, tx, detail, authorID, "bundles changed"); err != nil {
+			return fmt.Errorf("menusvc.SetBundles: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entities.MenuDetail{}, err
+	}
+	return detail, nil
+}
+
+// prepareBundles trims names and fills in the IDs, parents and sort
+// positions of bundles in place. IDs must be those of current bundles, each
+// used once.
+func prepareBundles(current []entities.MenuBundle, bundles []entities.MenuBundle, menuID string) error {
+	known := make(map[string]struct{}, len(current))
+	for _, bundle := range current {
+		known[bundle.ID] = struct{}{}
+	}
+	var errs validatorutil.FieldErrors
+	used := make(map[string]struct{}, len(bundles))
+	for idx := range bundles {
+		bundle := &bundles[idx]
+		field := fmt.Sprintf("bundles[%d].id", idx)
+		if bundle.ID == "" {
+			bundle.ID = util.NewID()
+		} else if _, ok := known[bundle.ID]; !ok {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeUnknown, "bundle %s is not in the menu", bundle.ID)
+		} else if _, ok := used[bundle.ID]; ok {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeDuplicate, "bundle %s is listed twice", bundle.ID)
+		}
+		used[bundle.ID] = struct{}{}
+		bundle.MenuID, bundle.SortPosition = menuID, idx
+		bundle.BundleName = strings.TrimSpace(bundle.BundleName)
+		bundle.Description = strings.TrimSpace(bundle.Description)
+		for slotIdx := range bundle.Slots {
+			slot := &bundle.Slots[slotIdx]
+			slot.ID, slot.BundleID, slot.SortPosition = util.NewID(), bundle.ID, slotIdx
+			slot.SlotName = strings.TrimSpace(slot.SlotName)
+			for choiceIdx := range slot.Choices {
+				slot.Choices[choiceIdx].SlotID = slot.ID
+				slot.Choices[choiceIdx].SortPosition = choiceIdx
+			}
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.prepareBundles: %w", err)
+	}
+	return nil
+}
+
+// validateBundle reports problems with fields relative to the bundle. Its
+// name is checked by validateMenu against the other bundles. itemIDs holds
+// the items of the bundle's menu.
+func validateBundle(bundle entities.MenuBundle, itemIDs map[string]struct{}) error {
+	var errs validatorutil.FieldErrors
+	if bundle.PriceScaled <= 0 {
+		errs.Add(ErrInvalidBundle, "price", validatorutil.CodeOutOfRange, "price must be greater than zero")
+	}
+	if n := utf8.RuneCountInString(bundle.Description); n > entities.MaxDescriptionLen {
+		errs.Add(ErrInvalidBundle, "description", validatorutil.CodeTooLong,
+			"description has %d characters, the limit is %d", n, entities.MaxDescriptionLen)
+	}
+	switch n := len(bundle.Slots); {
+	case n == 0:
+		errs.Add(ErrInvalidBundle, "slots", validatorutil.CodeRequired, "a bundle needs at least one slot")
+	case n > entities.MaxBundleSlots:
+		errs.Add(ErrInvalidBundle, "slots", validatorutil.CodeTooMany, "a bundle has at most %d slots, got %d", entities.MaxBundleSlots, n)
+	}
+	slotNames := make(map[string]struct{}, len(bundle.Slots))
+	for idx, slot := range bundle.Slots {
+		prefix := fmt.Sprintf("slots[%d]", idx)
+		validateName(&errs, ErrInvalidBundle, prefix+".name", slot.SlotName, slotNames, "slot")
+		switch n := len(slot.Choices); {
+		case n == 0:
+			errs.Add(ErrInvalidBundle, prefix+".choices", validatorutil.CodeRequired, "a slot needs at least one item")
+		case n > entities.MaxBundleChoices:
+			errs.Add(ErrInvalidBundle, prefix+".choices", validatorutil.CodeTooMany,
+				"a slot has at most %d items, got %d", entities.MaxBundleChoices, n)
+		}
+		picked := make(map[string]struct{}, len(slot.Choices))
+		for choiceIdx, choice := range slot.Choices {
+			field := fmt.Sprintf("%s.choices[%d]", prefix, choiceIdx)
+			_, known := itemIDs[choice.MenuItemID]
+			_, taken := picked[choice.MenuItemID]
+			switch {
+			case choice.MenuItemID == "":
+				errs.Add(ErrInvalidBundle, field+".item_id", validatorutil.CodeRequired, "item_id is required")
+			case !known:
+				errs.Add(ErrInvalidBundle, field+".item_id", validatorutil.CodeUnknown, "item %s is not in the menu", choice.MenuItemID)
+			case taken:
+				errs.Add(ErrInvalidBundle, field+".item_id", validatorutil.CodeDuplicate, "item %s is listed twice", choice.MenuItemID)
+			}
+			picked[choice.MenuItemID] = struct{}{}
+			if choice.UpchargeScaled < 0 {
+				errs.Add(ErrInvalidBundle, field+".upcharge", validatorutil.CodeOutOfRange, "upcharge cannot be negative")
+			}
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,96 @@
+package menusvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"reflect"
+	"testing"
+)
+
+func TestPrepareBundles(t *testing.T) {
+	current := []entities.MenuBundle{{ID: "b1", BundleName: "Burger combo"}}
+	bundles := []entities.MenuBundle{
+		{BundleName: " Kids meal ", Slots: []entities.MenuBundleSlot{{SlotName: " Main ", Choices: []entities.MenuBundleChoice{{MenuItemID: "i1"}, {MenuItemID: "i2"}}}}},
+		{ID: "b1", BundleName: "Burger combo", Slots: []entities.MenuBundleSlot{{SlotName: "Drink"}}},
+	}
+	if err := prepareBundles(current, bundles, "m1"); err != nil {
+		t.Fatalf("prepareBundles() = %v", err)
+	}
+	kids, burger := bundles[0], bundles[1]
+	if kids.ID == "" || kids.BundleName != "Kids meal" || kids.MenuID != "m1" || kids.SortPosition != 0 {
+		t.Errorf("new bundle = %+v, want an ID, trimmed name, menu m1 at 0", kids)
+	}
+	if burger.ID != "b1" || burger.SortPosition != 1 {
+		t.Errorf("kept bundle = %+v, want b1 at 1", burger)
+	}
+	slot := kids.Slots[0]
+	if slot.ID == "" || slot.BundleID != kids.ID || slot.SlotName != "Main" {
+		t.Errorf("slot = %+v, want an ID, bundle %s and trimmed name", slot, kids.ID)
+	}
+	if choice := slot.Choices[1]; choice.SlotID != slot.ID || choice.SortPosition != 1 {
+		t.Errorf("choice = %+v, want slot %s at 1", choice, slot.ID)
+	}
+
+	bundles = []entities.MenuBundle{{ID: "b9"}, {ID: "b1"}, {ID: "b1"}}
+	err := prepareBundles(current, bundles, "m1")
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	if want := []string{"bundles[0].id:unknown", "bundles[2].id:duplicate"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
+
+func TestValidateMenuBundles(t *testing.T) {
+	detail := entities.MenuDetail{
+		Items: []entities.MenuItem{
+			{ID: "burger", MenuItemName: "Burger", PriceScaled: 600},
+			{ID: "fries", MenuItemName: "Fries", PriceScaled: 300},
+			{ID: "cola", MenuItemName: "Cola", PriceScaled: 200},
+		},
+		Bundles: []entities.MenuBundle{{
+			BundleName:  "Burger combo",
+			PriceScaled: 900,
+			Slots: []entities.MenuBundleSlot{
+				{SlotName: "Main", Choices: []entities.MenuBundleChoice{{MenuItemID: "burger"}}},
+				{SlotName: "Side", Choices: []entities.MenuBundleChoice{{MenuItemID: "fries"}}},
+				{SlotName: "Drink", Choices: []entities.MenuBundleChoice{{MenuItemID: "cola", UpchargeScaled: 50}}},
+			},
+		}},
+	}
+	if err := validateMenu(detail); err != nil {
+		t.Fatalf("validateMenu() = %v, want nil", err)
+	}
+
+	detail.Bundles = append(detail.Bundles, entities.MenuBundle{
+		BundleName:  "burger combo",
+		PriceScaled: 0,
+		Slots: []entities.MenuBundleSlot{
+			{SlotName: "Main", Choices: []entities.MenuBundleChoice{{MenuItemID: "salad"}, {MenuItemID: "burger", UpchargeScaled: -10}}},
+			{SlotName: "main", Choices: []entities.MenuBundleChoice{{MenuItemID: "fries"}, {MenuItemID: "fries"}}},
+			{SlotName: "Drink"},
+		},
+	})
+	err := validateMenu(detail)
+	if !errors.Is(err, ErrInvalidMenu) || !errors.Is(err, ErrInvalidBundle) {
+		t.Fatalf("validateMenu() error = %v, want invalid menu and bundle", err)
+	}
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	want := []string{
+		"bundles[1].name:duplicate",
+		"bundles[1].price:out_of_range",
+		"bundles[1].slots[0].choices[0].item_id:unknown",
+		"bundles[1].slots[0].choices[1].upcharge:out_of_range",
+		"bundles[1].slots[1].name:duplicate",
+		"bundles[1].slots[1].choices[1].item_id:duplicate",
+		"bundles[1].slots[2].choices:required",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
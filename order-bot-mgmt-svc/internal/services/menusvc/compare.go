@@ -0,0 +1,148 @@
+package menusvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// CompareWithPublished reports how one of the bot's draft menus differs from
+// what the order bot currently serves.
+func (s *Svc) CompareWithPublished(ctx context.Context, botID string, menuID string) (entities.MenuComparison, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	draft, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return entities.MenuComparison{}, fmt.Errorf("menusvc.CompareWithPublished: %w", err)
+	}
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuComparison{}, fmt.Errorf("menusvc.CompareWithPublished: %w", err)
+	}
+	comparison, err := s.compareWithPublished(ctx, bot, draft)
+	if err != nil {
+		return entities.MenuComparison{}, fmt.Errorf("menusvc.CompareWithPublished: %w", err)
+	}
+	return comparison, nil
+}
+
+func (s *Svc) compareWithPublished(ctx context.Context, bot entities.Bot, draft entities.MenuDetail) (entities.MenuComparison, error) {
+	published, err := s.publishedMenuStore.FindPublishedMenu(ctx, draft.Menu.ID)
+	if errors.Is(err, store.ErrMenuNotFound) {
+		return compareMenus(bot, draft, nil)
+	}
+	if err != nil {
+		return entities.MenuComparison{}, fmt.Errorf("menusvc.Svc.compareWithPublished: %w", err)
+	}
+	return compareMenus(bot, draft, &published)
+}
+
+// compareMenus diffs draft against published, which is nil when the menu was
+// never published. Published prices are compared at the bot's current scale.
+func compareMenus(bot entities.Bot, draft entities.MenuDetail, published *entities.PublishedMenu) (entities.MenuComparison, error) {
+	if published == nil {
+		diff := diffMenus(entities.MenuDetail{}, draft)
+		return entities.MenuComparison{Menu: draft.Menu, Diff: diff}, nil
+	}
+	live := published.Detail
+	if err := live.ConvertPrices(moneyutil.Rescaler(published.PriceScale, bot.PriceScale)); err != nil {
+		return entities.MenuComparison{}, fmt.Errorf("menusvc.compareMenus: %w", err)
+	}
+	comparison := entities.MenuComparison{
+		Menu:            draft.Menu,
+		Published:       true,
+		Diff:            diffMenus(live, draft),
+		LayoutChanged:   layoutChanged(live, draft),
+		CurrencyChanged: published.Currency != bot.Currency || published.PriceScale != bot.PriceScale,
+		ScheduleChanged: live.Menu.MenuName != draft.Menu.MenuName || live.Menu.Window != draft.Menu.Window ||
+			published.Timezone != bot.Timezone,
+		LocalesChanged: published.DefaultLocale != bot.DefaultLocale || !slices.Equal(published.Locales, bot.Locales),
+	}
+	comparison.InSync = comparison.Diff.Empty() && !comparison.LayoutChanged && !comparison.CurrencyChanged &&
+		!comparison.ScheduleChanged && !comparison.LocalesChanged
+	return comparison, nil
+}
+
+// layoutChanged compares categories with their translations, item placement,
+// the option groups and images of items present in both menus, and bundles.
+// Categories, groups and slots get new IDs on every save, so they are
+// compared by content.
+func layoutChanged(from entities.MenuDetail, to entities.MenuDetail) bool {
+	categoryNames := func(detail entities.MenuDetail) []string {
+		names := make([]string, 0, len(detail.Categories))
+		for _, category := range detail.Categories {
+			name := category.CategoryName
+			for _, locale := range slices.Sorted(maps.Keys(category.Translations)) {
+				name += "|" + locale + "=" + category.Translations[locale].Name
+			}
+			names = append(names, name)
+		}
+		return names
+	}
+	if !slices.Equal(categoryNames(from), categoryNames(to)) {
+		return true
+	}
+	if !slices.Equal(bundleLayouts(from), bundleLayouts(to)) {
+		return true
+	}
+	fromLayout, toLayout := itemLayouts(from), itemLayouts(to)
+	for id, layout := range toLayout {
+		if prev, ok := fromLayout[id]; ok && prev != layout {
+			return true
+		}
+	}
+	return false
+}
+
+// itemLayouts describes where each item sits, which options it offers and
+// its image.
+func itemLayouts(detail entities.MenuDetail) map[string]string {
+	categories := make(map[string]string, len(detail.Categories))
+	for _, category := range detail.Categories {
+		categories[category.ID] = category.CategoryName
+	}
+	groups := make(map[string]string, len(detail.OptionGroups))
+	for _, group := range detail.OptionGroups {
+		var sb strings.Builder
+		sb.WriteString(group.GroupName + "|" + string(group.SelectionType))
+		sb.WriteString("|" + strconv.Itoa(group.MinChoices) + "|" + strconv.Itoa(group.MaxChoices) + "|" + strconv.FormatBool(group.Required))
+		for _, option := range group.Options {
+			sb.WriteString("|" + option.OptionName + "=" + strconv.FormatInt(option.PriceDeltaScaled, 10))
+		}
+		groups[group.ID] = sb.String()
+	}
+	layouts := make(map[string]string, len(detail.Items))
+	for _, item := range detail.Items {
+		parts := []string{categories[item.CategoryID], strconv.Itoa(item.SortPosition), item.Image.URL}
+		for _, groupID := range item.OptionGroupIDs {
+			parts = append(parts, groups[groupID])
+		}
+		layouts[item.ID] = strings.Join(parts, "\n")
+	}
+	return layouts
+}
+
+// bundleLayouts describes each bundle with its price, slots and choices.
+func bundleLayouts(detail entities.MenuDetail) []string {
+	layouts := make([]string, 0, len(detail.Bundles))
+	for _, bundle := range detail.Bundles {
+		var sb strings.Builder
+		sb.WriteString(bundle.ID + "|" + bundle.BundleName + "|" + strconv.FormatInt(bundle.PriceScaled, 10) + "|" + bundle.Description)
+		for _, slot := range bundle.Slots {
+			sb.WriteString("\n" + slot.SlotName)
+			for _, choice := range slot.Choices {
+				sb.WriteString("|" + choice.MenuItemID + "=" + strconv.FormatInt(choice.UpchargeScaled, 10))
+			}
+		}
+		layouts = append(layouts, sb.String())
+	}
+	return layouts
+}
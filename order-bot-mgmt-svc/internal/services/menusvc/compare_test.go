@@ -0,0 +1,57 @@
+package menusvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"testing"
+)
+
+func TestCompareMenus(t *testing.T) {
+	bot := entities.Bot{Currency: "USD", PriceScale: 2}
+	draft := entities.MenuDetail{
+		Categories: []entities.MenuCategory{{ID: "c2", CategoryName: "Coffee"}},
+		OptionGroups: []entities.MenuOptionGroup{{ID: "g2", GroupName: "Size", Options: []entities.MenuOption{
+			{OptionName: "Large", PriceDeltaScaled: 50},
+		}}},
+		Items: []entities.MenuItem{
+			{ID: "1", MenuItemName: "Latte", PriceScaled: 450, CategoryID: "c2", OptionGroupIDs: []string{"g2"}},
+		},
+	}
+	published := entities.PublishedMenu{
+		Currency:   "USD",
+		PriceScale: 2,
+		Detail: entities.MenuDetail{
+			Categories: []entities.MenuCategory{{ID: "c1", CategoryName: "Coffee"}},
+			OptionGroups: []entities.MenuOptionGroup{{ID: "g1", GroupName: "Size", Options: []entities.MenuOption{
+				{OptionName: "Large", PriceDeltaScaled: 50},
+			}}},
+			Items: []entities.MenuItem{
+				{ID: "1", MenuItemName: "Latte", PriceScaled: 450, CategoryID: "c1", OptionGroupIDs: []string{"g1"}},
+			},
+		},
+	}
+	got, err := compareMenus(bot, draft, &published)
+	if err != nil {
+		t.Fatalf("compareMenus() error = %v", err)
+	}
+	if !got.Published || !got.InSync {
+		t.Errorf("same content under new category and group ids = %+v, want in sync", got)
+	}
+
+	published.Detail.OptionGroups[0].Options[0].PriceDeltaScaled = 75
+	if got, _ := compareMenus(bot, draft, &published); got.InSync || !got.LayoutChanged || !got.Diff.Empty() {
+		t.Errorf("changed option price = %+v, want layout change only", got)
+	}
+	published.Detail.OptionGroups[0].Options[0].PriceDeltaScaled = 50
+
+	published.PriceScale = 3
+	published.Detail.Items[0].PriceScaled = 4500
+	published.Detail.OptionGroups[0].Options[0].PriceDeltaScaled = 500
+	if got, _ := compareMenus(bot, draft, &published); got.InSync || !got.CurrencyChanged || !got.Diff.Empty() || got.LayoutChanged {
+		t.Errorf("published at another scale = %+v, want currency change only", got)
+	}
+
+	got, err = compareMenus(bot, draft, nil)
+	if err != nil || got.Published || got.InSync || len(got.Diff.Added) != 1 {
+		t.Errorf("never published = %+v, %v, want every item added", got, err)
+	}
+}
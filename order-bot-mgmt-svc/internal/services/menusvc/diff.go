@@ -0,0 +1,77 @@
+package menusvc
+
+import (
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"slices"
+	"strings"
+)
+
+// diffMenus compares the items of two menus. Items are paired by ID first,
+// then by name, so a rename is only visible while the item keeps its ID.
+func diffMenus(from entities.MenuDetail, to entities.MenuDetail) entities.MenuDiff {
+	diff := entities.MenuDiff{}
+	fromByID := make(map[string]int, len(from.Items))
+	for idx, item := range from.Items {
+		fromByID[item.ID] = idx
+	}
+	paired := make([]bool, len(from.Items))
+	pairs := make([]int, len(to.Items))
+	for idx, item := range to.Items {
+		pairs[idx] = -1
+		if fromIdx, ok := fromByID[item.ID]; ok && item.ID != "" && !paired[fromIdx] {
+			pairs[idx], paired[fromIdx] = fromIdx, true
+		}
+	}
+	fromByName := make(map[string]int, len(from.Items))
+	for idx, item := range from.Items {
+		name := itemNameKey(item.MenuItemName)
+		if _, ok := fromByName[name]; !paired[idx] && !ok {
+			fromByName[name] = idx
+		}
+	}
+	for idx, item := range to.Items {
+		if pairs[idx] >= 0 {
+			continue
+		}
+		if fromIdx, ok := fromByName[itemNameKey(item.MenuItemName)]; ok && !paired[fromIdx] {
+			pairs[idx], paired[fromIdx] = fromIdx, true
+		}
+	}
+	for idx, item := range to.Items {
+		if pairs[idx] < 0 {
+			diff.Added = append(diff.Added, item)
+			continue
+		}
+		before := from.Items[pairs[idx]]
+		change := entities.MenuItemChange{Before: before, After: item}
+		if before.MenuItemName != item.MenuItemName {
+			diff.Renamed = append(diff.Renamed, change)
+		}
+		if before.PriceScaled != item.PriceScaled {
+			diff.Repriced = append(diff.Repriced, change)
+		}
+		if !sameItemInfo(before, item) {
+			diff.Described = append(diff.Described, change)
+		}
+	}
+	for idx, item := range from.Items {
+		if !paired[idx] {
+			diff.Removed = append(diff.Removed, item)
+		}
+	}
+	return diff
+}
+
+func sameItemInfo(a entities.MenuItem, b entities.MenuItem) bool {
+	if (a.Nutrition == nil) != (b.Nutrition == nil) || a.Nutrition != nil && *a.Nutrition != *b.Nutrition {
+		return false
+	}
+	return a.Description == b.Description && a.SpicyLevel == b.SpicyLevel && slices.Equal(a.Aliases, b.Aliases) &&
+		slices.Equal(a.Allergens, b.Allergens) && slices.Equal(a.DietaryTags, b.DietaryTags) &&
+		maps.Equal(a.Translations, b.Translations)
+}
+
+func itemNameKey(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
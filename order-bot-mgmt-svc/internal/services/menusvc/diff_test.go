@@ -0,0 +1,66 @@
+package menusvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"testing"
+)
+
+func TestDiffMenus(t *testing.T) {
+	from := entities.MenuDetail{Items: []entities.MenuItem{
+		{ID: "1", MenuItemName: "Latte", PriceScaled: 450},
+		{ID: "2", MenuItemName: "Mocha", PriceScaled: 500},
+		{ID: "3", MenuItemName: "Scone", PriceScaled: 300},
+		{ID: "4", MenuItemName: "Tea", PriceScaled: 250},
+	}}
+	to := entities.MenuDetail{Items: []entities.MenuItem{
+		{ID: "1", MenuItemName: "Oat Latte", PriceScaled: 500},
+		{ID: "2", MenuItemName: "Mocha", PriceScaled: 500},
+		{ID: "9", MenuItemName: "tea ", PriceScaled: 275},
+		{ID: "10", MenuItemName: "Bagel", PriceScaled: 350},
+	}}
+	diff := diffMenus(from, to)
+	if len(diff.Added) != 1 || diff.Added[0].ID != "10" {
+		t.Errorf("Added = %+v, want Bagel", diff.Added)
+	}
+	if len(diff.Removed) != 1 || diff.Removed[0].ID != "3" {
+		t.Errorf("Removed = %+v, want Scone", diff.Removed)
+	}
+	if len(diff.Renamed) != 2 || diff.Renamed[0].After.ID != "1" || diff.Renamed[1].Before.ID != "4" {
+		t.Errorf("Renamed = %+v, want latte and tea", diff.Renamed)
+	}
+	if len(diff.Repriced) != 2 || diff.Repriced[0].Before.PriceScaled != 450 || diff.Repriced[1].After.PriceScaled != 275 {
+		t.Errorf("Repriced = %+v, want latte and tea", diff.Repriced)
+	}
+	if diff.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+	if len(diff.Described) != 0 {
+		t.Errorf("Described = %+v, want none", diff.Described)
+	}
+	if same := diffMenus(from, from); !same.Empty() {
+		t.Errorf("diff of a menu with itself = %+v, want empty", same)
+	}
+}
+
+func TestDiffMenusDescribed(t *testing.T) {
+	from := entities.MenuDetail{Items: []entities.MenuItem{
+		{ID: "1", MenuItemName: "Latte", Allergens: []entities.Allergen{entities.AllergenMilk}},
+		{ID: "2", MenuItemName: "Curry", SpicyLevel: 1, Nutrition: &entities.Nutrition{Calories: 700}},
+		{ID: "3", MenuItemName: "Salad", Description: "Greens"},
+		{ID: "4", MenuItemName: "Tea", Translations: entities.Translations{"zh-TW": {Name: "茶"}}},
+	}}
+	to := entities.MenuDetail{Items: []entities.MenuItem{
+		{ID: "1", MenuItemName: "Latte", Allergens: []entities.Allergen{entities.AllergenMilk}},
+		{ID: "2", MenuItemName: "Curry", SpicyLevel: 1, Nutrition: &entities.Nutrition{Calories: 650}},
+		{ID: "3", MenuItemName: "Salad", Description: "Greens", DietaryTags: []entities.DietaryTag{entities.DietaryVegan}},
+		{ID: "4", MenuItemName: "Tea", Translations: entities.Translations{"zh-TW": {Name: "紅茶"}}},
+	}}
+	diff := diffMenus(from, to)
+	if len(diff.Described) != 3 || diff.Described[0].After.ID != "2" || diff.Described[1].After.ID != "3" ||
+		diff.Described[2].After.ID != "4" {
+		t.Errorf("Described = %+v, want curry, salad and tea", diff.Described)
+	}
+	if diff.Empty() {
+		t.Error("Empty() = true, want false")
+	}
+}
@@ -7,4 +7,48 @@ var (
 		Code: "ErrInvalidMenu",
 		Msg:  "invalid menu request",
 	}
+	ErrInvalidOptionGroup = apperr.Err{
+		Code: "ErrInvalidOptionGroup",
+		Msg:  "invalid option group",
+	}
+	ErrInvalidBundle = apperr.Err{
+		Code: "ErrInvalidBundle",
+		Msg:  "invalid bundle",
+	}
+	ErrInvalidAvailability = apperr.Err{
+		Code: "ErrInvalidAvailability",
+		Msg:  "invalid availability request",
+	}
+	ErrInvalidPublishJob = apperr.Err{
+		Code: "ErrInvalidPublishJob",
+		Msg:  "invalid publish schedule",
+	}
+	ErrPublishJobNotPending = apperr.Err{
+		Code: "ErrPublishJobNotPending",
+		Msg:  "publish job is running, already ran or was canceled",
+	}
+	ErrPublishFailed = apperr.Err{
+		Code: "ErrPublishFailed",
+		Msg:  "menu publish failed",
+	}
+	ErrPublishTimedOut = apperr.Err{
+		Code: "ErrPublishTimedOut",
+		Msg:  "menu publish did not finish",
+	}
+	ErrInvalidMenuImport = apperr.Err{
+		Code: "ErrInvalidMenuImport",
+		Msg:  "menu spreadsheet has errors",
+	}
+	ErrDuplicateAlias = apperr.Err{
+		Code: "ErrDuplicateAlias",
+		Msg:  "item alias is already used in the menu",
+	}
+	ErrCategoryNotFound = apperr.Err{
+		Code: "ErrCategoryNotFound",
+		Msg:  "menu category not found",
+	}
+	ErrInvalidMatch = apperr.Err{
+		Code: "ErrInvalidMatch",
+		Msg:  "invalid item match request",
+	}
 )
@@ -0,0 +1,39 @@
+package menusvc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+)
+
+// SetItemImage sets the image of an item of a draft menu, or clears it with a
+// zero image, and bumps the menu version. version is the version the change
+// is based on; zero changes the draft as it is. The order bot gets the image
+// on the next publish.
+func (s *Svc) SetItemImage(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	version int,
+	itemID string,
+	image entities.Image,
+) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return fmt.Errorf("menusvc.SetItemImage: %w", err)
+	}
+	if err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		if err := s.menuStore.BumpVersion(ctx, tx, menu.ID, cmp.Or(version, menu.Version)); err != nil {
+			return err
+		}
+		return s.menuItemStore.UpdateImage(ctx, tx, menu.ID, itemID, image)
+	}); err != nil {
+		return fmt.Errorf("menusvc.SetItemImage: %w", err)
+	}
+	return nil
+}
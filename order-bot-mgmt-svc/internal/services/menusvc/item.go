@@ -0,0 +1,378 @@
+package menusvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// itemChanges lists the items an item edit wrote, by ID, and the note of the
+// version it saves.
+type itemChanges struct {
+	created []string
+	updated []string
+	deleted []string
+	note    string
+}
+
+// GetItem returns an item of a draft menu and the menu it is in.
+func (s *Svc) GetItem(ctx context.Context, botID string, menuID string, itemID string) (entities.MenuItem, entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return entities.MenuItem{}, entities.Menu{}, fmt.Errorf("menusvc.GetItem: %w", err)
+	}
+	item, err := s.menuItemStore.FindItem(ctx, menu.ID, itemID)
+	if err != nil {
+		return entities.MenuItem{}, entities.Menu{}, fmt.Errorf("menusvc.GetItem: %w", err)
+	}
+	return item, menu, nil
+}
+
+// CreateItem adds an item to a draft menu, last in its category, and saves
+// the menu as a new version. Like the item edits below, version is the menu
+// version the edit is based on and a zero version edits the draft as it is.
+// Field errors of the item are relative to it, such as "price".
+func (s *Svc) CreateItem(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	item entities.MenuItem,
+) (entities.MenuItem, entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	item.ID = util.NewID()
+	detail, err := s.editItems(ctx, botID, menuID, authorID, version, item.ID, func(detail *entities.MenuDetail) (itemChanges, error) {
+		item.MenuID = detail.Menu.ID
+		item.SortPosition = nextSortPosition(detail.Items, item.CategoryID)
+		detail.Items = append(detail.Items, item)
+		return itemChanges{created: []string{item.ID}, note: fmt.Sprintf("item %q added", item.MenuItemName)}, nil
+	})
+	if err != nil {
+		return entities.MenuItem{}, entities.Menu{}, fmt.Errorf("menusvc.CreateItem: %w", err)
+	}
+	idx := slices.IndexFunc(detail.Items, func(i entities.MenuItem) bool { return i.ID == item.ID })
+	return detail.Items[idx], detail.Menu, nil
+}
+
+// PatchItem changes the fields of a draft item that patch sets and saves the
+// menu as a new version. An item moved to another category goes last in it.
+func (s *Svc) PatchItem(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	itemID string,
+	patch entities.MenuItemPatch,
+) (entities.MenuItem, entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	var idx int
+	detail, err := s.editItems(ctx, botID, menuID, authorID, version, itemID, func(detail *entities.MenuDetail) (itemChanges, error) {
+		idx = slices.IndexFunc(detail.Items, func(i entities.MenuItem) bool { return i.ID == itemID })
+		if idx < 0 {
+			return itemChanges{}, fmt.Errorf("menusvc.PatchItem(), item %s: %w", itemID, store.ErrMenuItemNotFound)
+		}
+		name := detail.Items[idx].MenuItemName
+		applyItemPatch(detail.Items, idx, patch)
+		return itemChanges{updated: []string{itemID}, note: fmt.Sprintf("item %q changed", name)}, nil
+	})
+	if err != nil {
+		return entities.MenuItem{}, entities.Menu{}, fmt.Errorf("menusvc.PatchItem: %w", err)
+	}
+	return detail.Items[idx], detail.Menu, nil
+}
+
+// DeleteItem removes an item from a draft menu and saves the menu as a new
+// version. The published menu keeps the item until the next publish.
+func (s *Svc) DeleteItem(ctx context.Context, botID string, menuID string, authorID string, version int, itemID string) (entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	detail, err := s.editItems(ctx, botID, menuID, authorID, version, "", func(detail *entities.MenuDetail) (itemChanges, error) {
+		idx := slices.IndexFunc(detail.Items, func(i entities.MenuItem) bool { return i.ID == itemID })
+		if idx < 0 {
+			return itemChanges{}, fmt.Errorf("menusvc.DeleteItem(), item %s: %w", itemID, store.ErrMenuItemNotFound)
+		}
+		name := detail.Items[idx].MenuItemName
+		detail.Items = slices.Delete(detail.Items, idx, idx+1)
+		return itemChanges{deleted: []string{itemID}, note: fmt.Sprintf("item %q deleted", name)}, nil
+	})
+	if err != nil {
+		return entities.Menu{}, fmt.Errorf("menusvc.DeleteItem: %w", err)
+	}
+	return detail.Menu, nil
+}
+
+// PatchItems applies patch to the items it selects and saves the menu as a
+// new version. It returns the changed items in menu order. Field errors of
+// the items name them by ID, such as "items[<id>].price".
+func (s *Svc) PatchItems(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	patch entities.MenuItemBulkPatch,
+) ([]entities.MenuItem, entities.Menu, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if err := validateBulkPatch(patch); err != nil {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.PatchItems: %w", err)
+	}
+	var ids []string
+	detail, err := s.editItems(ctx, botID, menuID, authorID, version, "", func(detail *entities.MenuDetail) (itemChanges, error) {
+		var err error
+		if ids, err = applyBulkPatch(detail, patch); err != nil {
+			return itemChanges{}, err
+		}
+		return itemChanges{updated: ids, note: fmt.Sprintf("bulk edit of %d items", len(ids))}, nil
+	})
+	if err != nil {
+		return nil, entities.Menu{}, fmt.Errorf("menusvc.PatchItems: %w", err)
+	}
+	items := make([]entities.MenuItem, 0, len(ids))
+	for _, item := range detail.Items {
+		if slices.Contains(ids, item.ID) {
+			items = append(items, item)
+		}
+	}
+	return items, detail.Menu, nil
+}
+
+// editItems applies edit to the draft menu and writes only the items it
+// changed. The whole menu is validated, since names and aliases are unique
+// across items, and saved as a new version. Field errors of the item with ID
+// target are made relative to it.
+func (s *Svc) editItems(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	target string,
+	edit func(detail *entities.MenuDetail) (itemChanges, error),
+) (entities.MenuDetail, error) {
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.Svc.editItems: %w", err)
+	}
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.Svc.editItems: %w", err)
+	}
+	if version != 0 && version != detail.Menu.Version {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.Svc.editItems(), based on version %d of %d: %w",
+			version, detail.Menu.Version, store.MenuChangedErr{Version: detail.Menu.Version})
+	}
+	changes, err := edit(&detail)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.Svc.editItems: %w", err)
+	}
+	normalizeItemInfo(detail.Items)
+	normalizeAliases(detail.Items)
+	normalizeTranslations(&detail)
+	if err := errors.Join(validateMenu(detail), validateTranslations(detail, bot)); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.Svc.editItems: %w", itemFieldErr(err, detail.Items, target))
+	}
+	byID := make(map[string]entities.MenuItem, len(detail.Items))
+	for _, item := range detail.Items {
+		byID[item.ID] = item
+	}
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		if err := s.menuStore.BumpVersion(ctx, tx, detail.Menu.ID, detail.Menu.Version); err != nil {
+			return fmt.Errorf("menusvc.Svc.editItems: %w", err)
+		}
+		detail.Menu.Version++
+		for _, id := range changes.deleted {
+			if err := s.menuItemStore.DeleteItem(ctx, tx, detail.Menu.ID, id); err != nil {
+				return fmt.Errorf("menusvc.Svc.editItems: %w", err)
+			}
+		}
+		for _, id := range changes.updated {
+			if err := s.menuItemStore.UpdateItem(ctx, tx, byID[id]); err != nil {
+				return fmt.Errorf("menusvc.Svc.editItems: %w", err)
+			}
+		}
+		for _, id := range changes.created {
+			if err := s.menuItemStore.CreateItem(ctx, tx, byID[id]); err != nil {
+				return fmt.Errorf("menusvc.Svc.editItems: %w", err)
+			}
+		}
+		if err := s.saveVersion(ctx, tx, detail, authorID, changes.note); err != nil {
+			return fmt.Errorf("menusvc.Svc.editItems: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return entities.MenuDetail{}, err
+	}
+	return detail, nil
+}
+
+// applyItemPatch changes items[idx] as patch says.
+func applyItemPatch(items []entities.MenuItem, idx int, patch entities.MenuItemPatch) {
+	item := &items[idx]
+	if patch.Name != nil {
+		item.MenuItemName = *patch.Name
+	}
+	if patch.PriceScaled != nil {
+		item.PriceScaled = *patch.PriceScaled
+	}
+	if patch.CategoryID != nil && *patch.CategoryID != item.CategoryID {
+		item.SortPosition = nextSortPosition(items, *patch.CategoryID)
+		item.CategoryID = *patch.CategoryID
+	}
+	if patch.OptionGroupIDs != nil {
+		item.OptionGroupIDs = *patch.OptionGroupIDs
+	}
+	if patch.Aliases != nil {
+		item.Aliases = *patch.Aliases
+	}
+	if patch.Translations != nil {
+		item.Translations = *patch.Translations
+	}
+	if patch.Description != nil {
+		item.Description = *patch.Description
+	}
+	if patch.Allergens != nil {
+		item.Allergens = *patch.Allergens
+	}
+	if patch.DietaryTags != nil {
+		item.DietaryTags = *patch.DietaryTags
+	}
+	if patch.SpicyLevel != nil {
+		item.SpicyLevel = *patch.SpicyLevel
+	}
+	if patch.SetNutrition {
+		item.Nutrition = patch.Nutrition
+	}
+}
+
+// validateBulkPatch checks that a bulk patch selects items and changes them
+// in one way at most per field.
+func validateBulkPatch(patch entities.MenuItemBulkPatch) error {
+	var errs validatorutil.FieldErrors
+	if !patch.All && len(patch.ItemIDs) == 0 && len(patch.CategoryIDs) == 0 {
+		errs.Add(ErrInvalidMenu, "item_ids", validatorutil.CodeRequired, "select items by id, by category or all of them")
+	}
+	priceChanges := 0
+	for _, change := range []*int64{patch.PriceScaled, patch.PriceDeltaScaled, patch.PricePercent} {
+		if change != nil {
+			priceChanges++
+		}
+	}
+	if priceChanges > 1 {
+		errs.Add(ErrInvalidMenu, "price", validatorutil.CodeConflict, "set the price, a price delta or a percent, not several")
+	}
+	if priceChanges == 0 && patch.CategoryID == nil {
+		errs.Add(ErrInvalidMenu, "price", validatorutil.CodeRequired, "the patch changes nothing")
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.validateBulkPatch: %w", err)
+	}
+	return nil
+}
+
+// applyBulkPatch changes the items of detail that patch selects and returns
+// their IDs in menu order. Selected IDs and categories must be in the menu.
+func applyBulkPatch(detail *entities.MenuDetail, patch entities.MenuItemBulkPatch) ([]string, error) {
+	for _, id := range patch.ItemIDs {
+		if !slices.ContainsFunc(detail.Items, func(i entities.MenuItem) bool { return i.ID == id }) {
+			return nil, fmt.Errorf("menusvc.applyBulkPatch(), item %s: %w", id, store.ErrMenuItemNotFound)
+		}
+	}
+	categoryIDs := patch.CategoryIDs
+	if patch.CategoryID != nil && *patch.CategoryID != "" {
+		categoryIDs = append(slices.Clone(categoryIDs), *patch.CategoryID)
+	}
+	for _, id := range categoryIDs {
+		if !slices.ContainsFunc(detail.Categories, func(c entities.MenuCategory) bool { return c.ID == id }) {
+			return nil, fmt.Errorf("menusvc.applyBulkPatch(), category %s: %w", id, ErrCategoryNotFound)
+		}
+	}
+	var ids []string
+	for idx := range detail.Items {
+		item := &detail.Items[idx]
+		if !patch.All && !slices.Contains(patch.ItemIDs, item.ID) &&
+			(item.CategoryID == "" || !slices.Contains(patch.CategoryIDs, item.CategoryID)) {
+			continue
+		}
+		ids = append(ids, item.ID)
+		price, err := bulkPrice(item.PriceScaled, patch)
+		if err != nil {
+			return nil, fmt.Errorf("menusvc.applyBulkPatch(), price of %q: %w", item.MenuItemName, err)
+		}
+		item.PriceScaled = price
+	}
+	if patch.CategoryID != nil {
+		for _, id := range ids {
+			applyItemPatch(detail.Items, slices.IndexFunc(detail.Items, func(i entities.MenuItem) bool { return i.ID == id }),
+				entities.MenuItemPatch{CategoryID: patch.CategoryID})
+		}
+	}
+	return ids, nil
+}
+
+func bulkPrice(price int64, patch entities.MenuItemBulkPatch) (int64, error) {
+	switch {
+	case patch.PriceScaled != nil:
+		return *patch.PriceScaled, nil
+	case patch.PriceDeltaScaled != nil:
+		return price + *patch.PriceDeltaScaled, nil
+	case patch.PricePercent != nil:
+		change, err := moneyutil.Percent(moneyutil.Money(price), *patch.PricePercent)
+		if err != nil {
+			return 0, fmt.Errorf("menusvc.bulkPrice: %w", err)
+		}
+		return price + int64(change), nil
+	}
+	return price, nil
+}
+
+// nextSortPosition is the position after the last item of a category.
+func nextSortPosition(items []entities.MenuItem, categoryID string) int {
+	next := 0
+	for _, item := range items {
+		if item.CategoryID == categoryID && item.SortPosition >= next {
+			next = item.SortPosition + 1
+		}
+	}
+	return next
+}
+
+// itemFieldErr names the items of the field errors of err by ID rather than
+// by their index in items, as "items[<id>].price", and makes the fields of
+// the item with ID target relative to it, as "price". err is returned as it
+// is when it has no field errors.
+func itemFieldErr(err error, items []entities.MenuItem, target string) error {
+	fields := validatorutil.Fields(err)
+	if len(fields) == 0 {
+		return err
+	}
+	moved := make(validatorutil.FieldErrors, 0, len(fields))
+	for _, fieldErr := range fields {
+		if rest, ok := strings.CutPrefix(fieldErr.Field, "items["); ok {
+			num, rest, ok := strings.Cut(rest, "]")
+			if idx, err := strconv.Atoi(num); ok && err == nil && idx >= 0 && idx < len(items) {
+				fieldErr.Field = "items[" + items[idx].ID + "]" + rest
+				if items[idx].ID == target {
+					fieldErr.Field = strings.TrimPrefix(rest, ".")
+				}
+			}
+		}
+		moved = append(moved, fieldErr)
+	}
+	return moved
+}
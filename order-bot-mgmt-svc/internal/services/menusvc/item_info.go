@@ -0,0 +1,102 @@
+package menusvc
+
+import (
+	"fmt"
+	"math"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// conflictingAllergens are allergens an item with the tag cannot contain.
+var conflictingAllergens = map[entities.DietaryTag][]entities.Allergen{
+	entities.DietaryVegan: {
+		entities.AllergenEggs, entities.AllergenMilk, entities.AllergenFish,
+		entities.AllergenCrustaceans, entities.AllergenMolluscs,
+	},
+	entities.DietaryVegetarian: {entities.AllergenFish, entities.AllergenCrustaceans, entities.AllergenMolluscs},
+	entities.DietaryGlutenFree: {entities.AllergenGluten},
+}
+
+// normalizeItemInfo trims descriptions, tags vegan items as vegetarian too and
+// puts allergens and tags in list order. Unknown values sort last and are left
+// for validateItemInfo to reject.
+func normalizeItemInfo(items []entities.MenuItem) {
+	for idx := range items {
+		item := &items[idx]
+		item.Description = strings.TrimSpace(item.Description)
+		if slices.Contains(item.DietaryTags, entities.DietaryVegan) && !slices.Contains(item.DietaryTags, entities.DietaryVegetarian) {
+			item.DietaryTags = append(item.DietaryTags, entities.DietaryVegetarian)
+		}
+		sortByList(item.Allergens, entities.Allergens)
+		sortByList(item.DietaryTags, entities.DietaryTags)
+	}
+}
+
+func sortByList[T comparable](values []T, list []T) {
+	rank := func(v T) int {
+		if pos := slices.Index(list, v); pos >= 0 {
+			return pos
+		}
+		return len(list)
+	}
+	slices.SortStableFunc(values, func(a, b T) int { return rank(a) - rank(b) })
+}
+
+// validateItemInfo checks the description length, that allergens and tags
+// are known, listed once and don't contradict each other, and that the spicy
+// level and nutrition facts are in range. Fields are relative to the item.
+func validateItemInfo(item entities.MenuItem) error {
+	var errs validatorutil.FieldErrors
+	if n := utf8.RuneCountInString(item.Description); n > entities.MaxDescriptionLen {
+		errs.Add(ErrInvalidMenu, "description", validatorutil.CodeTooLong,
+			"description has %d characters, the limit is %d", n, entities.MaxDescriptionLen)
+	}
+	for idx, allergen := range item.Allergens {
+		field := fmt.Sprintf("allergens[%d]", idx)
+		if !slices.Contains(entities.Allergens, allergen) {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeUnknown, "unknown allergen %q", allergen)
+		}
+		if slices.Contains(item.Allergens[:idx], allergen) {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeDuplicate, "allergen %q is listed twice", allergen)
+		}
+	}
+	for idx, tag := range item.DietaryTags {
+		field := fmt.Sprintf("dietary_tags[%d]", idx)
+		if !slices.Contains(entities.DietaryTags, tag) {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeUnknown, "unknown dietary tag %q", tag)
+		}
+		if slices.Contains(item.DietaryTags[:idx], tag) {
+			errs.Add(ErrInvalidMenu, field, validatorutil.CodeDuplicate, "dietary tag %q is listed twice", tag)
+		}
+		for _, allergen := range conflictingAllergens[tag] {
+			if slices.Contains(item.Allergens, allergen) {
+				errs.Add(ErrInvalidMenu, field, validatorutil.CodeConflict, "a %s item cannot contain %s", tag, allergen)
+			}
+		}
+	}
+	if item.SpicyLevel < 0 || item.SpicyLevel > entities.MaxSpicyLevel {
+		errs.Add(ErrInvalidMenu, "spicy_level", validatorutil.CodeOutOfRange,
+			"spicy level %d is not between 0 and %d", item.SpicyLevel, entities.MaxSpicyLevel)
+	}
+	if nutrition := item.Nutrition; nutrition != nil {
+		if nutrition.Calories < 0 {
+			errs.Add(ErrInvalidMenu, "nutrition.calories", validatorutil.CodeOutOfRange, "calories cannot be negative")
+		}
+		grams := []struct {
+			field string
+			g     float64
+		}{
+			{"protein_g", nutrition.ProteinG}, {"carbohydrate_g", nutrition.CarbohydrateG}, {"fat_g", nutrition.FatG},
+			{"sugar_g", nutrition.SugarG}, {"salt_g", nutrition.SaltG},
+		}
+		for _, gram := range grams {
+			if gram.g < 0 || math.IsNaN(gram.g) || math.IsInf(gram.g, 0) {
+				errs.Add(ErrInvalidMenu, "nutrition."+gram.field, validatorutil.CodeOutOfRange, "must be a non-negative number of grams")
+			}
+		}
+	}
+	return errs.Err()
+}
@@ -0,0 +1,102 @@
+package menusvc
+
+import (
+	"errors"
+	"math"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeItemInfo(t *testing.T) {
+	items := []entities.MenuItem{{
+		Description: "  Oat milk latte \n",
+		Allergens:   []entities.Allergen{entities.AllergenSoy, "celeriac", entities.AllergenGluten},
+		DietaryTags: []entities.DietaryTag{entities.DietaryGlutenFree, entities.DietaryVegan},
+	}}
+	normalizeItemInfo(items)
+	item := items[0]
+	if item.Description != "Oat milk latte" {
+		t.Errorf("Description = %q, want it trimmed", item.Description)
+	}
+	wantAllergens := []entities.Allergen{entities.AllergenGluten, entities.AllergenSoy, "celeriac"}
+	if !slices.Equal(item.Allergens, wantAllergens) {
+		t.Errorf("Allergens = %v, want %v", item.Allergens, wantAllergens)
+	}
+	wantTags := []entities.DietaryTag{entities.DietaryVegan, entities.DietaryVegetarian, entities.DietaryGlutenFree}
+	if !slices.Equal(item.DietaryTags, wantTags) {
+		t.Errorf("DietaryTags = %v, want %v", item.DietaryTags, wantTags)
+	}
+}
+
+func TestValidateItemInfo(t *testing.T) {
+	tests := []struct {
+		name    string
+		item    entities.MenuItem
+		wantErr bool
+	}{
+		{name: "no info"},
+		{
+			name: "full info",
+			item: entities.MenuItem{
+				Description: "Rice noodles with peanuts",
+				Allergens:   []entities.Allergen{entities.AllergenPeanuts, entities.AllergenSoy},
+				DietaryTags: []entities.DietaryTag{entities.DietaryVegan, entities.DietaryVegetarian, entities.DietaryGlutenFree},
+				SpicyLevel:  entities.MaxSpicyLevel,
+				Nutrition:   &entities.Nutrition{Calories: 640, ProteinG: 18.5, SaltG: 2.1},
+			},
+		},
+		{
+			name:    "description too long",
+			item:    entities.MenuItem{Description: strings.Repeat("é", entities.MaxDescriptionLen+1)},
+			wantErr: true,
+		},
+		{name: "unknown allergen", item: entities.MenuItem{Allergens: []entities.Allergen{"nuts"}}, wantErr: true},
+		{
+			name:    "allergen twice",
+			item:    entities.MenuItem{Allergens: []entities.Allergen{entities.AllergenMilk, entities.AllergenMilk}},
+			wantErr: true,
+		},
+		{name: "unknown tag", item: entities.MenuItem{DietaryTags: []entities.DietaryTag{"kosher"}}, wantErr: true},
+		{
+			name: "vegan with milk",
+			item: entities.MenuItem{
+				Allergens:   []entities.Allergen{entities.AllergenMilk},
+				DietaryTags: []entities.DietaryTag{entities.DietaryVegan},
+			},
+			wantErr: true,
+		},
+		{
+			name: "vegetarian with eggs",
+			item: entities.MenuItem{
+				Allergens:   []entities.Allergen{entities.AllergenEggs},
+				DietaryTags: []entities.DietaryTag{entities.DietaryVegetarian},
+			},
+		},
+		{
+			name: "gluten free with gluten",
+			item: entities.MenuItem{
+				Allergens:   []entities.Allergen{entities.AllergenGluten},
+				DietaryTags: []entities.DietaryTag{entities.DietaryGlutenFree},
+			},
+			wantErr: true,
+		},
+		{name: "spicy level too high", item: entities.MenuItem{SpicyLevel: entities.MaxSpicyLevel + 1}, wantErr: true},
+		{name: "negative spicy level", item: entities.MenuItem{SpicyLevel: -1}, wantErr: true},
+		{name: "negative calories", item: entities.MenuItem{Nutrition: &entities.Nutrition{Calories: -1}}, wantErr: true},
+		{name: "negative fat", item: entities.MenuItem{Nutrition: &entities.Nutrition{FatG: -0.5}}, wantErr: true},
+		{name: "NaN sugar", item: entities.MenuItem{Nutrition: &entities.Nutrition{SugarG: math.NaN()}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateItemInfo(tt.item)
+			if tt.wantErr && !errors.Is(err, ErrInvalidMenu) {
+				t.Fatalf("validateItemInfo() = %v, want ErrInvalidMenu", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validateItemInfo() = %v, want nil", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package menusvc
+
+import (
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util"
+)
+
+type itemSync struct {
+	inserts []entities.MenuItem
+	updates []entities.MenuItem
+	deletes []string
+}
+
+// planItemSync pairs the new items with existing rows by ID. Items without an
+// ID get a new one. The slices of items are updated in place: kept items take
+// over the availability, stock and image of their row, and new items take them
+// from a removed item with the same name. Unknown IDs fail unless reviveIDs is set.
+func planItemSync(existing []entities.MenuItem, items []entities.MenuItem, reviveIDs bool) (itemSync, error) {
+	byID := make(map[string]entities.MenuItem, len(existing))
+	for _, item := range existing {
+		byID[item.ID] = item
+	}
+	kept := make(map[string]struct{}, len(items))
+	var sync itemSync
+	var added []int
+	for idx := range items {
+		item := &items[idx]
+		if item.ID == "" {
+			item.ID = util.NewID()
+			added = append(added, idx)
+			continue
+		}
+		if _, ok := kept[item.ID]; ok {
+			return itemSync{}, fmt.Errorf("menusvc.planItemSync(), duplicated item id %s: %w", item.ID, ErrInvalidMenu)
+		}
+		kept[item.ID] = struct{}{}
+		prev, ok := byID[item.ID]
+		if !ok {
+			if !reviveIDs {
+				return itemSync{}, fmt.Errorf("menusvc.planItemSync(), item id %s is not in the menu: %w", item.ID, ErrInvalidMenu)
+			}
+			added = append(added, idx)
+			continue
+		}
+		item.SoldOut, item.RestoreAt = prev.SoldOut, prev.RestoreAt
+		item.TrackStock, item.Stock = prev.TrackStock, prev.Stock
+		item.Image = prev.Image
+		sync.updates = append(sync.updates, *item)
+	}
+	var removed []entities.MenuItem
+	for _, item := range existing {
+		if _, ok := kept[item.ID]; !ok {
+			removed = append(removed, item)
+			sync.deletes = append(sync.deletes, item.ID)
+		}
+	}
+	newItems := make([]entities.MenuItem, 0, len(added))
+	for _, idx := range added {
+		newItems = append(newItems, items[idx])
+	}
+	carryItemState(removed, newItems)
+	for pos, idx := range added {
+		items[idx] = newItems[pos]
+	}
+	sync.inserts = newItems
+	return sync, nil
+}
@@ -0,0 +1,52 @@
+package menusvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"testing"
+)
+
+func TestPlanItemSync(t *testing.T) {
+	existing := []entities.MenuItem{
+		{ID: "1", MenuItemName: "Latte", PriceScaled: 450, TrackStock: true, Stock: 4},
+		{ID: "2", MenuItemName: "Mocha", PriceScaled: 500, SoldOut: true},
+		{ID: "3", MenuItemName: "Scone", PriceScaled: 300},
+	}
+	items := []entities.MenuItem{
+		{ID: "1", MenuItemName: "Oat Latte", PriceScaled: 520},
+		{MenuItemName: "mocha", PriceScaled: 500},
+		{MenuItemName: "Bagel", PriceScaled: 350},
+	}
+	sync, err := planItemSync(existing, items, false)
+	if err != nil {
+		t.Fatalf("planItemSync() error = %v", err)
+	}
+	if len(sync.updates) != 1 || sync.updates[0].ID != "1" || sync.updates[0].MenuItemName != "Oat Latte" {
+		t.Errorf("updates = %+v, want the latte renamed", sync.updates)
+	}
+	if !items[0].TrackStock || items[0].Stock != 4 {
+		t.Errorf("kept latte = %+v, want its stock kept", items[0])
+	}
+	if len(sync.inserts) != 2 || items[1].ID == "" || items[2].ID == "" || items[1].ID == items[2].ID {
+		t.Errorf("inserts = %+v, want mocha and bagel with new ids", sync.inserts)
+	}
+	if !items[1].SoldOut || !sync.inserts[0].SoldOut {
+		t.Errorf("re-added mocha = %+v, want it still sold out", items[1])
+	}
+	if len(sync.deletes) != 2 || sync.deletes[0] != "2" || sync.deletes[1] != "3" {
+		t.Errorf("deletes = %v, want [2 3]", sync.deletes)
+	}
+
+	unknown := []entities.MenuItem{{ID: "9", MenuItemName: "Tea"}}
+	if _, err := planItemSync(existing, unknown, false); !errors.Is(err, ErrInvalidMenu) {
+		t.Errorf("unknown id error = %v, want ErrInvalidMenu", err)
+	}
+	sync, err = planItemSync(existing, unknown, true)
+	if err != nil || len(sync.inserts) != 1 || sync.inserts[0].ID != "9" {
+		t.Errorf("revived = %+v, %v, want tea inserted under id 9", sync.inserts, err)
+	}
+	duplicated := []entities.MenuItem{{ID: "1", MenuItemName: "Latte"}, {ID: "1", MenuItemName: "Latte 2"}}
+	if _, err := planItemSync(existing, duplicated, false); !errors.Is(err, ErrInvalidMenu) {
+		t.Errorf("duplicated id error = %v, want ErrInvalidMenu", err)
+	}
+}
@@ -0,0 +1,137 @@
+package menusvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"slices"
+	"testing"
+)
+
+func itemFixture() entities.MenuDetail {
+	return entities.MenuDetail{
+		Categories: []entities.MenuCategory{{ID: "drinks", CategoryName: "Drinks"}, {ID: "food", CategoryName: "Food"}},
+		Items: []entities.MenuItem{
+			{ID: "1", MenuItemName: "Latte", PriceScaled: 450, CategoryID: "drinks", SortPosition: 0},
+			{ID: "2", MenuItemName: "Mocha", PriceScaled: 499, CategoryID: "drinks", SortPosition: 1},
+			{ID: "3", MenuItemName: "Scone", PriceScaled: 300, CategoryID: "food", SortPosition: 0},
+			{ID: "4", MenuItemName: "Water", PriceScaled: 100},
+		},
+	}
+}
+
+func TestApplyItemPatch(t *testing.T) {
+	detail := itemFixture()
+	name, price, category := "Flat White", int64(480), "food"
+	nutrition := &entities.Nutrition{Calories: 120}
+	applyItemPatch(detail.Items, 0, entities.MenuItemPatch{
+		Name:         &name,
+		PriceScaled:  &price,
+		CategoryID:   &category,
+		SetNutrition: true,
+		Nutrition:    nutrition,
+	})
+	item := detail.Items[0]
+	if item.MenuItemName != name || item.PriceScaled != price || item.Nutrition != nutrition {
+		t.Errorf("item = %+v, want renamed, repriced and with nutrition", item)
+	}
+	if item.CategoryID != "food" || item.SortPosition != 1 {
+		t.Errorf("category %q at %d, want last in food", item.CategoryID, item.SortPosition)
+	}
+
+	applyItemPatch(detail.Items, 0, entities.MenuItemPatch{SetNutrition: true})
+	if detail.Items[0].Nutrition != nil || detail.Items[0].MenuItemName != name {
+		t.Errorf("item = %+v, want nutrition cleared and the rest kept", detail.Items[0])
+	}
+}
+
+func TestValidateBulkPatch(t *testing.T) {
+	percent, price := int64(500), int64(400)
+	tests := []struct {
+		name      string
+		patch     entities.MenuItemBulkPatch
+		wantField string
+	}{
+		{name: "valid", patch: entities.MenuItemBulkPatch{CategoryIDs: []string{"drinks"}, PricePercent: &percent}},
+		{name: "no selection", patch: entities.MenuItemBulkPatch{PricePercent: &percent}, wantField: "item_ids"},
+		{name: "no change", patch: entities.MenuItemBulkPatch{All: true}, wantField: "price"},
+		{
+			name:      "two price changes",
+			patch:     entities.MenuItemBulkPatch{All: true, PricePercent: &percent, PriceScaled: &price},
+			wantField: "price",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateBulkPatch(tc.patch)
+			if tc.wantField == "" {
+				if err != nil {
+					t.Fatalf("validateBulkPatch() = %v, want nil", err)
+				}
+				return
+			}
+			fields := validatorutil.Fields(err)
+			if len(fields) != 1 || fields[0].Field != tc.wantField || !errors.Is(err, ErrInvalidMenu) {
+				t.Fatalf("validateBulkPatch() fields = %+v, want one on %s", fields, tc.wantField)
+			}
+		})
+	}
+}
+
+func TestApplyBulkPatch(t *testing.T) {
+	percent := int64(500)
+	detail := itemFixture()
+	ids, err := applyBulkPatch(&detail, entities.MenuItemBulkPatch{CategoryIDs: []string{"drinks"}, ItemIDs: []string{"4"}, PricePercent: &percent})
+	if err != nil {
+		t.Fatalf("applyBulkPatch() = %v", err)
+	}
+	if want := []string{"1", "2", "4"}; !slices.Equal(ids, want) {
+		t.Errorf("ids = %v, want %v", ids, want)
+	}
+	var prices []int64
+	for _, item := range detail.Items {
+		prices = append(prices, item.PriceScaled)
+	}
+	if want := []int64{473, 524, 300, 105}; !slices.Equal(prices, want) {
+		t.Errorf("prices = %v, want %v", prices, want)
+	}
+
+	delta, category := int64(-50), "drinks"
+	detail = itemFixture()
+	ids, err = applyBulkPatch(&detail, entities.MenuItemBulkPatch{All: true, PriceDeltaScaled: &delta, CategoryID: &category})
+	if err != nil || len(ids) != 4 {
+		t.Fatalf("applyBulkPatch() = %v, %v, want every item", ids, err)
+	}
+	if item := detail.Items[2]; item.CategoryID != "drinks" || item.SortPosition != 2 || item.PriceScaled != 250 {
+		t.Errorf("scone = %+v, want moved to the end of drinks at 2.50", item)
+	}
+	if item := detail.Items[3]; item.SortPosition != 3 {
+		t.Errorf("water at %d, want after the scone", item.SortPosition)
+	}
+
+	detail = itemFixture()
+	if _, err := applyBulkPatch(&detail, entities.MenuItemBulkPatch{ItemIDs: []string{"9"}, PricePercent: &percent}); !errors.Is(err, store.ErrMenuItemNotFound) {
+		t.Errorf("unknown item: err = %v, want ErrMenuItemNotFound", err)
+	}
+	if _, err := applyBulkPatch(&detail, entities.MenuItemBulkPatch{CategoryIDs: []string{"cakes"}, PricePercent: &percent}); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("unknown category: err = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestItemFieldErr(t *testing.T) {
+	detail := itemFixture()
+	detail.Items[1].MenuItemName = "latte"
+	detail.Items[3].PriceScaled = 0
+	err := itemFieldErr(validateMenu(detail), detail.Items, "4")
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field)
+	}
+	if want := []string{"items[2].name", "price"}; !slices.Equal(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+	if !errors.Is(err, ErrInvalidMenu) {
+		t.Errorf("err = %v, want ErrInvalidMenu", err)
+	}
+}
@@ -0,0 +1,83 @@
+package menusvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/matchutil"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxMatchCandidates is the most candidates returned per phrase.
+	MaxMatchCandidates = 10
+	maxMatchTextLen    = 500
+)
+
+// MatchItems finds the published items that the customer text names, with a
+// score per candidate. It matches against what the order bot serves, so
+// draft changes only count after a publish. Names, aliases and translated
+// names are all matched.
+func (s *Svc) MatchItems(ctx context.Context, botID string, menuID string, text string, limit int) (entities.MenuMatch, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	text = strings.TrimSpace(text)
+	if text == "" || utf8.RuneCountInString(text) > maxMatchTextLen {
+		return entities.MenuMatch{}, fmt.Errorf("menusvc.MatchItems(), text must have 1 to %d characters: %w", maxMatchTextLen, ErrInvalidMatch)
+	}
+	if limit < 1 || limit > MaxMatchCandidates {
+		return entities.MenuMatch{}, fmt.Errorf("menusvc.MatchItems(), limit %d is not between 1 and %d: %w", limit, MaxMatchCandidates, ErrInvalidMatch)
+	}
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return entities.MenuMatch{}, fmt.Errorf("menusvc.MatchItems: %w", err)
+	}
+	published, err := s.publishedMenuStore.FindPublishedMenu(ctx, menu.ID)
+	if errors.Is(err, store.ErrMenuNotFound) {
+		return entities.MenuMatch{}, fmt.Errorf("menusvc.MatchItems(), menu %s is not published: %w", menu.ID, err)
+	}
+	if err != nil {
+		return entities.MenuMatch{}, fmt.Errorf("menusvc.MatchItems: %w", err)
+	}
+	result := matchItems(published.Detail.Items, text, limit)
+	result.Menu, result.Currency, result.PriceScale = menu, published.Currency, published.PriceScale
+	return result, nil
+}
+
+func matchItems(items []entities.MenuItem, text string, limit int) entities.MenuMatch {
+	entries := make([]matchutil.Entry, 0, len(items))
+	byID := make(map[string]entities.MenuItem, len(items))
+	for _, item := range items {
+		names := append([]string{item.MenuItemName}, item.Aliases...)
+		for _, locale := range slices.Sorted(maps.Keys(item.Translations)) {
+			if name := item.Translations[locale].Name; name != "" {
+				names = append(names, name)
+			}
+		}
+		entries = append(entries, matchutil.Entry{ID: item.ID, Names: names})
+		byID[item.ID] = item
+	}
+	var result entities.MenuMatch
+	for _, match := range matchutil.NewIndex(entries).Match(text, limit) {
+		phrase := entities.MenuPhraseMatch{
+			Text:       match.Text,
+			Quantity:   match.Quantity,
+			Candidates: make([]entities.MenuItemCandidate, 0, len(match.Candidates)),
+		}
+		for _, candidate := range match.Candidates {
+			phrase.Candidates = append(phrase.Candidates, entities.MenuItemCandidate{
+				Item:        byID[candidate.ID],
+				MatchedName: candidate.Name,
+				Score:       candidate.Score,
+			})
+		}
+		result.Phrases = append(result.Phrases, phrase)
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package menusvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"testing"
+)
+
+func TestMatchItems(t *testing.T) {
+	items := []entities.MenuItem{
+		{ID: "i1", MenuItemName: "Latte"},
+		{ID: "i2", MenuItemName: "Chicken Sandwich"},
+		{ID: "i3", MenuItemName: "Coca-Cola", Aliases: []string{"coke"}},
+		{ID: "i4", MenuItemName: "Fish and Chips"},
+	}
+	result := matchItems(items, "2 larg lattes and a chiken sandwich, 3 cokes please", 2)
+	want := []struct {
+		id       string
+		quantity int
+		matched  string
+	}{
+		{"i1", 2, "Latte"},
+		{"i2", 1, "Chicken Sandwich"},
+		{"i3", 3, "coke"},
+	}
+	if len(result.Phrases) != len(want) {
+		t.Fatalf("matchItems() phrases = %+v, want %d", result.Phrases, len(want))
+	}
+	for idx, w := range want {
+		phrase := result.Phrases[idx]
+		if len(phrase.Candidates) == 0 || len(phrase.Candidates) > 2 {
+			t.Fatalf("phrase %q has %d candidates, want 1 or 2", phrase.Text, len(phrase.Candidates))
+		}
+		best := phrase.Candidates[0]
+		if best.Item.ID != w.id || phrase.Quantity != w.quantity || best.MatchedName != w.matched {
+			t.Errorf("phrase %q = %s x%d by %q, want %s x%d by %q",
+				phrase.Text, best.Item.ID, phrase.Quantity, best.MatchedName, w.id, w.quantity, w.matched)
+		}
+	}
+}
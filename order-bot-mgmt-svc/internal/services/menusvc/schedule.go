@@ -0,0 +1,192 @@
+package menusvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"order-bot-mgmt-svc/internal/apperr"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/errutil"
+	"time"
+)
+
+const (
+	publishJobListLimit = 100
+	// publishJobTimeout bounds one publish of a scheduled job.
+	publishJobTimeout = 2 * time.Minute
+	// publishJobStaleAfter is how long a job may stay running before it is
+	// taken to have lost its worker. It must exceed publishJobTimeout.
+	publishJobStaleAfter  = 10 * time.Minute
+	maxPublishJobAttempts = 3
+)
+
+// SchedulePublish publishes the bot's draft menus, as they are then, at
+// runAt. runAt must be in the future and is stored in UTC.
+func (s *Svc) SchedulePublish(ctx context.Context, botID string, authorID string, runAt time.Time) (entities.PublishJob, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if !runAt.After(time.Now()) {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.SchedulePublish(), run time is not in the future: %w", ErrInvalidPublishJob)
+	}
+	menus, err := s.menuStore.ListByBotID(ctx, nil, botID)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.SchedulePublish: %w", err)
+	}
+	if len(menus) == 0 {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.SchedulePublish: %w", store.ErrMenuNotFound)
+	}
+	job, err := s.publishJobStore.Create(ctx, nil, entities.PublishJob{
+		ID:        util.NewID(),
+		BotID:     botID,
+		RunAt:     runAt.UTC(),
+		Status:    entities.PublishJobPending,
+		CreatedBy: authorID,
+	})
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.SchedulePublish: %w", err)
+	}
+	return job, nil
+}
+
+// ListPublishJobs returns the bot's recent publish jobs, latest run time
+// first.
+func (s *Svc) ListPublishJobs(ctx context.Context, botID string) ([]entities.PublishJob, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	jobs, err := s.publishJobStore.FindByBotID(ctx, botID, publishJobListLimit)
+	if err != nil {
+		return nil, fmt.Errorf("menusvc.ListPublishJobs: %w", err)
+	}
+	return jobs, nil
+}
+
+// CancelPublishJob cancels a job that has not run yet. A job being run
+// right now cannot be canceled.
+func (s *Svc) CancelPublishJob(ctx context.Context, botID string, jobID string) (entities.PublishJob, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	var job entities.PublishJob
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var err error
+		job, err = s.cancelPublishJob(ctx, tx, botID, jobID)
+		return err
+	})
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.CancelPublishJob: %w", err)
+	}
+	return job, nil
+}
+
+func (s *Svc) cancelPublishJob(ctx context.Context, tx store.Tx, botID string, jobID string) (entities.PublishJob, error) {
+	canceled, err := s.publishJobStore.Cancel(ctx, tx, botID, jobID)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.cancelPublishJob: %w", err)
+	}
+	job, err := s.publishJobStore.FindByID(ctx, tx, botID, jobID)
+	if err != nil {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.cancelPublishJob: %w", err)
+	}
+	if !canceled {
+		return entities.PublishJob{}, fmt.Errorf("menusvc.cancelPublishJob: %w", ErrPublishJobNotPending)
+	}
+	return job, nil
+}
+
+// RunDuePublishJobs publishes the menus of all jobs that are due and records
+// each outcome. A job is claimed with a row lock that other replicas skip and
+// marked running before it is published, so a job runs once. A job whose
+// worker dies mid-run is claimed again once publishJobStaleAfter passed, and
+// fails after maxPublishJobAttempts runs. It returns how many jobs ran.
+func (s *Svc) RunDuePublishJobs(ctx context.Context) (int, error) {
+	ran := 0
+	for {
+		job, ok, err := s.claimDuePublishJob(ctx)
+		if err != nil {
+			return ran, fmt.Errorf("menusvc.RunDuePublishJobs: %w", err)
+		}
+		if !ok {
+			return ran, nil
+		}
+		if job.Status != entities.PublishJobRunning {
+			continue
+		}
+		if err := s.runPublishJob(ctx, job); err != nil {
+			return ran, fmt.Errorf("menusvc.RunDuePublishJobs: %w", err)
+		}
+		ran++
+	}
+}
+
+func (s *Svc) claimDuePublishJob(ctx context.Context) (entities.PublishJob, bool, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	var job entities.PublishJob
+	claimed := false
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		var err error
+		job, claimed, err = s.claimPublishJob(ctx, tx, time.Now().UTC())
+		return err
+	})
+	if err != nil {
+		return entities.PublishJob{}, false, fmt.Errorf("menusvc.claimDuePublishJob: %w", err)
+	}
+	return job, claimed, nil
+}
+
+// claimPublishJob claims the next due job and marks it running. A job that
+// used up its attempts is marked failed instead and returned with that
+// status. It reports false when no job is due.
+func (s *Svc) claimPublishJob(ctx context.Context, tx store.Tx, now time.Time) (entities.PublishJob, bool, error) {
+	job, ok, err := s.publishJobStore.ClaimDue(ctx, tx, now, now.Add(-publishJobStaleAfter))
+	if err != nil {
+		return entities.PublishJob{}, false, fmt.Errorf("menusvc.claimPublishJob: %w", err)
+	}
+	if !ok {
+		return entities.PublishJob{}, false, nil
+	}
+	if job.Attempts >= maxPublishJobAttempts {
+		slog.Error("menusvc.claimPublishJob(), publish job gave up", "publish_job", job.ID, "attempts", job.Attempts)
+		job.Status, job.Error, job.FinishedAt = entities.PublishJobFailed, ErrPublishTimedOut.Msg, now
+		if err := s.publishJobStore.Finish(ctx, tx, job.ID, job.Status, job.Error, job.FinishedAt); err != nil {
+			return entities.PublishJob{}, false, fmt.Errorf("menusvc.claimPublishJob: %w", err)
+		}
+		return job, true, nil
+	}
+	job, err = s.publishJobStore.Start(ctx, tx, job.ID, now)
+	if err != nil {
+		return entities.PublishJob{}, false, fmt.Errorf("menusvc.claimPublishJob: %w", err)
+	}
+	return job, true, nil
+}
+
+// runPublishJob publishes the menus of a claimed job and records the outcome.
+// The publish gets its own deadline, so a slow publish still leaves time to
+// record that it failed.
+func (s *Svc) runPublishJob(ctx context.Context, job entities.PublishJob) error {
+	publishCtx, cancelPublish := context.WithTimeout(ctx, publishJobTimeout)
+	status, errMsg := entities.PublishJobSucceeded, ""
+	if _, _, err := s.PublishMenus(publishCtx, job.BotID, false); err != nil {
+		slog.Error(errutil.FormatErrChain(err), "publish_job", job.ID)
+		status, errMsg = entities.PublishJobFailed, publishErrMsg(err)
+	}
+	cancelPublish()
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if err := s.publishJobStore.Finish(ctx, nil, job.ID, status, errMsg, time.Now().UTC()); err != nil {
+		return fmt.Errorf("menusvc.runPublishJob: %w", err)
+	}
+	return nil
+}
+
+// publishErrMsg keeps internal details out of the stored outcome; the full
+// error is logged.
+func publishErrMsg(err error) string {
+	var appErr apperr.Err
+	if errors.As(err, &appErr) {
+		return appErr.Msg
+	}
+	return ErrPublishFailed.Msg
+}
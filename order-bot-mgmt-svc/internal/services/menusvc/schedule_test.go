@@ -0,0 +1,193 @@
+package menusvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"sort"
+	"testing"
+	"time"
+)
+
+// fakePublishJobStore keeps jobs in memory and claims them the way the
+// query does. Unexpected calls panic through the embedded nil interface.
+type fakePublishJobStore struct {
+	store.PublishJob
+	jobs map[string]*entities.PublishJob
+}
+
+func newFakePublishJobStore(jobs ...entities.PublishJob) *fakePublishJobStore {
+	f := &fakePublishJobStore{jobs: map[string]*entities.PublishJob{}}
+	for _, job := range jobs {
+		f.jobs[job.ID] = &job
+	}
+	return f
+}
+
+func (f *fakePublishJobStore) FindByID(_ context.Context, _ store.Tx, botID string, id string) (entities.PublishJob, error) {
+	job, ok := f.jobs[id]
+	if !ok || job.BotID != botID {
+		return entities.PublishJob{}, store.ErrPublishJobNotFound
+	}
+	return *job, nil
+}
+
+func (f *fakePublishJobStore) Cancel(_ context.Context, _ store.Tx, botID string, id string) (bool, error) {
+	job, ok := f.jobs[id]
+	if !ok || job.BotID != botID || job.Status != entities.PublishJobPending {
+		return false, nil
+	}
+	job.Status = entities.PublishJobCanceled
+	return true, nil
+}
+
+func (f *fakePublishJobStore) ClaimDue(
+	_ context.Context, _ store.Tx, now time.Time, staleBefore time.Time,
+) (entities.PublishJob, bool, error) {
+	var due []entities.PublishJob
+	for _, job := range f.jobs {
+		pending := job.Status == entities.PublishJobPending && !job.RunAt.After(now)
+		stale := job.Status == entities.PublishJobRunning && job.StartedAt.Before(staleBefore)
+		if pending || stale {
+			due = append(due, *job)
+		}
+	}
+	if len(due) == 0 {
+		return entities.PublishJob{}, false, nil
+	}
+	sort.Slice(due, func(i, j int) bool {
+		if !due[i].RunAt.Equal(due[j].RunAt) {
+			return due[i].RunAt.Before(due[j].RunAt)
+		}
+		return due[i].ID < due[j].ID
+	})
+	return due[0], true, nil
+}
+
+func (f *fakePublishJobStore) Start(_ context.Context, _ store.Tx, id string, startedAt time.Time) (entities.PublishJob, error) {
+	job := f.jobs[id]
+	job.Status, job.Attempts, job.StartedAt = entities.PublishJobRunning, job.Attempts+1, startedAt
+	return *job, nil
+}
+
+func (f *fakePublishJobStore) Finish(
+	ctx context.Context, _ store.Tx, id string, status entities.PublishJobStatus, errMsg string, finishedAt time.Time,
+) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	job := f.jobs[id]
+	job.Status, job.Error, job.FinishedAt = status, errMsg, finishedAt
+	return nil
+}
+
+// failingBotStore fails every lookup and records whether the caller set a
+// deadline.
+type failingBotStore struct {
+	store.Bot
+	hadDeadline bool
+}
+
+func (f *failingBotStore) FindByID(ctx context.Context, _ store.Tx, _ string) (entities.Bot, error) {
+	_, f.hadDeadline = ctx.Deadline()
+	return entities.Bot{}, errors.New("dial tcp 10.0.0.1:5432: connection refused")
+}
+
+func TestClaimPublishJob(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	jobs := newFakePublishJobStore(
+		entities.PublishJob{ID: "due", BotID: "bot-1", RunAt: now.Add(-time.Minute), Status: entities.PublishJobPending},
+		entities.PublishJob{ID: "later", BotID: "bot-1", RunAt: now.Add(time.Hour), Status: entities.PublishJobPending},
+		entities.PublishJob{ID: "done", BotID: "bot-1", RunAt: now.Add(-time.Hour), Status: entities.PublishJobSucceeded},
+	)
+	s := &Svc{publishJobStore: jobs}
+	job, ok, err := s.claimPublishJob(context.Background(), nil, now)
+	if err != nil || !ok {
+		t.Fatalf("claimPublishJob() = %v, %v", ok, err)
+	}
+	if job.ID != "due" || job.Status != entities.PublishJobRunning || job.Attempts != 1 || !job.StartedAt.Equal(now) {
+		t.Fatalf("claimed %+v, want due running since %s after 1 attempt", job, now)
+	}
+	if _, ok, err := s.claimPublishJob(context.Background(), nil, now.Add(time.Minute)); err != nil || ok {
+		t.Fatalf("claimPublishJob() while running = %v, %v, want nothing due", ok, err)
+	}
+	job, ok, err = s.claimPublishJob(context.Background(), nil, now.Add(publishJobStaleAfter+time.Second))
+	if err != nil || !ok {
+		t.Fatalf("claimPublishJob() after stale = %v, %v", ok, err)
+	}
+	if job.ID != "due" || job.Attempts != 2 {
+		t.Fatalf("reclaimed %+v, want due on attempt 2", job)
+	}
+}
+
+func TestClaimPublishJobGivesUp(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	jobs := newFakePublishJobStore(entities.PublishJob{
+		ID:        "stuck",
+		BotID:     "bot-1",
+		RunAt:     now.Add(-time.Hour),
+		Status:    entities.PublishJobRunning,
+		Attempts:  maxPublishJobAttempts,
+		StartedAt: now.Add(-publishJobStaleAfter - time.Second),
+	})
+	s := &Svc{publishJobStore: jobs}
+	job, ok, err := s.claimPublishJob(context.Background(), nil, now)
+	if err != nil || !ok {
+		t.Fatalf("claimPublishJob() = %v, %v", ok, err)
+	}
+	if job.Status != entities.PublishJobFailed {
+		t.Fatalf("claimed %+v, want it failed without another run", job)
+	}
+	stored := jobs.jobs["stuck"]
+	if stored.Status != entities.PublishJobFailed || stored.Error != ErrPublishTimedOut.Msg || stored.Attempts != maxPublishJobAttempts {
+		t.Fatalf("stored %+v, want failed with %q", stored, ErrPublishTimedOut.Msg)
+	}
+}
+
+func TestCancelRunningPublishJob(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	jobs := newFakePublishJobStore(
+		entities.PublishJob{ID: "job-1", BotID: "bot-1", RunAt: now, Status: entities.PublishJobPending},
+	)
+	s := &Svc{publishJobStore: jobs}
+	if _, _, err := s.claimPublishJob(context.Background(), nil, now); err != nil {
+		t.Fatalf("claimPublishJob() error = %v", err)
+	}
+	if _, err := s.cancelPublishJob(context.Background(), nil, "bot-1", "job-1"); !errors.Is(err, ErrPublishJobNotPending) {
+		t.Fatalf("cancelPublishJob() error = %v, want %v", err, ErrPublishJobNotPending)
+	}
+	if status := jobs.jobs["job-1"].Status; status != entities.PublishJobRunning {
+		t.Fatalf("status = %s, want the job still running", status)
+	}
+}
+
+func TestRunPublishJobFailure(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	jobs := newFakePublishJobStore(
+		entities.PublishJob{ID: "job-1", BotID: "bot-1", RunAt: now, Status: entities.PublishJobRunning, Attempts: 1},
+	)
+	bots := &failingBotStore{}
+	s := &Svc{publishJobStore: jobs, botStore: bots}
+	if err := s.runPublishJob(context.Background(), *jobs.jobs["job-1"]); err != nil {
+		t.Fatalf("runPublishJob() error = %v", err)
+	}
+	if !bots.hadDeadline {
+		t.Errorf("publish ran without its own deadline")
+	}
+	stored := jobs.jobs["job-1"]
+	if stored.Status != entities.PublishJobFailed || stored.Error != ErrPublishFailed.Msg || stored.FinishedAt.IsZero() {
+		t.Fatalf("stored %+v, want failed with %q", stored, ErrPublishFailed.Msg)
+	}
+}
+
+func TestPublishErrMsg(t *testing.T) {
+	wrapped := fmt.Errorf("menusvc.PublishMenu: %w", ErrInvalidMenu)
+	if got := publishErrMsg(wrapped); got != ErrInvalidMenu.Msg {
+		t.Fatalf("app error: got %q, want %q", got, ErrInvalidMenu.Msg)
+	}
+	if got := publishErrMsg(errors.New("dial tcp 10.0.0.1:5432: connection refused")); got != ErrPublishFailed.Msg {
+		t.Fatalf("internal error: got %q, want %q", got, ErrPublishFailed.Msg)
+	}
+}
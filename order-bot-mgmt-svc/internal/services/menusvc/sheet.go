@@ -0,0 +1,276 @@
+package menusvc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// ExportMenuSheet returns the items of a draft menu as spreadsheet rows,
+// categorized items first in category order, then uncategorized ones.
+func (s *Svc) ExportMenuSheet(ctx context.Context, botID string, menuID string) ([]entities.MenuSheetRow, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return nil, fmt.Errorf("menusvc.ExportMenuSheet: %w", err)
+	}
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return nil, fmt.Errorf("menusvc.ExportMenuSheet: %w", err)
+	}
+	return menuSheetRows(detail, bot.PriceScale), nil
+}
+
+// ImportMenuSheet applies spreadsheet rows to a draft menu and saves the
+// result through UpdateMenu as a new version. Rows name their category and
+// option groups; option groups must already exist in the menu. A row updates
+// the item with its ID, or else the item with the same name, and adds a new
+// item otherwise. With dryRun set, or when any row has an error, nothing is
+// saved and the returned MenuImport previews the outcome. Row errors on a
+// real import also fail with ErrInvalidMenuImport. version is the menu version
+// the import is based on; zero imports into the draft as it is.
+func (s *Svc) ImportMenuSheet(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	rows []entities.MenuSheetRow,
+	mode entities.MenuImportMode,
+	dryRun bool,
+) (entities.MenuImport, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if mode != entities.MenuImportReplace && mode != entities.MenuImportMerge {
+		return entities.MenuImport{}, fmt.Errorf("menusvc.ImportMenuSheet(), unknown mode %q: %w", mode, ErrInvalidMenuImport)
+	}
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuImport{}, fmt.Errorf("menusvc.ImportMenuSheet: %w", err)
+	}
+	current, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return entities.MenuImport{}, fmt.Errorf("menusvc.ImportMenuSheet: %w", err)
+	}
+	result := planMenuImport(current, rows, mode, bot.PriceScale)
+	if len(result.Errors) > 0 && !dryRun {
+		return result, fmt.Errorf("menusvc.ImportMenuSheet(), %d row errors: %w", len(result.Errors), ErrInvalidMenuImport)
+	}
+	if dryRun || len(result.Errors) > 0 {
+		return result, nil
+	}
+	note := fmt.Sprintf("imported from spreadsheet (%s)", mode)
+	result.Detail.Menu.Version = cmp.Or(version, current.Menu.Version)
+	detail, err := s.updateMenu(ctx, botID, authorID, note, result.Detail, false)
+	if err != nil {
+		return entities.MenuImport{}, fmt.Errorf("menusvc.ImportMenuSheet: %w", err)
+	}
+	result.Detail = detail
+	return result, nil
+}
+
+func menuSheetRows(detail entities.MenuDetail, priceScale int) []entities.MenuSheetRow {
+	categoryPos := make(map[string]int, len(detail.Categories))
+	categoryNames := make(map[string]string, len(detail.Categories))
+	for _, category := range detail.Categories {
+		categoryPos[category.ID] = category.SortPosition
+		categoryNames[category.ID] = category.CategoryName
+	}
+	groupNames := make(map[string]string, len(detail.OptionGroups))
+	for _, group := range detail.OptionGroups {
+		groupNames[group.ID] = group.GroupName
+	}
+	items := slices.Clone(detail.Items)
+	slices.SortStableFunc(items, func(a, b entities.MenuItem) int {
+		if (a.CategoryID == "") != (b.CategoryID == "") {
+			if a.CategoryID == "" {
+				return 1
+			}
+			return -1
+		}
+		return cmp.Or(cmp.Compare(categoryPos[a.CategoryID], categoryPos[b.CategoryID]), cmp.Compare(a.SortPosition, b.SortPosition))
+	})
+	rows := make([]entities.MenuSheetRow, 0, len(items))
+	for idx, item := range items {
+		row := entities.MenuSheetRow{
+			Line:     idx + 2,
+			ItemID:   item.ID,
+			Category: categoryNames[item.CategoryID],
+			Name:     item.MenuItemName,
+			Price:    moneyutil.Money(item.PriceScaled).Format(priceScale),
+		}
+		for _, groupID := range item.OptionGroupIDs {
+			row.OptionGroups = append(row.OptionGroups, groupNames[groupID])
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+// planMenuImport builds the menu that results from applying rows to current.
+// Errors are collected per row rather than stopping at the first one, so a
+// preview lists everything to fix at once.
+func planMenuImport(
+	current entities.MenuDetail,
+	rows []entities.MenuSheetRow,
+	mode entities.MenuImportMode,
+	priceScale int,
+) entities.MenuImport {
+	result := entities.MenuImport{Mode: mode}
+	if len(rows) == 0 {
+		result.Errors = append(result.Errors, entities.MenuImportError{Message: "the sheet has no item rows"})
+		return result
+	}
+	groupIDs := make(map[string]string, len(current.OptionGroups))
+	for _, group := range current.OptionGroups {
+		groupIDs[strings.ToLower(strings.TrimSpace(group.GroupName))] = group.ID
+	}
+	currentItems := make(map[string]entities.MenuItem, len(current.Items))
+	// itemsByName maps to the item ID, or to "" when several items share
+	// the name.
+	itemsByName := make(map[string]string, len(current.Items))
+	for _, item := range current.Items {
+		currentItems[item.ID] = item
+		name := strings.ToLower(strings.TrimSpace(item.MenuItemName))
+		if _, ok := itemsByName[name]; ok {
+			itemsByName[name] = ""
+			continue
+		}
+		itemsByName[name] = item.ID
+	}
+	existingCategories := make(map[string]entities.MenuCategory, len(current.Categories))
+	for _, category := range current.Categories {
+		existingCategories[strings.ToLower(strings.TrimSpace(category.CategoryName))] = category
+	}
+
+	detail := entities.MenuDetail{Menu: current.Menu, OptionGroups: current.OptionGroups, Bundles: current.Bundles}
+	categoryIDs := make(map[string]string, len(current.Categories))
+	slots := make(map[string]int, len(current.Items))
+	if mode == entities.MenuImportMerge {
+		detail.Categories = slices.Clone(current.Categories)
+		for _, category := range current.Categories {
+			categoryIDs[strings.ToLower(strings.TrimSpace(category.CategoryName))] = category.ID
+		}
+		detail.Items = slices.Clone(current.Items)
+		for idx, item := range detail.Items {
+			slots[item.ID] = idx
+		}
+	}
+	categoryID := func(name string) string {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if key == "" {
+			return ""
+		}
+		if id, ok := categoryIDs[key]; ok {
+			return id
+		}
+		category := entities.MenuCategory{ID: util.NewID(), CategoryName: name}
+		if prev, ok := existingCategories[key]; ok {
+			category.ID, category.Translations = prev.ID, prev.Translations
+		}
+		category.SortPosition = len(detail.Categories)
+		detail.Categories = append(detail.Categories, category)
+		categoryIDs[key] = category.ID
+		return category.ID
+	}
+
+	matchedLines := make(map[string]int, len(rows))
+	nameLines := make(map[string]int, len(rows))
+	for _, row := range rows {
+		rowErr := func(column string, format string, args ...any) {
+			result.Errors = append(result.Errors, entities.MenuImportError{
+				Line:    row.Line,
+				Column:  column,
+				Message: fmt.Sprintf(format, args...),
+			})
+		}
+		errCount := len(result.Errors)
+		name := strings.TrimSpace(row.Name)
+		nameKey := strings.ToLower(name)
+		if name == "" {
+			rowErr("name", "name is required")
+		} else if line, ok := nameLines[nameKey]; ok {
+			rowErr("name", "%q is also on line %d", name, line)
+		} else if n := utf8.RuneCountInString(name); n > entities.MaxNameLen {
+			rowErr("name", "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+		} else {
+			nameLines[nameKey] = row.Line
+		}
+		price, err := moneyutil.ParseMoney(row.Price, priceScale)
+		if err != nil {
+			rowErr("price", "%q is not a price with at most %d decimals", row.Price, priceScale)
+		} else if price <= 0 {
+			rowErr("price", "price must be greater than zero")
+		}
+		var itemGroupIDs []string
+		for _, groupName := range row.OptionGroups {
+			groupID, ok := groupIDs[strings.ToLower(strings.TrimSpace(groupName))]
+			switch {
+			case !ok:
+				rowErr("option_groups", "option group %q is not in the menu", groupName)
+			case slices.Contains(itemGroupIDs, groupID):
+				rowErr("option_groups", "option group %q is listed twice", groupName)
+			default:
+				itemGroupIDs = append(itemGroupIDs, groupID)
+			}
+		}
+		itemID := strings.TrimSpace(row.ItemID)
+		if itemID != "" {
+			if _, ok := currentItems[itemID]; !ok {
+				rowErr("id", "item %s is not in the menu", itemID)
+			}
+		} else if id, ok := itemsByName[nameKey]; ok && name != "" {
+			if id == "" {
+				rowErr("name", "several items are named %q, fill in the id", name)
+			}
+			itemID = id
+		}
+		if line, ok := matchedLines[itemID]; ok && itemID != "" {
+			rowErr("id", "the item is also on line %d", line)
+		}
+		if len(result.Errors) > errCount {
+			continue
+		}
+		if itemID != "" {
+			matchedLines[itemID] = row.Line
+		}
+
+		// Sheets carry no item info, so updated items keep theirs.
+		item := currentItems[itemID]
+		item.ID = itemID
+		item.MenuItemName = name
+		item.PriceScaled = int64(price)
+		item.CategoryID = categoryID(row.Category)
+		item.OptionGroupIDs = itemGroupIDs
+		if itemID == "" {
+			result.Added++
+			detail.Items = append(detail.Items, item)
+			continue
+		}
+		result.Updated++
+		if slot, ok := slots[itemID]; ok {
+			detail.Items[slot] = item
+			continue
+		}
+		detail.Items = append(detail.Items, item)
+	}
+	if mode == entities.MenuImportReplace {
+		result.Removed = len(current.Items) - len(matchedLines)
+	}
+	positions := make(map[string]int, len(detail.Categories)+1)
+	for idx := range detail.Items {
+		item := &detail.Items[idx]
+		item.SortPosition = positions[item.CategoryID]
+		positions[item.CategoryID]++
+	}
+	result.Detail = detail
+	return result
+}
@@ -0,0 +1,108 @@
+package menusvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func sheetTestMenu() entities.MenuDetail {
+	return entities.MenuDetail{
+		Menu:         entities.Menu{ID: "m1"},
+		Categories:   []entities.MenuCategory{{ID: "c1", CategoryName: "Coffee"}, {ID: "c2", CategoryName: "Cakes", SortPosition: 1}},
+		OptionGroups: []entities.MenuOptionGroup{{ID: "g1", GroupName: "Size"}},
+		Items: []entities.MenuItem{
+			{
+				ID: "i1", MenuItemName: "Latte", PriceScaled: 450, CategoryID: "c1", OptionGroupIDs: []string{"g1"},
+				Description: "Double shot", Allergens: []entities.Allergen{entities.AllergenMilk},
+			},
+			{ID: "i2", MenuItemName: "Mocha", PriceScaled: 500, CategoryID: "c1", SortPosition: 1},
+			{ID: "i3", MenuItemName: "Brownie", PriceScaled: 300, CategoryID: "c2"},
+		},
+	}
+}
+
+func TestMenuSheetRowsRoundTrip(t *testing.T) {
+	current := sheetTestMenu()
+	rows := menuSheetRows(current, 2)
+	if len(rows) != 3 || rows[0].Price != "4.50" || !slices.Equal(rows[0].OptionGroups, []string{"Size"}) || rows[2].Category != "Cakes" {
+		t.Fatalf("menuSheetRows() = %+v", rows)
+	}
+	got := planMenuImport(current, rows, entities.MenuImportReplace, 2)
+	if len(got.Errors) != 0 || got.Added != 0 || got.Updated != 3 || got.Removed != 0 {
+		t.Fatalf("planMenuImport() of an export = %+v, want 3 updates", got)
+	}
+	if d := diffMenus(current, got.Detail); !d.Empty() {
+		t.Errorf("planMenuImport() of an export changed the menu: %+v", d)
+	}
+}
+
+func TestPlanMenuImport(t *testing.T) {
+	rows := []entities.MenuSheetRow{
+		{Line: 2, Name: "latte", Price: "4.75", Category: "Coffee"},
+		{Line: 3, Name: "Scone", Price: "2", Category: "Bakery", OptionGroups: []string{"size"}},
+	}
+
+	merged := planMenuImport(sheetTestMenu(), rows, entities.MenuImportMerge, 2)
+	if len(merged.Errors) != 0 || merged.Added != 1 || merged.Updated != 1 || merged.Removed != 0 {
+		t.Fatalf("merge = %+v", merged)
+	}
+	items := merged.Detail.Items
+	if len(items) != 4 || items[0].ID != "i1" || items[0].PriceScaled != 475 || len(items[0].OptionGroupIDs) != 0 {
+		t.Errorf("merge items = %+v, want Latte updated by name in place", items)
+	}
+	if len(merged.Detail.Categories) != 3 || items[3].ID != "" || items[3].CategoryID != merged.Detail.Categories[2].ID {
+		t.Errorf("merge = %+v, want Scone added in a new category", merged.Detail)
+	}
+
+	replaced := planMenuImport(sheetTestMenu(), rows, entities.MenuImportReplace, 2)
+	if len(replaced.Errors) != 0 || replaced.Added != 1 || replaced.Updated != 1 || replaced.Removed != 2 {
+		t.Fatalf("replace = %+v", replaced)
+	}
+	categories := replaced.Detail.Categories
+	if len(categories) != 2 || categories[0].ID != "c1" || categories[1].CategoryName != "Bakery" {
+		t.Errorf("replace categories = %+v, want Coffee kept and Bakery added", categories)
+	}
+}
+
+func TestPlanMenuImportErrors(t *testing.T) {
+	current := sheetTestMenu()
+	current.Items = append(current.Items, entities.MenuItem{ID: "i4", MenuItemName: "Mocha", PriceScaled: 500})
+	rows := []entities.MenuSheetRow{
+		{Line: 2, Name: "", Price: "1"},
+		{Line: 3, Name: "Tea", Price: "1.234"},
+		{Line: 4, ItemID: "nope", Name: "Cocoa", Price: "1"},
+		{Line: 5, Name: "Mocha", Price: "1"},
+		{Line: 6, Name: "Chai", Price: "1", OptionGroups: []string{"Milk"}},
+		{Line: 7, ItemID: "i1", Name: "Latte", Price: "1"},
+		{Line: 8, ItemID: "i1", Name: "Flat white", Price: "1"},
+		{Line: 9, Name: "tea", Price: "1"},
+		{Line: 10, Name: "Water", Price: "0"},
+		{Line: 11, Name: strings.Repeat("x", entities.MaxNameLen+1), Price: "1"},
+	}
+	got := planMenuImport(current, rows, entities.MenuImportMerge, 2)
+	want := []entities.MenuImportError{
+		{Line: 2, Column: "name"},
+		{Line: 3, Column: "price"},
+		{Line: 4, Column: "id"},
+		{Line: 5, Column: "name"},
+		{Line: 6, Column: "option_groups"},
+		{Line: 8, Column: "id"},
+		{Line: 9, Column: "name"},
+		{Line: 10, Column: "price"},
+		{Line: 11, Column: "name"},
+	}
+	if len(got.Errors) != len(want) {
+		t.Fatalf("planMenuImport() errors = %+v, want %d", got.Errors, len(want))
+	}
+	for idx, w := range want {
+		if e := got.Errors[idx]; e.Line != w.Line || e.Column != w.Column || e.Message == "" {
+			t.Errorf("errors[%d] = %+v, want line %d column %s", idx, e, w.Line, w.Column)
+		}
+	}
+
+	if got := planMenuImport(current, nil, entities.MenuImportReplace, 2); len(got.Errors) != 1 || got.Errors[0].Line != 0 {
+		t.Errorf("planMenuImport() without rows errors = %+v, want one sheet error", got.Errors)
+	}
+}
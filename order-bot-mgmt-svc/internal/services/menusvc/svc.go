@@ -1,6 +1,7 @@
 package menusvc
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -9,141 +10,448 @@ import (
 	"order-bot-mgmt-svc/internal/models/entities"
 	"order-bot-mgmt-svc/internal/store"
 	"order-bot-mgmt-svc/internal/util"
+	"slices"
+	"strings"
 )
 
 type Svc struct {
-	menuStore          store.Menu
-	menuItemStore      store.MenuItem
-	publishedMenuStore *orderbotmgmtsqldb.PublishedMenuStore
-	db                 *sqldb.DB
-	orderBotDb         *sqldb.DB
-	ctxFunc            util.CtxFunc
+	botStore             store.Bot
+	menuStore            store.Menu
+	menuItemStore        store.MenuItem
+	menuCategoryStore    store.MenuCategory
+	menuOptionGroupStore store.MenuOptionGroup
+	menuBundleStore      store.MenuBundle
+	menuVersionStore     store.MenuVersion
+	publishJobStore      store.PublishJob
+	publishedMenuStore   *orderbotmgmtsqldb.PublishedMenuStore
+	db                   *sqldb.DB
+	orderBotDb           *sqldb.DB
+	ctxFunc              util.CtxFunc
 }
 
 func NewSvc(
 	db *sqldb.DB,
 	orderBotDb *sqldb.DB,
 	ctxFunc util.CtxFunc,
+	botStore store.Bot,
 	menuStore store.Menu,
 	menuItemStore store.MenuItem,
+	menuCategoryStore store.MenuCategory,
+	menuOptionGroupStore store.MenuOptionGroup,
+	menuBundleStore store.MenuBundle,
+	menuVersionStore store.MenuVersion,
+	publishJobStore store.PublishJob,
 	publishedMenuStore *orderbotmgmtsqldb.PublishedMenuStore,
 ) *Svc {
-	if menuStore == nil || menuItemStore == nil || db == nil || orderBotDb == nil || publishedMenuStore == nil {
-		panic("menusvc.NewSvc(), menuStore, menuItemStore, publishedMenuStore, db, or orderBotDb is nil")
+	if botStore == nil || menuStore == nil || menuItemStore == nil || menuCategoryStore == nil || menuOptionGroupStore == nil ||
+		menuBundleStore == nil || menuVersionStore == nil || publishJobStore == nil || db == nil || orderBotDb == nil ||
+		publishedMenuStore == nil {
+		panic("menusvc.NewSvc(), botStore, menuStore, menuItemStore, menuCategoryStore, menuOptionGroupStore, " +
+			"menuBundleStore, menuVersionStore, publishJobStore, publishedMenuStore, db, or orderBotDb is nil")
 	}
 	return &Svc{
-		menuStore:          menuStore,
-		menuItemStore:      menuItemStore,
-		publishedMenuStore: publishedMenuStore,
-		db:                 db,
-		orderBotDb:         orderBotDb,
-		ctxFunc:            ctxFunc,
+		botStore:             botStore,
+		menuStore:            menuStore,
+		menuItemStore:        menuItemStore,
+		menuCategoryStore:    menuCategoryStore,
+		menuOptionGroupStore: menuOptionGroupStore,
+		menuBundleStore:      menuBundleStore,
+		menuVersionStore:     menuVersionStore,
+		publishJobStore:      publishJobStore,
+		publishedMenuStore:   publishedMenuStore,
+		db:                   db,
+		orderBotDb:           orderBotDb,
+		ctxFunc:              ctxFunc,
 	}
 }
 
-func (s *Svc) CreateMenu(ctx context.Context, botID string, menuItems []entities.MenuItem) (entities.Menu, []entities.MenuItem, error) {
+// CreateMenu adds a menu to the bot and stores it as version 1. Only the name
+// and window of detail.Menu are used; a new menu row is created for botID.
+// Every item gets a new ID.
+func (s *Svc) CreateMenu(ctx context.Context, botID string, authorID string, detail entities.MenuDetail) (entities.MenuDetail, error) {
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
-	var (
-		menu  entities.Menu
-		items []entities.MenuItem
-	)
-	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
-		_, errFinding := s.menuStore.FindByBotID(ctx, botID)
-		switch {
-		case errFinding == nil:
-			return fmt.Errorf("menusvc.GetMenuMenuItems(), duplicated bot ID: %w", ErrInvalidMenu)
-		case !errors.Is(errFinding, store.ErrMenuNotFound):
-			return fmt.Errorf("menusvc.GetMenuMenuItems: %w", errFinding)
-		}
-		menu = entities.Menu{
-			ID:    util.NewID(),
-			BotID: botID,
-		}
-		items = menuItems
-		for idx := range items {
-			items[idx].MenuID = menu.ID
-		}
-		if err := s.menuStore.CreateMenu(ctx, tx, menu); err != nil {
+	for idx := range detail.Items {
+		detail.Items[idx].ID = util.NewID()
+	}
+	normalizeOptionGroups(detail.OptionGroups)
+	normalizeItemInfo(detail.Items)
+	normalizeAliases(detail.Items)
+	normalizeTranslations(&detail)
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.CreateMenu: %w", err)
+	}
+	if err := errors.Join(validateMenu(detail), validateTranslations(detail, bot)); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.CreateMenu: %w", err)
+	}
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		// Saves of the bot's menus take turns on the bot row, so they
+		// cannot each pass the window check and commit overlapping windows.
+		if _, err := s.botStore.FindByIDForUpdate(ctx, tx, botID); err != nil {
+			return fmt.Errorf("menusvc.CreateMenu: %w", err)
+		}
+		others, errListing := s.menuStore.ListByBotID(ctx, tx, botID)
+		if errListing != nil {
+			return fmt.Errorf("menusvc.CreateMenu: %w", errListing)
+		}
+		detail.Menu = entities.Menu{
+			ID:       util.NewID(),
+			BotID:    botID,
+			MenuName: strings.TrimSpace(detail.Menu.MenuName),
+			Window:   detail.Menu.Window,
+			Version:  1,
+		}
+		if err := validateMenuSchedule(detail.Menu, others); err != nil {
 			return fmt.Errorf("menusvc.CreateMenu: %w", err)
 		}
-		if err := s.menuItemStore.CreateMenuItems(ctx, tx, items); err != nil {
+		setMenuID(&detail)
+		if err := s.menuStore.CreateMenu(ctx, tx, detail.Menu); err != nil {
+			return fmt.Errorf("menusvc.CreateMenu: %w", err)
+		}
+		if err := s.createMenuContent(ctx, tx, detail); err != nil {
+			return fmt.Errorf("menusvc.CreateMenu: %w", err)
+		}
+		if err := s.saveVersion(ctx, tx, detail, authorID, ""); err != nil {
 			return fmt.Errorf("menusvc.CreateMenu: %w", err)
 		}
 		return nil
 	})
 	if err != nil {
-		return entities.Menu{}, nil, err
+		return entities.MenuDetail{}, err
 	}
-	return menu, items, nil
+	return detail, nil
 }
 
-func (s *Svc) GetMenu(ctx context.Context, botId string) (entities.Menu, error) {
+// ListMenus returns the bot's menus without their content, oldest first.
+func (s *Svc) ListMenus(ctx context.Context, botID string) ([]entities.Menu, error) {
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
-	menu, err := s.menuStore.FindByBotID(ctx, botId)
+	menus, err := s.menuStore.ListByBotID(ctx, nil, botID)
 	if err != nil {
-		return entities.Menu{}, fmt.Errorf("menusvc.GetMenu: %w", err)
+		return nil, fmt.Errorf("menusvc.ListMenus: %w", err)
 	}
-	return menu, nil
+	return menus, nil
 }
 
-func (s *Svc) GetMenuMenuItems(ctx context.Context, botId string) (entities.Menu, []entities.MenuItem, error) {
+// GetMenuMenuItems returns one of the bot's draft menus with its categories,
+// option groups, items and bundles. An empty menuID picks the bot's only menu.
+func (s *Svc) GetMenuMenuItems(ctx context.Context, botId string, menuID string) (entities.MenuDetail, error) {
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
-	menu, err := s.menuStore.FindByBotID(ctx, botId)
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botId, menuID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
+	}
+	categories, err := s.menuCategoryStore.FindByMenuID(ctx, nil, menu.ID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
+	}
+	groups, err := s.menuOptionGroupStore.FindByMenuID(ctx, nil, menu.ID)
 	if err != nil {
-		return entities.Menu{}, nil, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
 	}
-	items, err := s.menuItemStore.FindItems(ctx, menu.ID)
+	items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
 	if err != nil {
-		return entities.Menu{}, nil, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
 	}
-	return menu, items, nil
+	bundles, err := s.menuBundleStore.FindByMenuID(ctx, nil, menu.ID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.GetMenuMenuItems: %w", err)
+	}
+	return entities.MenuDetail{Menu: menu, Categories: categories, OptionGroups: groups, Items: items, Bundles: bundles}, nil
 }
 
-func (s *Svc) UpdateMenu(ctx context.Context, botID string, items []entities.MenuItem) (entities.Menu, []entities.MenuItem, error) {
+// UpdateMenu replaces the name, window and content of the draft menu
+// detail.Menu.ID, or of the bot's only menu when that is empty, and records
+// it as a new version. Items with the ID of an existing item update
+// that item, so it keeps its identity, availability and stock. Items without
+// an ID are added and existing items left out are removed. IDs that are not
+// in the menu are rejected. detail.Menu.Version is the version the edit is
+// based on; when the draft has been edited since, the update fails with
+// store.ErrMenuChanged. A zero version edits the draft as it is. Bundles are
+// edited with SetBundles; the update keeps those of the draft, so items they
+// use cannot be left out.
+func (s *Svc) UpdateMenu(ctx context.Context, botID string, authorID string, detail entities.MenuDetail) (entities.MenuDetail, error) {
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
-	var menu entities.Menu
-	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
-		menu, errMenu := s.menuStore.FindByBotID(ctx, botID)
-		for idx := range items {
-			items[idx].MenuID = menu.ID
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, detail.Menu.ID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.UpdateMenu: %w", err)
+	}
+	if detail.Bundles, err = s.menuBundleStore.FindByMenuID(ctx, nil, menu.ID); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.UpdateMenu: %w", err)
+	}
+	return s.updateMenu(ctx, botID, authorID, "", detail, false)
+}
+
+// updateMenu with reviveIDs set inserts items whose ID is not in the menu
+// under that ID, which brings back items deleted since a version was saved.
+func (s *Svc) updateMenu(
+	ctx context.Context,
+	botID string,
+	authorID string,
+	note string,
+	detail entities.MenuDetail,
+	reviveIDs bool,
+) (entities.MenuDetail, error) {
+	normalizeOptionGroups(detail.OptionGroups)
+	normalizeItemInfo(detail.Items)
+	normalizeAliases(detail.Items)
+	normalizeTranslations(&detail)
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.UpdateMenu: %w", err)
+	}
+	if err := errors.Join(validateMenu(detail), validateTranslations(detail, bot)); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.UpdateMenu: %w", err)
+	}
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		// Locked as in CreateMenu.
+		if _, err := s.botStore.FindByIDForUpdate(ctx, tx, botID); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
 		}
+		menu, errMenu := s.menuStore.FindByBotID(ctx, tx, botID, detail.Menu.ID)
 		if errMenu != nil {
 			return fmt.Errorf("menusvc.UpdateMenu: %w", errMenu)
 		}
-		if err := s.menuItemStore.DeleteMenuItems(ctx, tx, menu.ID); err != nil {
+		version := cmp.Or(detail.Menu.Version, menu.Version)
+		if err := s.menuStore.BumpVersion(ctx, tx, menu.ID, version); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		menu.Version = version + 1
+		menus, errListing := s.menuStore.ListByBotID(ctx, tx, botID)
+		if errListing != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", errListing)
+		}
+		menu.MenuName, menu.Window = strings.TrimSpace(detail.Menu.MenuName), detail.Menu.Window
+		others := slices.DeleteFunc(menus, func(other entities.Menu) bool { return other.ID == menu.ID })
+		if err := validateMenuSchedule(menu, others); err != nil {
 			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
 		}
-		if err := s.menuItemStore.CreateMenuItems(ctx, tx, items); err != nil {
+		if err := s.menuStore.UpdateMenu(ctx, tx, menu); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		detail.Menu = menu
+		setMenuID(&detail)
+		existing, errItems := s.menuItemStore.FindItems(ctx, tx, menu.ID)
+		if errItems != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", errItems)
+		}
+		sync, errPlan := planItemSync(existing, detail.Items, reviveIDs)
+		if errPlan != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", errPlan)
+		}
+		if err := s.replaceMenuStructure(ctx, tx, detail); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		if err := s.menuItemStore.DeleteItems(ctx, tx, menu.ID, sync.deletes); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		if err := s.menuItemStore.UpdateMenuItems(ctx, tx, sync.updates); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		if err := s.menuItemStore.CreateMenuItems(ctx, tx, sync.inserts); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		if err := s.menuBundleStore.CreateBundles(ctx, tx, detail.Bundles); err != nil {
+			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
+		}
+		if err := s.saveVersion(ctx, tx, detail, authorID, note); err != nil {
 			return fmt.Errorf("menusvc.UpdateMenu: %w", err)
 		}
 		return nil
 	})
 	if err != nil {
-		return entities.Menu{}, nil, err
+		return entities.MenuDetail{}, err
+	}
+	return detail, nil
+}
+
+// ReorderCategories sets the display order of the menu's categories and
+// records it as a new version. ids must list every category of the menu
+// exactly once. version is the menu version the order is based on; zero
+// reorders the draft as it is.
+func (s *Svc) ReorderCategories(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	ids []string,
+) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return fmt.Errorf("menusvc.ReorderCategories: %w", err)
+	}
+	current := make([]string, 0, len(detail.Categories))
+	for _, category := range detail.Categories {
+		current = append(current, category.ID)
+	}
+	if !sameIDs(current, ids) {
+		return fmt.Errorf("menusvc.ReorderCategories(), ids do not match the menu categories: %w", ErrInvalidMenu)
+	}
+	for idx := range detail.Categories {
+		detail.Categories[idx].SortPosition = slices.Index(ids, detail.Categories[idx].ID)
+	}
+	slices.SortFunc(detail.Categories, func(a, b entities.MenuCategory) int {
+		return cmp.Compare(a.SortPosition, b.SortPosition)
+	})
+	if err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		version := cmp.Or(version, detail.Menu.Version)
+		if err := s.menuStore.BumpVersion(ctx, tx, detail.Menu.ID, version); err != nil {
+			return err
+		}
+		detail.Menu.Version = version + 1
+		if err := s.menuCategoryStore.UpdateSortPositions(ctx, tx, detail.Menu.ID, ids); err != nil {
+			return err
+		}
+		return s.saveVersion(ctx, tx, detail, authorID, "reordered")
+	}); err != nil {
+		return fmt.Errorf("menusvc.ReorderCategories: %w", err)
+	}
+	return nil
+}
+
+// ReorderItems sets the display order of the items in one category. An empty
+// categoryID addresses the uncategorized items. ids must list every item of
+// that category exactly once. version works as for ReorderCategories.
+func (s *Svc) ReorderItems(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	categoryID string,
+	ids []string,
+) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	detail, err := s.GetMenuMenuItems(ctx, botID, menuID)
+	if err != nil {
+		return fmt.Errorf("menusvc.ReorderItems: %w", err)
+	}
+	if categoryID != "" &&
+		!slices.ContainsFunc(detail.Categories, func(c entities.MenuCategory) bool { return c.ID == categoryID }) {
+		return fmt.Errorf("menusvc.ReorderItems(), category %s: %w", categoryID, ErrCategoryNotFound)
+	}
+	current := make([]string, 0, len(detail.Items))
+	for _, item := range detail.Items {
+		if item.CategoryID == categoryID {
+			current = append(current, item.ID)
+		}
+	}
+	if !sameIDs(current, ids) {
+		return fmt.Errorf("menusvc.ReorderItems(), ids do not match the category items: %w", ErrInvalidMenu)
 	}
-	return menu, items, nil
+	for idx := range detail.Items {
+		if item := &detail.Items[idx]; item.CategoryID == categoryID {
+			item.SortPosition = slices.Index(ids, item.ID)
+		}
+	}
+	// The order FindItems reads them in.
+	slices.SortFunc(detail.Items, func(a, b entities.MenuItem) int {
+		return cmp.Or(cmp.Compare(a.SortPosition, b.SortPosition), strings.Compare(a.ID, b.ID))
+	})
+	if err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		version := cmp.Or(version, detail.Menu.Version)
+		if err := s.menuStore.BumpVersion(ctx, tx, detail.Menu.ID, version); err != nil {
+			return err
+		}
+		detail.Menu.Version = version + 1
+		if err := s.menuItemStore.UpdateSortPositions(ctx, tx, detail.Menu.ID, ids); err != nil {
+			return err
+		}
+		return s.saveVersion(ctx, tx, detail, authorID, "reordered")
+	}); err != nil {
+		return fmt.Errorf("menusvc.ReorderItems: %w", err)
+	}
+	return nil
 }
 
-func (s *Svc) PublishMenu(ctx context.Context, botID string) (entities.Menu, []entities.MenuItem, error) {
+// PublishMenus copies all of the bot's draft menus, with their windows, to
+// the order bot and takes down published menus that no longer exist. The
+// returned comparisons, one per menu, are the state before publishing. With
+// dryRun set nothing is written and the comparisons show what publishing
+// would change.
+func (s *Svc) PublishMenus(ctx context.Context, botID string, dryRun bool) ([]entities.MenuDetail, []entities.MenuComparison, error) {
 	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
 	defer cancel()
-	menu, items, err := s.GetMenuMenuItems(ctx, botID)
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
 	if err != nil {
-		return entities.Menu{}, nil, fmt.Errorf("menusvc.PublishMenu: %w", err)
+		return nil, nil, fmt.Errorf("menusvc.PublishMenus: %w", err)
+	}
+	menus, err := s.menuStore.ListByBotID(ctx, nil, botID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("menusvc.PublishMenus: %w", err)
+	}
+	if len(menus) == 0 {
+		return nil, nil, fmt.Errorf("menusvc.PublishMenus: %w", store.ErrMenuNotFound)
+	}
+	details := make([]entities.MenuDetail, 0, len(menus))
+	comparisons := make([]entities.MenuComparison, 0, len(menus))
+	for _, menu := range menus {
+		detail, err := s.GetMenuMenuItems(ctx, botID, menu.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("menusvc.PublishMenus: %w", err)
+		}
+		comparison, err := s.compareWithPublished(ctx, bot, detail)
+		if err != nil {
+			return nil, nil, fmt.Errorf("menusvc.PublishMenus: %w", err)
+		}
+		details = append(details, detail)
+		comparisons = append(comparisons, comparison)
+	}
+	if dryRun {
+		return details, comparisons, nil
 	}
 	if err := s.orderBotDb.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
-		if err := s.publishedMenuStore.ReplaceMenuItems(ctx, tx, menu, items); err != nil {
-			return fmt.Errorf("menusvc.PublishMenu: %w", err)
+		if err := s.publishedMenuStore.ReplaceMenus(ctx, tx, bot, details); err != nil {
+			return fmt.Errorf("menusvc.PublishMenus: %w", err)
 		}
 		return nil
 	}); err != nil {
-		return entities.Menu{}, nil, err
+		return nil, nil, err
 	}
-	return menu, items, nil
+	return details, comparisons, nil
+}
+
+// DeleteMenu removes a draft menu with its content and versions. The order
+// bot keeps serving the published copy until the next publish.
+func (s *Svc) DeleteMenu(ctx context.Context, botID string, menuID string) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if menuID == "" {
+		return fmt.Errorf("menusvc.DeleteMenu(), empty menu id: %w", ErrInvalidMenu)
+	}
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return fmt.Errorf("menusvc.DeleteMenu: %w", err)
+	}
+	err = s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		if err := s.menuBundleStore.DeleteByMenuID(ctx, tx, menu.ID); err != nil {
+			return err
+		}
+		if err := s.menuItemStore.DeleteMenuItems(ctx, tx, menu.ID); err != nil {
+			return err
+		}
+		if err := s.menuOptionGroupStore.DeleteByMenuID(ctx, tx, menu.ID); err != nil {
+			return err
+		}
+		if err := s.menuCategoryStore.DeleteByMenuID(ctx, tx, menu.ID); err != nil {
+			return err
+		}
+		if err := s.menuVersionStore.DeleteByMenuID(ctx, tx, menu.ID); err != nil {
+			return err
+		}
+		return s.menuStore.DeleteMenu(ctx, tx, menu.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("menusvc.DeleteMenu: %w", err)
+	}
+	return nil
 }
 
 func (s *Svc) IsMenuPublished(ctx context.Context, menuID string) (bool, error) {
@@ -155,3 +463,72 @@ func (s *Svc) IsMenuPublished(ctx context.Context, menuID string) (bool, error)
 	}
 	return exists, nil
 }
+
+// createMenuContent inserts categories, option groups, items and bundles,
+// each after what it references.
+func (s *Svc) createMenuContent(ctx context.Context, tx store.Tx, detail entities.MenuDetail) error {
+	if err := s.menuCategoryStore.CreateCategories(ctx, tx, detail.Categories); err != nil {
+		return fmt.Errorf("menusvc.Svc.createMenuContent: %w", err)
+	}
+	if err := s.menuOptionGroupStore.CreateOptionGroups(ctx, tx, detail.OptionGroups); err != nil {
+		return fmt.Errorf("menusvc.Svc.createMenuContent: %w", err)
+	}
+	if err := s.menuItemStore.CreateMenuItems(ctx, tx, detail.Items); err != nil {
+		return fmt.Errorf("menusvc.Svc.createMenuContent: %w", err)
+	}
+	if err := s.menuBundleStore.CreateBundles(ctx, tx, detail.Bundles); err != nil {
+		return fmt.Errorf("menusvc.Svc.createMenuContent: %w", err)
+	}
+	return nil
+}
+
+// replaceMenuStructure swaps the menu's categories and option groups for
+// those in detail and removes its bundles. Items stay; their links and the
+// bundles are written again by the caller once the items are in place.
+func (s *Svc) replaceMenuStructure(ctx context.Context, tx store.Tx, detail entities.MenuDetail) error {
+	if err := s.menuBundleStore.DeleteByMenuID(ctx, tx, detail.Menu.ID); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	if err := s.menuItemStore.DetachMenuItems(ctx, tx, detail.Menu.ID); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	if err := s.menuOptionGroupStore.DeleteByMenuID(ctx, tx, detail.Menu.ID); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	if err := s.menuCategoryStore.DeleteByMenuID(ctx, tx, detail.Menu.ID); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	if err := s.menuCategoryStore.CreateCategories(ctx, tx, detail.Categories); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	if err := s.menuOptionGroupStore.CreateOptionGroups(ctx, tx, detail.OptionGroups); err != nil {
+		return fmt.Errorf("menusvc.Svc.replaceMenuStructure: %w", err)
+	}
+	return nil
+}
+
+func setMenuID(detail *entities.MenuDetail) {
+	for idx := range detail.Categories {
+		detail.Categories[idx].MenuID = detail.Menu.ID
+	}
+	for idx := range detail.OptionGroups {
+		detail.OptionGroups[idx].MenuID = detail.Menu.ID
+	}
+	for idx := range detail.Items {
+		detail.Items[idx].MenuID = detail.Menu.ID
+	}
+	for idx := range detail.Bundles {
+		detail.Bundles[idx].MenuID = detail.Menu.ID
+	}
+}
+
+// sameIDs reports whether got is a permutation of want.
+func sameIDs(want []string, got []string) bool {
+	if len(want) != len(got) {
+		return false
+	}
+	want, got = slices.Clone(want), slices.Clone(got)
+	slices.Sort(want)
+	slices.Sort(got)
+	return slices.Equal(want, got)
+}
@@ -0,0 +1,112 @@
+package menusvc
+
+import (
+	"fmt"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/localeutil"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// normalizeTranslations trims translated text, drops empty translations and
+// puts locale keys in canonical case. A key is left as it is when its
+// canonical form is taken, for validateTranslations to reject.
+func normalizeTranslations(detail *entities.MenuDetail) {
+	for idx := range detail.Categories {
+		detail.Categories[idx].Translations = normalizedTranslations(detail.Categories[idx].Translations)
+	}
+	for idx := range detail.Items {
+		detail.Items[idx].Translations = normalizedTranslations(detail.Items[idx].Translations)
+	}
+}
+
+func normalizedTranslations(translations entities.Translations) entities.Translations {
+	out := make(entities.Translations, len(translations))
+	for _, locale := range slices.Sorted(maps.Keys(translations)) {
+		translation := entities.Translation{
+			Name:        strings.TrimSpace(translations[locale].Name),
+			Description: strings.TrimSpace(translations[locale].Description),
+		}
+		if translation == (entities.Translation{}) {
+			continue
+		}
+		if normalized, ok := localeutil.Normalize(locale); ok {
+			if _, taken := translations[normalized]; !taken || normalized == locale {
+				locale = normalized
+			}
+		}
+		out[locale] = translation
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// validateTranslations checks that translations are into the bot's locales
+// other than its default, which the untranslated text is written in, and
+// that translated text is within the limits of the untranslated text.
+// Category translations only have a name.
+func validateTranslations(detail entities.MenuDetail, bot entities.Bot) error {
+	var errs validatorutil.FieldErrors
+	for idx, category := range detail.Categories {
+		for _, locale := range slices.Sorted(maps.Keys(category.Translations)) {
+			field := fmt.Sprintf("categories[%d].translations.%s", idx, locale)
+			translation := category.Translations[locale]
+			validateTranslation(&errs, field, locale, translation, bot)
+			if translation.Description != "" {
+				errs.Add(ErrInvalidMenu, field+".description", validatorutil.CodeInvalid, "categories have no description")
+			}
+		}
+	}
+	for idx, item := range detail.Items {
+		for _, locale := range slices.Sorted(maps.Keys(item.Translations)) {
+			field := fmt.Sprintf("items[%d].translations.%s", idx, locale)
+			translation := item.Translations[locale]
+			validateTranslation(&errs, field, locale, translation, bot)
+			if n := utf8.RuneCountInString(translation.Description); n > entities.MaxDescriptionLen {
+				errs.Add(ErrInvalidMenu, field+".description", validatorutil.CodeTooLong,
+					"description has %d characters, the limit is %d", n, entities.MaxDescriptionLen)
+			}
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.validateTranslations: %w", err)
+	}
+	return nil
+}
+
+func validateTranslation(
+	errs *validatorutil.FieldErrors,
+	field string,
+	locale string,
+	translation entities.Translation,
+	bot entities.Bot,
+) {
+	switch {
+	case locale == bot.DefaultLocale:
+		errs.Add(ErrInvalidMenu, field, validatorutil.CodeInvalid, "%s is the default locale, which needs no translation", locale)
+	case !bot.Translatable(locale):
+		errs.Add(ErrInvalidMenu, field, validatorutil.CodeUnknown, "locale %q is not supported by the bot", locale)
+	}
+	if n := utf8.RuneCountInString(translation.Name); n > entities.MaxNameLen {
+		errs.Add(ErrInvalidMenu, field+".name", validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+	}
+}
+
+// supportedTranslations returns a copy of detail without translations into
+// locales the bot no longer translates to, such as in an old version.
+func supportedTranslations(detail entities.MenuDetail, bot entities.Bot) entities.MenuDetail {
+	detail.Categories = slices.Clone(detail.Categories)
+	for idx := range detail.Categories {
+		detail.Categories[idx].Translations = detail.Categories[idx].Translations.Supported(bot)
+	}
+	detail.Items = slices.Clone(detail.Items)
+	for idx := range detail.Items {
+		detail.Items[idx].Translations = detail.Items[idx].Translations.Supported(bot)
+	}
+	return detail
+}
@@ -0,0 +1,106 @@
+package menusvc
+
+import (
+	"errors"
+	"maps"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTranslations(t *testing.T) {
+	detail := entities.MenuDetail{
+		Categories: []entities.MenuCategory{{Translations: entities.Translations{"zh_tw": {Name: " 飲料 "}}}},
+		Items: []entities.MenuItem{
+			{Translations: entities.Translations{
+				"ZH-tw": {Name: "拿鐵", Description: " 燕麥奶 "},
+				"ja":    {Name: "  "},
+			}},
+			{Translations: entities.Translations{"ja": {}}},
+			{Translations: entities.Translations{"zh-TW": {Name: "拿鐵"}, "zh_tw": {Name: "拿铁"}}},
+		},
+	}
+	normalizeTranslations(&detail)
+	if got, want := detail.Categories[0].Translations, (entities.Translations{"zh-TW": {Name: "飲料"}}); !maps.Equal(got, want) {
+		t.Errorf("category translations = %v, want %v", got, want)
+	}
+	if got, want := detail.Items[0].Translations, (entities.Translations{"zh-TW": {Name: "拿鐵", Description: "燕麥奶"}}); !maps.Equal(got, want) {
+		t.Errorf("items[0] translations = %v, want %v", got, want)
+	}
+	if detail.Items[1].Translations != nil {
+		t.Errorf("items[1] translations = %v, want nil", detail.Items[1].Translations)
+	}
+	// The clashing key is kept as it is, for validation to reject.
+	if got, want := detail.Items[2].Translations, (entities.Translations{"zh-TW": {Name: "拿鐵"}, "zh_tw": {Name: "拿铁"}}); !maps.Equal(got, want) {
+		t.Errorf("items[2] translations = %v, want %v", got, want)
+	}
+}
+
+func TestValidateTranslations(t *testing.T) {
+	bot := entities.Bot{DefaultLocale: "en", Locales: []string{"en", "zh-TW", "ja"}}
+	item := func(locale string, translation entities.Translation) entities.MenuDetail {
+		return entities.MenuDetail{Items: []entities.MenuItem{{Translations: entities.Translations{locale: translation}}}}
+	}
+	tests := []struct {
+		name    string
+		detail  entities.MenuDetail
+		wantErr bool
+	}{
+		{name: "no translations", detail: entities.MenuDetail{Items: []entities.MenuItem{{MenuItemName: "Latte"}}}},
+		{name: "supported locale", detail: item("zh-TW", entities.Translation{Name: "拿鐵", Description: "燕麥奶"})},
+		{name: "default locale", detail: item("en", entities.Translation{Name: "Latte"}), wantErr: true},
+		{name: "unsupported locale", detail: item("ko", entities.Translation{Name: "라떼"}), wantErr: true},
+		{name: "non-canonical locale", detail: item("zh_tw", entities.Translation{Name: "拿铁"}), wantErr: true},
+		{
+			name:    "description too long",
+			detail:  item("ja", entities.Translation{Description: strings.Repeat("ラ", entities.MaxDescriptionLen+1)}),
+			wantErr: true,
+		},
+		{
+			name: "category name",
+			detail: entities.MenuDetail{Categories: []entities.MenuCategory{{
+				CategoryName: "Drinks", Translations: entities.Translations{"ja": {Name: "ドリンク"}},
+			}}},
+		},
+		{
+			name: "category description",
+			detail: entities.MenuDetail{Categories: []entities.MenuCategory{{
+				CategoryName: "Drinks", Translations: entities.Translations{"ja": {Name: "ドリンク", Description: "冷たい"}},
+			}}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTranslations(tt.detail, bot)
+			if tt.wantErr && !errors.Is(err, ErrInvalidMenu) {
+				t.Fatalf("validateTranslations() = %v, want ErrInvalidMenu", err)
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("validateTranslations() = %v, want nil", err)
+			}
+		})
+	}
+}
+
+func TestSupportedTranslations(t *testing.T) {
+	bot := entities.Bot{DefaultLocale: "zh-TW", Locales: []string{"zh-TW", "en"}}
+	draft := entities.MenuDetail{
+		Categories: []entities.MenuCategory{{Translations: entities.Translations{"ja": {Name: "ドリンク"}}}},
+		Items: []entities.MenuItem{{Translations: entities.Translations{
+			"en":    {Name: "Latte"},
+			"zh-TW": {Name: "拿鐵"},
+			"ja":    {Name: "ラテ"},
+		}}},
+	}
+	got := supportedTranslations(draft, bot)
+	if got.Categories[0].Translations != nil {
+		t.Errorf("category translations = %v, want nil", got.Categories[0].Translations)
+	}
+	if want := (entities.Translations{"en": {Name: "Latte"}}); !maps.Equal(got.Items[0].Translations, want) {
+		t.Errorf("item translations = %v, want %v", got.Items[0].Translations, want)
+	}
+	if len(draft.Items[0].Translations) != 3 {
+		t.Errorf("draft translations = %v, want them untouched", draft.Items[0].Translations)
+	}
+}
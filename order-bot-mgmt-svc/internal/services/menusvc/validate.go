@@ -0,0 +1,193 @@
+package menusvc
+
+import (
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"strings"
+	"unicode/utf8"
+)
+
+// normalizeOptionGroups fills in defaults: a zero MaxChoices means one for
+// single select and every option for multi select, and a required group needs
+// at least one choice.
+func normalizeOptionGroups(groups []entities.MenuOptionGroup) {
+	for idx := range groups {
+		group := &groups[idx]
+		if group.SelectionType == "" {
+			group.SelectionType = entities.OptionSelectionSingle
+		}
+		if group.MaxChoices == 0 {
+			group.MaxChoices = len(group.Options)
+			if group.SelectionType == entities.OptionSelectionSingle {
+				group.MaxChoices = 1
+			}
+		}
+		if group.Required && group.MinChoices == 0 {
+			group.MinChoices = 1
+		}
+		if group.MinChoices > 0 {
+			group.Required = true
+		}
+	}
+}
+
+// validateMenu checks names are present, not too long and unique, item
+// prices are positive, the menu is within its size limits, option group
+// limits are consistent, item info, aliases and bundles are valid, and every
+// item or bundle reference points into the same menu. Every problem is reported, as
+// validatorutil.FieldErrors with fields such as "items[3].price".
+func validateMenu(detail entities.MenuDetail) error {
+	var errs validatorutil.FieldErrors
+	if len(detail.Categories) > entities.MaxMenuCategories {
+		errs.Add(ErrInvalidMenu, "categories", validatorutil.CodeTooMany,
+			"a menu has at most %d categories, got %d", entities.MaxMenuCategories, len(detail.Categories))
+	}
+	if len(detail.Items) > entities.MaxMenuItems {
+		errs.Add(ErrInvalidMenu, "items", validatorutil.CodeTooMany,
+			"a menu has at most %d items, got %d", entities.MaxMenuItems, len(detail.Items))
+	}
+	categoryNames := make(map[string]struct{}, len(detail.Categories))
+	categoryIDs := make(map[string]struct{}, len(detail.Categories))
+	for idx, category := range detail.Categories {
+		field := fmt.Sprintf("categories[%d].name", idx)
+		validateName(&errs, ErrInvalidMenu, field, category.CategoryName, categoryNames, "category")
+		categoryIDs[category.ID] = struct{}{}
+	}
+	groupIDs := make(map[string]struct{}, len(detail.OptionGroups))
+	for idx, group := range detail.OptionGroups {
+		errs.Nest(fmt.Sprintf("option_groups[%d]", idx), validateOptionGroup(group))
+		groupIDs[group.ID] = struct{}{}
+	}
+	errs.Nest("", validateAliases(detail.Items))
+	itemNames := make(map[string]struct{}, len(detail.Items))
+	itemIDs := make(map[string]struct{}, len(detail.Items))
+	for idx, item := range detail.Items {
+		prefix := fmt.Sprintf("items[%d]", idx)
+		if item.ID != "" {
+			itemIDs[item.ID] = struct{}{}
+		}
+		validateName(&errs, ErrInvalidMenu, prefix+".name", item.MenuItemName, itemNames, "item")
+		if item.PriceScaled <= 0 {
+			errs.Add(ErrInvalidMenu, prefix+".price", validatorutil.CodeOutOfRange, "price must be greater than zero")
+		}
+		errs.Nest(prefix, validateItemInfo(item))
+		if item.CategoryID != "" {
+			if _, ok := categoryIDs[item.CategoryID]; !ok {
+				errs.Add(ErrInvalidMenu, prefix+".category_id", validatorutil.CodeUnknown, "category %s is not in the menu", item.CategoryID)
+			}
+		}
+		linked := make(map[string]struct{}, len(item.OptionGroupIDs))
+		for groupIdx, groupID := range item.OptionGroupIDs {
+			field := fmt.Sprintf("%s.option_group_ids[%d]", prefix, groupIdx)
+			if _, ok := groupIDs[groupID]; !ok {
+				errs.Add(ErrInvalidMenu, field, validatorutil.CodeUnknown, "option group %s is not in the menu", groupID)
+			}
+			if _, ok := linked[groupID]; ok {
+				errs.Add(ErrInvalidMenu, field, validatorutil.CodeDuplicate, "option group is linked twice")
+			}
+			linked[groupID] = struct{}{}
+		}
+	}
+	if len(detail.Bundles) > entities.MaxMenuBundles {
+		errs.Add(ErrInvalidMenu, "bundles", validatorutil.CodeTooMany,
+			"a menu has at most %d bundles, got %d", entities.MaxMenuBundles, len(detail.Bundles))
+	}
+	bundleNames := make(map[string]struct{}, len(detail.Bundles))
+	for idx, bundle := range detail.Bundles {
+		prefix := fmt.Sprintf("bundles[%d]", idx)
+		validateName(&errs, ErrInvalidMenu, prefix+".name", bundle.BundleName, bundleNames, "bundle")
+		errs.Nest(prefix, validateBundle(bundle, itemIDs))
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.validateMenu: %w", err)
+	}
+	return nil
+}
+
+// validateName checks a name is present, at most entities.MaxNameLen
+// characters and unique ignoring case among the names seen so far. kind names
+// what has the name in messages, such as "item".
+func validateName(errs *validatorutil.FieldErrors, err error, field string, name string, seen map[string]struct{}, kind string) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	if key == "" {
+		errs.Add(err, field, validatorutil.CodeRequired, "name is required")
+		return
+	}
+	if n := utf8.RuneCountInString(name); n > entities.MaxNameLen {
+		errs.Add(err, field, validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+	}
+	if _, ok := seen[key]; ok {
+		errs.Add(err, field, validatorutil.CodeDuplicate, "another %s is named %q", kind, strings.TrimSpace(name))
+		return
+	}
+	seen[key] = struct{}{}
+}
+
+// validateOptionGroup reports problems with fields relative to the group.
+func validateOptionGroup(group entities.MenuOptionGroup) error {
+	var errs validatorutil.FieldErrors
+	if strings.TrimSpace(group.GroupName) == "" {
+		errs.Add(ErrInvalidOptionGroup, "name", validatorutil.CodeRequired, "name is required")
+	} else if n := utf8.RuneCountInString(group.GroupName); n > entities.MaxNameLen {
+		errs.Add(ErrInvalidOptionGroup, "name", validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+	}
+	switch group.SelectionType {
+	case entities.OptionSelectionSingle:
+		if group.MaxChoices != 1 {
+			errs.Add(ErrInvalidOptionGroup, "max_choices", validatorutil.CodeConflict,
+				"a single select group allows 1 choice, got %d", group.MaxChoices)
+		}
+	case entities.OptionSelectionMulti:
+	default:
+		errs.Add(ErrInvalidOptionGroup, "selection_type", validatorutil.CodeUnknown, "unknown selection type %q", group.SelectionType)
+	}
+	if len(group.Options) == 0 {
+		errs.Add(ErrInvalidOptionGroup, "options", validatorutil.CodeRequired, "a group needs at least one option")
+	} else if group.MinChoices < 0 || group.MinChoices > group.MaxChoices || group.MaxChoices > len(group.Options) {
+		errs.Add(ErrInvalidOptionGroup, "min_choices", validatorutil.CodeOutOfRange,
+			"needs 0 <= min <= max <= %d options, got min %d max %d", len(group.Options), group.MinChoices, group.MaxChoices)
+	}
+	names := make(map[string]struct{}, len(group.Options))
+	for idx, option := range group.Options {
+		validateName(&errs, ErrInvalidOptionGroup, fmt.Sprintf("options[%d].name", idx), option.OptionName, names, "option")
+	}
+	return errs.Err()
+}
+
+// validateMenuSchedule checks a menu's name and window against the bot's
+// other menus. Once a bot has several menus each needs a name, unique
+// ignoring case. Windows of timed menus may not overlap, and a bot has at
+// most one all-day menu, which is served whenever no timed menu is.
+func validateMenuSchedule(menu entities.Menu, others []entities.Menu) error {
+	var errs validatorutil.FieldErrors
+	window := menu.Window
+	if window.From < 0 || window.From >= entities.MinutesPerDay {
+		errs.Add(ErrInvalidMenu, "available_from", validatorutil.CodeOutOfRange, "time of day out of range")
+	}
+	if window.Until < 0 || window.Until >= entities.MinutesPerDay {
+		errs.Add(ErrInvalidMenu, "available_until", validatorutil.CodeOutOfRange, "time of day out of range")
+	}
+	name := strings.ToLower(strings.TrimSpace(menu.MenuName))
+	if name == "" && len(others) > 0 {
+		errs.Add(ErrInvalidMenu, "name", validatorutil.CodeRequired, "a bot with several menus needs a name for each")
+	}
+	if n := utf8.RuneCountInString(menu.MenuName); n > entities.MaxNameLen {
+		errs.Add(ErrInvalidMenu, "name", validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+	}
+	for _, other := range others {
+		if strings.ToLower(strings.TrimSpace(other.MenuName)) == name {
+			errs.Add(ErrInvalidMenu, "name", validatorutil.CodeDuplicate, "another menu is named %q", other.MenuName)
+		}
+		if window.AllDay() != other.Window.AllDay() {
+			continue
+		}
+		if window.Overlaps(other.Window) {
+			errs.Add(ErrInvalidMenu, "available_from", validatorutil.CodeConflict, "the window overlaps menu %q", other.MenuName)
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return fmt.Errorf("menusvc.validateMenuSchedule: %w", err)
+	}
+	return nil
+}
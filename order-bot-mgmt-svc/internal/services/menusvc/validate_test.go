@@ -0,0 +1,266 @@
+package menusvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestValidateMenu(t *testing.T) {
+	categories := []entities.MenuCategory{
+		{ID: "c1", CategoryName: "Drinks"},
+		{ID: "c2", CategoryName: "Mains"},
+	}
+	size := entities.MenuOptionGroup{
+		ID:            "g1",
+		GroupName:     "Size",
+		SelectionType: entities.OptionSelectionSingle,
+		MaxChoices:    1,
+		Options:       []entities.MenuOption{{OptionName: "Small"}, {OptionName: "Large", PriceDeltaScaled: 50}},
+	}
+	withGroup := func(modify func(g *entities.MenuOptionGroup)) []entities.MenuOptionGroup {
+		group := size
+		group.Options = append([]entities.MenuOption(nil), size.Options...)
+		modify(&group)
+		return []entities.MenuOptionGroup{group}
+	}
+	tests := []struct {
+		name    string
+		detail  entities.MenuDetail
+		wantErr error
+	}{
+		{
+			name: "valid",
+			detail: entities.MenuDetail{
+				Categories:   categories,
+				OptionGroups: []entities.MenuOptionGroup{size},
+				Items: []entities.MenuItem{
+					{MenuItemName: "Latte", PriceScaled: 450, CategoryID: "c1", OptionGroupIDs: []string{"g1"}},
+					{MenuItemName: "Scone", PriceScaled: 300, CategoryID: ""},
+				},
+			},
+		},
+		{
+			name: "duplicated item name",
+			detail: entities.MenuDetail{Items: []entities.MenuItem{
+				{MenuItemName: "Latte", PriceScaled: 450},
+				{MenuItemName: " latte", PriceScaled: 500},
+			}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "zero price",
+			detail:  entities.MenuDetail{Items: []entities.MenuItem{{MenuItemName: "Latte"}}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "negative price",
+			detail:  entities.MenuDetail{Items: []entities.MenuItem{{MenuItemName: "Latte", PriceScaled: -450}}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name: "item name too long",
+			detail: entities.MenuDetail{Items: []entities.MenuItem{
+				{MenuItemName: strings.Repeat("é", entities.MaxNameLen+1), PriceScaled: 450},
+			}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "too many items",
+			detail:  entities.MenuDetail{Items: make([]entities.MenuItem, entities.MaxMenuItems+1)},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "empty category name",
+			detail:  entities.MenuDetail{Categories: []entities.MenuCategory{{ID: "c1", CategoryName: " "}}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name: "duplicated category name",
+			detail: entities.MenuDetail{
+				Categories: []entities.MenuCategory{{ID: "c1", CategoryName: "Drinks"}, {ID: "c2", CategoryName: "drinks "}},
+			},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "unknown category",
+			detail:  entities.MenuDetail{Categories: categories, Items: []entities.MenuItem{{CategoryID: "c3"}}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "unknown option group",
+			detail:  entities.MenuDetail{Items: []entities.MenuItem{{OptionGroupIDs: []string{"g1"}}}},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name: "option group linked twice",
+			detail: entities.MenuDetail{
+				OptionGroups: []entities.MenuOptionGroup{size},
+				Items:        []entities.MenuItem{{OptionGroupIDs: []string{"g1", "g1"}}},
+			},
+			wantErr: ErrInvalidMenu,
+		},
+		{
+			name:    "single select with two choices",
+			detail:  entities.MenuDetail{OptionGroups: withGroup(func(g *entities.MenuOptionGroup) { g.MaxChoices = 2 })},
+			wantErr: ErrInvalidOptionGroup,
+		},
+		{
+			name: "min above max",
+			detail: entities.MenuDetail{OptionGroups: withGroup(func(g *entities.MenuOptionGroup) {
+				g.SelectionType, g.MinChoices, g.MaxChoices = entities.OptionSelectionMulti, 2, 1
+			})},
+			wantErr: ErrInvalidOptionGroup,
+		},
+		{
+			name: "max above option count",
+			detail: entities.MenuDetail{OptionGroups: withGroup(func(g *entities.MenuOptionGroup) {
+				g.SelectionType, g.MaxChoices = entities.OptionSelectionMulti, 3
+			})},
+			wantErr: ErrInvalidOptionGroup,
+		},
+		{
+			name:    "no options",
+			detail:  entities.MenuDetail{OptionGroups: withGroup(func(g *entities.MenuOptionGroup) { g.Options = nil })},
+			wantErr: ErrInvalidOptionGroup,
+		},
+		{
+			name: "duplicated option",
+			detail: entities.MenuDetail{OptionGroups: withGroup(func(g *entities.MenuOptionGroup) {
+				g.Options[1].OptionName = "small"
+			})},
+			wantErr: ErrInvalidOptionGroup,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMenu(tt.detail)
+			if tt.wantErr == nil {
+				if err != nil {
+					t.Fatalf("validateMenu() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("validateMenu() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNormalizeOptionGroups(t *testing.T) {
+	options := []entities.MenuOption{{OptionName: "a"}, {OptionName: "b"}, {OptionName: "c"}}
+	groups := []entities.MenuOptionGroup{
+		{Options: options},
+		{SelectionType: entities.OptionSelectionMulti, Options: options},
+		{SelectionType: entities.OptionSelectionMulti, Required: true, Options: options},
+		{SelectionType: entities.OptionSelectionMulti, MinChoices: 2, Options: options},
+	}
+	normalizeOptionGroups(groups)
+	want := []struct {
+		selection entities.OptionSelection
+		min, max  int
+		required  bool
+	}{
+		{entities.OptionSelectionSingle, 0, 1, false},
+		{entities.OptionSelectionMulti, 0, 3, false},
+		{entities.OptionSelectionMulti, 1, 3, true},
+		{entities.OptionSelectionMulti, 2, 3, true},
+	}
+	for idx, w := range want {
+		g := groups[idx]
+		if g.SelectionType != w.selection || g.MinChoices != w.min || g.MaxChoices != w.max || g.Required != w.required {
+			t.Errorf("groups[%d] = %s min %d max %d required %v, want %s min %d max %d required %v",
+				idx, g.SelectionType, g.MinChoices, g.MaxChoices, g.Required, w.selection, w.min, w.max, w.required)
+		}
+	}
+}
+
+func TestSameIDs(t *testing.T) {
+	tests := []struct {
+		want []string
+		got  []string
+		same bool
+	}{
+		{want: []string{"a", "b", "c"}, got: []string{"c", "a", "b"}, same: true},
+		{want: []string{}, got: []string{}, same: true},
+		{want: []string{"a", "b"}, got: []string{"a"}, same: false},
+		{want: []string{"a", "b"}, got: []string{"a", "a"}, same: false},
+		{want: []string{"a", "b"}, got: []string{"a", "c"}, same: false},
+	}
+	for _, tt := range tests {
+		if got := sameIDs(tt.want, tt.got); got != tt.same {
+			t.Errorf("sameIDs(%v, %v) = %v, want %v", tt.want, tt.got, got, tt.same)
+		}
+	}
+}
+
+func TestValidateMenuFields(t *testing.T) {
+	detail := entities.MenuDetail{
+		Categories: []entities.MenuCategory{{ID: "c1", CategoryName: "Drinks"}},
+		OptionGroups: []entities.MenuOptionGroup{{
+			ID:            "g1",
+			GroupName:     "Size",
+			SelectionType: entities.OptionSelectionSingle,
+			MaxChoices:    1,
+			Options:       []entities.MenuOption{{OptionName: "Small"}, {OptionName: ""}},
+		}},
+		Items: []entities.MenuItem{
+			{MenuItemName: "Latte", PriceScaled: 450, CategoryID: "c1"},
+			{MenuItemName: "Mocha", PriceScaled: 0, SpicyLevel: 9},
+			{MenuItemName: "LATTE", PriceScaled: 500, Aliases: []string{"mocha"}},
+		},
+	}
+	err := validateMenu(detail)
+	if !errors.Is(err, ErrInvalidMenu) || !errors.Is(err, ErrInvalidOptionGroup) || !errors.Is(err, ErrDuplicateAlias) {
+		t.Fatalf("validateMenu() error = %v, want invalid menu, option group and alias", err)
+	}
+	type problem struct{ field, code string }
+	var got []problem
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, problem{fieldErr.Field, fieldErr.Code})
+	}
+	want := []problem{
+		{"option_groups[0].options[1].name", validatorutil.CodeRequired},
+		{"items[2].aliases[0]", validatorutil.CodeDuplicate},
+		{"items[1].price", validatorutil.CodeOutOfRange},
+		{"items[1].spicy_level", validatorutil.CodeOutOfRange},
+		{"items[2].name", validatorutil.CodeDuplicate},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("problems = %v, want %v", got, want)
+	}
+}
+
+func TestValidateMenuSchedule(t *testing.T) {
+	breakfast := entities.Menu{ID: "m1", MenuName: "Breakfast", Window: entities.MenuWindow{From: 7 * 60, Until: 11 * 60}}
+	late := entities.Menu{ID: "m2", MenuName: "Late night", Window: entities.MenuWindow{From: 22 * 60, Until: 2 * 60}}
+	allDay := entities.Menu{ID: "m3", MenuName: "Drinks"}
+	tests := []struct {
+		name    string
+		menu    entities.Menu
+		others  []entities.Menu
+		wantErr bool
+	}{
+		{name: "only menu without name", menu: entities.Menu{}},
+		{name: "adjacent windows", menu: entities.Menu{MenuName: "Lunch", Window: entities.MenuWindow{From: 11 * 60, Until: 15 * 60}}, others: []entities.Menu{breakfast, late}},
+		{name: "timed menu next to all-day menu", menu: breakfast, others: []entities.Menu{allDay}},
+		{name: "window out of range", menu: entities.Menu{Window: entities.MenuWindow{From: 0, Until: entities.MinutesPerDay}}, wantErr: true},
+		{name: "empty name among others", menu: entities.Menu{Window: entities.MenuWindow{From: 60, Until: 120}}, others: []entities.Menu{breakfast}, wantErr: true},
+		{name: "duplicated name", menu: entities.Menu{MenuName: " breakfast", Window: entities.MenuWindow{From: 60, Until: 120}}, others: []entities.Menu{breakfast}, wantErr: true},
+		{name: "overlapping windows", menu: entities.Menu{MenuName: "Brunch", Window: entities.MenuWindow{From: 10 * 60, Until: 13 * 60}}, others: []entities.Menu{breakfast}, wantErr: true},
+		{name: "overlap past midnight", menu: entities.Menu{MenuName: "Early", Window: entities.MenuWindow{From: 60, Until: 5 * 60}}, others: []entities.Menu{late}, wantErr: true},
+		{name: "second all-day menu", menu: entities.Menu{MenuName: "Mains"}, others: []entities.Menu{allDay}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateMenuSchedule(tt.menu, tt.others)
+			if tt.wantErr != errors.Is(err, ErrInvalidMenu) || (!tt.wantErr && err != nil) {
+				t.Fatalf("validateMenuSchedule() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
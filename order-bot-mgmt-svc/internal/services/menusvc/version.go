@@ -0,0 +1,119 @@
+package menusvc
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"time"
+)
+
+// ListVersions returns the saved versions of one of the bot's menus, newest
+// first. Versions are listed without their content.
+func (s *Svc) ListVersions(ctx context.Context, botID string, menuID string) ([]entities.MenuVersion, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return nil, fmt.Errorf("menusvc.ListVersions: %w", err)
+	}
+	versions, err := s.menuVersionStore.FindByMenuID(ctx, menu.ID)
+	if err != nil {
+		return nil, fmt.Errorf("menusvc.ListVersions: %w", err)
+	}
+	return versions, nil
+}
+
+// DiffVersions lists the item changes from version from to version to.
+// Prices of both versions are compared at the bot's current price scale.
+func (s *Svc) DiffVersions(ctx context.Context, botID string, menuID string, from int, to int) (entities.MenuDiff, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuDiff{}, fmt.Errorf("menusvc.DiffVersions: %w", err)
+	}
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return entities.MenuDiff{}, fmt.Errorf("menusvc.DiffVersions: %w", err)
+	}
+	var details [2]entities.MenuDetail
+	for idx, number := range []int{from, to} {
+		version, err := s.menuVersionStore.FindByVersion(ctx, menu.ID, number)
+		if err != nil {
+			return entities.MenuDiff{}, fmt.Errorf("menusvc.DiffVersions: %w", err)
+		}
+		if err := version.Detail.ConvertPrices(moneyutil.Rescaler(version.PriceScale, bot.PriceScale)); err != nil {
+			return entities.MenuDiff{}, fmt.Errorf("menusvc.DiffVersions(), version %d: %w", number, err)
+		}
+		details[idx] = version.Detail
+	}
+	return diffMenus(details[0], details[1]), nil
+}
+
+// RollbackMenu makes the content of an earlier version the draft again. The
+// rollback is saved as a new version; the published menu is left alone until
+// the next publish. Sold-out and stock state, the menu name and its window
+// stay as they are now. version is the draft version the rollback replaces;
+// zero replaces the draft as it is.
+func (s *Svc) RollbackMenu(
+	ctx context.Context,
+	botID string,
+	menuID string,
+	authorID string,
+	version int,
+	number int,
+) (entities.MenuDetail, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.RollbackMenu: %w", err)
+	}
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.RollbackMenu: %w", err)
+	}
+	saved, err := s.menuVersionStore.FindByVersion(ctx, menu.ID, number)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.RollbackMenu: %w", err)
+	}
+	// Translations into locales the bot dropped since are left out.
+	detail := supportedTranslations(saved.Detail, bot)
+	detail.Menu = menu
+	detail.Menu.Version = cmp.Or(version, menu.Version)
+	if err := detail.ConvertPrices(moneyutil.Rescaler(saved.PriceScale, bot.PriceScale)); err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.RollbackMenu: %w", err)
+	}
+	for idx := range detail.Items {
+		item := &detail.Items[idx]
+		item.SoldOut, item.RestoreAt, item.TrackStock, item.Stock = false, time.Time{}, false, 0
+	}
+	detail, err = s.updateMenu(ctx, botID, authorID, fmt.Sprintf("rollback to version %d", number), detail, true)
+	if err != nil {
+		return entities.MenuDetail{}, fmt.Errorf("menusvc.RollbackMenu: %w", err)
+	}
+	return detail, nil
+}
+
+func (s *Svc) saveVersion(ctx context.Context, tx store.Tx, detail entities.MenuDetail, authorID string, note string) error {
+	bot, err := s.botStore.FindByID(ctx, tx, detail.Menu.BotID)
+	if err != nil {
+		return fmt.Errorf("menusvc.Svc.saveVersion: %w", err)
+	}
+	_, err = s.menuVersionStore.Create(ctx, tx, entities.MenuVersion{
+		ID:         util.NewID(),
+		MenuID:     detail.Menu.ID,
+		AuthorID:   authorID,
+		Note:       note,
+		PriceScale: bot.PriceScale,
+		Detail:     detail,
+	})
+	if err != nil {
+		return fmt.Errorf("menusvc.Svc.saveVersion: %w", err)
+	}
+	return nil
+}
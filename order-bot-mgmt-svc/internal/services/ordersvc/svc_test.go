@@ -0,0 +1,61 @@
+package ordersvc
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"reflect"
+	"testing"
+)
+
+type fakeOrderStore struct {
+	store.Order
+	orders []entities.Order
+}
+
+type fakeOrderItemStore struct {
+	store.OrderItem
+	items []entities.OrderItem
+}
+
+func (f *fakeOrderStore) FindByBotID(_ context.Context, _ store.Tx, _ string) ([]entities.Order, error) {
+	return f.orders, nil
+}
+
+func (f *fakeOrderItemStore) FindByOrderIDs(_ context.Context, _ []string) ([]entities.OrderItem, error) {
+	return f.items, nil
+}
+
+func TestGetOrdersWithItems(t *testing.T) {
+	std := entities.TaxRate{Code: "std", RateName: "Standard", Rate: 100000}
+	taxed := entities.Order{
+		ID:          "o1",
+		TotalScaled: 1500,
+		Tax: entities.TaxBreakdown{
+			Mode:        entities.TaxExclusive,
+			NetScaled:   1500,
+			TaxScaled:   100,
+			GrossScaled: 1600,
+			Rates:       []entities.TaxRateTotal{{TaxRate: std, NetScaled: 1000, TaxScaled: 100}},
+		},
+	}
+	untaxed := entities.Order{ID: "o2", TotalScaled: 300}
+	items := []entities.OrderItem{
+		{ID: "i1", OrderID: "o1", TotalPriceScaled: 1000, TaxRateCode: "std", TaxScaled: 100},
+		{ID: "i2", OrderID: "o2", TotalPriceScaled: 300},
+		{ID: "i3", OrderID: "o1", TotalPriceScaled: 500},
+	}
+	svc := NewSvc(nil, &fakeOrderStore{orders: []entities.Order{taxed, untaxed}}, &fakeOrderItemStore{items: items})
+
+	got, err := svc.GetOrdersWithItems(context.Background(), "bot")
+	if err != nil {
+		t.Fatalf("GetOrdersWithItems() error = %v", err)
+	}
+	want := []OrderWithItems{
+		{Order: taxed, Items: []entities.OrderItem{items[0], items[2]}},
+		{Order: untaxed, Items: []entities.OrderItem{items[1]}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOrdersWithItems() = %+v, want %+v", got, want)
+	}
+}
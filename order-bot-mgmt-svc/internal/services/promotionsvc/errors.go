@@ -0,0 +1,10 @@
+package promotionsvc
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidPromotion = apperr.Err{
+		Code: "ErrInvalidPromotion",
+		Msg:  "invalid promotion request",
+	}
+)
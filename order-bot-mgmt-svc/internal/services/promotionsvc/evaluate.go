@@ -0,0 +1,132 @@
+package promotionsvc
+
+import (
+	"cmp"
+	"math/big"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/moneyutil"
+	"slices"
+	"time"
+)
+
+// Evaluate computes the discounts of promotions for the cart lines at t,
+// which must be in the bot's timezone. Disabled promotions, promotions out of
+// schedule and promotions whose threshold the subtotal misses are skipped.
+// The rest go by priority, highest first, then by name; each one discounts
+// what earlier ones left of a line, so a line never goes below zero.
+// Discounts of several lines are computed on their sum and split across them
+// in proportion, so each promotion rounds once, half up.
+func Evaluate(promotions []entities.Promotion, lines []entities.PromotionLine, t time.Time) entities.PromotionResult {
+	remaining := make([]int64, len(lines))
+	result := entities.PromotionResult{LineDiscountsScaled: make([]int64, len(lines))}
+	for idx, line := range lines {
+		remaining[idx] = int64(line.Quantity) * line.UnitPriceScaled
+		result.SubtotalScaled += remaining[idx]
+	}
+	candidates := make([]entities.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.Enabled && promotion.Schedule.Active(t) && result.SubtotalScaled >= promotion.MinSubtotalScaled {
+			candidates = append(candidates, promotion)
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b entities.Promotion) int {
+		return cmp.Or(cmp.Compare(b.Priority, a.Priority), cmp.Compare(a.PromotionName, b.PromotionName), cmp.Compare(a.ID, b.ID))
+	})
+	for _, promotion := range candidates {
+		if !promotion.Stackable && len(result.Applied) > 0 {
+			continue
+		}
+		discounts := lineDiscounts(promotion, lines, remaining)
+		var total int64
+		for idx, discount := range discounts {
+			remaining[idx] -= discount
+			result.LineDiscountsScaled[idx] += discount
+			total += discount
+		}
+		if total == 0 {
+			continue
+		}
+		result.DiscountScaled += total
+		result.Applied = append(result.Applied, entities.AppliedPromotion{
+			PromotionID:    promotion.ID,
+			PromotionName:  promotion.PromotionName,
+			DiscountScaled: total,
+		})
+		if !promotion.Stackable {
+			break
+		}
+	}
+	result.TotalScaled = result.SubtotalScaled - result.DiscountScaled
+	return result
+}
+
+// lineDiscounts is what promotion takes off each line, given what is left of
+// the lines. No discount is more than what is left of its line.
+func lineDiscounts(promotion entities.Promotion, lines []entities.PromotionLine, remaining []int64) []int64 {
+	discounts := make([]int64, len(lines))
+	targets := make([]int64, len(lines))
+	var targetTotal int64
+	for idx, line := range lines {
+		if line.Quantity > 0 && remaining[idx] > 0 && promotion.Targets(line.MenuItemID, line.CategoryID) {
+			targets[idx] = remaining[idx]
+			targetTotal += remaining[idx]
+		}
+	}
+	if targetTotal == 0 {
+		return discounts
+	}
+	switch promotion.Type {
+	case entities.PromotionPercentOff:
+		total := moneyutil.MulDivRound(targetTotal, min(promotion.PercentOff, entities.FullPercent), entities.FullPercent)
+		return moneyutil.Allocate(total, targets)
+	case entities.PromotionFixedOff:
+		if len(promotion.ItemIDs) == 0 && len(promotion.CategoryIDs) == 0 {
+			return moneyutil.Allocate(min(promotion.AmountOffScaled, targetTotal), targets)
+		}
+		for idx, line := range lines {
+			if targets[idx] > 0 {
+				discounts[idx] = min(promotion.AmountOffScaled*int64(line.Quantity), targets[idx])
+			}
+		}
+		return discounts
+	case entities.PromotionBuyXGetY:
+		return buyXGetYDiscounts(promotion, lines, targets)
+	}
+	return discounts
+}
+
+// buyXGetYDiscounts discounts the cheapest target units, GetQuantity of every
+// BuyQuantity + GetQuantity units. A unit is worth its share of what is left
+// of its line.
+func buyXGetYDiscounts(promotion entities.Promotion, lines []entities.PromotionLine, targets []int64) []int64 {
+	discounts := make([]int64, len(lines))
+	group := promotion.BuyQuantity + promotion.GetQuantity
+	if promotion.BuyQuantity <= 0 || promotion.GetQuantity <= 0 {
+		return discounts
+	}
+	var order []int
+	units := 0
+	for idx, line := range lines {
+		if targets[idx] > 0 {
+			order = append(order, idx)
+			units += line.Quantity
+		}
+	}
+	// Cheapest first; lines of equal unit price keep the cart order.
+	slices.SortStableFunc(order, func(a, b int) int {
+		return new(big.Int).Mul(big.NewInt(targets[a]), big.NewInt(int64(lines[b].Quantity))).Cmp(
+			new(big.Int).Mul(big.NewInt(targets[b]), big.NewInt(int64(lines[a].Quantity))))
+	})
+	free := units / group * promotion.GetQuantity
+	percent := min(promotion.PercentOff, entities.FullPercent)
+	for _, idx := range order {
+		if free == 0 {
+			break
+		}
+		quantity := int64(lines[idx].Quantity)
+		picked := min(int64(free), quantity)
+		free -= int(picked)
+		discounts[idx] = moneyutil.MulDivRound(targets[idx], picked*percent, quantity*entities.FullPercent)
+	}
+	return discounts
+}
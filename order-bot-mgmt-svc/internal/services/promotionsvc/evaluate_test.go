@@ -0,0 +1,302 @@
+package promotionsvc
+
+import (
+	"order-bot-mgmt-svc/internal/models/entities"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// monday is a Monday at noon.
+var monday = time.Date(2024, 6, 3, 12, 0, 0, 0, time.UTC)
+
+func TestEvaluate(t *testing.T) {
+	burger := entities.PromotionLine{MenuItemID: "burger", CategoryID: "mains", Quantity: 2, UnitPriceScaled: 800}
+	fries := entities.PromotionLine{MenuItemID: "fries", CategoryID: "sides", Quantity: 1, UnitPriceScaled: 300}
+	cola := entities.PromotionLine{MenuItemID: "cola", CategoryID: "drinks", Quantity: 3, UnitPriceScaled: 200}
+	lines := []entities.PromotionLine{burger, fries, cola}
+	percent := func(id string, basisPoints int64) entities.Promotion {
+		return entities.Promotion{ID: id, PromotionName: id, Type: entities.PromotionPercentOff, PercentOff: basisPoints, Stackable: true, Enabled: true}
+	}
+	fixed := func(id string, amount int64) entities.Promotion {
+		return entities.Promotion{ID: id, PromotionName: id, Type: entities.PromotionFixedOff, AmountOffScaled: amount, Stackable: true, Enabled: true}
+	}
+	with := func(promotion entities.Promotion, change func(p *entities.Promotion)) entities.Promotion {
+		change(&promotion)
+		return promotion
+	}
+	tests := []struct {
+		name       string
+		promotions []entities.Promotion
+		lines      []entities.PromotionLine
+		wantLines  []int64
+		wantIDs    []string
+	}{
+		{
+			name:      "no promotions",
+			lines:     lines,
+			wantLines: []int64{0, 0, 0},
+		},
+		{
+			name:       "percent off the order",
+			promotions: []entities.Promotion{percent("p10", 1000)},
+			lines:      lines,
+			wantLines:  []int64{160, 30, 60},
+			wantIDs:    []string{"p10"},
+		},
+		{
+			name:       "percent off an item",
+			promotions: []entities.Promotion{with(percent("p25", 2500), func(p *entities.Promotion) { p.ItemIDs = []string{"fries"} })},
+			lines:      lines,
+			wantLines:  []int64{0, 75, 0},
+			wantIDs:    []string{"p25"},
+		},
+		{
+			name:       "percent off a category",
+			promotions: []entities.Promotion{with(percent("p50", 5000), func(p *entities.Promotion) { p.CategoryIDs = []string{"drinks"} })},
+			lines:      lines,
+			wantLines:  []int64{0, 0, 300},
+			wantIDs:    []string{"p50"},
+		},
+		{
+			name:       "percent rounds half up once for the order",
+			promotions: []entities.Promotion{percent("p15", 1500)},
+			lines: []entities.PromotionLine{
+				{MenuItemID: "a", Quantity: 1, UnitPriceScaled: 333},
+				{MenuItemID: "b", Quantity: 1, UnitPriceScaled: 333},
+				{MenuItemID: "c", Quantity: 1, UnitPriceScaled: 334},
+			},
+			// 15% of 1000 is 150, split 49.95, 49.95, 50.1.
+			wantLines: []int64{50, 50, 50},
+			wantIDs:   []string{"p15"},
+		},
+		{
+			name:       "fixed off the order is split by line total",
+			promotions: []entities.Promotion{fixed("f5", 500)},
+			lines:      lines,
+			wantLines:  []int64{320, 60, 120},
+			wantIDs:    []string{"f5"},
+		},
+		{
+			name:       "fixed off the order is capped at the subtotal",
+			promotions: []entities.Promotion{fixed("f50", 5000)},
+			lines:      lines,
+			wantLines:  []int64{1600, 300, 600},
+			wantIDs:    []string{"f50"},
+		},
+		{
+			name:       "fixed off each target unit",
+			promotions: []entities.Promotion{with(fixed("f1", 100), func(p *entities.Promotion) { p.CategoryIDs = []string{"drinks", "sides"} })},
+			lines:      lines,
+			wantLines:  []int64{0, 100, 300},
+			wantIDs:    []string{"f1"},
+		},
+		{
+			name:       "fixed off a unit is capped at its price",
+			promotions: []entities.Promotion{with(fixed("f3", 300), func(p *entities.Promotion) { p.ItemIDs = []string{"cola"} })},
+			lines:      lines,
+			wantLines:  []int64{0, 0, 600},
+			wantIDs:    []string{"f3"},
+		},
+		{
+			name: "buy one get one free",
+			promotions: []entities.Promotion{with(percent("bogo", entities.FullPercent), func(p *entities.Promotion) {
+				p.Type, p.BuyQuantity, p.GetQuantity, p.ItemIDs = entities.PromotionBuyXGetY, 1, 1, []string{"cola"}
+			})},
+			lines: lines,
+			// 3 colas make one group of two.
+			wantLines: []int64{0, 0, 200},
+			wantIDs:   []string{"bogo"},
+		},
+		{
+			name: "second at half price discounts the cheapest units",
+			promotions: []entities.Promotion{with(percent("half", 5000), func(p *entities.Promotion) {
+				p.Type, p.BuyQuantity, p.GetQuantity = entities.PromotionBuyXGetY, 1, 1
+			})},
+			lines: lines,
+			// 6 units make 3 free: the colas.
+			wantLines: []int64{0, 0, 300},
+			wantIDs:   []string{"half"},
+		},
+		{
+			name: "buy two get one spans lines",
+			promotions: []entities.Promotion{with(percent("b2g1", entities.FullPercent), func(p *entities.Promotion) {
+				p.Type, p.BuyQuantity, p.GetQuantity, p.CategoryIDs = entities.PromotionBuyXGetY, 2, 1, []string{"mains", "sides"}
+			})},
+			lines:     lines,
+			wantLines: []int64{0, 300, 0},
+			wantIDs:   []string{"b2g1"},
+		},
+		{
+			name: "buy X get Y needs a full group",
+			promotions: []entities.Promotion{with(percent("b3g1", entities.FullPercent), func(p *entities.Promotion) {
+				p.Type, p.BuyQuantity, p.GetQuantity, p.ItemIDs = entities.PromotionBuyXGetY, 3, 1, []string{"cola"}
+			})},
+			lines:     lines,
+			wantLines: []int64{0, 0, 0},
+		},
+		{
+			name:       "threshold reached",
+			promotions: []entities.Promotion{with(fixed("over25", 300), func(p *entities.Promotion) { p.MinSubtotalScaled = 2500 })},
+			lines:      lines,
+			wantLines:  []int64{192, 36, 72},
+			wantIDs:    []string{"over25"},
+		},
+		{
+			name:       "threshold missed",
+			promotions: []entities.Promotion{with(fixed("over30", 300), func(p *entities.Promotion) { p.MinSubtotalScaled = 3000 })},
+			lines:      lines,
+			wantLines:  []int64{0, 0, 0},
+		},
+		{
+			name:       "disabled",
+			promotions: []entities.Promotion{with(percent("off", 1000), func(p *entities.Promotion) { p.Enabled = false })},
+			lines:      lines,
+			wantLines:  []int64{0, 0, 0},
+		},
+		{
+			name: "out of schedule",
+			promotions: []entities.Promotion{
+				with(percent("weekend", 1000), func(p *entities.Promotion) {
+					p.Schedule.Weekdays = []time.Weekday{time.Saturday, time.Sunday}
+				}),
+				with(percent("evening", 1000), func(p *entities.Promotion) {
+					p.Schedule.Window = entities.MenuWindow{From: 17 * 60, Until: 20 * 60}
+				}),
+				with(percent("ended", 1000), func(p *entities.Promotion) { p.Schedule.EndsAt = monday }),
+				with(percent("future", 1000), func(p *entities.Promotion) { p.Schedule.StartsAt = monday.Add(time.Minute) }),
+			},
+			lines:     lines,
+			wantLines: []int64{0, 0, 0},
+		},
+		{
+			name: "in schedule",
+			promotions: []entities.Promotion{with(percent("lunch", 1000), func(p *entities.Promotion) {
+				p.Schedule = entities.PromotionSchedule{
+					StartsAt: monday,
+					EndsAt:   monday.AddDate(0, 1, 0),
+					Weekdays: []time.Weekday{time.Monday},
+					Window:   entities.MenuWindow{From: 11 * 60, Until: 14 * 60},
+				}
+			})},
+			lines:     lines,
+			wantLines: []int64{160, 30, 60},
+			wantIDs:   []string{"lunch"},
+		},
+		{
+			name: "stackable promotions go by priority on what is left",
+			promotions: []entities.Promotion{
+				with(percent("p10", 1000), func(p *entities.Promotion) { p.Priority = 1 }),
+				with(fixed("f1", 100), func(p *entities.Promotion) { p.ItemIDs, p.Priority = []string{"burger"}, 2 }),
+			},
+			lines: lines,
+			// f1 first: burgers 1600 - 200 = 1400, then 10% of 2300 is 230.
+			wantLines: []int64{340, 30, 60},
+			wantIDs:   []string{"f1", "p10"},
+		},
+		{
+			name: "same priority goes by name",
+			promotions: []entities.Promotion{
+				with(fixed("zeta", 5000), func(p *entities.Promotion) { p.PromotionName = "Zeta" }),
+				with(fixed("alpha", 100), func(p *entities.Promotion) { p.PromotionName = "Alpha" }),
+			},
+			lines:     lines,
+			wantLines: []int64{1600, 300, 600},
+			wantIDs:   []string{"alpha", "zeta"},
+		},
+		{
+			name: "exclusive promotion first stops the rest",
+			promotions: []entities.Promotion{
+				with(percent("p20", 2000), func(p *entities.Promotion) { p.Stackable, p.Priority = false, 5 }),
+				percent("p10", 1000),
+			},
+			lines:     lines,
+			wantLines: []int64{320, 60, 120},
+			wantIDs:   []string{"p20"},
+		},
+		{
+			name: "exclusive promotion after another is skipped",
+			promotions: []entities.Promotion{
+				with(percent("p10", 1000), func(p *entities.Promotion) { p.Priority = 5 }),
+				with(percent("p20", 2000), func(p *entities.Promotion) { p.Stackable = false }),
+				with(fixed("f1", 100), func(p *entities.Promotion) { p.ItemIDs, p.Priority = []string{"fries"}, -1 }),
+			},
+			lines:     lines,
+			wantLines: []int64{160, 130, 60},
+			wantIDs:   []string{"p10", "f1"},
+		},
+		{
+			name: "exclusive promotion that gives nothing does not block",
+			promotions: []entities.Promotion{
+				with(percent("p20", 2000), func(p *entities.Promotion) { p.Stackable, p.Priority, p.ItemIDs = false, 5, []string{"salad"} }),
+				percent("p10", 1000),
+			},
+			lines:     lines,
+			wantLines: []int64{160, 30, 60},
+			wantIDs:   []string{"p10"},
+		},
+		{
+			name: "stacked promotions never go below zero",
+			promotions: []entities.Promotion{
+				with(percent("p60", 6000), func(p *entities.Promotion) { p.Priority = 1 }),
+				fixed("f50", 5000),
+			},
+			lines:     lines,
+			wantLines: []int64{1600, 300, 600},
+			wantIDs:   []string{"p60", "f50"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := Evaluate(tt.promotions, tt.lines, monday)
+			if !reflect.DeepEqual(result.LineDiscountsScaled, tt.wantLines) {
+				t.Errorf("line discounts = %v, want %v", result.LineDiscountsScaled, tt.wantLines)
+			}
+			var subtotal, discount, applied int64
+			for idx, line := range tt.lines {
+				subtotal += int64(line.Quantity) * line.UnitPriceScaled
+				discount += tt.wantLines[idx]
+			}
+			var ids []string
+			for _, promotion := range result.Applied {
+				ids = append(ids, promotion.PromotionID)
+				applied += promotion.DiscountScaled
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("applied = %v, want %v", ids, tt.wantIDs)
+			}
+			if result.SubtotalScaled != subtotal || result.DiscountScaled != discount || applied != discount ||
+				result.TotalScaled != subtotal-discount {
+				t.Errorf("result = %+v, want subtotal %d and discount %d", result, subtotal, discount)
+			}
+		})
+	}
+}
+
+func TestPromotionScheduleActive(t *testing.T) {
+	overnight := entities.PromotionSchedule{
+		Weekdays: []time.Weekday{time.Friday},
+		Window:   entities.MenuWindow{From: 22 * 60, Until: 2 * 60},
+	}
+	friday := time.Date(2024, 6, 7, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		at   time.Time
+		want bool
+	}{
+		{at: friday.Add(23 * time.Hour), want: true},
+		{at: friday.Add(1 * time.Hour), want: true},
+		{at: friday.Add(12 * time.Hour), want: false},
+		{at: friday.Add(25 * time.Hour), want: false},
+	}
+	for _, tt := range tests {
+		if got := overnight.Active(tt.at); got != tt.want {
+			t.Errorf("Active(%s) = %v, want %v", tt.at.Format(time.RFC3339), got, tt.want)
+		}
+	}
+	if (entities.PromotionSchedule{}).Ended(friday) {
+		t.Error("open schedule Ended() = true, want false")
+	}
+	if !(entities.PromotionSchedule{EndsAt: friday}).Ended(friday) {
+		t.Error("Ended() at the end = false, want true")
+	}
+}
@@ -0,0 +1,271 @@
+package promotionsvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/infra/sqldb/orderbotmgmtsqldb"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"time"
+)
+
+type Svc struct {
+	botStore                store.Bot
+	menuStore               store.Menu
+	menuItemStore           store.MenuItem
+	menuCategoryStore       store.MenuCategory
+	promotionStore          store.Promotion
+	publishedPromotionStore *orderbotmgmtsqldb.PublishedPromotionStore
+	db                      *sqldb.DB
+	orderBotDb              *sqldb.DB
+	ctxFunc                 util.CtxFunc
+}
+
+func NewSvc(
+	db *sqldb.DB,
+	orderBotDb *sqldb.DB,
+	ctxFunc util.CtxFunc,
+	botStore store.Bot,
+	menuStore store.Menu,
+	menuItemStore store.MenuItem,
+	menuCategoryStore store.MenuCategory,
+	promotionStore store.Promotion,
+	publishedPromotionStore *orderbotmgmtsqldb.PublishedPromotionStore,
+) *Svc {
+	if db == nil || orderBotDb == nil || botStore == nil || menuStore == nil || menuItemStore == nil ||
+		menuCategoryStore == nil || promotionStore == nil || publishedPromotionStore == nil {
+		panic("promotionsvc.NewSvc(), db, orderBotDb, botStore, menuStore, menuItemStore, menuCategoryStore, " +
+			"promotionStore, or publishedPromotionStore is nil")
+	}
+	return &Svc{
+		botStore:                botStore,
+		menuStore:               menuStore,
+		menuItemStore:           menuItemStore,
+		menuCategoryStore:       menuCategoryStore,
+		promotionStore:          promotionStore,
+		publishedPromotionStore: publishedPromotionStore,
+		db:                      db,
+		orderBotDb:              orderBotDb,
+		ctxFunc:                 ctxFunc,
+	}
+}
+
+// ListPromotions returns the bot's promotions, highest priority first.
+func (s *Svc) ListPromotions(ctx context.Context, botID string) ([]entities.Promotion, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	promotions, err := s.promotionStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return nil, fmt.Errorf("promotionsvc.ListPromotions: %w", err)
+	}
+	return promotions, nil
+}
+
+func (s *Svc) GetPromotion(ctx context.Context, botID string, id string) (entities.Promotion, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	promotion, err := s.promotionStore.FindByID(ctx, botID, id)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.GetPromotion: %w", err)
+	}
+	return promotion, nil
+}
+
+// CreatePromotion adds a promotion to the bot and republishes the bot's
+// promotions.
+func (s *Svc) CreatePromotion(ctx context.Context, botID string, promotion entities.Promotion) (entities.Promotion, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	promotion.ID, promotion.BotID = util.NewID(), botID
+	promotion, err := s.savePromotion(ctx, promotion, s.promotionStore.Create)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.CreatePromotion: %w", err)
+	}
+	return promotion, nil
+}
+
+// UpdatePromotion replaces one of the bot's promotions and republishes the
+// bot's promotions.
+func (s *Svc) UpdatePromotion(ctx context.Context, botID string, id string, promotion entities.Promotion) (entities.Promotion, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	current, err := s.promotionStore.FindByID(ctx, botID, id)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.UpdatePromotion: %w", err)
+	}
+	promotion.ID, promotion.BotID = current.ID, current.BotID
+	promotion, err = s.savePromotion(ctx, promotion, s.promotionStore.Update)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.UpdatePromotion: %w", err)
+	}
+	return promotion, nil
+}
+
+// DeletePromotion removes one of the bot's promotions and republishes the
+// bot's promotions.
+func (s *Svc) DeletePromotion(ctx context.Context, botID string, id string) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if err := s.promotionStore.Delete(ctx, nil, botID, id); err != nil {
+		return fmt.Errorf("promotionsvc.DeletePromotion: %w", err)
+	}
+	if err := s.publish(ctx, botID); err != nil {
+		return fmt.Errorf("promotionsvc.DeletePromotion: %w", err)
+	}
+	return nil
+}
+
+// PublishPromotions republishes the bot's promotions, for bots whose
+// promotions were written without this service, such as copies of a bot.
+func (s *Svc) PublishPromotions(ctx context.Context, botID string) error {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	if err := s.publish(ctx, botID); err != nil {
+		return fmt.Errorf("promotionsvc.PublishPromotions: %w", err)
+	}
+	return nil
+}
+
+// EvaluateCart prices lines of one of the bot's menus and computes the
+// discounts the bot's promotions give them at t. Lines only need MenuItemID
+// and Quantity. An empty menuID picks the bot's only menu.
+func (s *Svc) EvaluateCart(
+	ctx context.Context, botID string, menuID string, lines []entities.PromotionLine, t time.Time,
+) (entities.PromotionResult, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart: %w", err)
+	}
+	loc, err := time.LoadLocation(bot.Timezone)
+	if err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart(), timezone %q: %w", bot.Timezone, err)
+	}
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart: %w", err)
+	}
+	items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+	if err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart: %w", err)
+	}
+	byID := make(map[string]entities.MenuItem, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+	var errs validatorutil.FieldErrors
+	priced := make([]entities.PromotionLine, len(lines))
+	for idx, line := range lines {
+		item, ok := byID[line.MenuItemID]
+		if !ok {
+			errs.Add(ErrInvalidPromotion, fmt.Sprintf("items[%d].item_id", idx), validatorutil.CodeUnknown,
+				"no item %q in the menu", line.MenuItemID)
+		}
+		if line.Quantity < 1 {
+			errs.Add(ErrInvalidPromotion, fmt.Sprintf("items[%d].quantity", idx), validatorutil.CodeOutOfRange,
+				"quantity must be positive")
+		}
+		priced[idx] = entities.PromotionLine{
+			MenuItemID:      line.MenuItemID,
+			CategoryID:      item.CategoryID,
+			Quantity:        line.Quantity,
+			UnitPriceScaled: item.PriceScaled,
+		}
+	}
+	if err := errs.Err(); err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart: %w", err)
+	}
+	promotions, err := s.promotionStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return entities.PromotionResult{}, fmt.Errorf("promotionsvc.EvaluateCart: %w", err)
+	}
+	return Evaluate(promotions, priced, t.In(loc)), nil
+}
+
+// savePromotion validates promotion against the bot's other promotions and
+// menus, writes it with write and republishes the bot's promotions. It
+// returns the promotion as stored.
+func (s *Svc) savePromotion(
+	ctx context.Context,
+	promotion entities.Promotion,
+	write func(ctx context.Context, tx store.Tx, promotion entities.Promotion) error,
+) (entities.Promotion, error) {
+	promotion = normalizePromotion(promotion)
+	others, err := s.promotionStore.FindByBotID(ctx, nil, promotion.BotID)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	targets, err := s.menuTargets(ctx, promotion.BotID)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	if err := validatePromotion(promotion, others, targets); err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	if err := write(ctx, nil, promotion); err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	if err := s.publish(ctx, promotion.BotID); err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	saved, err := s.promotionStore.FindByID(ctx, promotion.BotID, promotion.ID)
+	if err != nil {
+		return entities.Promotion{}, fmt.Errorf("promotionsvc.savePromotion: %w", err)
+	}
+	return saved, nil
+}
+
+func (s *Svc) menuTargets(ctx context.Context, botID string) (menuTargets, error) {
+	targets := menuTargets{items: map[string]struct{}{}, categories: map[string]struct{}{}}
+	menus, err := s.menuStore.ListByBotID(ctx, nil, botID)
+	if err != nil {
+		return menuTargets{}, fmt.Errorf("promotionsvc.menuTargets: %w", err)
+	}
+	for _, menu := range menus {
+		items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+		if err != nil {
+			return menuTargets{}, fmt.Errorf("promotionsvc.menuTargets: %w", err)
+		}
+		for _, item := range items {
+			targets.items[item.ID] = struct{}{}
+		}
+		categories, err := s.menuCategoryStore.FindByMenuID(ctx, nil, menu.ID)
+		if err != nil {
+			return menuTargets{}, fmt.Errorf("promotionsvc.menuTargets: %w", err)
+		}
+		for _, category := range categories {
+			targets.categories[category.ID] = struct{}{}
+		}
+	}
+	return targets, nil
+}
+
+// publish replaces the bot's promotions in the order-bot schema with those
+// that are enabled and not over yet.
+func (s *Svc) publish(ctx context.Context, botID string) error {
+	bot, err := s.botStore.FindByID(ctx, nil, botID)
+	if err != nil {
+		return fmt.Errorf("promotionsvc.publish: %w", err)
+	}
+	promotions, err := s.promotionStore.FindByBotID(ctx, nil, botID)
+	if err != nil {
+		return fmt.Errorf("promotionsvc.publish: %w", err)
+	}
+	now := time.Now()
+	live := make([]entities.Promotion, 0, len(promotions))
+	for _, promotion := range promotions {
+		if promotion.Live(now) {
+			live = append(live, promotion)
+		}
+	}
+	if err := s.orderBotDb.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		return s.publishedPromotionStore.ReplaceBotPromotions(ctx, tx, bot, live)
+	}); err != nil {
+		return fmt.Errorf("promotionsvc.publish: %w", err)
+	}
+	return nil
+}
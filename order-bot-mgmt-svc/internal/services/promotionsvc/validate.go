@@ -0,0 +1,128 @@
+package promotionsvc
+
+import (
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// menuTargets are the item and category IDs across a bot's menus.
+type menuTargets struct {
+	items      map[string]struct{}
+	categories map[string]struct{}
+}
+
+// normalizePromotion trims the name and clears the fields the type does not
+// use. A buy-X-get-Y promotion without PercentOff makes its units free.
+func normalizePromotion(promotion entities.Promotion) entities.Promotion {
+	promotion.PromotionName = strings.TrimSpace(promotion.PromotionName)
+	switch promotion.Type {
+	case entities.PromotionPercentOff:
+		promotion.AmountOffScaled, promotion.BuyQuantity, promotion.GetQuantity = 0, 0, 0
+	case entities.PromotionFixedOff:
+		promotion.PercentOff, promotion.BuyQuantity, promotion.GetQuantity = 0, 0, 0
+	case entities.PromotionBuyXGetY:
+		promotion.AmountOffScaled = 0
+		if promotion.PercentOff == 0 {
+			promotion.PercentOff = entities.FullPercent
+		}
+	}
+	return promotion
+}
+
+// validatePromotion reports every problem of promotion as
+// validatorutil.FieldErrors with fields such as "item_ids[2]". Names are
+// unique ignoring case among the bot's other promotions, and targets must be
+// items or categories of the bot's menus.
+func validatePromotion(promotion entities.Promotion, others []entities.Promotion, targets menuTargets) error {
+	var errs validatorutil.FieldErrors
+	name := strings.ToLower(promotion.PromotionName)
+	if name == "" {
+		errs.Add(ErrInvalidPromotion, "name", validatorutil.CodeRequired, "name is required")
+	} else if n := utf8.RuneCountInString(promotion.PromotionName); n > entities.MaxNameLen {
+		errs.Add(ErrInvalidPromotion, "name", validatorutil.CodeTooLong, "name has %d characters, the limit is %d", n, entities.MaxNameLen)
+	}
+	for _, other := range others {
+		if other.ID != promotion.ID && name != "" && strings.ToLower(other.PromotionName) == name {
+			errs.Add(ErrInvalidPromotion, "name", validatorutil.CodeDuplicate, "another promotion is named %q", other.PromotionName)
+		}
+	}
+	switch promotion.Type {
+	case entities.PromotionPercentOff, entities.PromotionBuyXGetY:
+		if promotion.PercentOff <= 0 || promotion.PercentOff > entities.FullPercent {
+			errs.Add(ErrInvalidPromotion, "percent_off", validatorutil.CodeOutOfRange, "percent off must be above 0 and at most 100")
+		}
+	case entities.PromotionFixedOff:
+		if promotion.AmountOffScaled <= 0 {
+			errs.Add(ErrInvalidPromotion, "amount_off", validatorutil.CodeOutOfRange, "amount off must be positive")
+		}
+	case "":
+		errs.Add(ErrInvalidPromotion, "type", validatorutil.CodeRequired, "type is required")
+	default:
+		errs.Add(ErrInvalidPromotion, "type", validatorutil.CodeInvalid, "unknown type %q", promotion.Type)
+	}
+	if promotion.Type == entities.PromotionBuyXGetY {
+		if promotion.BuyQuantity < 1 || promotion.BuyQuantity > entities.MaxPromotionQuantity {
+			errs.Add(ErrInvalidPromotion, "buy_quantity", validatorutil.CodeOutOfRange,
+				"buy quantity must be from 1 to %d", entities.MaxPromotionQuantity)
+		}
+		if promotion.GetQuantity < 1 || promotion.GetQuantity > entities.MaxPromotionQuantity {
+			errs.Add(ErrInvalidPromotion, "get_quantity", validatorutil.CodeOutOfRange,
+				"get quantity must be from 1 to %d", entities.MaxPromotionQuantity)
+		}
+	}
+	if promotion.MinSubtotalScaled < 0 {
+		errs.Add(ErrInvalidPromotion, "min_subtotal", validatorutil.CodeOutOfRange, "minimum subtotal must be zero or more")
+	}
+	validateTargets(&errs, "item_ids", promotion.ItemIDs, targets.items, "item")
+	validateTargets(&errs, "category_ids", promotion.CategoryIDs, targets.categories, "category")
+	errs.Nest("schedule", validateSchedule(promotion.Schedule))
+	return errs.Err()
+}
+
+func validateTargets(errs *validatorutil.FieldErrors, field string, ids []string, known map[string]struct{}, kind string) {
+	if len(ids) > entities.MaxPromotionTargets {
+		errs.Add(ErrInvalidPromotion, field, validatorutil.CodeTooMany,
+			"a promotion targets at most %d of each, got %d", entities.MaxPromotionTargets, len(ids))
+		return
+	}
+	seen := make(map[string]struct{}, len(ids))
+	for idx, id := range ids {
+		itemField := fmt.Sprintf("%s[%d]", field, idx)
+		if _, ok := seen[id]; ok {
+			errs.Add(ErrInvalidPromotion, itemField, validatorutil.CodeDuplicate, "%s %q is listed twice", kind, id)
+			continue
+		}
+		seen[id] = struct{}{}
+		if _, ok := known[id]; !ok {
+			errs.Add(ErrInvalidPromotion, itemField, validatorutil.CodeUnknown, "no %s %q in the bot's menus", kind, id)
+		}
+	}
+}
+
+// validateSchedule reports problems with fields relative to the schedule.
+func validateSchedule(schedule entities.PromotionSchedule) error {
+	var errs validatorutil.FieldErrors
+	if !schedule.StartsAt.IsZero() && !schedule.EndsAt.IsZero() && !schedule.EndsAt.After(schedule.StartsAt) {
+		errs.Add(ErrInvalidPromotion, "ends_at", validatorutil.CodeOutOfRange, "end must be after start")
+	}
+	for idx, weekday := range schedule.Weekdays {
+		field := fmt.Sprintf("weekdays[%d]", idx)
+		if weekday < time.Sunday || weekday > time.Saturday {
+			errs.Add(ErrInvalidPromotion, field, validatorutil.CodeOutOfRange, "weekday must be from 0 (Sunday) to 6")
+		} else if slices.Contains(schedule.Weekdays[:idx], weekday) {
+			errs.Add(ErrInvalidPromotion, field, validatorutil.CodeDuplicate, "%s is listed twice", weekday)
+		}
+	}
+	if schedule.Window.From < 0 || schedule.Window.From >= entities.MinutesPerDay {
+		errs.Add(ErrInvalidPromotion, "available_from", validatorutil.CodeOutOfRange, "time of day out of range")
+	}
+	if schedule.Window.Until < 0 || schedule.Window.Until >= entities.MinutesPerDay {
+		errs.Add(ErrInvalidPromotion, "available_until", validatorutil.CodeOutOfRange, "time of day out of range")
+	}
+	return errs.Err()
+}
@@ -0,0 +1,105 @@
+package promotionsvc
+
+import (
+	"errors"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/util/validatorutil"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNormalizePromotion(t *testing.T) {
+	got := normalizePromotion(entities.Promotion{
+		PromotionName:   " BOGO ",
+		Type:            entities.PromotionBuyXGetY,
+		AmountOffScaled: 100,
+		BuyQuantity:     1,
+		GetQuantity:     1,
+	})
+	if got.PromotionName != "BOGO" || got.PercentOff != entities.FullPercent || got.AmountOffScaled != 0 {
+		t.Errorf("normalizePromotion() = %+v, want trimmed name, free units and no amount", got)
+	}
+	got = normalizePromotion(entities.Promotion{Type: entities.PromotionFixedOff, PercentOff: 1000, AmountOffScaled: 100, BuyQuantity: 2})
+	if got.PercentOff != 0 || got.BuyQuantity != 0 || got.AmountOffScaled != 100 {
+		t.Errorf("normalizePromotion() = %+v, want only the amount", got)
+	}
+}
+
+func TestValidatePromotion(t *testing.T) {
+	targets := menuTargets{
+		items:      map[string]struct{}{"burger": {}, "cola": {}},
+		categories: map[string]struct{}{"drinks": {}},
+	}
+	others := []entities.Promotion{{ID: "p1", PromotionName: "Happy hour"}}
+	valid := entities.Promotion{
+		ID:            "p2",
+		PromotionName: "Combo deal",
+		Type:          entities.PromotionBuyXGetY,
+		PercentOff:    5000,
+		BuyQuantity:   2,
+		GetQuantity:   1,
+		ItemIDs:       []string{"burger"},
+		CategoryIDs:   []string{"drinks"},
+		Schedule: entities.PromotionSchedule{
+			Weekdays: []time.Weekday{time.Friday},
+			Window:   entities.MenuWindow{From: 17 * 60, Until: 19 * 60},
+		},
+	}
+	if err := validatePromotion(valid, others, targets); err != nil {
+		t.Fatalf("validatePromotion() = %v, want nil", err)
+	}
+	if err := validatePromotion(entities.Promotion{ID: "p1", PromotionName: "happy hour", Type: entities.PromotionPercentOff, PercentOff: 1000},
+		others, targets); err != nil {
+		t.Errorf("validatePromotion() of the same promotion = %v, want nil", err)
+	}
+
+	invalid := valid
+	invalid.PromotionName = "happy HOUR"
+	invalid.PercentOff = entities.FullPercent + 1
+	invalid.BuyQuantity, invalid.GetQuantity = 0, entities.MaxPromotionQuantity+1
+	invalid.MinSubtotalScaled = -1
+	invalid.ItemIDs = []string{"salad", "burger", "burger"}
+	invalid.CategoryIDs = []string{"mains"}
+	invalid.Schedule = entities.PromotionSchedule{
+		StartsAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC),
+		Weekdays: []time.Weekday{time.Monday, 7, time.Monday},
+		Window:   entities.MenuWindow{From: -1, Until: entities.MinutesPerDay},
+	}
+	err := validatePromotion(invalid, others, targets)
+	if !errors.Is(err, ErrInvalidPromotion) {
+		t.Fatalf("validatePromotion() error = %v, want ErrInvalidPromotion", err)
+	}
+	var got []string
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	want := []string{
+		"name:duplicate",
+		"percent_off:out_of_range",
+		"buy_quantity:out_of_range",
+		"get_quantity:out_of_range",
+		"min_subtotal:out_of_range",
+		"item_ids[0]:unknown",
+		"item_ids[2]:duplicate",
+		"category_ids[0]:unknown",
+		"schedule.ends_at:out_of_range",
+		"schedule.weekdays[1]:out_of_range",
+		"schedule.weekdays[2]:duplicate",
+		"schedule.available_from:out_of_range",
+		"schedule.available_until:out_of_range",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+
+	err = validatePromotion(entities.Promotion{Type: "bogus"}, nil, targets)
+	got = nil
+	for _, fieldErr := range validatorutil.Fields(err) {
+		got = append(got, fieldErr.Field+":"+fieldErr.Code)
+	}
+	if want := []string{"name:required", "type:invalid"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("fields = %q, want %q", got, want)
+	}
+}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"order-bot-mgmt-svc/internal/services/botsvc"
+	"order-bot-mgmt-svc/internal/services/filesvc"
 	"order-bot-mgmt-svc/internal/services/menusvc"
 	"order-bot-mgmt-svc/internal/services/ordersvc"
+	"order-bot-mgmt-svc/internal/services/promotionsvc"
+	"order-bot-mgmt-svc/internal/services/stocksvc"
 	"sync"
 
 	"order-bot-mgmt-svc/internal/services/authsvc"
@@ -33,10 +36,13 @@ func (l *lazy[T]) Get() *T {
 }
 
 type Services struct {
-	Auth  *lazy[authsvc.Svc]
-	Menu  *lazy[menusvc.Svc]
-	Bot   *lazy[botsvc.Svc]
-	Order *lazy[ordersvc.Svc]
+	Auth      *lazy[authsvc.Svc]
+	Menu      *lazy[menusvc.Svc]
+	Bot       *lazy[botsvc.Svc]
+	Order     *lazy[ordersvc.Svc]
+	File      *lazy[filesvc.Svc]
+	Stock     *lazy[stocksvc.Svc]
+	Promotion *lazy[promotionsvc.Svc]
 }
 
 func NewServices(
@@ -44,11 +50,17 @@ func NewServices(
 	menuInit func() *menusvc.Svc,
 	botInit func() *botsvc.Svc,
 	orderInit func() *ordersvc.Svc,
+	fileInit func() *filesvc.Svc,
+	stockInit func() *stocksvc.Svc,
+	promotionInit func() *promotionsvc.Svc,
 ) *Services {
 	return &Services{
-		Auth:  newLazy(authInit),
-		Menu:  newLazy(menuInit),
-		Bot:   newLazy(botInit),
-		Order: newLazy(orderInit),
+		Auth:      newLazy(authInit),
+		Menu:      newLazy(menuInit),
+		Bot:       newLazy(botInit),
+		Order:     newLazy(orderInit),
+		File:      newLazy(fileInit),
+		Stock:     newLazy(stockInit),
+		Promotion: newLazy(promotionInit),
 	}
 }
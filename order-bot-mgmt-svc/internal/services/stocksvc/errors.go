@@ -0,0 +1,14 @@
+package stocksvc
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidStock = apperr.Err{
+		Code: "ErrInvalidStock",
+		Msg:  "invalid stock request",
+	}
+	ErrStockNotTracked = apperr.Err{
+		Code: "ErrStockNotTracked",
+		Msg:  "stock is not tracked for this item",
+	}
+)
@@ -0,0 +1,338 @@
+package stocksvc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"order-bot-mgmt-svc/internal/infra/sqldb"
+	"order-bot-mgmt-svc/internal/infra/sqldb/orderbotmgmtsqldb"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"order-bot-mgmt-svc/internal/util"
+	"time"
+)
+
+const (
+	orderCursorName = "stock_order_reconcile"
+	// orderOverlap is how far behind the cursor each run re-reads. Order
+	// items get created_at from their transaction start, so a row can become
+	// visible after newer rows were already read.
+	orderOverlap   = 5 * time.Minute
+	orderBatchSize = 500
+	movementLimit  = 200
+)
+
+type Svc struct {
+	menuStore          store.Menu
+	menuItemStore      store.MenuItem
+	stockMovementStore store.StockMovement
+	jobCursorStore     store.JobCursor
+	orderItemStore     store.OrderItem
+	publishedMenuStore *orderbotmgmtsqldb.PublishedMenuStore
+	db                 *sqldb.DB
+	ctxFunc            util.CtxFunc
+}
+
+func NewSvc(
+	db *sqldb.DB,
+	ctxFunc util.CtxFunc,
+	menuStore store.Menu,
+	menuItemStore store.MenuItem,
+	stockMovementStore store.StockMovement,
+	jobCursorStore store.JobCursor,
+	orderItemStore store.OrderItem,
+	publishedMenuStore *orderbotmgmtsqldb.PublishedMenuStore,
+) *Svc {
+	if db == nil || menuStore == nil || menuItemStore == nil || stockMovementStore == nil || jobCursorStore == nil ||
+		orderItemStore == nil || publishedMenuStore == nil {
+		panic("stocksvc.NewSvc(), db, menuStore, menuItemStore, stockMovementStore, jobCursorStore, " +
+			"orderItemStore, or publishedMenuStore is nil")
+	}
+	return &Svc{
+		menuStore:          menuStore,
+		menuItemStore:      menuItemStore,
+		stockMovementStore: stockMovementStore,
+		jobCursorStore:     jobCursorStore,
+		orderItemStore:     orderItemStore,
+		publishedMenuStore: publishedMenuStore,
+		db:                 db,
+		ctxFunc:            ctxFunc,
+	}
+}
+
+// ListItems returns the items of one of the bot's menus with their stock
+// state. An empty menuID picks the bot's only menu.
+func (s *Svc) ListItems(ctx context.Context, botID string, menuID string) ([]entities.MenuItem, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return nil, fmt.Errorf("stocksvc.ListItems: %w", err)
+	}
+	items, err := s.menuItemStore.FindItems(ctx, nil, menu.ID)
+	if err != nil {
+		return nil, fmt.Errorf("stocksvc.ListItems: %w", err)
+	}
+	return items, nil
+}
+
+// SetStock starts tracking the item with the given count, or stops tracking
+// it when track is false. A count of zero sells the item out.
+func (s *Svc) SetStock(ctx context.Context, botID string, itemID string, track bool, stock int) (entities.MenuItem, error) {
+	if stock < 0 || (!track && stock != 0) {
+		return entities.MenuItem{}, fmt.Errorf("stocksvc.SetStock(), stock must be zero or more and only set when tracked: %w", ErrInvalidStock)
+	}
+	return s.changeStock(ctx, "stocksvc.SetStock", botID, itemID, func(item entities.MenuItem) (stockChange, error) {
+		if !track {
+			return stockChange{track: false}, nil
+		}
+		prev := 0
+		if item.TrackStock {
+			prev = item.Stock
+		}
+		return stockChange{track: true, delta: stock - prev, reason: entities.StockReasonSet}, nil
+	})
+}
+
+// Restock adds quantity to a tracked item. An item sold out by its stock
+// count comes back once the count is above zero.
+func (s *Svc) Restock(ctx context.Context, botID string, itemID string, quantity int) (entities.MenuItem, error) {
+	if quantity <= 0 {
+		return entities.MenuItem{}, fmt.Errorf("stocksvc.Restock(), quantity must be positive: %w", ErrInvalidStock)
+	}
+	return s.changeStock(ctx, "stocksvc.Restock", botID, itemID, func(item entities.MenuItem) (stockChange, error) {
+		if !item.TrackStock {
+			return stockChange{}, ErrStockNotTracked
+		}
+		return stockChange{track: true, delta: quantity, reason: entities.StockReasonRestock}, nil
+	})
+}
+
+// ListMovements returns the newest stock movements of one of the bot's
+// menus. An empty itemID lists every item.
+func (s *Svc) ListMovements(ctx context.Context, botID string, menuID string, itemID string) ([]entities.StockMovement, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	menu, err := s.menuStore.FindByBotID(ctx, nil, botID, menuID)
+	if err != nil {
+		return nil, fmt.Errorf("stocksvc.ListMovements: %w", err)
+	}
+	movements, err := s.stockMovementStore.FindByMenuID(ctx, menu.ID, itemID, movementLimit)
+	if err != nil {
+		return nil, fmt.Errorf("stocksvc.ListMovements: %w", err)
+	}
+	return movements, nil
+}
+
+// ReconcileOrders decrements stock for order items created since the last
+// run. The first run only records where to start, so orders placed before
+// stock tracking existed are not counted. Each order item is counted once,
+// however often it is read. It returns how many order items were applied.
+func (s *Svc) ReconcileOrders(ctx context.Context) (int, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	cursor, err := s.jobCursorStore.Get(ctx, nil, orderCursorName)
+	if err != nil {
+		return 0, fmt.Errorf("stocksvc.ReconcileOrders: %w", err)
+	}
+	if cursor.IsZero() {
+		if err := s.jobCursorStore.Set(ctx, nil, orderCursorName, time.Now().UTC()); err != nil {
+			return 0, fmt.Errorf("stocksvc.ReconcileOrders: %w", err)
+		}
+		return 0, nil
+	}
+	applied, cursor, err := s.reconcileSince(ctx, cursor, s.applyOrderItem)
+	if err != nil {
+		return applied, fmt.Errorf("stocksvc.ReconcileOrders: %w", err)
+	}
+	if err := s.jobCursorStore.Set(ctx, nil, orderCursorName, cursor); err != nil {
+		return applied, fmt.Errorf("stocksvc.ReconcileOrders: %w", err)
+	}
+	return applied, nil
+}
+
+// reconcileSince pages through the order items from orderOverlap before
+// cursor on, in (created_at, id) order, and passes each to apply. It returns
+// how many items apply counted and the newest created_at it read, or cursor
+// when none is newer.
+func (s *Svc) reconcileSince(
+	ctx context.Context,
+	cursor time.Time,
+	apply func(ctx context.Context, orderItem entities.OrderItem) (bool, error),
+) (int, time.Time, error) {
+	applied := 0
+	since, afterID := cursor.Add(-orderOverlap), ""
+	for {
+		orderItems, err := s.orderItemStore.FindCreatedSince(ctx, since, afterID, orderBatchSize)
+		if err != nil {
+			return applied, cursor, fmt.Errorf("stocksvc.reconcileSince: %w", err)
+		}
+		for _, orderItem := range orderItems {
+			ok, err := apply(ctx, orderItem)
+			if err != nil {
+				return applied, cursor, fmt.Errorf("stocksvc.reconcileSince: %w", err)
+			}
+			if ok {
+				applied++
+			}
+		}
+		if len(orderItems) == 0 {
+			return applied, cursor, nil
+		}
+		last := orderItems[len(orderItems)-1]
+		if last.CreatedAt.After(cursor) {
+			cursor = last.CreatedAt
+		}
+		if len(orderItems) < orderBatchSize {
+			return applied, cursor, nil
+		}
+		since, afterID = last.CreatedAt, last.ID
+	}
+}
+
+// applyOrderItem reports false for items that are not tracked, no longer on a
+// menu, or already counted.
+func (s *Svc) applyOrderItem(ctx context.Context, orderItem entities.OrderItem) (bool, error) {
+	var (
+		applied bool
+		updated entities.MenuItem
+		toggled bool
+	)
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		item, err := s.menuItemStore.FindItemByID(ctx, tx, orderItem.MenuItemID)
+		if errors.Is(err, store.ErrMenuItemNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !item.TrackStock || orderItem.Quantity <= 0 {
+			return nil
+		}
+		change := stockChange{track: true, delta: -orderItem.Quantity, reason: entities.StockReasonOrder, orderItemID: orderItem.ID}
+		updated, toggled, applied, err = s.applyChange(ctx, tx, item, change)
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("stocksvc.applyOrderItem: %w", err)
+	}
+	if toggled {
+		if err := s.publishAvailability(ctx, updated); err != nil {
+			return false, fmt.Errorf("stocksvc.applyOrderItem: %w", err)
+		}
+	}
+	return applied, nil
+}
+
+type stockChange struct {
+	track       bool
+	delta       int
+	reason      entities.StockReason
+	orderItemID string
+}
+
+func (s *Svc) changeStock(
+	ctx context.Context,
+	op string,
+	botID string,
+	itemID string,
+	plan func(item entities.MenuItem) (stockChange, error),
+) (entities.MenuItem, error) {
+	ctx, cancel := util.CallCtxFunc(ctx, s.ctxFunc)
+	defer cancel()
+	var (
+		updated entities.MenuItem
+		toggled bool
+	)
+	err := s.db.WithTx(ctx, func(ctx context.Context, tx store.Tx) error {
+		item, err := s.menuItemStore.FindItemByID(ctx, tx, itemID)
+		if err != nil {
+			return err
+		}
+		if _, err := s.menuStore.FindByBotID(ctx, tx, botID, item.MenuID); err != nil {
+			if errors.Is(err, store.ErrMenuNotFound) {
+				return store.ErrMenuItemNotFound
+			}
+			return err
+		}
+		change, err := plan(item)
+		if err != nil {
+			return err
+		}
+		updated, toggled, _, err = s.applyChange(ctx, tx, item, change)
+		return err
+	})
+	if err != nil {
+		return entities.MenuItem{}, fmt.Errorf("%s: %w", op, err)
+	}
+	if toggled {
+		if err := s.publishAvailability(ctx, updated); err != nil {
+			return entities.MenuItem{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+	return updated, nil
+}
+
+// applyChange writes the change and its ledger entry. It reports whether the
+// sold-out flag flipped and whether the change was applied at all; an order
+// item already in the ledger is skipped.
+func (s *Svc) applyChange(
+	ctx context.Context,
+	tx store.Tx,
+	item entities.MenuItem,
+	change stockChange,
+) (entities.MenuItem, bool, bool, error) {
+	if !change.track {
+		if err := s.menuItemStore.UpdateStock(ctx, tx, item.ID, false, 0); err != nil {
+			return item, false, false, err
+		}
+		item.TrackStock, item.Stock = false, 0
+		return item, false, true, nil
+	}
+	next := nextStock(item, change.delta)
+	created, err := s.stockMovementStore.Create(ctx, tx, entities.StockMovement{
+		ID:          util.NewID(),
+		MenuID:      item.MenuID,
+		MenuItemID:  item.ID,
+		Delta:       change.delta,
+		Balance:     next.Stock,
+		Reason:      change.reason,
+		OrderItemID: change.orderItemID,
+	})
+	if err != nil || !created {
+		return item, false, false, err
+	}
+	if err := s.menuItemStore.UpdateStock(ctx, tx, item.ID, true, next.Stock); err != nil {
+		return item, false, false, err
+	}
+	toggled := next.SoldOut != item.SoldOut
+	if toggled {
+		if err := s.menuItemStore.UpdateAvailability(ctx, tx, item.MenuID, []string{item.ID}, next.SoldOut, next.RestoreAt); err != nil {
+			return item, false, false, err
+		}
+	}
+	return next, toggled, true, nil
+}
+
+func (s *Svc) publishAvailability(ctx context.Context, item entities.MenuItem) error {
+	return s.publishedMenuStore.UpdateAvailability(ctx, nil, item.MenuID, []string{item.ID}, item.SoldOut, item.RestoreAt)
+}
+
+// nextStock applies delta to a tracked item. The item sells out when the
+// count drops to zero or below, and comes back when a stock-out is refilled.
+// Items sold out by hand while still in stock stay sold out.
+func nextStock(item entities.MenuItem, delta int) entities.MenuItem {
+	wasOut := item.TrackStock && item.Stock <= 0
+	if !item.TrackStock {
+		item.Stock = 0
+	}
+	item.TrackStock = true
+	item.Stock += delta
+	switch {
+	case item.Stock <= 0:
+		item.SoldOut, item.RestoreAt = true, time.Time{}
+	case wasOut && item.SoldOut:
+		item.SoldOut, item.RestoreAt = false, time.Time{}
+	}
+	return item
+}
@@ -0,0 +1,187 @@
+package stocksvc
+
+import (
+	"context"
+	"fmt"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"order-bot-mgmt-svc/internal/store"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestNextStock(t *testing.T) {
+	restoreAt := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		item        entities.MenuItem
+		delta       int
+		wantStock   int
+		wantSoldOut bool
+	}{
+		{name: "start tracking", item: entities.MenuItem{}, delta: 5, wantStock: 5},
+		{name: "untracked count ignored", item: entities.MenuItem{Stock: 9}, delta: 2, wantStock: 2},
+		{name: "order decrements", item: entities.MenuItem{TrackStock: true, Stock: 5}, delta: -2, wantStock: 3},
+		{name: "order sells out", item: entities.MenuItem{TrackStock: true, Stock: 2}, delta: -2, wantSoldOut: true},
+		{name: "oversold", item: entities.MenuItem{TrackStock: true, Stock: 1}, delta: -3, wantStock: -2, wantSoldOut: true},
+		{
+			name:      "restock restores stock-out",
+			item:      entities.MenuItem{TrackStock: true, Stock: 0, SoldOut: true},
+			delta:     4,
+			wantStock: 4,
+		},
+		{
+			name:        "restock keeps manual sold out",
+			item:        entities.MenuItem{TrackStock: true, Stock: 3, SoldOut: true, RestoreAt: restoreAt},
+			delta:       4,
+			wantStock:   7,
+			wantSoldOut: true,
+		},
+		{
+			name:        "restock not enough",
+			item:        entities.MenuItem{TrackStock: true, Stock: -3, SoldOut: true},
+			delta:       2,
+			wantStock:   -1,
+			wantSoldOut: true,
+		},
+	}
+	for _, tt := range tests {
+		got := nextStock(tt.item, tt.delta)
+		if !got.TrackStock || got.Stock != tt.wantStock || got.SoldOut != tt.wantSoldOut {
+			t.Errorf("%s: nextStock() = track %v stock %d sold out %v, want stock %d sold out %v",
+				tt.name, got.TrackStock, got.Stock, got.SoldOut, tt.wantStock, tt.wantSoldOut)
+		}
+	}
+	manual := nextStock(entities.MenuItem{TrackStock: true, Stock: 3, SoldOut: true, RestoreAt: restoreAt}, 1)
+	if !manual.RestoreAt.Equal(restoreAt) {
+		t.Errorf("manual sold out restore time = %s, want %s", manual.RestoreAt, restoreAt)
+	}
+}
+
+// fakeOrderItemStore holds items in (created_at, id) order. Like the other
+// fakes it embeds its store interface, so unexpected calls panic.
+type fakeOrderItemStore struct {
+	store.OrderItem
+	items []entities.OrderItem
+	pages int
+}
+
+func (f *fakeOrderItemStore) FindCreatedSince(_ context.Context, since time.Time, afterID string, limit int) ([]entities.OrderItem, error) {
+	f.pages++
+	var page []entities.OrderItem
+	for _, item := range f.items {
+		if (item.CreatedAt.After(since) || item.CreatedAt.Equal(since) && item.ID > afterID) && len(page) < limit {
+			page = append(page, item)
+		}
+	}
+	return page, nil
+}
+
+func TestReconcileSincePagesThroughTies(t *testing.T) {
+	cursor := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	orderItems := &fakeOrderItemStore{}
+	// More items than fit in two batches share one created_at.
+	tied := cursor.Add(time.Minute)
+	for idx := range 2*orderBatchSize + 200 {
+		orderItems.items = append(orderItems.items, entities.OrderItem{ID: fmt.Sprintf("item-%05d", idx), CreatedAt: tied})
+	}
+	s := &Svc{orderItemStore: orderItems}
+	var seen []string
+	applied, next, err := s.reconcileSince(context.Background(), cursor, func(_ context.Context, item entities.OrderItem) (bool, error) {
+		seen = append(seen, item.ID)
+		return true, nil
+	})
+	if err != nil {
+		t.Fatalf("reconcileSince() error = %v", err)
+	}
+	if applied != len(orderItems.items) || len(seen) != len(orderItems.items) || !slices.IsSorted(seen) || len(slices.Compact(seen)) != len(orderItems.items) {
+		t.Errorf("reconcileSince() applied %d of %d items, each once in order: %t", applied, len(orderItems.items), slices.IsSorted(seen))
+	}
+	if !next.Equal(tied) {
+		t.Errorf("cursor = %s, want %s", next, tied)
+	}
+	if orderItems.pages != 3 {
+		t.Errorf("read %d pages, want 3", orderItems.pages)
+	}
+}
+
+func TestReconcileSinceOverlap(t *testing.T) {
+	cursor := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	orderItems := &fakeOrderItemStore{items: []entities.OrderItem{
+		{ID: "before-window", CreatedAt: cursor.Add(-orderOverlap - time.Second)},
+		{ID: "window-start", CreatedAt: cursor.Add(-orderOverlap)},
+		{ID: "late-visible", CreatedAt: cursor.Add(-time.Minute)},
+		{ID: "new", CreatedAt: cursor.Add(time.Minute)},
+	}}
+	s := &Svc{orderItemStore: orderItems}
+	var seen []string
+	applied, next, err := s.reconcileSince(context.Background(), cursor, func(_ context.Context, item entities.OrderItem) (bool, error) {
+		seen = append(seen, item.ID)
+		// Items counted by an earlier run report false.
+		return item.ID == "late-visible" || item.ID == "new", nil
+	})
+	if err != nil {
+		t.Fatalf("reconcileSince() error = %v", err)
+	}
+	if want := []string{"window-start", "late-visible", "new"}; !slices.Equal(seen, want) {
+		t.Errorf("read %q, want %q", seen, want)
+	}
+	if applied != 2 || !next.Equal(cursor.Add(time.Minute)) {
+		t.Errorf("reconcileSince() = %d, %s, want 2, %s", applied, next, cursor.Add(time.Minute))
+	}
+
+	// Only older items: the cursor stays where it was.
+	orderItems.items = orderItems.items[:3]
+	if _, next, _ := s.reconcileSince(context.Background(), cursor, func(context.Context, entities.OrderItem) (bool, error) {
+		return false, nil
+	}); !next.Equal(cursor) {
+		t.Errorf("cursor = %s, want it to stay at %s", next, cursor)
+	}
+}
+
+type fakeStockMovementStore struct {
+	store.StockMovement
+	orderItemIDs map[string]bool
+}
+
+func (f *fakeStockMovementStore) Create(_ context.Context, _ store.Tx, movement entities.StockMovement) (bool, error) {
+	if movement.OrderItemID != "" && f.orderItemIDs[movement.OrderItemID] {
+		return false, nil
+	}
+	f.orderItemIDs[movement.OrderItemID] = true
+	return true, nil
+}
+
+type fakeMenuItemStore struct {
+	store.MenuItem
+	stock map[string]int
+}
+
+func (f *fakeMenuItemStore) UpdateStock(_ context.Context, _ store.Tx, id string, _ bool, stock int) error {
+	f.stock[id] = stock
+	return nil
+}
+
+func (f *fakeMenuItemStore) UpdateAvailability(context.Context, store.Tx, string, []string, bool, time.Time) error {
+	return nil
+}
+
+func TestApplyChangeCountsOrderItemsOnce(t *testing.T) {
+	movements := &fakeStockMovementStore{orderItemIDs: map[string]bool{}}
+	items := &fakeMenuItemStore{stock: map[string]int{}}
+	s := &Svc{stockMovementStore: movements, menuItemStore: items}
+	item := entities.MenuItem{ID: "burger", MenuID: "menu", TrackStock: true, Stock: 5}
+	change := stockChange{track: true, delta: -2, reason: entities.StockReasonOrder, orderItemID: "order-item-1"}
+	updated, _, applied, err := s.applyChange(context.Background(), nil, item, change)
+	if err != nil || !applied || updated.Stock != 3 {
+		t.Fatalf("applyChange() = stock %d, applied %t, %v, want 3, true", updated.Stock, applied, err)
+	}
+	// A replay of the same order item, as the overlap window reads it again.
+	updated, toggled, applied, err := s.applyChange(context.Background(), nil, updated, change)
+	if err != nil || applied || toggled || updated.Stock != 3 {
+		t.Errorf("replayed applyChange() = stock %d, applied %t, toggled %t, %v, want 3, false, false", updated.Stock, applied, toggled, err)
+	}
+	if items.stock["burger"] != 3 {
+		t.Errorf("stored stock = %d, want 3", items.stock["burger"])
+	}
+}
@@ -0,0 +1,20 @@
+package store
+
+import (
+	"context"
+	"io"
+)
+
+// BlobObject is an opened blob. The caller must close Body.
+type BlobObject struct {
+	Body        io.ReadCloser
+	ContentType string
+	Size        int64
+}
+
+// Blob stores uploaded files under slash-separated keys.
+type Blob interface {
+	Put(ctx context.Context, key string, contentType string, data []byte) error
+	Get(ctx context.Context, key string) (BlobObject, error)
+	Delete(ctx context.Context, key string) error
+}
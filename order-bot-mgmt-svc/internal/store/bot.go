@@ -8,4 +8,12 @@ import (
 type Bot interface {
 	Create(ctx context.Context, tx Tx, bot entities.Bot) error
 	FindByID(ctx context.Context, tx Tx, id string) (entities.Bot, error)
+	// FindByIDForUpdate is FindByID that locks the bot row until tx commits.
+	// Writes that check or rewrite state spanning the bot, such as menu
+	// windows or the whole profile, take it first.
+	FindByIDForUpdate(ctx context.Context, tx Tx, id string) (entities.Bot, error)
+	UpdateProfile(ctx context.Context, tx Tx, id string, profile entities.BotProfile) error
+	UpdateCurrency(ctx context.Context, tx Tx, id string, currency string, priceScale int) error
+	UpdateTimezone(ctx context.Context, tx Tx, id string, timezone string) error
+	UpdateLocales(ctx context.Context, tx Tx, id string, defaultLocale string, locales []string) error
 }
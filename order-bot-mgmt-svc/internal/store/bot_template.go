@@ -0,0 +1,12 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type BotTemplate interface {
+	Create(ctx context.Context, tx Tx, template entities.BotTemplate) error
+	FindByID(ctx context.Context, tx Tx, id string) (entities.BotTemplate, error)
+	FindByUserID(ctx context.Context, tx Tx, userID string) ([]entities.BotTemplate, error)
+}
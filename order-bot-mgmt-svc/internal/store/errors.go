@@ -23,8 +23,40 @@ var (
 		Code: "ErrMenuNotFound",
 		Msg:  "menu not found",
 	}
+	ErrMenuAmbiguous = apperr.Err{
+		Code: "ErrMenuAmbiguous",
+		Msg:  "the bot has several menus, choose one by id",
+	}
+	ErrMenuChanged = apperr.Err{
+		Code: "ErrMenuChanged",
+		Msg:  "the menu was changed in the meantime",
+	}
 	ErrUserBotNotFound = apperr.Err{
 		Code: "ErrUserBotNotFound",
 		Msg:  "user bot not found",
 	}
+	ErrBlobNotFound = apperr.Err{
+		Code: "ErrBlobNotFound",
+		Msg:  "blob not found",
+	}
+	ErrMenuVersionNotFound = apperr.Err{
+		Code: "ErrMenuVersionNotFound",
+		Msg:  "menu version not found",
+	}
+	ErrPublishJobNotFound = apperr.Err{
+		Code: "ErrPublishJobNotFound",
+		Msg:  "publish job not found",
+	}
+	ErrMenuItemNotFound = apperr.Err{
+		Code: "ErrMenuItemNotFound",
+		Msg:  "menu item not found",
+	}
+	ErrBotTemplateNotFound = apperr.Err{
+		Code: "ErrBotTemplateNotFound",
+		Msg:  "bot template not found",
+	}
+	ErrPromotionNotFound = apperr.Err{
+		Code: "ErrPromotionNotFound",
+		Msg:  "promotion not found",
+	}
 )
@@ -0,0 +1,13 @@
+package store
+
+import (
+	"context"
+	"time"
+)
+
+// JobCursor remembers how far a background job has read.
+type JobCursor interface {
+	// Get returns the zero time when the job has no cursor yet.
+	Get(ctx context.Context, tx Tx, name string) (time.Time, error)
+	Set(ctx context.Context, tx Tx, name string, position time.Time) error
+}
@@ -6,8 +6,26 @@ import (
 )
 
 type Menu interface {
-	FindByBotID(ctx context.Context, menuID string) (entities.Menu, error)
+	// FindByBotID returns the bot's menu with the given ID. An empty menuID
+	// picks the bot's only menu and fails with ErrMenuAmbiguous when the bot
+	// has several.
+	FindByBotID(ctx context.Context, tx Tx, botID string, menuID string) (entities.Menu, error)
+	// ListByBotID returns the bot's menus, oldest first.
+	ListByBotID(ctx context.Context, tx Tx, botID string) ([]entities.Menu, error)
 	CreateMenu(ctx context.Context, tx Tx, menu entities.Menu) error
+	// UpdateMenu saves the menu's name and window.
 	UpdateMenu(ctx context.Context, tx Tx, menu entities.Menu) error
+	// BumpVersion moves the menu from version to the next one and fails with
+	// a MenuChangedErr when the menu is no longer at version.
+	BumpVersion(ctx context.Context, tx Tx, menuID string, version int) error
 	DeleteMenu(ctx context.Context, tx Tx, menuID string) error
 }
+
+// MenuChangedErr is ErrMenuChanged with the version the menu is at now.
+type MenuChangedErr struct {
+	Version int
+}
+
+func (e MenuChangedErr) Error() string { return ErrMenuChanged.Error() }
+
+func (e MenuChangedErr) Unwrap() error { return ErrMenuChanged }
@@ -0,0 +1,17 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+// MenuBundle stores bundles together with their slots and choices. Choices
+// reference items, so bundles are deleted before the items they use.
+type MenuBundle interface {
+	FindByMenuID(ctx context.Context, tx Tx, menuID string) ([]entities.MenuBundle, error)
+	CreateBundles(ctx context.Context, tx Tx, bundles []entities.MenuBundle) error
+	DeleteByMenuID(ctx context.Context, tx Tx, menuID string) error
+	// UpdatePrices writes only the price of each bundle and the upcharges of
+	// its choices.
+	UpdatePrices(ctx context.Context, tx Tx, bundles []entities.MenuBundle) error
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type MenuCategory interface {
+	FindByMenuID(ctx context.Context, tx Tx, menuID string) ([]entities.MenuCategory, error)
+	CreateCategories(ctx context.Context, tx Tx, categories []entities.MenuCategory) error
+	DeleteByMenuID(ctx context.Context, tx Tx, menuID string) error
+	// UpdateSortPositions sets each category's sort position to its index in ids.
+	UpdateSortPositions(ctx context.Context, tx Tx, menuID string, ids []string) error
+	UpdateTranslations(ctx context.Context, tx Tx, menuID string, id string, translations entities.Translations) error
+}
@@ -3,10 +3,42 @@ package store
 import (
 	"context"
 	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
 )
 
 type MenuItem interface {
-	FindItems(ctx context.Context, menuID string) ([]entities.MenuItem, error)
+	FindItems(ctx context.Context, tx Tx, menuID string) ([]entities.MenuItem, error)
 	DeleteMenuItems(ctx context.Context, tx Tx, menuID string) error
 	CreateMenuItems(ctx context.Context, tx Tx, items []entities.MenuItem) error
+	// DetachMenuItems drops the option group links, aliases and category
+	// references of the menu's items, so categories and groups can be
+	// replaced while the items stay.
+	DetachMenuItems(ctx context.Context, tx Tx, menuID string) error
+	// UpdateMenuItems writes name, price, category, sort position, item info,
+	// option group links and aliases. Availability and stock are left as they
+	// are.
+	UpdateMenuItems(ctx context.Context, tx Tx, items []entities.MenuItem) error
+	DeleteItems(ctx context.Context, tx Tx, menuID string, ids []string) error
+	// UpdatePrices writes only the price of each item.
+	UpdatePrices(ctx context.Context, tx Tx, items []entities.MenuItem) error
+	// FindItem returns an item of the menu with its option groups and
+	// aliases, or fails with ErrMenuItemNotFound.
+	FindItem(ctx context.Context, menuID string, id string) (entities.MenuItem, error)
+	CreateItem(ctx context.Context, tx Tx, item entities.MenuItem) error
+	// UpdateItem writes what UpdateMenuItems writes for one item and replaces
+	// its option group links and aliases.
+	UpdateItem(ctx context.Context, tx Tx, item entities.MenuItem) error
+	// DeleteItem fails with ErrMenuItemNotFound unless the item is in the
+	// menu.
+	DeleteItem(ctx context.Context, tx Tx, menuID string, id string) error
+	// UpdateSortPositions sets each item's sort position to its index in ids.
+	UpdateSortPositions(ctx context.Context, tx Tx, menuID string, ids []string) error
+	// UpdateAvailability fails with ErrNotFound unless every id is in the menu.
+	UpdateAvailability(ctx context.Context, tx Tx, menuID string, ids []string, soldOut bool, restoreAt time.Time) error
+	RestoreDue(ctx context.Context, tx Tx, now time.Time) ([]entities.MenuItem, error)
+	FindItemByID(ctx context.Context, tx Tx, id string) (entities.MenuItem, error)
+	UpdateStock(ctx context.Context, tx Tx, id string, trackStock bool, stock int) error
+	// UpdateImage sets or, with a zero image, clears the item's image.
+	UpdateImage(ctx context.Context, tx Tx, menuID string, id string, image entities.Image) error
+	UpdateTranslations(ctx context.Context, tx Tx, menuID string, id string, translations entities.Translations) error
 }
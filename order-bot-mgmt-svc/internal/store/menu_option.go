@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+// MenuOptionGroup stores option groups together with their options.
+type MenuOptionGroup interface {
+	FindByMenuID(ctx context.Context, tx Tx, menuID string) ([]entities.MenuOptionGroup, error)
+	CreateOptionGroups(ctx context.Context, tx Tx, groups []entities.MenuOptionGroup) error
+	DeleteByMenuID(ctx context.Context, tx Tx, menuID string) error
+	// UpdatePriceDeltas writes only the price delta of each option of groups.
+	UpdatePriceDeltas(ctx context.Context, tx Tx, groups []entities.MenuOptionGroup) error
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type MenuVersion interface {
+	// Create numbers the version after the menu's latest one and returns it.
+	Create(ctx context.Context, tx Tx, version entities.MenuVersion) (entities.MenuVersion, error)
+	// FindByMenuID returns the menu's versions newest first, without Detail.
+	FindByMenuID(ctx context.Context, menuID string) ([]entities.MenuVersion, error)
+	FindByVersion(ctx context.Context, menuID string, version int) (entities.MenuVersion, error)
+	DeleteByMenuID(ctx context.Context, tx Tx, menuID string) error
+}
@@ -3,8 +3,13 @@ package store
 import (
 	"context"
 	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
 )
 
 type OrderItem interface {
 	FindByOrderIDs(ctx context.Context, orderIDs []string) ([]entities.OrderItem, error)
+	// FindCreatedSince returns up to limit order items that come after
+	// (since, afterID) in (created_at, id) order. An empty afterID includes
+	// the items created at since.
+	FindCreatedSince(ctx context.Context, since time.Time, afterID string, limit int) ([]entities.OrderItem, error)
 }
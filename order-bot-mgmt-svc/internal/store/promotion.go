@@ -0,0 +1,21 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type Promotion interface {
+	// FindByBotID returns the bot's promotions, highest priority first.
+	FindByBotID(ctx context.Context, tx Tx, botID string) ([]entities.Promotion, error)
+	// FindByID fails with ErrPromotionNotFound unless the promotion is the
+	// bot's.
+	FindByID(ctx context.Context, botID string, id string) (entities.Promotion, error)
+	Create(ctx context.Context, tx Tx, promotion entities.Promotion) error
+	// Update fails with ErrPromotionNotFound unless the promotion is the
+	// bot's.
+	Update(ctx context.Context, tx Tx, promotion entities.Promotion) error
+	// Delete fails with ErrPromotionNotFound unless the promotion is the
+	// bot's.
+	Delete(ctx context.Context, tx Tx, botID string, id string) error
+}
@@ -0,0 +1,24 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+	"time"
+)
+
+type PublishJob interface {
+	Create(ctx context.Context, tx Tx, job entities.PublishJob) (entities.PublishJob, error)
+	// FindByBotID returns the bot's jobs, latest run time first.
+	FindByBotID(ctx context.Context, botID string, limit int) ([]entities.PublishJob, error)
+	FindByID(ctx context.Context, tx Tx, botID string, id string) (entities.PublishJob, error)
+	// Cancel reports false when the job is no longer pending.
+	Cancel(ctx context.Context, tx Tx, botID string, id string) (bool, error)
+	// ClaimDue locks the oldest pending job due at now, or the oldest running
+	// job started before staleBefore, skipping jobs locked by other workers.
+	// It must run in a transaction; the lock is held until commit. It reports
+	// false when no job is due.
+	ClaimDue(ctx context.Context, tx Tx, now time.Time, staleBefore time.Time) (entities.PublishJob, bool, error)
+	// Start marks the job running and counts the attempt.
+	Start(ctx context.Context, tx Tx, id string, startedAt time.Time) (entities.PublishJob, error)
+	Finish(ctx context.Context, tx Tx, id string, status entities.PublishJobStatus, errMsg string, finishedAt time.Time) error
+}
@@ -0,0 +1,15 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type StockMovement interface {
+	// Create reports false when a movement for the same order item already
+	// exists, so replayed order items are counted once.
+	Create(ctx context.Context, tx Tx, movement entities.StockMovement) (bool, error)
+	// FindByMenuID returns the newest movements first. An empty itemID
+	// returns movements of every item.
+	FindByMenuID(ctx context.Context, menuID string, itemID string, limit int) ([]entities.StockMovement, error)
+}
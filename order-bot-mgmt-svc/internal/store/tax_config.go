@@ -0,0 +1,13 @@
+package store
+
+import (
+	"context"
+	"order-bot-mgmt-svc/internal/models/entities"
+)
+
+type TaxConfig interface {
+	// FindByBotID returns a config with only BotID set when the bot has none
+	// yet.
+	FindByBotID(ctx context.Context, tx Tx, botID string) (entities.TaxConfig, error)
+	Upsert(ctx context.Context, tx Tx, config entities.TaxConfig) error
+}
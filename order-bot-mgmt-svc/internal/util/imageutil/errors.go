@@ -0,0 +1,14 @@
+package imageutil
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidImage = apperr.Err{
+		Code: "ErrInvalidImage",
+		Msg:  "image could not be read",
+	}
+	ErrTooManyPixels = apperr.Err{
+		Code: "ErrTooManyPixels",
+		Msg:  "image dimensions too large",
+	}
+)
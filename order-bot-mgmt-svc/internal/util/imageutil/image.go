@@ -0,0 +1,112 @@
+package imageutil
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+)
+
+// Formats as reported by image.DecodeConfig.
+const (
+	FormatJPEG = "jpeg"
+	FormatPNG  = "png"
+)
+
+const jpegQuality = 85
+
+// Decode reads a JPEG or PNG image. The dimensions are checked against
+// maxPixels before any pixel is decoded. JPEGs are turned upright according
+// to their EXIF orientation, because images written by Encode carry no
+// metadata at all.
+func Decode(data []byte, maxPixels int) (*image.RGBA, string, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("imageutil.Decode(), %v: %w", err, ErrInvalidImage)
+	}
+	if format != FormatJPEG && format != FormatPNG {
+		return nil, "", fmt.Errorf("imageutil.Decode(), format %s: %w", format, ErrInvalidImage)
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > maxPixels/cfg.Height {
+		return nil, "", fmt.Errorf("imageutil.Decode(), %dx%d: %w", cfg.Width, cfg.Height, ErrTooManyPixels)
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, "", fmt.Errorf("imageutil.Decode(), %v: %w", err, ErrInvalidImage)
+	}
+	rgba := toRGBA(img)
+	if format == FormatJPEG {
+		rgba = orient(rgba, jpegOrientation(data))
+	}
+	return rgba, format, nil
+}
+
+// Encode writes img in format without any metadata.
+func Encode(w io.Writer, img image.Image, format string) error {
+	var err error
+	switch format {
+	case FormatJPEG:
+		err = jpeg.Encode(w, img, &jpeg.Options{Quality: jpegQuality})
+	case FormatPNG:
+		err = png.Encode(w, img)
+	default:
+		return fmt.Errorf("imageutil.Encode(), format %s: %w", format, ErrInvalidImage)
+	}
+	if err != nil {
+		return fmt.Errorf("imageutil.Encode: %w", err)
+	}
+	return nil
+}
+
+// Fit scales img down, keeping its aspect ratio, so neither side exceeds
+// maxEdge. Each target pixel averages the block of source pixels it covers.
+// Smaller images are returned as is.
+func Fit(img *image.RGBA, maxEdge int) *image.RGBA {
+	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	if w <= maxEdge && h <= maxEdge {
+		return img
+	}
+	dw, dh := maxEdge, maxEdge
+	if w >= h {
+		dh = max(1, h*maxEdge/w)
+	} else {
+		dw = max(1, w*maxEdge/h)
+	}
+	minX, minY := img.Bounds().Min.X, img.Bounds().Min.Y
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for dy := 0; dy < dh; dy++ {
+		y0 := dy * h / dh
+		y1 := max((dy+1)*h/dh, y0+1)
+		for dx := 0; dx < dw; dx++ {
+			x0 := dx * w / dw
+			x1 := max((dx+1)*w/dw, x0+1)
+			var sum [4]uint64
+			for y := y0; y < y1; y++ {
+				off := img.PixOffset(minX+x0, minY+y)
+				for x := x0; x < x1; x++ {
+					sum[0] += uint64(img.Pix[off])
+					sum[1] += uint64(img.Pix[off+1])
+					sum[2] += uint64(img.Pix[off+2])
+					sum[3] += uint64(img.Pix[off+3])
+					off += 4
+				}
+			}
+			n := uint64((y1 - y0) * (x1 - x0))
+			off := dst.PixOffset(dx, dy)
+			for c := range sum {
+				dst.Pix[off+c] = uint8((sum[c] + n/2) / n)
+			}
+		}
+	}
+	return dst
+}
+
+func toRGBA(img image.Image) *image.RGBA {
+	b := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, b.Dx(), b.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, b.Min, draw.Src)
+	return dst
+}
@@ -0,0 +1,101 @@
+package imageutil
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+// exifSegment builds an APP1 segment holding only an orientation tag.
+func exifSegment(orientation uint16) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08")
+	tiff = binary.BigEndian.AppendUint16(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, exifOrientationTag)
+	tiff = binary.BigEndian.AppendUint16(tiff, 3)
+	tiff = binary.BigEndian.AppendUint32(tiff, 1)
+	tiff = binary.BigEndian.AppendUint16(tiff, orientation)
+	tiff = append(tiff, 0, 0, 0, 0, 0, 0)
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	seg := []byte{0xFF, 0xE1}
+	seg = binary.BigEndian.AppendUint16(seg, uint16(len(payload)+2))
+	return append(seg, payload...)
+}
+
+func TestDecodeJPEGOrientation(t *testing.T) {
+	// A 64x32 image, red on the left half and blue on the right.
+	src := image.NewRGBA(image.Rect(0, 0, 64, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 64; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= 32 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	if err := Encode(&buf, src, FormatJPEG); err != nil {
+		t.Fatal(err)
+	}
+	plain := buf.Bytes()
+	withExif := append(append(append([]byte{}, plain[:2]...), exifSegment(6)...), plain[2:]...)
+
+	img, format, err := Decode(withExif, 1<<20)
+	if err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if format != FormatJPEG || img.Bounds().Dx() != 32 || img.Bounds().Dy() != 64 {
+		t.Fatalf("Decode() = %s %v, want jpeg 32x64", format, img.Bounds())
+	}
+	// Rotated 90 degrees clockwise, the red half ends up on top.
+	if top, bottom := img.RGBAAt(16, 8), img.RGBAAt(16, 56); top.R < 200 || bottom.B < 200 {
+		t.Errorf("Decode() top %v bottom %v, want red over blue", top, bottom)
+	}
+
+	var out bytes.Buffer
+	if err := Encode(&out, img, format); err != nil {
+		t.Fatal(err)
+	}
+	if bytes.Contains(out.Bytes(), []byte("Exif")) {
+		t.Error("Encode() output keeps EXIF data")
+	}
+}
+
+func TestDecodeLimits(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 100, 100))); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := Decode(buf.Bytes(), 9999); !errors.Is(err, ErrTooManyPixels) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrTooManyPixels)
+	}
+	if _, _, err := Decode([]byte("GIF89a not really"), 9999); !errors.Is(err, ErrInvalidImage) {
+		t.Errorf("Decode() error = %v, want %v", err, ErrInvalidImage)
+	}
+}
+
+func TestFit(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 400, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 400; x++ {
+			if (x+y)%2 == 0 {
+				img.Set(x, y, color.RGBA{R: 200, G: 100, A: 255})
+			}
+		}
+	}
+	got := Fit(img, 100)
+	if got.Bounds().Dx() != 100 || got.Bounds().Dy() != 50 {
+		t.Fatalf("Fit() size = %v, want 100x50", got.Bounds())
+	}
+	// A checkerboard averages out to half of its color.
+	if c := got.RGBAAt(10, 10); c.R != 100 || c.G != 50 || c.A != 128 {
+		t.Errorf("Fit() pixel = %v, want the average of the block", c)
+	}
+	if small := Fit(got, 200); small != got {
+		t.Error("Fit() should not upscale")
+	}
+}
@@ -0,0 +1,112 @@
+package localeutil
+
+import (
+	"cmp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Normalize puts a BCP 47 tag of the form language[-Script][-REGION] in its
+// canonical case, such as "zh-Hant-TW", and reports whether it has that form.
+// Underscores are read as hyphens, so "zh_TW" works too. Extensions and
+// variants are not supported.
+func Normalize(tag string) (string, bool) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	if len(parts) > 3 || !isAlpha(parts[0]) || len(parts[0]) < 2 || len(parts[0]) > 3 {
+		return "", false
+	}
+	out := []string{strings.ToLower(parts[0])}
+	rest := parts[1:]
+	if len(rest) > 0 && len(rest[0]) == 4 && isAlpha(rest[0]) {
+		out = append(out, strings.ToUpper(rest[0][:1])+strings.ToLower(rest[0][1:]))
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		region := rest[0]
+		switch {
+		case len(region) == 2 && isAlpha(region):
+			out = append(out, strings.ToUpper(region))
+		case len(region) == 3 && isDigits(region):
+			out = append(out, region)
+		default:
+			return "", false
+		}
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return "", false
+	}
+	return strings.Join(out, "-"), true
+}
+
+func isAlpha(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') }) < 0
+}
+
+func isDigits(s string) bool {
+	return s != "" && strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header, most
+// preferred first. Tags with q=0, "*" and tags that don't normalize are
+// skipped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var tags []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(part, ";")
+		q := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(key) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+		normalized, ok := Normalize(tag)
+		if !ok || q == 0 {
+			continue
+		}
+		tags = append(tags, weighted{tag: normalized, q: q})
+	}
+	slices.SortStableFunc(tags, func(a, b weighted) int { return cmp.Compare(b.q, a.q) })
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		out = append(out, tag.tag)
+	}
+	return out
+}
+
+// Match picks the supported locale for the first preferred tag that has one.
+// A tag matches itself, then its parents ("zh-Hant-TW" tries "zh-Hant" and
+// "zh"), then the first supported locale of the same language, so "zh" finds
+// "zh-TW". Both lists must be normalized.
+func Match(preferred []string, supported []string) (string, bool) {
+	for _, tag := range preferred {
+		for parent := tag; ; {
+			if slices.Contains(supported, parent) {
+				return parent, true
+			}
+			cut := strings.LastIndex(parent, "-")
+			if cut < 0 {
+				break
+			}
+			parent = parent[:cut]
+		}
+		language, _, _ := strings.Cut(tag, "-")
+		for _, locale := range supported {
+			if l, _, _ := strings.Cut(locale, "-"); l == language {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,74 @@
+package localeutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+		ok   bool
+	}{
+		{"en", "en", true},
+		{"ZH-tw", "zh-TW", true},
+		{"zh_hant_tw", "zh-Hant-TW", true},
+		{"es-419", "es-419", true},
+		{" ja ", "ja", true},
+		{"", "", false},
+		{"e", "", false},
+		{"english", "", false},
+		{"en-USA", "", false},
+		{"en-US-x-foo", "", false},
+		{"zh-Hant-TW-1", "", false},
+		{"*", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Normalize(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Normalize(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		header string
+		want   []string
+	}{
+		{"zh-TW,zh;q=0.9,en-US;q=0.8,en;q=0.7", []string{"zh-TW", "zh", "en-US", "en"}},
+		{"en;q=0.5, ja", []string{"ja", "en"}},
+		{"fr;q=0, de, *;q=0.1", []string{"de"}},
+		{"en;q=abc, ko", []string{"ko"}},
+		{"", []string{}},
+	}
+	for _, tt := range tests {
+		if got := ParseAcceptLanguage(tt.header); !slices.Equal(got, tt.want) {
+			t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestMatch(t *testing.T) {
+	supported := []string{"en", "zh-TW", "ja"}
+	tests := []struct {
+		preferred []string
+		want      string
+		ok        bool
+	}{
+		{[]string{"zh-TW"}, "zh-TW", true},
+		{[]string{"en-GB"}, "en", true},
+		{[]string{"zh"}, "zh-TW", true},
+		{[]string{"zh-Hant-HK"}, "zh-TW", true},
+		{[]string{"fr", "ja-JP"}, "ja", true},
+		{[]string{"fr"}, "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		got, ok := Match(tt.preferred, supported)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Match(%q) = %q, %v, want %q, %v", tt.preferred, got, ok, tt.want, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,290 @@
+package matchutil
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+)
+
+const (
+	// MinScore is the lowest score reported as a candidate.
+	MinScore = 0.3
+	// mergeMinScore is the least score for two phrases split at a word to
+	// be treated as one name, such as "fish and chips". The joined phrase
+	// also has to score at least as well as either part.
+	mergeMinScore = 0.8
+	// fuzzyTokenMin is the least similarity for two different words to count
+	// as the same one misspelled.
+	fuzzyTokenMin = 0.7
+	maxQuantity   = 99
+
+	// A score weighs how much of the name the phrase covers (recall) over
+	// how much of the phrase the name explains (precision), so "large latte"
+	// still matches "Latte" well. Character trigrams add a little for
+	// misspellings that break words apart.
+	recallWeight   = 0.75
+	tokenWeight    = 0.8
+	trigramsWeight = 1 - tokenWeight
+)
+
+// Entry is something to match, with its name first and its aliases after.
+type Entry struct {
+	ID    string
+	Names []string
+}
+
+type Candidate struct {
+	ID string
+	// Name is the name or alias that matched best.
+	Name string
+	// Score runs from 0 to 1, where 1 is an exact match after normalizing.
+	Score float64
+}
+
+// Match is one phrase of the text with the quantity it asks for and its
+// candidates, best first.
+type Match struct {
+	Text       string
+	Quantity   int
+	Candidates []Candidate
+}
+
+// Index scores phrases against a fixed list of entries. Words are weighted by
+// their inverse document frequency over the entries, so words shared by many
+// items, like "latte" on a coffee menu, count less than distinctive ones.
+type Index struct {
+	entries []Entry
+	names   []indexedName
+	idf     map[string]float64
+	// unknownIDF weighs words of the phrase that no entry uses.
+	unknownIDF float64
+}
+
+type indexedName struct {
+	entry    int
+	name     string
+	key      string
+	tokens   []string
+	trigrams map[string]struct{}
+}
+
+func NewIndex(entries []Entry) *Index {
+	ix := &Index{entries: entries, idf: make(map[string]float64)}
+	df := make(map[string]int)
+	for idx, entry := range entries {
+		seen := make(map[string]struct{})
+		for _, name := range entry.Names {
+			tokens := Tokens(name)
+			if len(tokens) == 0 {
+				continue
+			}
+			key := strings.Join(tokens, " ")
+			ix.names = append(ix.names, indexedName{entry: idx, name: name, key: key, tokens: tokens, trigrams: trigrams(key)})
+			for _, token := range tokens {
+				seen[token] = struct{}{}
+			}
+		}
+		for token := range seen {
+			df[token]++
+		}
+	}
+	n := float64(len(entries))
+	ix.unknownIDF = math.Log(1 + n)
+	for token, count := range df {
+		ix.idf[token] = math.Log(1 + n/float64(count))
+	}
+	return ix
+}
+
+// Match splits text into one phrase per item and returns the candidates of
+// each, at most limit per phrase. Phrases left with only filler words, such
+// as "please", are dropped.
+func (ix *Index) Match(text string, limit int) []Match {
+	phrases := splitPhrases(text)
+	var matches []Match
+	for i := 0; i < len(phrases); i++ {
+		current := phrases[i]
+		match, ok := ix.matchPhrase(current.text, limit)
+		for current.joiner != "" && i+1 < len(phrases) {
+			next := phrases[i+1]
+			text := current.text + " " + current.joiner + " " + next.text
+			joined, okJoined := ix.matchPhrase(text, limit)
+			nextMatch, _ := ix.matchPhrase(next.text, limit)
+			if !okJoined || bestScore(joined) < mergeMinScore ||
+				bestScore(joined) < bestScore(match) || bestScore(joined) < bestScore(nextMatch) {
+				break
+			}
+			match, ok = joined, true
+			current = phrase{text: text, joiner: next.joiner}
+			i++
+		}
+		if ok {
+			matches = append(matches, match)
+		}
+	}
+	return matches
+}
+
+func bestScore(m Match) float64 {
+	if len(m.Candidates) == 0 {
+		return 0
+	}
+	return m.Candidates[0].Score
+}
+
+// matchPhrase reads a leading quantity such as "2" or "two", or a trailing
+// one such as "x2", and scores the rest against every name.
+func (ix *Index) matchPhrase(text string, limit int) (Match, bool) {
+	var tokens []string
+	for _, token := range Tokens(text) {
+		if _, known := ix.idf[token]; !known {
+			if _, filler := fillerWords[token]; filler {
+				continue
+			}
+		}
+		tokens = append(tokens, token)
+	}
+	match := Match{Text: strings.TrimSpace(text), Quantity: 1}
+	if len(tokens) > 1 {
+		if n, ok := quantity(tokens[0]); ok {
+			match.Quantity, tokens = n, tokens[1:]
+		} else if last := tokens[len(tokens)-1]; strings.Contains(last, "x") {
+			if n, ok := quantity(last); ok {
+				match.Quantity, tokens = n, tokens[:len(tokens)-1]
+			}
+		}
+	}
+	if len(tokens) == 0 {
+		return Match{}, false
+	}
+	key := strings.Join(tokens, " ")
+	queryTrigrams := trigrams(key)
+	best := make(map[int]Candidate)
+	for _, name := range ix.names {
+		score := ix.score(tokens, key, queryTrigrams, name)
+		if prev, ok := best[name.entry]; ok && prev.Score >= score {
+			continue
+		}
+		best[name.entry] = Candidate{ID: ix.entries[name.entry].ID, Name: name.name, Score: score}
+	}
+	order := make([]int, 0, len(best))
+	for entry, candidate := range best {
+		if candidate.Score >= MinScore {
+			order = append(order, entry)
+		}
+	}
+	slices.SortFunc(order, func(a, b int) int {
+		return cmp.Or(cmp.Compare(best[b].Score, best[a].Score), cmp.Compare(a, b))
+	})
+	if limit > 0 && len(order) > limit {
+		order = order[:limit]
+	}
+	match.Candidates = make([]Candidate, 0, len(order))
+	for _, entry := range order {
+		candidate := best[entry]
+		candidate.Score = math.Round(candidate.Score*1000) / 1000
+		match.Candidates = append(match.Candidates, candidate)
+	}
+	return match, true
+}
+
+func (ix *Index) score(tokens []string, key string, queryTrigrams map[string]struct{}, name indexedName) float64 {
+	if key == name.key {
+		return 1
+	}
+	weight := func(token string) float64 {
+		if w, ok := ix.idf[token]; ok {
+			return w
+		}
+		return ix.unknownIDF
+	}
+	recall := coverage(name.tokens, tokens, weight)
+	precision := coverage(tokens, name.tokens, weight)
+	tokenScore := recallWeight*recall + (1-recallWeight)*precision
+	return tokenWeight*tokenScore + trigramsWeight*jaccard(queryTrigrams, name.trigrams)
+}
+
+// coverage is the weighted share of from that has a similar word in to.
+func coverage(from []string, to []string, weight func(string) float64) float64 {
+	var covered, total float64
+	for _, a := range from {
+		w := weight(a)
+		total += w
+		var best float64
+		for _, b := range to {
+			best = max(best, tokenSimilarity(a, b))
+		}
+		covered += w * best
+	}
+	if total == 0 {
+		return 0
+	}
+	return covered / total
+}
+
+// tokenSimilarity is 1 for equal words and the Levenshtein similarity for
+// words of at least three letters that are close enough. Numbers only match
+// exactly, so "12 inch" never matches "16 inch".
+func tokenSimilarity(a string, b string) float64 {
+	if a == b {
+		return 1
+	}
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < 3 || len(rb) < 3 || isNumber(a) || isNumber(b) {
+		return 0
+	}
+	sim := 1 - float64(levenshtein(ra, rb))/float64(max(len(ra), len(rb)))
+	if sim < fuzzyTokenMin {
+		return 0
+	}
+	return sim
+}
+
+func isNumber(s string) bool {
+	return strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' }) < 0
+}
+
+func levenshtein(a []rune, b []rune) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
+
+// trigrams returns the character trigrams of s padded with spaces, so short
+// words still have some.
+func trigrams(s string) map[string]struct{} {
+	runes := []rune("  " + s + " ")
+	set := make(map[string]struct{}, len(runes))
+	for i := 0; i+3 <= len(runes); i++ {
+		set[string(runes[i:i+3])] = struct{}{}
+	}
+	return set
+}
+
+func jaccard(a map[string]struct{}, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	var shared int
+	for gram := range a {
+		if _, ok := b[gram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
@@ -0,0 +1,114 @@
+package matchutil
+
+import (
+	"slices"
+	"testing"
+)
+
+func testIndex() *Index {
+	return NewIndex([]Entry{
+		{ID: "latte", Names: []string{"Latte", "cafe latte"}},
+		{ID: "iced-latte", Names: []string{"Iced Latte"}},
+		{ID: "chicken-sandwich", Names: []string{"Chicken Sandwich"}},
+		{ID: "chicken-wrap", Names: []string{"Chicken Wrap"}},
+		{ID: "fish-chips", Names: []string{"Fish and Chips"}},
+		{ID: "coke", Names: []string{"Coca-Cola", "coke", "cola"}},
+		{ID: "mac", Names: []string{"Mac & Cheese"}},
+		{ID: "chips", Names: []string{"Chips"}},
+		{ID: "pizza-12", Names: []string{"12 inch Pizza"}},
+	})
+}
+
+func TestIndexMatch(t *testing.T) {
+	type want struct {
+		id       string
+		quantity int
+	}
+	tests := []struct {
+		text string
+		want []want
+	}{
+		{text: "2 larg lattes and a chiken sandwich", want: []want{{"latte", 2}, {"chicken-sandwich", 1}}},
+		{text: "fish and chips, 3 cokes please", want: []want{{"fish-chips", 1}, {"coke", 3}}},
+		{text: "I'd like a mac and cheese and chips", want: []want{{"mac", 1}, {"chips", 1}}},
+		{text: "iced latte x2", want: []want{{"iced-latte", 2}}},
+		{text: "two colas with fish & chips", want: []want{{"coke", 2}, {"fish-chips", 1}}},
+		{text: "Coca Cola", want: []want{{"coke", 1}}},
+		{text: "please", want: nil},
+	}
+	ix := testIndex()
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			matches := ix.Match(tt.text, 3)
+			var got []want
+			for _, m := range matches {
+				if len(m.Candidates) == 0 {
+					t.Fatalf("Match() phrase %q has no candidates", m.Text)
+				}
+				got = append(got, want{m.Candidates[0].ID, m.Quantity})
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("Match() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIndexMatchScores(t *testing.T) {
+	ix := testIndex()
+	matches := ix.Match("latte", 0)
+	if len(matches) != 1 {
+		t.Fatalf("Match() = %+v, want one phrase", matches)
+	}
+	candidates := matches[0].Candidates
+	if candidates[0].ID != "latte" || candidates[0].Score != 1 {
+		t.Errorf("best = %+v, want an exact latte", candidates[0])
+	}
+	for idx := 1; idx < len(candidates); idx++ {
+		if candidates[idx].Score > candidates[idx-1].Score || candidates[idx].Score < MinScore {
+			t.Errorf("candidates %+v are not sorted above MinScore", candidates)
+		}
+	}
+	if got := ix.Match("cafe latte", 1)[0].Candidates[0]; got.ID != "latte" || got.Name != "cafe latte" {
+		t.Errorf("alias match = %+v, want latte by its alias", got)
+	}
+	if got := ix.Match("16 inch pizza", 1)[0].Candidates; len(got) == 1 && got[0].Score >= 0.8 {
+		t.Errorf("16 inch pizza = %+v, want a weak match at best", got)
+	}
+	if got := ix.Match("sushi", 3)[0].Candidates; len(got) != 0 {
+		t.Errorf("sushi = %+v, want no candidates", got)
+	}
+}
+
+func TestTokens(t *testing.T) {
+	tests := map[string][]string{
+		"Chef's Lattes!":     {"chef", "latte"},
+		"Sandwiches & Fries": {"sandwich", "and", "fry"},
+		"Hummus, glass":      {"hummus", "glass"},
+		"  ":                 nil,
+	}
+	for in, want := range tests {
+		if got := Tokens(in); !slices.Equal(got, want) {
+			t.Errorf("Tokens(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestTokenSimilarity(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"chicken", "chiken", true},
+		{"large", "larg", true},
+		{"sandwich", "sandwhich", true},
+		{"latte", "lotus", false},
+		{"12", "16", false},
+		{"ab", "ac", false},
+	}
+	for _, tt := range tests {
+		if got := tokenSimilarity(tt.a, tt.b) > 0; got != tt.want {
+			t.Errorf("tokenSimilarity(%q, %q) > 0 = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
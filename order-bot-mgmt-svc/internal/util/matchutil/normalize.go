@@ -0,0 +1,102 @@
+package matchutil
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Tokens lower-cases s, drops apostrophes and punctuation, spells out "&" and
+// reduces plural words to their singular, so "Chef's Lattes" becomes
+// ["chef", "latte"].
+func Tokens(s string) []string {
+	s = strings.NewReplacer("'", "", "’", "", "&", " and ").Replace(strings.ToLower(s))
+	fields := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for idx, field := range fields {
+		fields[idx] = singular(field)
+	}
+	return fields
+}
+
+// singular strips common English plural endings. It only has to map both
+// the menu and the customer's wording to the same form, not be correct.
+func singular(t string) string {
+	n := len(t)
+	switch {
+	case n > 4 && strings.HasSuffix(t, "ies"):
+		return t[:n-3] + "y"
+	case n > 4 && (strings.HasSuffix(t, "ches") || strings.HasSuffix(t, "shes") ||
+		strings.HasSuffix(t, "sses") || strings.HasSuffix(t, "xes")):
+		return t[:n-2]
+	case n > 3 && strings.HasSuffix(t, "s") && !strings.HasSuffix(t, "ss") && !strings.HasSuffix(t, "us"):
+		return t[:n-1]
+	}
+	return t
+}
+
+var numberWords = map[string]int{
+	"a": 1, "an": 1, "one": 1, "two": 2, "three": 3, "four": 4, "five": 5, "six": 6,
+	"seven": 7, "eight": 8, "nine": 9, "ten": 10, "eleven": 11, "twelve": 12, "dozen": 12,
+}
+
+// quantity reads a token such as "2", "two", "2x" or "x2".
+func quantity(token string) (int, bool) {
+	if n, ok := numberWords[token]; ok {
+		return n, true
+	}
+	token = strings.TrimSuffix(strings.TrimPrefix(token, "x"), "x")
+	n, err := strconv.Atoi(token)
+	if err != nil || n <= 0 || n > maxQuantity {
+		return 0, false
+	}
+	return n, true
+}
+
+// fillerWords are dropped from customer text unless a menu name uses them.
+var fillerWords = map[string]struct{}{
+	"i": {}, "id": {}, "im": {}, "we": {}, "want": {}, "would": {}, "like": {}, "please": {}, "pls": {},
+	"can": {}, "could": {}, "get": {}, "have": {}, "some": {}, "the": {}, "of": {}, "me": {}, "us": {},
+	"give": {}, "order": {}, "to": {}, "for": {}, "my": {}, "let": {}, "take": {}, "need": {},
+}
+
+// separatorWords split customer text into one phrase per item.
+var separatorWords = map[string]struct{}{"and": {}, "plus": {}, "also": {}, "then": {}, "with": {}}
+
+type phrase struct {
+	text string
+	// joiner is the separator word between this phrase and the next, or ""
+	// when punctuation or nothing follows.
+	joiner string
+}
+
+// splitPhrases cuts text at punctuation and separator words. Phrases split by
+// a word keep it as joiner, so a name like "fish and chips" can be put back
+// together.
+func splitPhrases(text string) []phrase {
+	var phrases []phrase
+	var words []string
+	text = strings.ReplaceAll(text, "&", " and ")
+	for _, chunk := range strings.FieldsFunc(text, func(r rune) bool {
+		return strings.ContainsRune(",;+\n/", r)
+	}) {
+		for _, word := range strings.Fields(chunk) {
+			if _, ok := separatorWords[strings.ToLower(word)]; !ok {
+				words = append(words, word)
+				continue
+			}
+			if len(words) > 0 {
+				phrases = append(phrases, phrase{text: strings.Join(words, " "), joiner: word})
+				words = nil
+			}
+		}
+		if len(words) > 0 {
+			phrases = append(phrases, phrase{text: strings.Join(words, " ")})
+			words = nil
+		} else if len(phrases) > 0 {
+			phrases[len(phrases)-1].joiner = ""
+		}
+	}
+	return phrases
+}
@@ -0,0 +1,51 @@
+package moneyutil
+
+import (
+	"cmp"
+	"math/big"
+	"slices"
+)
+
+// Allocate splits total, at most the sum of weights, across weights in
+// proportion, giving the units lost to rounding to the largest remainders.
+// No share is more than its weight.
+func Allocate(total int64, weights []int64) []int64 {
+	shares := make([]int64, len(weights))
+	var sum int64
+	for _, weight := range weights {
+		sum += weight
+	}
+	if total <= 0 || sum == 0 {
+		return shares
+	}
+	remainders := make([]int64, len(weights))
+	left := total
+	for idx, weight := range weights {
+		product := new(big.Int).Mul(big.NewInt(total), big.NewInt(weight))
+		quo, rem := product.QuoRem(product, big.NewInt(sum), new(big.Int))
+		shares[idx], remainders[idx] = quo.Int64(), rem.Int64()
+		left -= shares[idx]
+	}
+	order := make([]int, 0, len(weights))
+	for idx, weight := range weights {
+		if weight > 0 {
+			order = append(order, idx)
+		}
+	}
+	slices.SortStableFunc(order, func(a, b int) int { return cmp.Compare(remainders[b], remainders[a]) })
+	for _, idx := range order[:left] {
+		shares[idx]++
+	}
+	return shares
+}
+
+// MulDivRound returns a * b / c rounded half up, for a and b of zero or more
+// and c above zero. The product may exceed int64.
+func MulDivRound(a int64, b int64, c int64) int64 {
+	product := new(big.Int).Mul(big.NewInt(a), big.NewInt(b))
+	quo, rem := product.QuoRem(product, big.NewInt(c), new(big.Int))
+	if rem.Lsh(rem, 1).Cmp(big.NewInt(c)) >= 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return quo.Int64()
+}
@@ -0,0 +1,41 @@
+package moneyutil
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		total   int64
+		weights []int64
+		want    []int64
+	}{
+		{total: 100, weights: []int64{1, 1, 1}, want: []int64{34, 33, 33}},
+		{total: 2, weights: []int64{1, 0, 1, 1}, want: []int64{1, 0, 1, 0}},
+		{total: 10, weights: []int64{3, 7}, want: []int64{3, 7}},
+		{total: 5, weights: []int64{333, 333, 334}, want: []int64{2, 1, 2}},
+		{total: 0, weights: []int64{5, 5}, want: []int64{0, 0}},
+		{total: 7, weights: []int64{0, 0}, want: []int64{0, 0}},
+	}
+	for _, tt := range tests {
+		if got := Allocate(tt.total, tt.weights); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Allocate(%d, %v) = %v, want %v", tt.total, tt.weights, got, tt.want)
+		}
+	}
+}
+
+func TestMulDivRound(t *testing.T) {
+	tests := []struct{ a, b, c, want int64 }{
+		{a: 999, b: 1000, c: 10000, want: 100},
+		{a: 995, b: 1000, c: 10000, want: 100},
+		{a: 994, b: 1000, c: 10000, want: 99},
+		{a: 5, b: 1, c: 2, want: 3},
+		{a: 1 << 62, b: 3, c: 4, want: 3 << 60},
+	}
+	for _, tt := range tests {
+		if got := MulDivRound(tt.a, tt.b, tt.c); got != tt.want {
+			t.Errorf("MulDivRound(%d, %d, %d) = %d, want %d", tt.a, tt.b, tt.c, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package moneyutil
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidAmount = apperr.Err{
+		Code: "ErrInvalidAmount",
+		Msg:  "invalid amount",
+	}
+	ErrTooPrecise = apperr.Err{
+		Code: "ErrTooPrecise",
+		Msg:  "amount has more decimal places than the currency allows",
+	}
+)
@@ -0,0 +1,31 @@
+package moneyutil
+
+import (
+	"strconv"
+	"strings"
+)
+
+// FormatScaled renders an integer amount with an implicit scale as a decimal
+// string, e.g. FormatScaled(1250, 2) == "12.50".
+func FormatScaled(v int64, scale int) string {
+	if scale <= 0 {
+		return strconv.FormatInt(v, 10)
+	}
+	sign := ""
+	if v < 0 {
+		sign = "-"
+	}
+	digits := strconv.FormatUint(absInt64(v), 10)
+	if len(digits) <= scale {
+		digits = strings.Repeat("0", scale-len(digits)+1) + digits
+	}
+	cut := len(digits) - scale
+	return sign + digits[:cut] + "." + digits[cut:]
+}
+
+func absInt64(v int64) uint64 {
+	if v < 0 {
+		return uint64(-(v + 1)) + 1
+	}
+	return uint64(v)
+}
@@ -0,0 +1,24 @@
+package moneyutil
+
+import "testing"
+
+func TestFormatScaled(t *testing.T) {
+	cases := []struct {
+		v     int64
+		scale int
+		want  string
+	}{
+		{1250, 2, "12.50"},
+		{5, 2, "0.05"},
+		{0, 2, "0.00"},
+		{-5, 2, "-0.05"},
+		{-1250, 2, "-12.50"},
+		{1250, 0, "1250"},
+		{1, 3, "0.001"},
+	}
+	for _, tc := range cases {
+		if got := FormatScaled(tc.v, tc.scale); got != tc.want {
+			t.Fatalf("FormatScaled(%d, %d): expected %q, got %q", tc.v, tc.scale, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package moneyutil
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Money is an amount in minor units. Its scale is the price scale of the bot
+// that owns it, e.g. Money(450) is 4.50 at scale 2.
+type Money int64
+
+func (m Money) Format(scale int) string { return FormatScaled(int64(m), scale) }
+
+// ParseMoney parses a plain decimal string such as "4.5" or "-12.00" into
+// minor units. Digits beyond the scale are rejected with ErrTooPrecise unless
+// they are trailing zeros; nothing is rounded.
+func ParseMoney(s string, scale int) (Money, error) {
+	s = strings.TrimSpace(s)
+	neg := false
+	switch {
+	case strings.HasPrefix(s, "-"):
+		neg = true
+		s = s[1:]
+	case strings.HasPrefix(s, "+"):
+		s = s[1:]
+	}
+	intPart, fracPart, hasDot := strings.Cut(s, ".")
+	if intPart == "" && fracPart == "" || hasDot && fracPart == "" || !isDigits(intPart) || !isDigits(fracPart) {
+		return 0, fmt.Errorf("moneyutil.ParseMoney(), %q: %w", s, ErrInvalidAmount)
+	}
+	trimmed := strings.TrimRight(fracPart, "0")
+	if len(trimmed) > scale {
+		return 0, fmt.Errorf("moneyutil.ParseMoney(), %q at scale %d: %w", s, scale, ErrTooPrecise)
+	}
+	digits := intPart + trimmed + strings.Repeat("0", scale-len(trimmed))
+	var v int64
+	for _, r := range digits {
+		if v > (math.MaxInt64-int64(r-'0'))/10 {
+			return 0, fmt.Errorf("moneyutil.ParseMoney(), %q overflows: %w", s, ErrInvalidAmount)
+		}
+		v = v*10 + int64(r-'0')
+	}
+	if neg {
+		v = -v
+	}
+	return Money(v), nil
+}
+
+// Rescale converts minor units between scales. Moving to a smaller scale fails
+// with ErrTooPrecise if it would drop non-zero digits.
+func Rescale(m Money, from int, to int) (Money, error) {
+	switch {
+	case to > from:
+		factor := int64(math.Pow10(to - from))
+		if int64(m) > math.MaxInt64/factor || int64(m) < math.MinInt64/factor {
+			return 0, fmt.Errorf("moneyutil.Rescale(), %d overflows: %w", m, ErrInvalidAmount)
+		}
+		return m * Money(factor), nil
+	case to < from:
+		factor := Money(math.Pow10(from - to))
+		if m%factor != 0 {
+			return 0, fmt.Errorf("moneyutil.Rescale(), %d from scale %d to %d: %w", m, from, to, ErrTooPrecise)
+		}
+		return m / factor, nil
+	default:
+		return m, nil
+	}
+}
+
+// Rescaler returns Rescale from one scale to another for plain minor-unit
+// amounts.
+func Rescaler(from int, to int) func(int64) (int64, error) {
+	return func(v int64) (int64, error) {
+		m, err := Rescale(Money(v), from, to)
+		return int64(m), err
+	}
+}
+
+// Percent returns basisPoints ten-thousandths of m, so 500 is 5% of m. The
+// result is rounded half away from zero.
+func Percent(m Money, basisPoints int64) (Money, error) {
+	if basisPoints != 0 && absInt64(int64(m)) > uint64(math.MaxInt64)/absInt64(basisPoints) {
+		return 0, fmt.Errorf("moneyutil.Percent(), %d by %d basis points overflows: %w", m, basisPoints, ErrInvalidAmount)
+	}
+	product := int64(m) * basisPoints
+	q, r := product/10000, product%10000
+	if absInt64(r)*2 >= 10000 {
+		if product < 0 {
+			q--
+		} else {
+			q++
+		}
+	}
+	return Money(q), nil
+}
+
+// Decimal is a decimal amount received as either a JSON string or a JSON
+// number. It keeps the original text so that ParseMoney sees exactly what the
+// client sent, without a float64 round trip.
+type Decimal string
+
+func (d *Decimal) UnmarshalJSON(b []byte) error {
+	b = bytes.TrimSpace(b)
+	if len(b) > 0 && b[0] == '"' {
+		var s string
+		if err := json.Unmarshal(b, &s); err != nil {
+			return fmt.Errorf("moneyutil.Decimal.UnmarshalJSON: %w", err)
+		}
+		*d = Decimal(s)
+		return nil
+	}
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("moneyutil.Decimal.UnmarshalJSON: %w", err)
+	}
+	*d = Decimal(n)
+	return nil
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
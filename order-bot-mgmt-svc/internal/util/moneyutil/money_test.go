@@ -0,0 +1,94 @@
+package moneyutil
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := []struct {
+		in    string
+		scale int
+		want  Money
+		err   error
+	}{
+		{"4.5", 2, 450, nil},
+		{"4.50", 2, 450, nil},
+		{"4.500", 2, 450, nil},
+		{"0.1", 2, 10, nil},
+		{".75", 2, 75, nil},
+		{"12", 0, 12, nil},
+		{"-3.25", 2, -325, nil},
+		{"1.005", 2, 0, ErrTooPrecise},
+		{"1.5", 0, 0, ErrTooPrecise},
+		{"", 2, 0, ErrInvalidAmount},
+		{"4.", 2, 0, ErrInvalidAmount},
+		{"1e2", 2, 0, ErrInvalidAmount},
+		{"abc", 2, 0, ErrInvalidAmount},
+		{"99999999999999999999", 2, 0, ErrInvalidAmount},
+	}
+	for _, tc := range cases {
+		got, err := ParseMoney(tc.in, tc.scale)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("ParseMoney(%q, %d): expected %v, got %v", tc.in, tc.scale, tc.err, err)
+			}
+			continue
+		}
+		if err != nil || got != tc.want {
+			t.Fatalf("ParseMoney(%q, %d): expected %d, got %d (%v)", tc.in, tc.scale, tc.want, got, err)
+		}
+	}
+}
+
+func TestRescale(t *testing.T) {
+	if got, err := Rescale(450, 2, 3); err != nil || got != 4500 {
+		t.Fatalf("expected 4500, got %d (%v)", got, err)
+	}
+	if got, err := Rescale(4500, 3, 2); err != nil || got != 450 {
+		t.Fatalf("expected 450, got %d (%v)", got, err)
+	}
+	if _, err := Rescale(4505, 3, 2); !errors.Is(err, ErrTooPrecise) {
+		t.Fatalf("expected ErrTooPrecise, got %v", err)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	cases := []struct {
+		m           Money
+		basisPoints int64
+		want        Money
+	}{
+		{450, 500, 23},
+		{450, 1000, 45},
+		{-450, 500, -23},
+		{450, -500, -23},
+		{449, 1000, 45},
+		{1, 4999, 0},
+		{0, 500, 0},
+		{450, 0, 0},
+	}
+	for _, tc := range cases {
+		if got, err := Percent(tc.m, tc.basisPoints); err != nil || got != tc.want {
+			t.Fatalf("Percent(%d, %d): expected %d, got %d (%v)", tc.m, tc.basisPoints, tc.want, got, err)
+		}
+	}
+	if _, err := Percent(math.MaxInt64/2, 500); !errors.Is(err, ErrInvalidAmount) {
+		t.Fatalf("expected ErrInvalidAmount, got %v", err)
+	}
+}
+
+func TestDecimalUnmarshalJSON(t *testing.T) {
+	var req struct {
+		A Decimal `json:"a"`
+		B Decimal `json:"b"`
+	}
+	if err := json.Unmarshal([]byte(`{"a":"4.50","b":0.1}`), &req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.A != "4.50" || req.B != "0.1" {
+		t.Fatalf("expected the original text, got %q and %q", req.A, req.B)
+	}
+}
@@ -0,0 +1,35 @@
+package sheetutil
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"io"
+)
+
+// utf8BOM is written by Excel at the start of CSV files saved as UTF-8.
+var utf8BOM = []byte("\xef\xbb\xbf")
+
+// ReadCSV reads every record of a CSV file. Rows may have different lengths.
+func ReadCSV(data []byte) ([][]string, error) {
+	r := csv.NewReader(bytes.NewReader(bytes.TrimPrefix(data, utf8BOM)))
+	r.FieldsPerRecord = -1
+	rows, err := r.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("sheetutil.ReadCSV(), %v: %w", err, ErrInvalidSheet)
+	}
+	return rows, nil
+}
+
+// WriteCSV writes the rows with a leading BOM so Excel opens the file as
+// UTF-8.
+func WriteCSV(w io.Writer, rows [][]string) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return fmt.Errorf("sheetutil.WriteCSV: %w", err)
+	}
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return fmt.Errorf("sheetutil.WriteCSV: %w", err)
+	}
+	return nil
+}
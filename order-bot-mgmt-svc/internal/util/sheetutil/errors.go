@@ -0,0 +1,10 @@
+package sheetutil
+
+import "order-bot-mgmt-svc/internal/apperr"
+
+var (
+	ErrInvalidSheet = apperr.Err{
+		Code: "ErrInvalidSheet",
+		Msg:  "spreadsheet could not be read",
+	}
+)
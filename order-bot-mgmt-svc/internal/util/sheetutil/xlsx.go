@@ -0,0 +1,292 @@
+package sheetutil
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// maxXLSXPartBytes caps each unpacked part of a workbook, so a small upload
+// cannot expand into an unbounded amount of XML.
+const maxXLSXPartBytes = 32 << 20
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		RelID string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// xlsxRichText is a shared or inline string, either plain or split in runs.
+type xlsxRichText struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t xlsxRichText) String() string {
+	if len(t.Runs) == 0 {
+		return t.T
+	}
+	var b strings.Builder
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+type xlsxSharedStrings struct {
+	Items []xlsxRichText `xml:"si"`
+}
+
+type xlsxSheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string        `xml:"r,attr"`
+			T      string        `xml:"t,attr"`
+			V      string        `xml:"v"`
+			Inline *xlsxRichText `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// ReadXLSX reads the cell values of the first worksheet of a workbook. Rows
+// keep their spreadsheet position, so row i of the result is row i+1 in
+// Excel, with empty rows for gaps. Formulas yield their cached value.
+func ReadXLSX(data []byte) ([][]string, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("sheetutil.ReadXLSX(), %v: %w", err, ErrInvalidSheet)
+	}
+	files := make(map[string]*zip.File, len(zr.File))
+	for _, f := range zr.File {
+		files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+	sheetPath, err := firstSheetPath(files)
+	if err != nil {
+		return nil, fmt.Errorf("sheetutil.ReadXLSX: %w", err)
+	}
+	var shared xlsxSharedStrings
+	if f, ok := files["xl/sharedStrings.xml"]; ok {
+		if err := decodeXLSXPart(f, &shared); err != nil {
+			return nil, fmt.Errorf("sheetutil.ReadXLSX: %w", err)
+		}
+	}
+	f, ok := files[sheetPath]
+	if !ok {
+		return nil, fmt.Errorf("sheetutil.ReadXLSX(), missing %s: %w", sheetPath, ErrInvalidSheet)
+	}
+	var sheet xlsxSheet
+	if err := decodeXLSXPart(f, &sheet); err != nil {
+		return nil, fmt.Errorf("sheetutil.ReadXLSX: %w", err)
+	}
+	var rows [][]string
+	for _, row := range sheet.Rows {
+		if row.R > len(rows)+1 {
+			rows = append(rows, make([][]string, row.R-len(rows)-1)...)
+		}
+		var values []string
+		for _, cell := range row.Cells {
+			col := len(values)
+			if cell.R != "" {
+				if col, ok = columnIndex(cell.R); !ok {
+					return nil, fmt.Errorf("sheetutil.ReadXLSX(), bad cell reference %q: %w", cell.R, ErrInvalidSheet)
+				}
+			}
+			if col < len(values) {
+				return nil, fmt.Errorf("sheetutil.ReadXLSX(), cell %s out of order: %w", cell.R, ErrInvalidSheet)
+			}
+			value := cell.V
+			switch cell.T {
+			case "s":
+				idx, err := strconv.Atoi(cell.V)
+				if err != nil || idx < 0 || idx >= len(shared.Items) {
+					return nil, fmt.Errorf("sheetutil.ReadXLSX(), bad shared string %q: %w", cell.V, ErrInvalidSheet)
+				}
+				value = shared.Items[idx].String()
+			case "inlineStr":
+				value = ""
+				if cell.Inline != nil {
+					value = cell.Inline.String()
+				}
+			case "b":
+				value = strconv.FormatBool(cell.V == "1")
+			case "", "n":
+				// Excel stores up to 17 significant digits; print the shortest
+				// form that reads back as the same number, so 4.5 stays 4.5.
+				if n, err := strconv.ParseFloat(cell.V, 64); err == nil {
+					value = strconv.FormatFloat(n, 'f', -1, 64)
+				}
+			}
+			values = append(values, make([]string, col-len(values))...)
+			values = append(values, value)
+		}
+		rows = append(rows, values)
+	}
+	return rows, nil
+}
+
+func firstSheetPath(files map[string]*zip.File) (string, error) {
+	const fallback = "xl/worksheets/sheet1.xml"
+	wbFile, ok := files["xl/workbook.xml"]
+	if !ok {
+		return "", fmt.Errorf("sheetutil.firstSheetPath(), missing workbook: %w", ErrInvalidSheet)
+	}
+	var wb xlsxWorkbook
+	if err := decodeXLSXPart(wbFile, &wb); err != nil {
+		return "", fmt.Errorf("sheetutil.firstSheetPath: %w", err)
+	}
+	relsFile, ok := files["xl/_rels/workbook.xml.rels"]
+	if !ok || len(wb.Sheets) == 0 {
+		return fallback, nil
+	}
+	var rels xlsxRelationships
+	if err := decodeXLSXPart(relsFile, &rels); err != nil {
+		return "", fmt.Errorf("sheetutil.firstSheetPath: %w", err)
+	}
+	for _, rel := range rels.Relationships {
+		if rel.ID != wb.Sheets[0].RelID {
+			continue
+		}
+		if strings.HasPrefix(rel.Target, "/") {
+			return strings.TrimPrefix(rel.Target, "/"), nil
+		}
+		return path.Join("xl", rel.Target), nil
+	}
+	return fallback, nil
+}
+
+func decodeXLSXPart(f *zip.File, v any) error {
+	rc, err := f.Open()
+	if err != nil {
+		return fmt.Errorf("sheetutil.decodeXLSXPart(), %s: %v: %w", f.Name, err, ErrInvalidSheet)
+	}
+	defer rc.Close()
+	if err := xml.NewDecoder(io.LimitReader(rc, maxXLSXPartBytes)).Decode(v); err != nil {
+		return fmt.Errorf("sheetutil.decodeXLSXPart(), %s: %v: %w", f.Name, err, ErrInvalidSheet)
+	}
+	return nil
+}
+
+// columnIndex returns the zero-based column of a cell reference like "C12".
+func columnIndex(ref string) (int, bool) {
+	col := 0
+	letters := 0
+	for _, r := range ref {
+		if r < 'A' || r > 'Z' {
+			break
+		}
+		col = col*26 + int(r-'A') + 1
+		letters++
+	}
+	if letters == 0 || letters > 3 {
+		return 0, false
+	}
+	return col - 1, true
+}
+
+// columnName is the inverse of columnIndex without the row number.
+func columnName(col int) string {
+	var name []byte
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = append([]byte{byte('A' + (col-1)%26)}, name...)
+	}
+	return string(name)
+}
+
+const (
+	xlsxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+	xlsxRootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+	xlsxWorkbookFormat = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+)
+
+// WriteXLSX writes the rows as a workbook with a single sheet. Every cell is
+// stored as text, so prices and IDs come back exactly as written.
+func WriteXLSX(w io.Writer, sheetName string, rows [][]string) error {
+	zw := zip.NewWriter(w)
+	var name bytes.Buffer
+	if err := xml.EscapeText(&name, []byte(sheetName)); err != nil {
+		return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+	}
+	parts := []struct {
+		name    string
+		content string
+	}{
+		{"[Content_Types].xml", xlsxContentTypes},
+		{"_rels/.rels", xlsxRootRels},
+		{"xl/workbook.xml", fmt.Sprintf(xlsxWorkbookFormat, name.String())},
+		{"xl/_rels/workbook.xml.rels", xlsxWorkbookRels},
+	}
+	for _, part := range parts {
+		pw, err := zw.Create(part.name)
+		if err != nil {
+			return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+		}
+		if _, err := io.WriteString(pw, part.content); err != nil {
+			return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+		}
+	}
+	pw, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+	}
+	if err := writeXLSXSheet(pw, rows); err != nil {
+		return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+	}
+	if err := zw.Close(); err != nil {
+		return fmt.Errorf("sheetutil.WriteXLSX: %w", err)
+	}
+	return nil
+}
+
+func writeXLSXSheet(w io.Writer, rows [][]string) error {
+	var b bytes.Buffer
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n")
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for rowIdx, row := range rows {
+		fmt.Fprintf(&b, `<row r="%d">`, rowIdx+1)
+		for col, value := range row {
+			if value == "" {
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s%d" t="inlineStr"><is><t xml:space="preserve">`, columnName(col), rowIdx+1)
+			if err := xml.EscapeText(&b, []byte(value)); err != nil {
+				return err
+			}
+			b.WriteString(`</t></is></c>`)
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	_, err := w.Write(b.Bytes())
+	return err
+}
@@ -0,0 +1,98 @@
+package sheetutil
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestXLSXRoundTrip(t *testing.T) {
+	rows := [][]string{
+		{"id", "category", "name", "price"},
+		{"", "Drinks", "Tea & <Milk>", "4.50"},
+		{},
+		{"i2", "", "  spaced  ", "12"},
+	}
+	var buf bytes.Buffer
+	if err := WriteXLSX(&buf, "Menu", rows); err != nil {
+		t.Fatalf("WriteXLSX() error = %v", err)
+	}
+	got, err := ReadXLSX(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ReadXLSX() error = %v", err)
+	}
+	want := [][]string{
+		{"id", "category", "name", "price"},
+		{"", "Drinks", "Tea & <Milk>", "4.50"},
+		nil,
+		{"i2", "", "  spaced  ", "12"},
+	}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("ReadXLSX() = %q, want %q", got, want)
+	}
+}
+
+// TestReadXLSXSharedStrings reads cells the way Excel stores them: shared
+// strings, rich text runs, numbers with float noise and skipped cells.
+func TestReadXLSXSharedStrings(t *testing.T) {
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheets><sheet name="A" sheetId="1"/></sheets></workbook>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>name</t></si><si><r><t>Ice</t></r><r><t>d tea</t></r></si></sst>`,
+		"xl/worksheets/sheet1.xml": `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>` +
+			`<row r="1"><c r="A1" t="s"><v>0</v></c></row>` +
+			`<row r="3"><c r="A3" t="s"><v>1</v></c><c r="C3"><v>4.4999999999999998</v></c><c r="D3" t="b"><v>1</v></c></row>` +
+			`</sheetData></worksheet>`,
+	}
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range parts {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadXLSX(buf.Bytes())
+	if err != nil {
+		t.Fatalf("ReadXLSX() error = %v", err)
+	}
+	want := [][]string{{"name"}, nil, {"Iced tea", "", "4.5", "true"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("ReadXLSX() = %q, want %q", got, want)
+	}
+}
+
+func TestReadXLSXInvalid(t *testing.T) {
+	if _, err := ReadXLSX([]byte("id,name\n")); !errors.Is(err, ErrInvalidSheet) {
+		t.Fatalf("ReadXLSX() error = %v, want %v", err, ErrInvalidSheet)
+	}
+}
+
+func TestColumnIndex(t *testing.T) {
+	for _, col := range []int{0, 1, 25, 26, 27, 701, 702} {
+		name := columnName(col)
+		got, ok := columnIndex(name + "7")
+		if !ok || got != col {
+			t.Errorf("columnIndex(%q) = %d, %v, want %d", name+"7", got, ok, col)
+		}
+	}
+}
+
+func TestReadCSV(t *testing.T) {
+	got, err := ReadCSV([]byte("\xef\xbb\xbfname,price\nTea,4.5,extra\n"))
+	if err != nil {
+		t.Fatalf("ReadCSV() error = %v", err)
+	}
+	want := [][]string{{"name", "price"}, {"Tea", "4.5", "extra"}}
+	if !slices.EqualFunc(got, want, slices.Equal) {
+		t.Fatalf("ReadCSV() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,120 @@
+package validatorutil
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Codes of field errors. Clients branch on the code and show the message.
+const (
+	CodeRequired   = "required"
+	CodeDuplicate  = "duplicate"
+	CodeTooLong    = "too_long"
+	CodeTooMany    = "too_many"
+	CodeOutOfRange = "out_of_range"
+	CodePrecision  = "precision"
+	CodeUnknown    = "unknown"
+	CodeConflict   = "conflict"
+	CodeInvalid    = "invalid"
+)
+
+// FieldError is a problem with one field of a request. Field is a path such
+// as "items[3].price". Err is the error the problem is reported under and is
+// left out of responses.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	Err     error  `json:"-"`
+}
+
+func (e FieldError) Error() string { return e.Field + ": " + e.Message }
+
+func (e FieldError) Unwrap() error { return e.Err }
+
+// FieldErrors lists every problem of a request, so that they can be fixed at
+// once. It matches the errors of its problems with errors.Is.
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		msgs = append(msgs, fieldErr.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e FieldErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, fieldErr := range e {
+		errs = append(errs, fieldErr)
+	}
+	return errs
+}
+
+// Add reports a problem with field under err.
+func (e *FieldErrors) Add(err error, field string, code string, format string, args ...any) {
+	*e = append(*e, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...), Err: err})
+}
+
+// Nest adds the problems of err with their fields under prefix, so "price"
+// under "items[3]" becomes "items[3].price". An err without field errors is
+// added as a problem with prefix itself.
+func (e *FieldErrors) Nest(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	fields := Fields(err)
+	if len(fields) == 0 {
+		e.Add(err, prefix, CodeInvalid, "%s", err.Error())
+		return
+	}
+	for _, fieldErr := range fields {
+		fieldErr.Field = joinField(prefix, fieldErr.Field)
+		*e = append(*e, fieldErr)
+	}
+}
+
+func joinField(prefix string, field string) string {
+	switch {
+	case prefix == "":
+		return field
+	case field == "":
+		return prefix
+	case strings.HasPrefix(field, "["):
+		return prefix + field
+	}
+	return prefix + "." + field
+}
+
+// Err returns e, or nil when there are no problems.
+func (e FieldErrors) Err() error {
+	if len(e) == 0 {
+		return nil
+	}
+	return e
+}
+
+// Fields returns the field errors anywhere in the chain of err, in order.
+func Fields(err error) FieldErrors {
+	var out FieldErrors
+	var walk func(err error)
+	walk = func(err error) {
+		switch err := err.(type) {
+		case nil:
+		case FieldErrors:
+			out = append(out, err...)
+		case FieldError:
+			out = append(out, err)
+		case interface{ Unwrap() []error }:
+			for _, inner := range err.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(err))
+		}
+	}
+	walk(err)
+	return out
+}
@@ -0,0 +1,51 @@
+package validatorutil
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestFieldErrorsNest(t *testing.T) {
+	errInvalid := errors.New("invalid")
+	var inner FieldErrors
+	inner.Add(errInvalid, "price", CodeOutOfRange, "price must be greater than %d", 0)
+	inner.Add(errInvalid, "[2]", CodeDuplicate, "listed twice")
+
+	var errs FieldErrors
+	errs.Nest("items[3]", fmt.Errorf("wrapped: %w", inner))
+	errs.Nest("items[4]", errInvalid)
+	errs.Nest("items[5]", nil)
+
+	want := FieldErrors{
+		{Field: "items[3].price", Code: CodeOutOfRange, Message: "price must be greater than 0", Err: errInvalid},
+		{Field: "items[3][2]", Code: CodeDuplicate, Message: "listed twice", Err: errInvalid},
+		{Field: "items[4]", Code: CodeInvalid, Message: "invalid", Err: errInvalid},
+	}
+	if !reflect.DeepEqual(errs, want) {
+		t.Errorf("Nest() = %+v, want %+v", errs, want)
+	}
+	if !errors.Is(errs.Err(), errInvalid) {
+		t.Error("errors.Is(Err(), errInvalid) = false, want true")
+	}
+	if (FieldErrors{}).Err() != nil {
+		t.Error("Err() of no problems is not nil")
+	}
+}
+
+func TestFields(t *testing.T) {
+	var first, second FieldErrors
+	first.Add(nil, "name", CodeRequired, "name is required")
+	second.Add(nil, "items[0].name", CodeTooLong, "name is too long")
+	err := fmt.Errorf("saving: %w", errors.Join(fmt.Errorf("menu: %w", first), errors.New("other"), second))
+
+	got := Fields(err)
+	want := FieldErrors{first[0], second[0]}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Fields() = %+v, want %+v", got, want)
+	}
+	if got := Fields(errors.New("plain")); got != nil {
+		t.Errorf("Fields() of a plain error = %+v, want nil", got)
+	}
+}
@@ -0,0 +1,29 @@
+package worker
+
+import (
+	"context"
+	"log/slog"
+	"order-bot-mgmt-svc/internal/util/errutil"
+	"time"
+)
+
+// Run calls fn every interval until ctx is done. A failed run is logged and
+// the loop keeps going; a non-positive interval disables the worker.
+func Run(ctx context.Context, name string, interval time.Duration, fn func(ctx context.Context) error) {
+	if interval <= 0 {
+		slog.Info("worker.Run(), disabled", "worker", name)
+		return
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(ctx); err != nil {
+				slog.Error(errutil.FormatErrChain(err), "worker", name)
+			}
+		}
+	}
+}
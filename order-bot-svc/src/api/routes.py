@@ -19,6 +19,17 @@ intent_parser = IntentParser()
 logger = logging.getLogger(__name__)
 
 
+@router.get("/menu/{bot_id}")
+async def get_active_menu(
+        bot_id: str,
+        db: AsyncSession = Depends(get_db_session),
+):
+    active_menu = await menu_service.find_active_menu(db, bot_id)
+    if not active_menu:
+        return {"menu_id": None, "name": None}
+    return {"menu_id": active_menu.id, "name": active_menu.name}
+
+
 @router.get("/menu/{bot_id}/{menu_id}")
 async def get_published_menu(
         bot_id: str,
@@ -33,14 +44,17 @@ async def get_published_menu(
 async def get_published_menu_items(
         bot_id: str,
         menu_id: str,
+        locale: str | None = None,
+        accept_language: str | None = Header(default=None, alias="Accept-Language"),
         db: AsyncSession = Depends(get_db_session),
 ):
     published_menu = await repositories.get_published_menu(db, bot_id, menu_id)
     if not published_menu:
         return {"published_menu_items": []}
 
-    menu_items = await menu_service.search_menu_for_intent(db, menu_id)
-    return {"published_menu_items": menu_items}
+    locale = menu_service.resolve_locale(published_menu, locale, accept_language)
+    menu_items = await menu_service.search_menu_for_intent(db, menu_id, locale)
+    return {"published_menu_items": menu_items, "locale": locale}
 
 
 @router.post("", response_model=ChatResponse)
@@ -48,6 +62,7 @@ async def chat(
         req: ChatRequest,
         res: Response,
         session_id: str | None = Header(default=None, alias="Session-Id"),
+        accept_language: str | None = Header(default=None, alias="Accept-Language"),
         db: AsyncSession = Depends(get_db_session),
 ):
     if not session_id:
@@ -58,11 +73,22 @@ async def chat(
     else:
         cart = await cart_service.get_cart(db, session_id)
 
+    menu_id = req.menu_id
+    if menu_id:
+        menu = await repositories.get_published_menu(db, req.bot_id, menu_id)
+    else:
+        menu = await menu_service.find_active_menu(db, req.bot_id)
+        menu_id = menu.id if menu else ""
+    locale = menu_service.resolve_locale(menu, req.locale, accept_language)
+
     cart_summary = await cart_service.build_cart_summary(cart)
     cart_item_intents = await cart_service.build_cart_item_intents(cart)
-    menu_item_intents = await menu_service.search_menu_for_intent(db, req.menu_id)
+    menu_item_intents = await menu_service.search_menu_for_intent(db, menu_id)
+    bundle_intents = await menu_service.bundles_for_intent(db, menu_id)
     logger.info("before...")
-    intent = await intent_parser.parse(req.message, menu_item_intents, bool(cart_summary.items), cart_item_intents)
+    intent = await intent_parser.parse(
+        req.message, menu_item_intents, bool(cart_summary.items), cart_item_intents, bundle_intents
+    )
     logger.info("routes.chat(), intent: %s", intent)
 
     if not intent.valid:
@@ -82,23 +108,25 @@ async def chat(
             cart=cart_summary,
         )
 
-    return await handler(db=db, bot_id=req.bot_id, menu_id=req.menu_id, intent=intent, cart=cart)
+    res_body = await handler(db=db, bot_id=req.bot_id, menu_id=menu_id, intent=intent, cart=cart, locale=locale)
+    res_body.locale = locale
+    return res_body
 
 
 async def _handle_search_menu(
-        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart
+        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart, locale: str | None
 ) -> ChatResponse:
-    return await menu_service.search_menu(db, menu_id, intent, cart)
+    return await menu_service.search_menu(db, menu_id, intent, cart, locale)
 
 
 async def _handle_cart_mutation(
-        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart
+        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart, locale: str | None
 ) -> ChatResponse:
     return await cart_service.mutate_cart(db, cart.session_id, intent)
 
 
 async def _handle_show_cart(
-        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart
+        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart, locale: str | None
 ) -> ChatResponse:
     cart_summary = await cart_service.build_cart_summary(cart)
     return ChatResponse(
@@ -110,7 +138,7 @@ async def _handle_show_cart(
 
 
 async def _handle_checkout(
-        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart
+        *, db: AsyncSession, bot_id: str, menu_id: str, intent: IntentResult, cart: Cart, locale: str | None
 ) -> ChatResponse:
     return await order_service.checkout(db, cart.session_id, bot_id, intent, cart)
 
@@ -1,16 +1,25 @@
 import uuid
 from datetime import datetime, UTC
 
-from sqlalchemy import String, Integer, Double, Enum, ForeignKey, DateTime, UniqueConstraint, func
+from sqlalchemy import String, Integer, BigInteger, Boolean, Enum, ForeignKey, DateTime, UniqueConstraint, func
+from sqlalchemy.dialects.postgresql import JSONB
 from sqlalchemy.orm import Mapped, mapped_column, relationship
 from src.db import Base
 from src.enums import CartStatus
+from src.utils import money_util
 
 
 def _uuid_str() -> str:
     return str(uuid.uuid4())
 
 
+def _translated(translations: dict | None, locale: str | None, field: str) -> str:
+    # Missing translations fall back to the default-locale text.
+    if not translations or not locale:
+        return ""
+    return (translations.get(locale) or {}).get(field, "")
+
+
 class BaseModel(Base):
     __abstract__ = True
 
@@ -32,6 +41,76 @@ class Menu(BaseModel):
 
     id: Mapped[str] = mapped_column(String(64), primary_key=True)
     bot_id: Mapped[str] = mapped_column(String(64), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="menu_name", default="")
+    timezone: Mapped[str] = mapped_column(String(64), default="UTC")
+    # The locale names are written in, and every locale the bot answers in.
+    default_locale: Mapped[str] = mapped_column(String(35), default="en")
+    locales: Mapped[list[str]] = mapped_column(JSONB, default=list)
+    # Minutes after local midnight; equal bounds mean the menu is served all day.
+    available_from: Mapped[int] = mapped_column(Integer, default=0)
+    available_until: Mapped[int] = mapped_column(Integer, default=0)
+
+
+class MenuCategory(BaseModel):
+    __tablename__ = "published_menu_category"
+
+    id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    menu_id: Mapped[str] = mapped_column(String(64), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="category_name")
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+    translations: Mapped[dict] = mapped_column(JSONB, default=dict)
+
+    def localized_name(self, locale: str | None) -> str:
+        return _translated(self.translations, locale, "name") or self.name
+
+
+class MenuOption(BaseModel):
+    __tablename__ = "published_menu_option"
+
+    id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    group_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_option_group.id"), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="option_name")
+    price_delta_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+    @property
+    def price_delta(self) -> float:
+        return money_util.to_float(self.price_delta_scaled)
+
+
+class MenuOptionGroup(BaseModel):
+    __tablename__ = "published_menu_option_group"
+
+    id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    menu_id: Mapped[str] = mapped_column(String(64), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="group_name")
+    selection_type: Mapped[str] = mapped_column(String(16), default="single")
+    min_choices: Mapped[int] = mapped_column(Integer, default=0)
+    max_choices: Mapped[int] = mapped_column(Integer, default=1)
+    required: Mapped[bool] = mapped_column(Boolean, default=False)
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+    options: Mapped[list[MenuOption]] = relationship(
+        "MenuOption", lazy="selectin", order_by="MenuOption.sort_position"
+    )
+
+
+class MenuItemOptionGroup(BaseModel):
+    __tablename__ = "published_menu_item_option_group"
+
+    menu_item_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_item.id"), primary_key=True)
+    group_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_option_group.id"), primary_key=True)
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+
+class MenuItemAlias(BaseModel):
+    # Another name the item is matched by, unique ignoring case in the menu.
+    __tablename__ = "published_menu_item_alias"
+
+    menu_item_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_item.id"), primary_key=True)
+    alias: Mapped[str] = mapped_column(String(100), primary_key=True)
+    menu_id: Mapped[str] = mapped_column(String(64), index=True)
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
 
 
 class MenuItem(BaseModel):
@@ -40,7 +119,144 @@ class MenuItem(BaseModel):
     id: Mapped[str] = mapped_column(String(36), primary_key=True, default=_uuid_str)
     menu_id: Mapped[str] = mapped_column(String(64), unique=True, index=True)
     name: Mapped[str] = mapped_column(String(200), name="menu_item_name")
-    price: Mapped[float] = mapped_column(Double)
+    price_scaled: Mapped[int] = mapped_column(BigInteger)
+    category_id: Mapped[str | None] = mapped_column(
+        String(64), ForeignKey("published_menu_category.id"), nullable=True
+    )
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+    sold_out: Mapped[bool] = mapped_column(Boolean, default=False)
+    restore_at: Mapped[datetime | None] = mapped_column(DateTime, nullable=True)
+    image_url: Mapped[str] = mapped_column(String(2048), default="")
+    thumbnail_url: Mapped[str] = mapped_column(String(2048), default="")
+    description: Mapped[str] = mapped_column(String(1000), default="")
+    # Allergens and dietary tags use the values accepted by order-bot-mgmt-svc,
+    # e.g. "tree_nuts" and "gluten_free". Spicy level runs from 0 to 3.
+    allergens: Mapped[list[str]] = mapped_column(JSONB, default=list)
+    dietary_tags: Mapped[list[str]] = mapped_column(JSONB, default=list)
+    spicy_level: Mapped[int] = mapped_column(Integer, default=0)
+    nutrition: Mapped[dict | None] = mapped_column(JSONB, nullable=True)
+    # Keyed by locale, e.g. {"zh-TW": {"name": "拿鐵", "description": "..."}}.
+    translations: Mapped[dict] = mapped_column(JSONB, default=dict)
+
+    category: Mapped[MenuCategory | None] = relationship("MenuCategory", lazy="joined")
+    option_groups: Mapped[list[MenuOptionGroup]] = relationship(
+        "MenuOptionGroup",
+        secondary="published_menu_item_option_group",
+        lazy="selectin",
+        order_by="MenuOptionGroup.sort_position",
+        viewonly=True,
+    )
+    alias_rows: Mapped[list[MenuItemAlias]] = relationship(
+        "MenuItemAlias", lazy="selectin", order_by="MenuItemAlias.sort_position", viewonly=True
+    )
+
+    @property
+    def aliases(self) -> list[str]:
+        return [row.alias for row in self.alias_rows]
+
+    @property
+    def available(self) -> bool:
+        # The mgmt service clears sold_out once restore_at passes; checking the
+        # time here hides its polling delay.
+        if not self.sold_out:
+            return True
+        return self.restore_at is not None and self.restore_at <= datetime.now(UTC).replace(tzinfo=None)
+
+    @property
+    def category_name(self) -> str | None:
+        return self.category.name if self.category else None
+
+    def localized_name(self, locale: str | None) -> str:
+        return _translated(self.translations, locale, "name") or self.name
+
+    def localized_description(self, locale: str | None) -> str:
+        return _translated(self.translations, locale, "description") or self.description
+
+    def localized_category_name(self, locale: str | None) -> str | None:
+        return self.category.localized_name(locale) if self.category else None
+
+    @property
+    def price(self) -> float:
+        return money_util.to_float(self.price_scaled)
+
+
+class MenuBundleChoice(BaseModel):
+    __tablename__ = "published_menu_bundle_choice"
+
+    slot_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_bundle_slot.id"), primary_key=True)
+    menu_item_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_item.id"), primary_key=True)
+    # Added to the bundle price when the item is picked.
+    upcharge_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+    menu_item: Mapped[MenuItem] = relationship("MenuItem", lazy="selectin", viewonly=True)
+
+
+class MenuBundleSlot(BaseModel):
+    # A component of a bundle, such as "Drink", filled with one of its choices.
+    __tablename__ = "published_menu_bundle_slot"
+
+    id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    bundle_id: Mapped[str] = mapped_column(String(64), ForeignKey("published_menu_bundle.id"), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="slot_name")
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+    choices: Mapped[list[MenuBundleChoice]] = relationship(
+        "MenuBundleChoice", lazy="selectin", order_by="MenuBundleChoice.sort_position", viewonly=True
+    )
+
+
+class MenuBundle(BaseModel):
+    # A combo such as "burger + fries + drink", sold as one cart line.
+    __tablename__ = "published_menu_bundle"
+
+    id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    menu_id: Mapped[str] = mapped_column(String(64), index=True)
+    name: Mapped[str] = mapped_column(String(200), name="bundle_name")
+    price_scaled: Mapped[int] = mapped_column(BigInteger)
+    description: Mapped[str] = mapped_column(String(1000), default="")
+    sort_position: Mapped[int] = mapped_column(Integer, default=0)
+
+    slots: Mapped[list[MenuBundleSlot]] = relationship(
+        "MenuBundleSlot", lazy="selectin", order_by="MenuBundleSlot.sort_position", viewonly=True
+    )
+
+    @property
+    def price(self) -> float:
+        return money_util.to_float(self.price_scaled)
+
+
+class TaxConfig(BaseModel):
+    # Published by order-bot-mgmt-svc. Rates are {"code", "name", "rate"} with
+    # rate in millionths; category_rates and item_rates map category and item
+    # IDs to rate codes.
+    __tablename__ = "published_tax_config"
+
+    bot_id: Mapped[str] = mapped_column(String(64), primary_key=True)
+    mode: Mapped[str] = mapped_column(String(16), name="tax_mode", default="exclusive")
+    default_rate_code: Mapped[str] = mapped_column(String(64), default="")
+    rates: Mapped[list[dict]] = mapped_column(JSONB, default=list)
+    category_rates: Mapped[dict] = mapped_column(JSONB, default=dict)
+    item_rates: Mapped[dict] = mapped_column(JSONB, default=dict)
+
+    @property
+    def inclusive(self) -> bool:
+        return self.mode == "inclusive"
+
+    def rate(self, code: str) -> dict | None:
+        return next((rate for rate in self.rates or [] if rate.get("code") == code), None)
+
+    def rate_for(self, item_id: str, category_id: str | None) -> dict | None:
+        # An item's own override wins over its category's, which wins over the
+        # default. Bundles have no category and get the default; None means
+        # untaxed.
+        if item_id and item_id in (self.item_rates or {}):
+            return self.rate(self.item_rates[item_id])
+        if category_id and category_id in (self.category_rates or {}):
+            return self.rate(self.category_rates[category_id])
+        if not self.default_rate_code:
+            return None
+        return self.rate(self.default_rate_code)
 
 
 class Cart(BaseModel):
@@ -75,6 +291,8 @@ class CartItem(BaseModel):
     quantity: Mapped[int] = mapped_column(Integer)
     unit_price_scaled: Mapped[int] = mapped_column(Integer)
     total_price_scaled: Mapped[int] = mapped_column(Integer)
+    # The picks of a bundle line, one per slot; empty for plain items.
+    components: Mapped[list[dict]] = mapped_column(JSONB, default=list)
 
     cart: Mapped[Cart] = relationship("Cart", back_populates="items")
 
@@ -87,6 +305,13 @@ class Order(BaseModel):
     bot_id: Mapped[str] = mapped_column(String(64), index=True)
     session_id: Mapped[str] = mapped_column(String(36), index=True)
     total_scaled: Mapped[int] = mapped_column(Integer)
+    # The tax breakdown at the time the order was placed. tax_rates has
+    # {"code", "name", "rate", "net_scaled", "tax_scaled"} for each rate used.
+    tax_mode: Mapped[str] = mapped_column(String(16), default="exclusive")
+    net_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
+    tax_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
+    gross_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
+    tax_rates: Mapped[list[dict]] = mapped_column(JSONB, default=list)
 
     order_items: Mapped[list["OrderItem"]] = relationship(
         "OrderItem",
@@ -104,5 +329,9 @@ class OrderItem(BaseModel):
     quantity: Mapped[int] = mapped_column(Integer)
     unit_price_scaled: Mapped[int] = mapped_column(Integer)
     total_price_scaled: Mapped[int] = mapped_column(Integer)
+    components: Mapped[list[dict]] = mapped_column(JSONB, default=list)
+    # Empty for untaxed items.
+    tax_rate_code: Mapped[str] = mapped_column(String(64), default="")
+    tax_scaled: Mapped[int] = mapped_column(BigInteger, default=0)
 
     order: Mapped[Order] = relationship("Order", back_populates="order_items")
@@ -11,7 +11,7 @@ from langchain_mcp_adapters.client import MultiServerMCPClient
 from langchain_mistralai import ChatMistralAI
 
 from src.config import settings
-from src.schemas import IntentResult, MenuItemIntent, CartItemIntent
+from src.schemas import IntentResult, MenuItemIntent, MenuBundleIntent, CartItemIntent
 
 
 class MCPIntentClient:
@@ -43,7 +43,12 @@ class IntentParser:
         self._agent = None
 
     async def parse(
-        self, message: str, menu_item_intents: list[MenuItemIntent], has_cart_items: bool, cart_item_intents: list[CartItemIntent]
+        self,
+        message: str,
+        menu_item_intents: list[MenuItemIntent],
+        has_cart_items: bool,
+        cart_item_intents: list[CartItemIntent],
+        bundle_intents: list[MenuBundleIntent] | None = None,
     ) -> IntentResult:
         text = message.strip()
         if not text:
@@ -66,7 +71,9 @@ class IntentParser:
                     "messages": [
                         {
                             "role": "user",
-                            "content": self._build_user_prompt(text, menu_item_intents, has_cart_items, cart_item_intents),
+                            "content": self._build_user_prompt(
+                                text, menu_item_intents, has_cart_items, cart_item_intents, bundle_intents or []
+                            ),
                         }
                     ]
                 }
@@ -91,21 +98,32 @@ class IntentParser:
         )
 
     def _build_user_prompt(
-        self, message: str, menu_item_intents: list[MenuItemIntent], has_cart_items: bool, cart_item_intents: list[CartItemIntent]
+        self,
+        message: str,
+        menu_item_intents: list[MenuItemIntent],
+        has_cart_items: bool,
+        cart_item_intents: list[CartItemIntent],
+        bundle_intents: list[MenuBundleIntent],
     ) -> str:
         menu_payload = json.dumps([m.model_dump() for m in menu_item_intents], ensure_ascii=False)
+        bundle_payload = json.dumps([b.model_dump() for b in bundle_intents], ensure_ascii=False)
         cart_payload = json.dumps([m.model_dump() for m in cart_item_intents], ensure_ascii=False)
         prompt = ChatPromptTemplate.from_template(
             "Message: {message}\n"
             "Has cart items: {has_cart_items}\n"
             "Cart: {cart}\n"
             "Menu: {menu}\n"
+            "Bundles: {bundles}\n"
             "Steps:\n"
             "1. Select exactly one intent type from: search_menu, mutate_cart_items, show_cart, checkout, unknown.\n"
-            "2. Use intent args derived from the message.\n"
+            "2. Use intent args derived from the message. "
+            "To order a bundle, use its bundle_id as menu_item_id and put the picked menu_item_id "
+            "of each slot, in slot order, in bundle_choices.\n"
             "3. Return the final response as IntentResult JSON only."
         )
-        return prompt.format(message=message, has_cart_items=has_cart_items, menu=menu_payload, cart=cart_payload)
+        return prompt.format(
+            message=message, has_cart_items=has_cart_items, menu=menu_payload, bundles=bundle_payload, cart=cart_payload
+        )
 
     def _parse_agent_response(self, response: dict[str, Any]) -> IntentResult:
         if isinstance(response, str):
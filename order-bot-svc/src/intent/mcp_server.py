@@ -30,7 +30,8 @@ def search_menu() -> str:
 
 @mcp.tool()
 def mutate_cart_items(items: list[CartItemIntent]) -> str:
-    """Update menu items in the cart by menu item id and quantity and return the whole cart items including the ones in the cart"""
+    """Update menu items in the cart by menu item id and quantity and return the whole cart items including the ones in the cart.
+    A bundle goes in by its bundle id with the picked menu item id of each slot in bundle_choices."""
     return _json_payload({"intent_type": "mutate_cart_items", "items": items})
 
 
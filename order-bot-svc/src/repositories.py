@@ -1,22 +1,68 @@
-from sqlalchemy import select, delete
+from sqlalchemy import select, delete, func, or_
+from sqlalchemy.dialects.postgresql import array
 from sqlalchemy.ext.asyncio import AsyncSession
-from src.entities import MenuItem, Cart, CartItem, Order, OrderItem, Menu
+from src.entities import MenuItem, MenuItemAlias, MenuCategory, MenuBundle, Cart, CartItem, Order, OrderItem, Menu, TaxConfig
 
 
-async def get_menu_by_query(db: AsyncSession, menu_id: str) -> list[MenuItem]:
-    stmt = select(MenuItem).where(
-        MenuItem.menu_id == menu_id
+async def get_menu_by_query(
+    db: AsyncSession,
+    menu_id: str,
+    exclude_allergens: list[str] | None = None,
+    dietary_tags: list[str] | None = None,
+) -> list[MenuItem]:
+    # Categorized items first in category order, then uncategorized ones.
+    # Sold-out items are left out unless their restore time has passed, as are
+    # items with any excluded allergen or missing any required dietary tag.
+    stmt = (
+        select(MenuItem)
+        .outerjoin(MenuCategory, MenuItem.category_id == MenuCategory.id)
+        .where(
+            MenuItem.menu_id == menu_id,
+            or_(MenuItem.sold_out.is_(False), MenuItem.restore_at <= func.timezone("UTC", func.now())),
+        )
+        .order_by(MenuCategory.sort_position.asc().nulls_last(), MenuItem.sort_position, MenuItem.id)
     )
+    if exclude_allergens:
+        stmt = stmt.where(~MenuItem.allergens.has_any(array(exclude_allergens)))
+    if dietary_tags:
+        stmt = stmt.where(MenuItem.dietary_tags.contains(dietary_tags))
     result = await db.scalars(stmt)
     return list(result.all())
 
 
+async def find_menu_item_by_name(db: AsyncSession, menu_id: str, name: str) -> MenuItem | None:
+    # Matches the item name or one of its aliases, ignoring case and spacing.
+    key = " ".join(name.split()).lower()
+    stmt = select(MenuItem).where(MenuItem.menu_id == menu_id, func.lower(MenuItem.name) == key)
+    item = (await db.scalars(stmt)).first()
+    if item is not None:
+        return item
+    stmt = (
+        select(MenuItem)
+        .join(MenuItemAlias, MenuItemAlias.menu_item_id == MenuItem.id)
+        .where(MenuItemAlias.menu_id == menu_id, func.lower(MenuItemAlias.alias) == key)
+    )
+    return (await db.scalars(stmt)).first()
+
+
 async def get_menu_item_by_menu_item_ids(db: AsyncSession, menu_item_ids: list[str]) -> list[MenuItem]:
     stmt = select(MenuItem).where(MenuItem.id.in_(menu_item_ids))
     result = await db.scalars(stmt)
     return list(result.all())
 
 
+async def get_bundles(db: AsyncSession, menu_id: str) -> list[MenuBundle]:
+    stmt = select(MenuBundle).where(MenuBundle.menu_id == menu_id).order_by(MenuBundle.sort_position, MenuBundle.id)
+    result = await db.scalars(stmt)
+    return list(result.all())
+
+
+async def get_bundles_by_ids(db: AsyncSession, bundle_ids: list[str]) -> list[MenuBundle]:
+    stmt = select(MenuBundle).where(MenuBundle.id.in_(bundle_ids))
+    result = await db.scalars(stmt)
+    return list(result.all())
+
+
 async def get_published_menu(db: AsyncSession, bot_id: str, menu_id: str) -> Menu | None:
     stmt = select(Menu).where(
         Menu.id == menu_id,
@@ -26,6 +72,12 @@ async def get_published_menu(db: AsyncSession, bot_id: str, menu_id: str) -> Men
     return result.first()
 
 
+async def list_published_menus(db: AsyncSession, bot_id: str) -> list[Menu]:
+    stmt = select(Menu).where(Menu.bot_id == bot_id).order_by(Menu.available_from, Menu.id)
+    result = await db.scalars(stmt)
+    return list(result.all())
+
+
 async def get_cart_by_session(
     db: AsyncSession, session_id: str, for_update: bool = False
 ) -> Cart | None:
@@ -60,9 +112,16 @@ async def insert_order(db: AsyncSession, cart: Cart, bot_id: str, total_scaled:
     return order
 
 
+async def get_tax_config(db: AsyncSession, bot_id: str) -> TaxConfig | None:
+    stmt = select(TaxConfig).where(TaxConfig.bot_id == bot_id)
+    result = await db.scalars(stmt)
+    return result.first()
+
+
 async def insert_order_items(
     db: AsyncSession, order: Order, cart_items: list[CartItem]
-) -> None:
+) -> list[OrderItem]:
+    order_items = []
     for item in cart_items:
         order_item = OrderItem(
             order_id=order.id,
@@ -71,5 +130,8 @@ async def insert_order_items(
             quantity=item.quantity,
             unit_price_scaled=item.unit_price_scaled,
             total_price_scaled=item.total_price_scaled,
+            components=item.components or [],
         )
         db.add(order_item)
+        order_items.append(order_item)
+    return order_items
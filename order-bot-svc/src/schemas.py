@@ -5,20 +5,84 @@ from src.enums import CartStatus
 
 
 class ChatRequest(BaseModel):
-    menu_id: str
+    # Defaults to the bot's menu that is active at the time of the request.
+    menu_id: str | None = None
     bot_id: str
     message: str = Field(..., min_length=1)
+    # Wins over the Accept-Language header; unsupported locales fall back to
+    # the menu's default locale.
+    locale: str | None = None
+
+
+class MenuOptionIntent(BaseModel):
+    name: str
+    price_delta: float
+
+
+class MenuOptionGroupIntent(BaseModel):
+    name: str
+    selection_type: Literal["single", "multi"]
+    min_choices: int
+    max_choices: int
+    required: bool
+    options: list[MenuOptionIntent] = Field(default_factory=list)
 
 
 class MenuItemIntent(BaseModel):
     menu_item_id: str
     name: str
     price: float
+    category: str | None = None
+    option_groups: list[MenuOptionGroupIntent] = Field(default_factory=list)
+    # Other names customers use for the item, e.g. "coke" for "Coca-Cola".
+    aliases: list[str] = Field(default_factory=list)
+    description: str = ""
+    allergens: list[str] = Field(default_factory=list)
+    dietary_tags: list[str] = Field(default_factory=list)
+    spicy_level: int = 0
+    nutrition: dict | None = None
+    # Names and descriptions by locale, so customers can order in any of them.
+    translations: dict[str, dict[str, str]] = Field(default_factory=dict)
+
+
+class MenuBundleChoiceIntent(BaseModel):
+    menu_item_id: str
+    name: str
+    upcharge: float = 0
+
+
+class MenuBundleSlotIntent(BaseModel):
+    name: str
+    choices: list[MenuBundleChoiceIntent] = Field(default_factory=list)
+
+
+class MenuBundleIntent(BaseModel):
+    # Ordered like an item by bundle_id, with one choice picked per slot.
+    bundle_id: str
+    name: str
+    price: float
+    description: str = ""
+    slots: list[MenuBundleSlotIntent] = Field(default_factory=list)
 
 
 class MenuItemOut(BaseModel):
     name: str
     price: float
+    category: str | None = None
+    image_url: str = ""
+    thumbnail_url: str = ""
+    description: str = ""
+    allergens: list[str] = Field(default_factory=list)
+    dietary_tags: list[str] = Field(default_factory=list)
+    spicy_level: int = 0
+
+
+class CartItemComponent(BaseModel):
+    # The item picked for a slot of a bundle line.
+    slot: str
+    menu_item_id: str
+    name: str
+    upcharge_scaled: int = 0
 
 
 class CartItemOut(BaseModel):
@@ -29,11 +93,15 @@ class CartItemOut(BaseModel):
     total_price_scaled: int
     unit_price: float
     total_price: float
+    components: list[CartItemComponent] = Field(default_factory=list)
 
 
 class CartItemIntent(BaseModel):
     menu_item_id: str
     quantity: int
+    # For a bundle, whose bundle_id goes in menu_item_id: the menu item id
+    # picked for each slot, in slot order.
+    bundle_choices: list[str] = Field(default_factory=list)
 
 
 class CartSummary(BaseModel):
@@ -54,6 +122,9 @@ class IntentResult(BaseModel):
         "unknown",
     ]
     items: list[CartItemIntent] = Field(default_factory=list)
+    # Filters for search_menu, e.g. "no nuts" or "vegan only".
+    exclude_allergens: list[str] = Field(default_factory=list)
+    dietary_tags: list[str] = Field(default_factory=list)
     confirmed: bool = False
     reason: str | None = None
 
@@ -65,3 +136,5 @@ class ChatResponse(BaseModel):
     cart: CartSummary
     order_id: str | None = None
     menu_results: list[MenuItemOut] = Field(default_factory=list)
+    # The locale menu results are shown in.
+    locale: str | None = None
@@ -1,9 +1,9 @@
 import uuid
 from sqlalchemy.ext.asyncio import AsyncSession
 from src import repositories
-from src.entities import Cart, MenuItem, CartItem
+from src.entities import Cart, MenuItem, MenuBundle, CartItem
 from src.enums import CartStatus
-from src.schemas import CartSummary, CartItemOut, IntentResult, ChatResponse, CartItemIntent
+from src.schemas import CartSummary, CartItemOut, CartItemComponent, IntentResult, ChatResponse, CartItemIntent
 from fastapi import HTTPException
 from src.services import response_builder
 from src.utils import money_util
@@ -19,7 +19,8 @@ async def build_cart_summary(cart: Cart) -> CartSummary:
             unit_price_scaled=item.unit_price_scaled,
             total_price_scaled=item.total_price_scaled,
             unit_price=money_util.to_float(item.unit_price_scaled),
-            total_price=money_util.to_float(item.total_price_scaled)
+            total_price=money_util.to_float(item.total_price_scaled),
+            components=[CartItemComponent.model_validate(component) for component in item.components or []],
         )
         for item in items_src
     ]
@@ -40,6 +41,7 @@ async def build_cart_item_intents(cart: Cart) -> list[CartItemIntent]:
         CartItemIntent(
             menu_item_id=item.menu_item_id,
             quantity=item.quantity,
+            bundle_choices=[component["menu_item_id"] for component in item.components or []],
         )
         for item in items_src
     ]
@@ -69,12 +71,20 @@ async def mutate_cart(db: AsyncSession, session_id: str, intent: IntentResult) -
 
     cart_items: list[CartItem] = []
     menu_item_ids: list[str] = list(map(lambda intent_item: intent_item.menu_item_id, intent.items))
+    bundles = await repositories.get_bundles_by_ids(db, menu_item_ids)
+    bundles_dic: dict[str, MenuBundle] = {bundle.id: bundle for bundle in bundles}
+    menu_item_ids.extend(choice for item in intent.items for choice in item.bundle_choices)
     menu_items = await repositories.get_menu_item_by_menu_item_ids(db, menu_item_ids)
     menu_items_dic: dict[str, MenuItem] = {mi.id: mi for mi in menu_items}
     for item in intent.items:
         if item.quantity <= 0:
             raise HTTPException(status_code=400, detail="Quantity must be positive")
-        unit_price_scaled = money_util.to_scaled_val(menu_items_dic[item.menu_item_id].price)
+        if item.menu_item_id in bundles_dic:
+            cart_items.append(_bundle_cart_item(cart, bundles_dic[item.menu_item_id], item, menu_items_dic))
+            continue
+        if not menu_items_dic[item.menu_item_id].available:
+            raise HTTPException(status_code=409, detail=f"{menu_items_dic[item.menu_item_id].name} is sold out")
+        unit_price_scaled = menu_items_dic[item.menu_item_id].price_scaled
         cart_item = CartItem(
             id=str(uuid.uuid4()),
             cart_id=cart.id,
@@ -89,9 +99,58 @@ async def mutate_cart(db: AsyncSession, session_id: str, intent: IntentResult) -
     await db.commit()
 
     cart_summary = await build_cart_summary(cart)
+    reply = response_builder.build_reply(intent, cart_summary)
+    questions = response_builder.build_option_questions(
+        [
+            menu_items_dic[item.menu_item_id]
+            for item in intent.items
+            if item.quantity > 0 and item.menu_item_id not in bundles_dic
+        ]
+    )
+    if questions:
+        reply = "\n".join([reply, "", *questions])
     return ChatResponse(
         session_id=session_id,
-        reply=response_builder.build_reply(intent, cart_summary),
+        reply=reply,
         intent=intent,
         cart=cart_summary,
     )
+
+
+def _bundle_cart_item(
+    cart: Cart, bundle: MenuBundle, item: CartItemIntent, menu_items_dic: dict[str, MenuItem]
+) -> CartItem:
+    # One line for the whole combo, priced at the bundle price plus the
+    # upcharges of the picks; each slot takes exactly one of its choices.
+    if len(item.bundle_choices) != len(bundle.slots):
+        slots = ", ".join(slot.name for slot in bundle.slots)
+        raise HTTPException(status_code=400, detail=f"{bundle.name} needs one pick for each of: {slots}")
+    components: list[dict] = []
+    unit_price_scaled = bundle.price_scaled
+    for slot, menu_item_id in zip(bundle.slots, item.bundle_choices):
+        choice = next((choice for choice in slot.choices if choice.menu_item_id == menu_item_id), None)
+        if choice is None:
+            raise HTTPException(status_code=400, detail=f"That is not a {slot.name} choice of {bundle.name}")
+        menu_item = menu_items_dic[menu_item_id]
+        if not menu_item.available:
+            raise HTTPException(status_code=409, detail=f"{menu_item.name} is sold out")
+        unit_price_scaled += choice.upcharge_scaled
+        components.append(
+            CartItemComponent(
+                slot=slot.name,
+                menu_item_id=menu_item.id,
+                name=menu_item.name,
+                upcharge_scaled=choice.upcharge_scaled,
+            ).model_dump()
+        )
+    picks = ", ".join(component["name"] for component in components)
+    return CartItem(
+        id=str(uuid.uuid4()),
+        cart_id=cart.id,
+        menu_item_id=bundle.id,
+        name=f"{bundle.name} ({picks})"[:200],
+        quantity=item.quantity,
+        unit_price_scaled=unit_price_scaled,
+        total_price_scaled=item.quantity * unit_price_scaled,
+        components=components,
+    )
@@ -1,31 +1,151 @@
+from datetime import datetime, UTC
+from zoneinfo import ZoneInfo, ZoneInfoNotFoundError
+
 from src import repositories
-from src.schemas import IntentResult, ChatResponse, MenuItemOut, MenuItemIntent
+from src.entities import Menu, MenuOptionGroup, MenuBundle
+from src.schemas import (
+    IntentResult,
+    ChatResponse,
+    MenuItemOut,
+    MenuItemIntent,
+    MenuOptionGroupIntent,
+    MenuOptionIntent,
+    MenuBundleIntent,
+    MenuBundleSlotIntent,
+    MenuBundleChoiceIntent,
+)
 from src.services import cart_service
 from src.services import response_builder
+from src.utils import locale_util, money_util
 from sqlalchemy.ext.asyncio import AsyncSession
 
 
-async def search_menu_for_intent(db: AsyncSession, menu_id: str) -> list[MenuItemIntent]:
+async def find_active_menu(db: AsyncSession, bot_id: str, now: datetime | None = None) -> Menu | None:
+    menus = await repositories.list_published_menus(db, bot_id)
+    return pick_active_menu(menus, now or datetime.now(UTC))
+
+
+def pick_active_menu(menus: list[Menu], now: datetime) -> Menu | None:
+    # A menu whose window holds the local time wins over the all-day menu.
+    all_day = None
+    for menu in menus:
+        if menu.available_from == menu.available_until:
+            all_day = all_day or menu
+            continue
+        minute = _local_minute(now, menu.timezone)
+        if menu.available_from < menu.available_until:
+            active = menu.available_from <= minute < menu.available_until
+        else:
+            active = minute >= menu.available_from or minute < menu.available_until
+        if active:
+            return menu
+    return all_day
+
+
+def _local_minute(now: datetime, timezone: str) -> int:
+    try:
+        local = now.astimezone(ZoneInfo(timezone))
+    except (ZoneInfoNotFoundError, ValueError):
+        local = now.astimezone(UTC)
+    return local.hour * 60 + local.minute
+
+
+def resolve_locale(menu: Menu | None, requested: str | None, accept_language: str | None) -> str | None:
+    # The requested locale wins over the header; either must be one the bot
+    # answers in, or the menu's default locale is used.
+    if menu is None:
+        return None
+    supported = menu.locales or [menu.default_locale]
+    preferred = []
+    if requested and (tag := locale_util.normalize(requested)):
+        preferred.append(tag)
+    if accept_language:
+        preferred.extend(locale_util.parse_accept_language(accept_language))
+    return locale_util.match(preferred, supported) or menu.default_locale
+
+
+async def search_menu_for_intent(db: AsyncSession, menu_id: str, locale: str | None = None) -> list[MenuItemIntent]:
+    # Without a locale names stay in the default locale, which cart
+    # mutations look items up by; translations are listed either way.
     menu_items = await repositories.get_menu_by_query(db, menu_id)
     menu_item_intents = [
         MenuItemIntent(
             menu_item_id=item.id,
-            name=item.name,
-            price=item.price
+            name=item.localized_name(locale),
+            price=item.price,
+            category=item.localized_category_name(locale),
+            option_groups=[_option_group_intent(group) for group in item.option_groups],
+            aliases=item.aliases,
+            description=item.localized_description(locale),
+            allergens=item.allergens,
+            dietary_tags=item.dietary_tags,
+            spicy_level=item.spicy_level,
+            nutrition=item.nutrition,
+            translations=item.translations or {},
         )
         for item in menu_items
     ]
     return menu_item_intents
 
 
+async def bundles_for_intent(db: AsyncSession, menu_id: str) -> list[MenuBundleIntent]:
+    # Choices whose item is sold out are left out, as items are.
+    bundles = await repositories.get_bundles(db, menu_id)
+    return [_bundle_intent(bundle) for bundle in bundles]
+
+
+def _bundle_intent(bundle: MenuBundle) -> MenuBundleIntent:
+    return MenuBundleIntent(
+        bundle_id=bundle.id,
+        name=bundle.name,
+        price=bundle.price,
+        description=bundle.description,
+        slots=[
+            MenuBundleSlotIntent(
+                name=slot.name,
+                choices=[
+                    MenuBundleChoiceIntent(
+                        menu_item_id=choice.menu_item_id,
+                        name=choice.menu_item.name,
+                        upcharge=money_util.to_float(choice.upcharge_scaled),
+                    )
+                    for choice in slot.choices
+                    if choice.menu_item.available
+                ],
+            )
+            for slot in bundle.slots
+        ],
+    )
+
+
+def _option_group_intent(group: MenuOptionGroup) -> MenuOptionGroupIntent:
+    return MenuOptionGroupIntent(
+        name=group.name,
+        selection_type=group.selection_type,
+        min_choices=group.min_choices,
+        max_choices=group.max_choices,
+        required=group.required,
+        options=[MenuOptionIntent(name=option.name, price_delta=option.price_delta) for option in group.options],
+    )
+
+
 async def search_menu(
-    db: AsyncSession, menu_id: str, intent: IntentResult, cart
+    db: AsyncSession, menu_id: str, intent: IntentResult, cart, locale: str | None = None
 ) -> ChatResponse:
-    results = await repositories.get_menu_by_query(db, menu_id)
+    results = await repositories.get_menu_by_query(
+        db, menu_id, exclude_allergens=intent.exclude_allergens, dietary_tags=intent.dietary_tags
+    )
     menu_out = [
         MenuItemOut(
-            name=item.name,
+            name=item.localized_name(locale),
             price=item.price,
+            category=item.localized_category_name(locale),
+            image_url=item.image_url,
+            thumbnail_url=item.thumbnail_url,
+            description=item.localized_description(locale),
+            allergens=item.allergens,
+            dietary_tags=item.dietary_tags,
+            spicy_level=item.spicy_level,
         )
         for item in results
     ]
@@ -36,4 +156,5 @@ async def search_menu(
         intent=intent,
         cart=cart_summary,
         menu_results=menu_out,
+        locale=locale,
     )
@@ -2,10 +2,11 @@ from sqlalchemy.ext.asyncio import AsyncSession
 from fastapi import HTTPException
 
 from src import repositories
-from src.entities import Cart, CartItem
+from src.entities import Cart, CartItem, Order, OrderItem, TaxConfig
 from src.enums import CartStatus
 from src.schemas import IntentResult, ChatResponse
 from src.services import cart_service
+from src.utils import tax_util
 
 
 async def checkout(db: AsyncSession, session_id: str, bot_id: str, intent: IntentResult, cart: Cart) -> ChatResponse:
@@ -31,7 +32,8 @@ async def checkout(db: AsyncSession, session_id: str, bot_id: str, intent: Inten
 
     total_scaled = sum(item.total_price_scaled for item in items)
     order = await repositories.insert_order(db, cart, bot_id, total_scaled)
-    await repositories.insert_order_items(db, order, items)
+    order_items = await repositories.insert_order_items(db, order, items)
+    await _tax_order(db, bot_id, order, order_items)
     order_id = order.id
 
     cart.status = CartStatus.CLOSED
@@ -45,3 +47,40 @@ async def checkout(db: AsyncSession, session_id: str, bot_id: str, intent: Inten
         cart=cart_summary,
         order_id=order_id,
     )
+
+
+async def _tax_order(db: AsyncSession, bot_id: str, order: Order, order_items: list[OrderItem]) -> None:
+    # The breakdown is stored with the order, so later changes to the tax
+    # config or the menu leave placed orders as they were. Bots without a
+    # published config leave their orders untaxed.
+    config = await repositories.get_tax_config(db, bot_id) or TaxConfig(bot_id=bot_id)
+    menu_items = await repositories.get_menu_item_by_menu_item_ids(db, [item.menu_item_id for item in order_items])
+    categories = {menu_item.id: menu_item.category_id for menu_item in menu_items}
+    lines = []
+    for item in order_items:
+        rate = config.rate_for(item.menu_item_id, categories.get(item.menu_item_id))
+        lines.append(tax_util.TaxLine(
+            key=rate["code"] if rate else "",
+            rate=rate["rate"] if rate else 0,
+            amount_scaled=item.total_price_scaled,
+        ))
+    result = tax_util.compute(lines, config.inclusive)
+
+    order.tax_mode = "inclusive" if config.inclusive else "exclusive"
+    order.net_scaled = result.net_scaled
+    order.tax_scaled = result.tax_scaled
+    order.gross_scaled = result.gross_scaled
+    order.tax_rates = [
+        {
+            "code": group.key,
+            "name": config.rate(group.key)["name"],
+            "rate": group.rate,
+            "net_scaled": group.net_scaled,
+            "tax_scaled": group.tax_scaled,
+        }
+        # Untaxed items count towards the net amount but have no rate.
+        for group in result.groups if group.key
+    ]
+    for item, line, tax_scaled in zip(order_items, lines, result.line_taxes_scaled):
+        item.tax_rate_code = line.key
+        item.tax_scaled = tax_scaled
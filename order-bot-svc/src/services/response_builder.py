@@ -1,5 +1,6 @@
 import json
 
+from src.entities import MenuItem
 from src.schemas import CartSummary, IntentResult
 
 
@@ -54,6 +55,24 @@ def build_reply(intent: IntentResult, cart: CartSummary) -> str:
             return "Sorry, I didn't understand that."
 
 
+def build_option_questions(menu_items: list[MenuItem]) -> list[str]:
+    """Follow-up questions for the required option groups of the given items."""
+    questions = []
+    for item in menu_items:
+        for group in item.option_groups:
+            if not group.required:
+                continue
+            choices = ", ".join(option.name for option in group.options)
+            if group.max_choices > 1:
+                questions.append(
+                    f"For {item.name}, which {group.name} would you like? "
+                    f"Pick {group.min_choices} to {group.max_choices} of: {choices}."
+                )
+            else:
+                questions.append(f"For {item.name}, which {group.name} would you like? Options: {choices}.")
+    return questions
+
+
 def _cart_pretty_json(cart: CartSummary) -> str:
     payload = {
         "items": [
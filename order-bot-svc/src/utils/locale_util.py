@@ -0,0 +1,57 @@
+# Locale tags are matched the way order-bot-mgmt-svc matches them: a tag
+# tries itself, then its parents ("zh-Hant-TW" tries "zh-Hant" and "zh"), then
+# the first supported locale of the same language.
+import re
+
+_TAG = re.compile(r"^([A-Za-z]{2,3})(?:-([A-Za-z]{4}))?(?:-([A-Za-z]{2}|[0-9]{3}))?$")
+
+
+def normalize(tag: str) -> str | None:
+    match = _TAG.match(tag.strip().replace("_", "-"))
+    if not match:
+        return None
+    language, script, region = match.groups()
+    parts = [language.lower()]
+    if script:
+        parts.append(script.title())
+    if region:
+        parts.append(region.upper())
+    return "-".join(parts)
+
+
+def parse_accept_language(header: str) -> list[str]:
+    weighted: list[tuple[str, float]] = []
+    for part in header.split(","):
+        tag, *params = part.split(";")
+        q = 1.0
+        for param in params:
+            key, _, value = param.strip().partition("=")
+            if key.strip() != "q":
+                continue
+            try:
+                q = float(value)
+            except ValueError:
+                q = 0.0
+            if not 0 <= q <= 1:
+                q = 0.0
+        normalized = normalize(tag)
+        if normalized and q > 0:
+            weighted.append((normalized, q))
+    weighted.sort(key=lambda pair: -pair[1])
+    return [tag for tag, _ in weighted]
+
+
+def match(preferred: list[str], supported: list[str]) -> str | None:
+    for tag in preferred:
+        parent = tag
+        while True:
+            if parent in supported:
+                return parent
+            if "-" not in parent:
+                break
+            parent = parent.rsplit("-", 1)[0]
+        language = tag.split("-", 1)[0]
+        for locale in supported:
+            if locale.split("-", 1)[0] == language:
+                return locale
+    return None
@@ -0,0 +1,84 @@
+# Taxes order lines the way order-bot-mgmt-svc defines its tax config: rates
+# are in millionths, and tax is rounded half up once per rate rather than per
+# line, then split across the rate's lines in proportion to their amounts.
+from dataclasses import dataclass, field
+
+FULL_RATE = 1_000_000
+
+
+@dataclass
+class TaxLine:
+    # Lines with the same key, a rate code, are taxed together.
+    key: str
+    rate: int
+    amount_scaled: int
+
+
+@dataclass
+class TaxGroup:
+    key: str
+    rate: int
+    net_scaled: int = 0
+    tax_scaled: int = 0
+
+
+@dataclass
+class TaxResult:
+    net_scaled: int = 0
+    tax_scaled: int = 0
+    gross_scaled: int = 0
+    # Groups are in the order their keys first appear in the lines.
+    groups: list[TaxGroup] = field(default_factory=list)
+    line_taxes_scaled: list[int] = field(default_factory=list)
+
+
+def allocate(total: int, weights: list[int]) -> list[int]:
+    # Splits total, at most the sum of weights, in proportion to weights; the
+    # units lost to rounding go to the largest remainders.
+    shares = [0] * len(weights)
+    weight_sum = sum(weights)
+    if total <= 0 or weight_sum == 0:
+        return shares
+    remainders = [0] * len(weights)
+    for idx, weight in enumerate(weights):
+        shares[idx], remainders[idx] = divmod(total * weight, weight_sum)
+    left = total - sum(shares)
+    order = sorted((idx for idx, weight in enumerate(weights) if weight > 0), key=lambda idx: -remainders[idx])
+    for idx in order[:left]:
+        shares[idx] += 1
+    return shares
+
+
+def mul_div_round(a: int, b: int, c: int) -> int:
+    # a * b / c rounded half up, for a and b of zero or more and c above zero.
+    quotient, remainder = divmod(a * b, c)
+    return quotient + 1 if remainder * 2 >= c else quotient
+
+
+def compute(lines: list[TaxLine], inclusive: bool) -> TaxResult:
+    # Amounts are net, or gross when inclusive. Amounts below zero count as
+    # zero and rates are capped at FULL_RATE.
+    result = TaxResult(line_taxes_scaled=[0] * len(lines))
+    groups: dict[str, TaxGroup] = {}
+    members: dict[str, list[int]] = {}
+    for idx, line in enumerate(lines):
+        if line.key not in groups:
+            groups[line.key] = TaxGroup(key=line.key, rate=min(max(line.rate, 0), FULL_RATE))
+            members[line.key] = []
+            result.groups.append(groups[line.key])
+        members[line.key].append(idx)
+    for group in result.groups:
+        amounts = [max(lines[idx].amount_scaled, 0) for idx in members[group.key]]
+        total = sum(amounts)
+        if inclusive:
+            group.tax_scaled = mul_div_round(total, group.rate, FULL_RATE + group.rate)
+            group.net_scaled = total - group.tax_scaled
+        else:
+            group.tax_scaled = mul_div_round(total, group.rate, FULL_RATE)
+            group.net_scaled = total
+        for idx, tax in zip(members[group.key], allocate(group.tax_scaled, amounts)):
+            result.line_taxes_scaled[idx] = tax
+        result.net_scaled += group.net_scaled
+        result.tax_scaled += group.tax_scaled
+    result.gross_scaled = result.net_scaled + result.tax_scaled
+    return result
@@ -6,7 +6,7 @@ from sqlalchemy.ext.asyncio import create_async_engine, async_sessionmaker
 from sqlalchemy.pool import StaticPool
 
 from src.db import Base
-from src.entities import Cart, CartItem, MenuItem
+from src.entities import Cart, CartItem, MenuItem, MenuBundle, MenuBundleSlot, MenuBundleChoice
 from src.enums import CartStatus
 from src.schemas import CartItemIntent, IntentResult
 from src.services import cart_service, menu_service, order_service
@@ -35,7 +35,7 @@ class AsyncServiceTestCase(unittest.IsolatedAsyncioTestCase):
         name,
         price,
     ):
-        menu_item = MenuItem(id=item_id, menu_id=menu_id, name=name, price=price)
+        menu_item = MenuItem(id=item_id, menu_id=menu_id, name=name, price_scaled=money_util.to_scaled_val(price))
         session.add(menu_item)
         await session.flush()
         return menu_item
@@ -47,7 +47,7 @@ class AsyncServiceTestCase(unittest.IsolatedAsyncioTestCase):
         return cart
 
     async def add_cart_item(self, session, cart, menu_item, quantity=2):
-        scaled_val = money_util.to_scaled_val(menu_item.price)
+        scaled_val = menu_item.price_scaled
         cart_item = CartItem(
             cart_id=cart.id,
             menu_item_id=menu_item.id,
@@ -123,6 +123,34 @@ class CartServiceTests(AsyncServiceTestCase):
         self.assertEqual(response.cart.items[0].menu_item_id, "item_id-1")
         self.assertEqual(response.cart.items[0].total_price_scaled, 700)
 
+    async def test_mutate_cart_adds_bundle_as_one_line(self):
+        async with self.SessionLocal() as session:
+            async with session.begin():
+                burger = await self.create_menu_item(
+                    session, item_id="burger-1", menu_id="menu_id-1", name="Burger", price=6)
+                cola = await self.create_menu_item(
+                    session, item_id="cola-1", menu_id="menu_id-1", name="Cola", price=2)
+                session.add_all([
+                    MenuBundle(id="combo-1", menu_id="menu_id-1", name="Burger Combo", price_scaled=700),
+                    MenuBundleSlot(id="slot-main", bundle_id="combo-1", name="Main", sort_position=0),
+                    MenuBundleSlot(id="slot-drink", bundle_id="combo-1", name="Drink", sort_position=1),
+                    MenuBundleChoice(slot_id="slot-main", menu_item_id=burger.id),
+                    MenuBundleChoice(slot_id="slot-drink", menu_item_id=cola.id, upcharge_scaled=50),
+                ])
+                await session.flush()
+                intent = IntentResult(
+                    valid=True,
+                    intent_type="mutate_cart_items",
+                    items=[CartItemIntent(menu_item_id="combo-1", quantity=2, bundle_choices=[burger.id, cola.id])],
+                )
+            response = await cart_service.mutate_cart(session, "session-1", intent)
+
+        self.assertEqual(len(response.cart.items), 1)
+        self.assertEqual(response.cart.items[0].name, "Burger Combo (Burger, Cola)")
+        self.assertEqual(response.cart.items[0].unit_price_scaled, 750)
+        self.assertEqual(response.cart.items[0].total_price_scaled, 1500)
+        self.assertEqual([c.slot for c in response.cart.items[0].components], ["Main", "Drink"])
+
 
 class MenuServiceTests(AsyncServiceTestCase):
     async def test_search_menu_returns_results(self):
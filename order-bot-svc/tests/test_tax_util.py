@@ -0,0 +1,80 @@
+import unittest
+
+from src.utils import tax_util
+from src.utils.tax_util import TaxGroup, TaxLine, TaxResult
+
+
+class ComputeTests(unittest.TestCase):
+    def test_exclusive_rounds_once_per_rate(self):
+        lines = [
+            TaxLine(key="std", rate=100000, amount_scaled=5),
+            TaxLine(key="food", rate=0, amount_scaled=800),
+            TaxLine(key="std", rate=100000, amount_scaled=5),
+            TaxLine(key="std", rate=100000, amount_scaled=5),
+        ]
+
+        result = tax_util.compute(lines, inclusive=False)
+
+        self.assertEqual(result, TaxResult(
+            net_scaled=815,
+            tax_scaled=2,
+            gross_scaled=817,
+            groups=[
+                TaxGroup(key="std", rate=100000, net_scaled=15, tax_scaled=2),
+                TaxGroup(key="food", rate=0, net_scaled=800, tax_scaled=0),
+            ],
+            line_taxes_scaled=[1, 0, 1, 0],
+        ))
+
+    def test_exclusive_rounds_half_up(self):
+        result = tax_util.compute([TaxLine(key="low", rate=10000, amount_scaled=50)], inclusive=False)
+
+        self.assertEqual(result.tax_scaled, 1)
+        self.assertEqual(result.gross_scaled, 51)
+
+    def test_inclusive_takes_the_tax_out_of_the_price(self):
+        lines = [
+            TaxLine(key="vat", rate=190000, amount_scaled=1190),
+            TaxLine(key="ny", rate=88750, amount_scaled=1000),
+        ]
+
+        result = tax_util.compute(lines, inclusive=True)
+
+        self.assertEqual(result, TaxResult(
+            net_scaled=1918,
+            tax_scaled=272,
+            gross_scaled=2190,
+            groups=[
+                TaxGroup(key="vat", rate=190000, net_scaled=1000, tax_scaled=190),
+                TaxGroup(key="ny", rate=88750, net_scaled=918, tax_scaled=82),
+            ],
+            line_taxes_scaled=[190, 82],
+        ))
+
+    def test_clamps_amounts_and_rates(self):
+        lines = [
+            TaxLine(key="odd", rate=2 * tax_util.FULL_RATE, amount_scaled=300),
+            TaxLine(key="odd", rate=2 * tax_util.FULL_RATE, amount_scaled=-100),
+        ]
+
+        result = tax_util.compute(lines, inclusive=False)
+
+        self.assertEqual(result.groups, [TaxGroup(key="odd", rate=tax_util.FULL_RATE, net_scaled=300, tax_scaled=300)])
+        self.assertEqual(result.line_taxes_scaled, [300, 0])
+
+    def test_no_lines(self):
+        self.assertEqual(tax_util.compute([], inclusive=False), TaxResult())
+
+
+class AllocateTests(unittest.TestCase):
+    def test_gives_rounding_units_to_largest_remainders(self):
+        self.assertEqual(tax_util.allocate(10, [1, 1, 1]), [4, 3, 3])
+        self.assertEqual(tax_util.allocate(2, [5, 0, 10]), [1, 0, 1])
+
+    def test_nothing_to_split(self):
+        self.assertEqual(tax_util.allocate(0, [1, 2]), [0, 0])
+        self.assertEqual(tax_util.allocate(5, [0, 0]), [0, 0])
+
+
+if __name__ == "__main__":
+    unittest.main()